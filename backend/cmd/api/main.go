@@ -69,7 +69,11 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
-	// 4. Start Server in Goroutine
+	// 4. Start background jobs and the server in goroutines
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	s.StartJobs(jobsCtx)
+
 	go func() {
 		slog.Info("Server is starting", "addr", serverAddr)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -85,10 +89,14 @@ func main() {
 	<-stop
 
 	slog.Info("Shutting down server...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if err := s.Shutdown(ctx); err != nil {
+		slog.Error("Live sessions forced to close", "error", err)
+	}
 	if err := srv.Shutdown(ctx); err != nil {
 		slog.Error("Server forced to shutdown", "error", err)
 	}
@@ -4,12 +4,19 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
 
+// baselineVersion is the migration that created the quizzes table, the only
+// one applied by the runner before it recorded migrations
+const baselineVersion = "000001_create_quizzes_table"
+
 func main() {
 	_ = godotenv.Load()
 
@@ -18,6 +25,11 @@ func main() {
 		log.Fatal("DATABASE_URL is not set")
 	}
 
+	dir := os.Getenv("MIGRATIONS_DIR")
+	if dir == "" {
+		dir = "migrations"
+	}
+
 	db, err := sqlx.Connect("postgres", dsn)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -26,16 +38,66 @@ func main() {
 
 	fmt.Println("Connected to database successfully")
 
-	// Read and execute migration file
-	migrationSQL, err := os.ReadFile("migrations/000001_create_quizzes_table.up.sql")
+	// Track applied migrations so each file runs exactly once
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`)
+	if err != nil {
+		log.Fatalf("Failed to create schema_migrations table: %v", err)
+	}
+
+	// Databases set up before migrations were tracked only ran the first
+	// migration; record it so it is not run again
+	_, err = db.Exec(`INSERT INTO schema_migrations (version)
+		SELECT $1 WHERE to_regclass('quizzes') IS NOT NULL
+		ON CONFLICT (version) DO NOTHING`, baselineVersion)
 	if err != nil {
-		log.Fatalf("Failed to read migration file: %v", err)
+		log.Fatalf("Failed to record the baseline migration: %v", err)
 	}
 
-	_, err = db.Exec(string(migrationSQL))
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
 	if err != nil {
-		log.Fatalf("Failed to execute migration: %v", err)
+		log.Fatalf("Failed to list migration files: %v", err)
+	}
+	sort.Strings(files)
+
+	applied := 0
+	for _, file := range files {
+		version := strings.TrimSuffix(filepath.Base(file), ".up.sql")
+
+		var exists bool
+		if err := db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version); err != nil {
+			log.Fatalf("Failed to check migration %s: %v", version, err)
+		}
+		if exists {
+			continue
+		}
+
+		migrationSQL, err := os.ReadFile(file)
+		if err != nil {
+			log.Fatalf("Failed to read migration file: %v", err)
+		}
+
+		tx, err := db.Beginx()
+		if err != nil {
+			log.Fatalf("Failed to begin transaction: %v", err)
+		}
+		if _, err := tx.Exec(string(migrationSQL)); err != nil {
+			_ = tx.Rollback()
+			log.Fatalf("Failed to execute migration %s: %v", version, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+			_ = tx.Rollback()
+			log.Fatalf("Failed to record migration %s: %v", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			log.Fatalf("Failed to commit migration %s: %v", version, err)
+		}
+
+		fmt.Printf("Applied %s\n", version)
+		applied++
 	}
 
-	fmt.Println("Migration completed successfully!")
+	fmt.Printf("Migration completed successfully! (%d applied)\n", applied)
 }
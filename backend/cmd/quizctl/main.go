@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/infrastructure/format"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: quizctl <command> [flags]
+
+Commands:
+  import -format <name> [-dry-run] <file>   Import quizzes from a file ("-" for stdin)
+         [-on-duplicate warn|block]         Report or skip questions duplicating existing quizzes
+  export -format <name> [-ids a,b] [-o file] Export quizzes to a file (default stdout)
+  purge [-days n]                            Permanently remove quizzes deleted more than n days ago
+                                             (default TRASH_RETENTION_DAYS, or 30)
+
+Formats: %s
+`
+
+func main() {
+	_ = godotenv.Load()
+
+	if len(os.Args) < 2 {
+		fmt.Fprintf(os.Stderr, usage, strings.Join(formatNames(), ", "))
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	case "purge":
+		err = runPurge(os.Args[2:])
+	default:
+		fmt.Fprintf(os.Stderr, usage, strings.Join(formatNames(), ", "))
+		os.Exit(2)
+	}
+
+	if err != nil {
+		printError(err)
+		os.Exit(1)
+	}
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	formatName := fs.String("format", "", "document format")
+	dryRun := fs.Bool("dry-run", false, "parse and validate only, without touching the database")
+	onDuplicate := fs.String("on-duplicate", "warn", "warn about or skip (block) questions that duplicate existing quizzes")
+	fs.Parse(args)
+
+	if *formatName == "" || fs.NArg() != 1 {
+		return errors.New("usage: quizctl import -format <name> [-dry-run] [-on-duplicate warn|block] <file>")
+	}
+
+	in, err := openInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if *dryRun {
+		codec := findCodec(*formatName)
+		if codec == nil {
+			return fmt.Errorf("unknown format %q (supported: %s)", *formatName, strings.Join(formatNames(), ", "))
+		}
+		doc, err := codec.Decode(in)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d quizzes would be imported\n", len(doc.Quizzes))
+		printSkipped(doc.Skipped)
+		return nil
+	}
+
+	module, closeDB, err := openModule()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	result, err := module.Interchange.Import(context.Background(), *formatName, in, application.DuplicatePolicy(*onDuplicate))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d quizzes imported\n", len(result.Imported))
+	printSkipped(result.Skipped)
+	for _, d := range result.Duplicates {
+		fmt.Printf("duplicate line %d: %q is like quiz %s\n", d.Line, result.Imported[d.Index].Question, d.Matches[0].ID)
+	}
+	return nil
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	formatName := fs.String("format", "", "document format")
+	ids := fs.String("ids", "", "comma-separated quiz IDs (default: all quizzes)")
+	output := fs.String("o", "", "output file (default: stdout)")
+	fs.Parse(args)
+
+	if *formatName == "" {
+		return errors.New("usage: quizctl export -format <name> [-ids a,b] [-o file]")
+	}
+
+	module, closeDB, err := openModule()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	var selected []string
+	if *ids != "" {
+		selected = strings.Split(*ids, ",")
+	}
+
+	// Whoever runs quizctl can read the database, so it exports as an admin
+	ctx := utils.SetUserRole(context.Background(), utils.RoleAdmin)
+	result, err := module.Interchange.Export(ctx, *formatName, selected)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(result.Data)
+		return err
+	}
+	return os.WriteFile(*output, result.Data, 0o644)
+}
+
+func runPurge(args []string) error {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	days := fs.Int("days", trashRetentionDays(), "retention window in days")
+	fs.Parse(args)
+
+	if *days <= 0 {
+		return errors.New("usage: quizctl purge [-days n] (n must be positive)")
+	}
+
+	module, closeDB, err := openModuleWithRetention(time.Duration(*days) * 24 * time.Hour)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	n, err := module.Trash.PurgeExpired(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d quizzes purged\n", n)
+	return nil
+}
+
+// trashRetentionDays reads TRASH_RETENTION_DAYS like the API server does
+func trashRetentionDays() int {
+	if days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil {
+		return days
+	}
+	return 30
+}
+
+func openModule() (*quiz.Module, func(), error) {
+	return openModuleWithRetention(time.Duration(trashRetentionDays()) * 24 * time.Hour)
+}
+
+func openModuleWithRetention(retention time.Duration) (*quiz.Module, func(), error) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return nil, nil, errors.New("DATABASE_URL is not set")
+	}
+	db, err := database.NewPostgresDBFromDSN(dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return quiz.NewModule(db, quiz.Options{TrashRetention: retention}), func() { db.Close() }, nil
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+func findCodec(name string) domain.QuizCodec {
+	for _, c := range format.Codecs() {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func formatNames() []string {
+	var names []string
+	for _, c := range format.Codecs() {
+		names = append(names, c.Name())
+	}
+	return names
+}
+
+func printSkipped(skipped []domain.ImportIssue) {
+	for _, issue := range skipped {
+		fmt.Println("skipped", issue.String())
+	}
+}
+
+// printError reports located issues when the error carries them
+func printError(err error) {
+	var parseErr *domain.ParseError
+	if errors.As(err, &parseErr) {
+		for _, issue := range parseErr.Issues {
+			fmt.Fprintln(os.Stderr, issue.String())
+		}
+		return
+	}
+
+	var appErr *sharedDomain.AppError
+	if errors.As(err, &appErr) {
+		fmt.Fprintln(os.Stderr, "error:", appErr.Error())
+		if issues, ok := appErr.Details.([]domain.ImportIssue); ok {
+			for _, issue := range issues {
+				fmt.Fprintln(os.Stderr, issue.String())
+			}
+		} else if appErr.Details != nil {
+			fmt.Fprintf(os.Stderr, "details: %v\n", appErr.Details)
+		}
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "error:", err)
+}
@@ -2,9 +2,11 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -19,6 +21,25 @@ type Config struct {
 	RateLimitBurst int
 	DatabaseURL    string
 	Database       *DatabaseConfig
+	PDFFontPath    string
+	// TrashRetentionDays is how long deleted quizzes are kept; 0 disables purging
+	TrashRetentionDays int
+	// MaxBatchSize limits the number of items in a bulk request
+	MaxBatchSize int
+	// LegacyQuizList keeps GET /quizzes returning every quiz when no page is requested
+	LegacyQuizList bool
+	// DuplicateThreshold is the trigram similarity (0.3 to 1) from which questions count as near-duplicates
+	DuplicateThreshold float64
+	// DefaultLocale is the locale quizzes are written in and the last fallback for localized content
+	DefaultLocale string
+	// AdminUserIDs are the users who may change every quiz; other users only change their own
+	AdminUserIDs []string
+	// ReviewerUserIDs are the users who may approve or reject quizzes in review
+	ReviewerUserIDs []string
+	// ScheduleTimeZone is the IANA time zone publication schedules are written in
+	ScheduleTimeZone string
+	// ScheduleLocation is ScheduleTimeZone loaded
+	ScheduleLocation *time.Location
 }
 
 // DatabaseConfig holds database configuration
@@ -42,13 +63,22 @@ func Load() (*Config, error) {
 	corsOrigins := getEnv("CORS_ORIGINS", "http://localhost:3000,http://localhost:5173")
 
 	cfg := &Config{
-		Port:           getEnv("PORT", "3000"),
-		Environment:    getEnv("APP_ENV", "development"),
-		JWTSecret:      getEnv("JWT_SECRET", "your-super-secret-key-change-in-production"),
-		CORSOrigins:    strings.Split(corsOrigins, ","),
-		RateLimit:      getEnvFloat("RATE_LIMIT", 20.0),  // 20 req/s
-		RateLimitBurst: getEnvInt("RATE_LIMIT_BURST", 5), // burst 5
-		DatabaseURL:    getEnv("DATABASE_URL", ""),
+		Port:               getEnv("PORT", "3000"),
+		Environment:        getEnv("APP_ENV", "development"),
+		JWTSecret:          getEnv("JWT_SECRET", "your-super-secret-key-change-in-production"),
+		CORSOrigins:        strings.Split(corsOrigins, ","),
+		RateLimit:          getEnvFloat("RATE_LIMIT", 20.0),  // 20 req/s
+		RateLimitBurst:     getEnvInt("RATE_LIMIT_BURST", 5), // burst 5
+		DatabaseURL:        getEnv("DATABASE_URL", ""),
+		PDFFontPath:        getEnv("PDF_FONT_PATH", ""), // TrueType font for printed exams, e.g. a Thai font
+		TrashRetentionDays: getEnvInt("TRASH_RETENTION_DAYS", 30),
+		MaxBatchSize:       getEnvInt("MAX_BATCH_SIZE", 100),
+		LegacyQuizList:     getEnvBool("LEGACY_QUIZ_LIST", true),
+		DuplicateThreshold: getEnvFloat("DUPLICATE_THRESHOLD", 0.6),
+		DefaultLocale:      getEnv("DEFAULT_LOCALE", "th"),
+		ScheduleTimeZone:   getEnv("SCHEDULE_TIMEZONE", "Asia/Bangkok"),
+		AdminUserIDs:       strings.Split(getEnv("ADMIN_USER_IDS", ""), ","),
+		ReviewerUserIDs:    strings.Split(getEnv("REVIEWER_USER_IDS", ""), ","),
 		Database: &DatabaseConfig{
 			Host:                   getEnv("DB_HOST", "localhost"),
 			Port:                   getEnv("DB_PORT", "5432"),
@@ -69,6 +99,12 @@ func Load() (*Config, error) {
 		cfg.Port = *flagPort
 	}
 
+	loc, err := time.LoadLocation(cfg.ScheduleTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULE_TIMEZONE %q: %w", cfg.ScheduleTimeZone, err)
+	}
+	cfg.ScheduleLocation = loc
+
 	return cfg, nil
 }
 
@@ -77,6 +113,11 @@ func (c *Config) HasDatabaseURL() bool {
 	return c.DatabaseURL != ""
 }
 
+// TrashRetention returns how long deleted quizzes are kept before they are purged
+func (c *Config) TrashRetention() time.Duration {
+	return time.Duration(c.TrashRetentionDays) * 24 * time.Hour
+}
+
 // IsDevelopment returns true if running in development mode
 func (c *Config) IsDevelopment() bool {
 	return c.Environment == "development"
@@ -103,6 +144,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
 func getEnvFloat(key string, defaultValue float64) float64 {
 	if value := os.Getenv(key); value != "" {
 		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
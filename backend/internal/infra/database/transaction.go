@@ -2,12 +2,21 @@ package database
 
 import (
 	"context"
+	"sync"
 
 	"github.com/jmoiron/sqlx"
 )
 
 type txKey struct{}
 
+type afterCommitKey struct{}
+
+// afterCommit collects the functions to run once a transaction commits
+type afterCommit struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
 // TxManager handles database transactions
 type TxManager interface {
 	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
@@ -22,8 +31,13 @@ func NewTxManager(db *sqlx.DB) TxManager {
 	return &txManager{db: db}
 }
 
-// WithTransaction executes fn within a transaction
+// WithTransaction executes fn within a transaction. When ctx already carries
+// one, fn joins it and the outermost call commits or rolls back.
 func (tm *txManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if GetTx(ctx) != nil {
+		return fn(ctx)
+	}
+
 	tx, err := tm.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
@@ -31,6 +45,8 @@ func (tm *txManager) WithTransaction(ctx context.Context, fn func(ctx context.Co
 
 	// Inject transaction into context
 	ctx = context.WithValue(ctx, txKey{}, tx)
+	hooks := &afterCommit{}
+	ctx = context.WithValue(ctx, afterCommitKey{}, hooks)
 
 	defer func() {
 		if p := recover(); p != nil {
@@ -44,7 +60,26 @@ func (tm *txManager) WithTransaction(ctx context.Context, fn func(ctx context.Co
 		return err
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, fn := range hooks.fns {
+		fn()
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction carried by ctx commits, and never
+// if it rolls back. Without a transaction fn runs immediately.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommit)
+	if !ok {
+		fn()
+		return
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.fns = append(hooks.fns, fn)
 }
 
 // GetTx extracts transaction from context
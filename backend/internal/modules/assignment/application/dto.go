@@ -0,0 +1,139 @@
+package application
+
+import (
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/domain"
+)
+
+// AssignmentRequest DTO for creating or replacing an assignment. OpensAt is
+// optional and DueAt required; both take RFC 3339 timestamps or local times
+// such as 2025-03-01T09:00. LatePolicy defaults to reject; LatePenalty is
+// the percentage taken off late attempts under the penalty policy.
+// MaxAttempts limits each learner's attempts, and is unlimited when nil.
+type AssignmentRequest struct {
+	QuizSetID   string            `json:"quiz_set_id"`
+	Title       string            `json:"title"`
+	OpensAt     string            `json:"opens_at"`
+	DueAt       string            `json:"due_at"`
+	LatePolicy  domain.LatePolicy `json:"late_policy"`
+	LatePenalty float64           `json:"late_penalty"`
+	MaxAttempts *int              `json:"max_attempts"`
+	UserIDs     []string          `json:"user_ids"`
+	GroupIDs    []string          `json:"group_ids"`
+}
+
+// AssignmentResponse DTO for an assignment as its creator sees it
+type AssignmentResponse struct {
+	ID          string            `json:"id"`
+	QuizSetID   string            `json:"quiz_set_id"`
+	Title       string            `json:"title"`
+	OpensAt     *time.Time        `json:"opens_at,omitempty"`
+	DueAt       time.Time         `json:"due_at"`
+	LatePolicy  domain.LatePolicy `json:"late_policy"`
+	LatePenalty float64           `json:"late_penalty,omitempty"`
+	MaxAttempts *int              `json:"max_attempts,omitempty"`
+	UserIDs     []string          `json:"user_ids"`
+	GroupIDs    []string          `json:"group_ids"`
+	CreatedBy   *string           `json:"created_by,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// MyAssignmentResponse DTO for an assignment as a learner given it sees it.
+// BestScore is the best submitted score after any late penalty, and Late
+// tells whether that attempt was late.
+type MyAssignmentResponse struct {
+	ID            string            `json:"id"`
+	QuizSetID     string            `json:"quiz_set_id"`
+	Title         string            `json:"title"`
+	OpensAt       *time.Time        `json:"opens_at,omitempty"`
+	DueAt         time.Time         `json:"due_at"`
+	LatePolicy    domain.LatePolicy `json:"late_policy"`
+	LatePenalty   float64           `json:"late_penalty,omitempty"`
+	MaxAttempts   *int              `json:"max_attempts,omitempty"`
+	Status        domain.Status     `json:"status"`
+	AttemptsUsed  int               `json:"attempts_used"`
+	AttemptsLeft  *int              `json:"attempts_left,omitempty"`
+	CanStart      bool              `json:"can_start"`
+	BestAttemptID *string           `json:"best_attempt_id,omitempty"`
+	BestScore     *float64          `json:"best_score,omitempty"`
+	MaxScore      *float64          `json:"max_score,omitempty"`
+	Late          bool              `json:"late"`
+}
+
+// GroupRequest DTO for creating or replacing a group
+type GroupRequest struct {
+	Name    string   `json:"name"`
+	UserIDs []string `json:"user_ids"`
+}
+
+// GroupResponse DTO for a group with its members
+type GroupResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	UserIDs   []string  `json:"user_ids"`
+	CreatedBy *string   `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// in returns t in loc, or nil
+func in(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := t.In(loc)
+	return &local
+}
+
+func toAssignmentResponse(a domain.Assignment, loc *time.Location) AssignmentResponse {
+	return AssignmentResponse{
+		ID:          a.ID,
+		QuizSetID:   a.QuizSetID,
+		Title:       a.Title,
+		OpensAt:     in(a.OpensAt, loc),
+		DueAt:       a.DueAt.In(loc),
+		LatePolicy:  a.LatePolicy,
+		LatePenalty: a.LatePenalty,
+		MaxAttempts: a.MaxAttempts,
+		UserIDs:     a.UserIDs,
+		GroupIDs:    a.GroupIDs,
+		CreatedBy:   a.CreatedBy,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+}
+
+func toMyAssignmentResponse(a domain.Assignment, p domain.Progress, loc *time.Location) MyAssignmentResponse {
+	resp := MyAssignmentResponse{
+		ID:           a.ID,
+		QuizSetID:    a.QuizSetID,
+		Title:        a.Title,
+		OpensAt:      in(a.OpensAt, loc),
+		DueAt:        a.DueAt.In(loc),
+		LatePolicy:   a.LatePolicy,
+		LatePenalty:  a.LatePenalty,
+		MaxAttempts:  a.MaxAttempts,
+		Status:       p.Status,
+		AttemptsUsed: p.AttemptsUsed,
+		AttemptsLeft: p.AttemptsLeft,
+		CanStart:     p.CanStart,
+		BestScore:    p.BestScore,
+	}
+	if p.Best != nil {
+		resp.BestAttemptID, resp.MaxScore, resp.Late = &p.Best.ID, &p.Best.MaxScore, p.Best.Late
+	}
+	return resp
+}
+
+func toGroupResponse(g domain.Group) GroupResponse {
+	return GroupResponse{
+		ID:        g.ID,
+		Name:      g.Name,
+		UserIDs:   g.UserIDs,
+		CreatedBy: g.CreatedBy,
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+	}
+}
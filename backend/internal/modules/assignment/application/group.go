@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// GroupService defines the group business logic interface
+type GroupService interface {
+	List(ctx context.Context) ([]GroupResponse, error)
+	Get(ctx context.Context, id string) (*GroupResponse, error)
+	Create(ctx context.Context, req GroupRequest) (*GroupResponse, error)
+	Update(ctx context.Context, id string, req GroupRequest) (*GroupResponse, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type groupService struct {
+	repo      domain.GroupRepository
+	txManager database.TxManager
+}
+
+// NewGroupService creates a new GroupService
+func NewGroupService(repo domain.GroupRepository, txManager database.TxManager) GroupService {
+	return &groupService{repo: repo, txManager: txManager}
+}
+
+// List returns the groups the current user created, or every group for
+// admins, by name
+func (s *groupService) List(ctx context.Context) ([]GroupResponse, error) {
+	createdBy, err := listedBy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.repo.List(ctx, createdBy)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch groups", err)
+	}
+
+	responses := make([]GroupResponse, len(groups))
+	for i, g := range groups {
+		responses[i] = toGroupResponse(g)
+	}
+	return responses, nil
+}
+
+// Get returns a group to its creator or an admin
+func (s *groupService) Get(ctx context.Context, id string) (*GroupResponse, error) {
+	group, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toGroupResponse(*group)
+	return &resp, nil
+}
+
+// Create creates a group of users
+func (s *groupService) Create(ctx context.Context, req GroupRequest) (*GroupResponse, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	group := &domain.Group{ID: sharedDomain.NewID(), CreatedBy: &userID}
+	if err := applyGroup(group, req); err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, group); err != nil {
+			return sharedDomain.NewInternalError("Failed to create group", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toGroupResponse(*group)
+	return &resp, nil
+}
+
+// Update replaces the name and members of a group. Assignments given to
+// the group reach its new members and no longer reach removed ones.
+func (s *groupService) Update(ctx context.Context, id string, req GroupRequest) (*GroupResponse, error) {
+	group, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyGroup(group, req); err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, group); err != nil {
+			if errors.Is(err, domain.ErrGroupNotFound) {
+				return err
+			}
+			return sharedDomain.NewInternalError("Failed to update group", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toGroupResponse(*group)
+	return &resp, nil
+}
+
+// Delete removes a group
+func (s *groupService) Delete(ctx context.Context, id string) error {
+	if _, err := s.load(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrGroupNotFound) {
+			return err
+		}
+		return sharedDomain.NewInternalError("Failed to delete group", err)
+	}
+	return nil
+}
+
+// load returns a group the current user may see and change
+func (s *groupService) load(ctx context.Context, id string) (*domain.Group, error) {
+	if _, err := currentUser(ctx); err != nil {
+		return nil, err
+	}
+	group, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrGroupNotFound) {
+			return nil, err
+		}
+		return nil, sharedDomain.NewInternalError("Failed to fetch group", err)
+	}
+	if err := authorize(ctx, group.IsOwnedBy); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// applyGroup validates req and copies it onto group
+func applyGroup(group *domain.Group, req GroupRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return domain.ErrInvalidName
+	}
+	group.Name, group.UserIDs = name, distinct(req.UserIDs)
+	return nil
+}
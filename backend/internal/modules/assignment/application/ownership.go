@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// currentUser returns the ID of the signed-in user
+func currentUser(ctx context.Context) (string, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return "", domain.ErrSignInRequired
+	}
+	return userID, nil
+}
+
+// listedBy returns the creator whose assignments and groups the current user
+// may list: everyone's for admins, their own otherwise
+func listedBy(ctx context.Context) (*string, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if utils.IsAdmin(ctx) {
+		return nil, nil
+	}
+	return &userID, nil
+}
+
+// authorize returns nil if the current user may see and change something
+// created by a user for whom owned reports true. Admins may change
+// everything and other users what they created.
+func authorize(ctx context.Context, owned func(userID string) bool) error {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if !utils.IsAdmin(ctx) && !owned(userID) {
+		return domain.ErrNotOwner
+	}
+	return nil
+}
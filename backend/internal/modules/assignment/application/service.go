@@ -0,0 +1,325 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/domain"
+	attemptDomain "github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// AssignmentService defines the assignment business logic interface
+type AssignmentService interface {
+	List(ctx context.Context) ([]AssignmentResponse, error)
+	Get(ctx context.Context, id string) (*AssignmentResponse, error)
+	Create(ctx context.Context, req AssignmentRequest) (*AssignmentResponse, error)
+	Update(ctx context.Context, id string, req AssignmentRequest) (*AssignmentResponse, error)
+	Delete(ctx context.Context, id string) error
+	Mine(ctx context.Context) ([]MyAssignmentResponse, error)
+	attemptDomain.AssignmentGate
+}
+
+type assignmentService struct {
+	repo      domain.AssignmentRepository
+	txManager database.TxManager
+	location  *time.Location
+	now       func() time.Time
+}
+
+// NewAssignmentService creates a new AssignmentService. Times without a UTC
+// offset are read in loc, and responses are rendered in it.
+func NewAssignmentService(repo domain.AssignmentRepository, txManager database.TxManager, loc *time.Location) AssignmentService {
+	return &assignmentService{repo: repo, txManager: txManager, location: loc, now: time.Now}
+}
+
+// List returns the assignments the current user created, or every
+// assignment for admins, by due date
+func (s *assignmentService) List(ctx context.Context) ([]AssignmentResponse, error) {
+	createdBy, err := listedBy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.repo.List(ctx, createdBy)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch assignments", err)
+	}
+
+	responses := make([]AssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		responses[i] = toAssignmentResponse(a, s.location)
+	}
+	return responses, nil
+}
+
+// Get returns an assignment to its creator or an admin
+func (s *assignmentService) Get(ctx context.Context, id string) (*AssignmentResponse, error) {
+	assignment, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toAssignmentResponse(*assignment, s.location)
+	return &resp, nil
+}
+
+// Create assigns a quiz set to users and groups
+func (s *assignmentService) Create(ctx context.Context, req AssignmentRequest) (*AssignmentResponse, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignment := &domain.Assignment{ID: sharedDomain.NewID(), CreatedBy: &userID}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.apply(ctx, assignment, req); err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, assignment); err != nil {
+			return sharedDomain.NewInternalError("Failed to create assignment", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toAssignmentResponse(*assignment, s.location)
+	return &resp, nil
+}
+
+// Update replaces an assignment. Attempts already started keep counting
+// against it, and stay late or on time as they were started.
+func (s *assignmentService) Update(ctx context.Context, id string, req AssignmentRequest) (*AssignmentResponse, error) {
+	assignment, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.apply(ctx, assignment, req); err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, assignment); err != nil {
+			if errors.Is(err, domain.ErrAssignmentNotFound) {
+				return err
+			}
+			return sharedDomain.NewInternalError("Failed to update assignment", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toAssignmentResponse(*assignment, s.location)
+	return &resp, nil
+}
+
+// Delete removes an assignment; its attempts are kept as ordinary attempts
+func (s *assignmentService) Delete(ctx context.Context, id string) error {
+	if _, err := s.load(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrAssignmentNotFound) {
+			return err
+		}
+		return sharedDomain.NewInternalError("Failed to delete assignment", err)
+	}
+	return nil
+}
+
+// Mine returns the assignments given to the current user, directly or
+// through a group, with where they stand on each, by due date
+func (s *assignmentService) Mine(ctx context.Context) ([]MyAssignmentResponse, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.repo.ListForUser(ctx, userID, nil)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch assignments", err)
+	}
+
+	ids := make([]string, len(assignments))
+	for i, a := range assignments {
+		ids[i] = a.ID
+	}
+	attempts, err := s.repo.ListAttempts(ctx, ids, userID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch attempts", err)
+	}
+	byAssignment := make(map[string][]domain.AttemptSummary, len(assignments))
+	for _, attempt := range attempts {
+		byAssignment[attempt.AssignmentID] = append(byAssignment[attempt.AssignmentID], attempt)
+	}
+
+	now := s.now()
+	responses := make([]MyAssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		responses[i] = toMyAssignmentResponse(a, a.Progress(now, byAssignment[a.ID]), s.location)
+	}
+	return responses, nil
+}
+
+// Admit decides whether userID may start an attempt at now. With an
+// assignment ID the attempt is for that assignment, which must be given to
+// the user. Otherwise it counts against the user's assignments of the quiz
+// set, preferring those still on time and then the one due soonest; it is
+// refused only when every one of them refuses it.
+func (s *assignmentService) Admit(ctx context.Context, userID string, quizSetID, assignmentID *string, now time.Time) (*attemptDomain.Admission, error) {
+	if assignmentID == nil && quizSetID == nil {
+		return nil, nil
+	}
+
+	filter := quizSetID
+	if assignmentID != nil {
+		filter = nil
+	}
+	candidates, err := s.repo.ListForUser(ctx, userID, filter)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch assignments", err)
+	}
+	if assignmentID != nil {
+		var found *domain.Assignment
+		for i := range candidates {
+			if candidates[i].ID == *assignmentID {
+				found = &candidates[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		if quizSetID != nil && *quizSetID != found.QuizSetID {
+			return nil, domain.ErrQuizSetMismatch
+		}
+		candidates = []domain.Assignment{*found}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return !candidates[i].IsLate(now) && candidates[j].IsLate(now)
+	})
+
+	var refused error
+	for _, a := range candidates {
+		used, err := s.repo.CountAttempts(ctx, a.ID, userID)
+		if err != nil {
+			return nil, sharedDomain.NewInternalError("Failed to count attempts", err)
+		}
+		late, err := a.Admit(now, used)
+		if err == nil {
+			return &attemptDomain.Admission{AssignmentID: a.ID, QuizSetID: a.QuizSetID, Late: late}, nil
+		}
+		if refused == nil {
+			refused = err
+		}
+	}
+	return nil, refused
+}
+
+// load returns an assignment the current user may see and change
+func (s *assignmentService) load(ctx context.Context, id string) (*domain.Assignment, error) {
+	if _, err := currentUser(ctx); err != nil {
+		return nil, err
+	}
+	assignment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrAssignmentNotFound) {
+			return nil, err
+		}
+		return nil, sharedDomain.NewInternalError("Failed to fetch assignment", err)
+	}
+	if err := authorize(ctx, assignment.IsOwnedBy); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// apply validates req and copies it onto assignment
+func (s *assignmentService) apply(ctx context.Context, assignment *domain.Assignment, req AssignmentRequest) error {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return domain.ErrInvalidTitle
+	}
+	opensAt, err := sharedDomain.ParseTime(req.OpensAt, s.location)
+	if err != nil {
+		return domain.ErrInvalidTime.WithDetails(map[string]interface{}{"field": "opens_at"})
+	}
+	dueAt, err := sharedDomain.ParseTime(req.DueAt, s.location)
+	if err != nil {
+		return domain.ErrInvalidTime.WithDetails(map[string]interface{}{"field": "due_at"})
+	}
+	if dueAt == nil {
+		return domain.ErrDueDateRequired
+	}
+	if opensAt != nil && !dueAt.After(*opensAt) {
+		return domain.ErrInvalidWindow
+	}
+
+	policy := req.LatePolicy
+	if policy == "" {
+		policy = domain.LatePolicyReject
+	}
+	if !policy.Valid() {
+		return domain.ErrInvalidLatePolicy
+	}
+	if policy == domain.LatePolicyPenalty {
+		if req.LatePenalty <= 0 || req.LatePenalty > 100 {
+			return domain.ErrInvalidLatePenalty
+		}
+	} else if req.LatePenalty != 0 {
+		return domain.ErrInvalidLatePenalty
+	}
+	if req.MaxAttempts != nil && *req.MaxAttempts < 1 {
+		return domain.ErrInvalidMaxAttempts
+	}
+
+	userIDs, groupIDs := distinct(req.UserIDs), distinct(req.GroupIDs)
+	if len(userIDs) == 0 && len(groupIDs) == 0 {
+		return domain.ErrNoAssignees
+	}
+
+	quizSetID := strings.TrimSpace(req.QuizSetID)
+	exists, err := s.repo.QuizSetExists(ctx, quizSetID)
+	if err != nil {
+		return sharedDomain.NewInternalError("Failed to check quiz set", err)
+	}
+	if !exists {
+		return domain.ErrQuizSetNotFound
+	}
+	if len(groupIDs) > 0 {
+		missing, err := s.repo.MissingGroupIDs(ctx, groupIDs)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to check groups", err)
+		}
+		if len(missing) > 0 {
+			return domain.ErrGroupNotFound.WithDetails(map[string]interface{}{"group_ids": missing})
+		}
+	}
+
+	assignment.QuizSetID, assignment.Title = quizSetID, title
+	assignment.OpensAt, assignment.DueAt = opensAt, *dueAt
+	assignment.LatePolicy, assignment.LatePenalty, assignment.MaxAttempts = policy, req.LatePenalty, req.MaxAttempts
+	assignment.UserIDs, assignment.GroupIDs = userIDs, groupIDs
+	return nil
+}
+
+// distinct returns the non-empty IDs in ids without duplicates, in order
+func distinct(ids []string) []string {
+	result := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
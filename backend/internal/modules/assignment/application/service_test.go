@@ -0,0 +1,289 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// passthroughTxManager runs fn without a real transaction
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// mockAssignmentRepository keeps assignments, group members and attempts in memory
+type mockAssignmentRepository struct {
+	assignments map[string]domain.Assignment
+	members     map[string][]string // user IDs by group
+	attempts    []domain.AttemptSummary
+	attemptUser map[string]string // user ID by attempt
+	quizSets    map[string]bool
+}
+
+func newMockRepo() *mockAssignmentRepository {
+	return &mockAssignmentRepository{
+		assignments: map[string]domain.Assignment{},
+		members:     map[string][]string{},
+		attemptUser: map[string]string{},
+		quizSets:    map[string]bool{"s1": true, "s2": true},
+	}
+}
+
+func (m *mockAssignmentRepository) GetByID(_ context.Context, id string) (*domain.Assignment, error) {
+	a, ok := m.assignments[id]
+	if !ok {
+		return nil, domain.ErrAssignmentNotFound
+	}
+	return &a, nil
+}
+
+func (m *mockAssignmentRepository) sorted(keep func(a domain.Assignment) bool) []domain.Assignment {
+	out := []domain.Assignment{}
+	for _, a := range m.assignments {
+		if keep(a) {
+			out = append(out, a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].DueAt.Before(out[j].DueAt) })
+	return out
+}
+
+func (m *mockAssignmentRepository) List(_ context.Context, createdBy *string) ([]domain.Assignment, error) {
+	return m.sorted(func(a domain.Assignment) bool { return createdBy == nil || a.IsOwnedBy(*createdBy) }), nil
+}
+
+func (m *mockAssignmentRepository) ListForUser(_ context.Context, userID string, quizSetID *string) ([]domain.Assignment, error) {
+	return m.sorted(func(a domain.Assignment) bool {
+		if quizSetID != nil && a.QuizSetID != *quizSetID {
+			return false
+		}
+		for _, id := range a.UserIDs {
+			if id == userID {
+				return true
+			}
+		}
+		for _, group := range a.GroupIDs {
+			for _, id := range m.members[group] {
+				if id == userID {
+					return true
+				}
+			}
+		}
+		return false
+	}), nil
+}
+
+func (m *mockAssignmentRepository) Create(_ context.Context, a *domain.Assignment) error {
+	m.assignments[a.ID] = *a
+	return nil
+}
+
+func (m *mockAssignmentRepository) Update(_ context.Context, a *domain.Assignment) error {
+	m.assignments[a.ID] = *a
+	return nil
+}
+
+func (m *mockAssignmentRepository) Delete(_ context.Context, id string) error {
+	delete(m.assignments, id)
+	return nil
+}
+
+func (m *mockAssignmentRepository) CountAttempts(_ context.Context, assignmentID, userID string) (int, error) {
+	n := 0
+	for _, attempt := range m.attempts {
+		if attempt.AssignmentID == assignmentID && m.attemptUser[attempt.ID] == userID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *mockAssignmentRepository) ListAttempts(_ context.Context, assignmentIDs []string, userID string) ([]domain.AttemptSummary, error) {
+	out := []domain.AttemptSummary{}
+	for _, attempt := range m.attempts {
+		for _, id := range assignmentIDs {
+			if attempt.AssignmentID == id && m.attemptUser[attempt.ID] == userID {
+				out = append(out, attempt)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *mockAssignmentRepository) QuizSetExists(_ context.Context, quizSetID string) (bool, error) {
+	return m.quizSets[quizSetID], nil
+}
+
+func (m *mockAssignmentRepository) MissingGroupIDs(_ context.Context, ids []string) ([]string, error) {
+	missing := []string{}
+	for _, id := range ids {
+		if _, ok := m.members[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+func (m *mockAssignmentRepository) addAttempt(attempt domain.AttemptSummary, userID string) {
+	m.attempts = append(m.attempts, attempt)
+	m.attemptUser[attempt.ID] = userID
+}
+
+var testNow = time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+
+func newTestService(repo *mockAssignmentRepository) *assignmentService {
+	service := NewAssignmentService(repo, passthroughTxManager{}, time.UTC).(*assignmentService)
+	service.now = func() time.Time { return testNow }
+	return service
+}
+
+func intPtr(n int) *int { return &n }
+
+func strPtr(s string) *string { return &s }
+
+// sameError reports whether err is want, possibly carrying details
+func sameError(err error, want *sharedDomain.AppError) bool {
+	var appErr *sharedDomain.AppError
+	return errors.As(err, &appErr) && appErr.Code == want.Code && appErr.Message == want.Message
+}
+
+func TestCreateAssignment_Validation(t *testing.T) {
+	repo := newMockRepo()
+	repo.members["g1"] = []string{"bob"}
+	service := newTestService(repo)
+	teacher := utils.SetUserID(context.Background(), "teacher")
+	valid := func(change func(req *AssignmentRequest)) AssignmentRequest {
+		req := AssignmentRequest{QuizSetID: "s1", Title: "Week 1", DueAt: "2026-03-08T09:00", UserIDs: []string{"alice"}}
+		change(&req)
+		return req
+	}
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		req  AssignmentRequest
+		want *sharedDomain.AppError
+	}{
+		{"anonymous", context.Background(), valid(func(*AssignmentRequest) {}), domain.ErrSignInRequired},
+		{"no title", teacher, valid(func(r *AssignmentRequest) { r.Title = " " }), domain.ErrInvalidTitle},
+		{"no due date", teacher, valid(func(r *AssignmentRequest) { r.DueAt = "" }), domain.ErrDueDateRequired},
+		{"bad time", teacher, valid(func(r *AssignmentRequest) { r.OpensAt = "soon" }), domain.ErrInvalidTime},
+		{"opens after due", teacher, valid(func(r *AssignmentRequest) { r.OpensAt = "2026-03-09T09:00" }), domain.ErrInvalidWindow},
+		{"unknown policy", teacher, valid(func(r *AssignmentRequest) { r.LatePolicy = "maybe" }), domain.ErrInvalidLatePolicy},
+		{"penalty without percent", teacher, valid(func(r *AssignmentRequest) { r.LatePolicy = domain.LatePolicyPenalty }), domain.ErrInvalidLatePenalty},
+		{"percent without penalty", teacher, valid(func(r *AssignmentRequest) { r.LatePenalty = 10 }), domain.ErrInvalidLatePenalty},
+		{"zero attempts", teacher, valid(func(r *AssignmentRequest) { r.MaxAttempts = intPtr(0) }), domain.ErrInvalidMaxAttempts},
+		{"nobody", teacher, valid(func(r *AssignmentRequest) { r.UserIDs = []string{""} }), domain.ErrNoAssignees},
+		{"unknown quiz set", teacher, valid(func(r *AssignmentRequest) { r.QuizSetID = "missing" }), domain.ErrQuizSetNotFound},
+		{"unknown group", teacher, valid(func(r *AssignmentRequest) { r.GroupIDs = []string{"g1", "g2"} }), domain.ErrGroupNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := service.Create(tc.ctx, tc.req); !sameError(err, tc.want) {
+				t.Errorf("expected %v, got: %v", tc.want, err)
+			}
+		})
+	}
+
+	resp, err := service.Create(teacher, valid(func(r *AssignmentRequest) {
+		r.LatePolicy, r.LatePenalty = domain.LatePolicyPenalty, 25
+		r.UserIDs, r.GroupIDs = []string{"alice", "alice"}, []string{"g1"}
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.CreatedBy == nil || *resp.CreatedBy != "teacher" || len(resp.UserIDs) != 1 || !resp.DueAt.Equal(time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the teacher's assignment due 2026-03-08 09:00 for alice, got %+v", resp)
+	}
+}
+
+func TestAssignment_OnlyCreatorOrAdmin(t *testing.T) {
+	repo := newMockRepo()
+	service := newTestService(repo)
+	teacher := utils.SetUserID(context.Background(), "teacher")
+	other := utils.SetUserID(context.Background(), "other")
+
+	resp, err := service.Create(teacher, AssignmentRequest{QuizSetID: "s1", Title: "Week 1", DueAt: "2026-03-08T09:00", UserIDs: []string{"alice"}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err := service.Get(other, resp.ID); !errors.Is(err, domain.ErrNotOwner) {
+		t.Errorf("expected ErrNotOwner, got: %v", err)
+	}
+	if err := service.Delete(other, resp.ID); !errors.Is(err, domain.ErrNotOwner) {
+		t.Errorf("expected ErrNotOwner, got: %v", err)
+	}
+	if list, err := service.List(other); err != nil || len(list) != 0 {
+		t.Errorf("expected other users to list none, got %v, %v", list, err)
+	}
+	admin := utils.SetUserRole(other, utils.RoleAdmin)
+	if list, err := service.List(admin); err != nil || len(list) != 1 {
+		t.Errorf("expected admins to list every assignment, got %v, %v", list, err)
+	}
+	if err := service.Delete(admin, resp.ID); err != nil {
+		t.Errorf("expected admins to delete, got: %v", err)
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	repo := newMockRepo()
+	repo.members["g1"] = []string{"alice"}
+	repo.assignments["past"] = domain.Assignment{ID: "past", QuizSetID: "s1", DueAt: testNow.Add(-time.Hour), LatePolicy: domain.LatePolicyAccept, GroupIDs: []string{"g1"}}
+	repo.assignments["soon"] = domain.Assignment{ID: "soon", QuizSetID: "s1", DueAt: testNow.Add(time.Hour), LatePolicy: domain.LatePolicyReject, MaxAttempts: intPtr(1), UserIDs: []string{"alice"}}
+	repo.assignments["other"] = domain.Assignment{ID: "other", QuizSetID: "s2", DueAt: testNow.Add(time.Hour), UserIDs: []string{"bob"}}
+	service := newTestService(repo)
+	ctx := context.Background()
+
+	admission, err := service.Admit(ctx, "alice", strPtr("s1"), nil, testNow)
+	if err != nil || admission == nil || admission.AssignmentID != "soon" || admission.Late {
+		t.Fatalf("expected the on-time assignment first, got %+v, %v", admission, err)
+	}
+	repo.addAttempt(domain.AttemptSummary{ID: "t1", AssignmentID: "soon"}, "alice")
+
+	admission, err = service.Admit(ctx, "alice", strPtr("s1"), nil, testNow)
+	if err != nil || admission == nil || admission.AssignmentID != "past" || !admission.Late {
+		t.Fatalf("expected a late attempt on the overdue assignment, got %+v, %v", admission, err)
+	}
+	if _, err := service.Admit(ctx, "alice", nil, strPtr("soon"), testNow); !errors.Is(err, domain.ErrNoAttemptsLeft) {
+		t.Errorf("expected ErrNoAttemptsLeft, got: %v", err)
+	}
+	if _, err := service.Admit(ctx, "alice", strPtr("s2"), strPtr("past"), testNow); !errors.Is(err, domain.ErrQuizSetMismatch) {
+		t.Errorf("expected ErrQuizSetMismatch, got: %v", err)
+	}
+	if _, err := service.Admit(ctx, "alice", nil, strPtr("other"), testNow); !errors.Is(err, domain.ErrAssignmentNotFound) {
+		t.Errorf("expected ErrAssignmentNotFound for another learner's assignment, got: %v", err)
+	}
+	if admission, err := service.Admit(ctx, "carol", strPtr("s1"), nil, testNow); err != nil || admission != nil {
+		t.Errorf("expected learners without assignments to be let through, got %+v, %v", admission, err)
+	}
+}
+
+func TestMine(t *testing.T) {
+	repo := newMockRepo()
+	repo.assignments["a1"] = domain.Assignment{ID: "a1", QuizSetID: "s1", DueAt: testNow.Add(time.Hour), LatePolicy: domain.LatePolicyReject, MaxAttempts: intPtr(3), UserIDs: []string{"alice"}}
+	repo.assignments["a2"] = domain.Assignment{ID: "a2", QuizSetID: "s2", DueAt: testNow.Add(-time.Hour), LatePolicy: domain.LatePolicyReject, UserIDs: []string{"alice"}}
+	repo.addAttempt(domain.AttemptSummary{ID: "t1", AssignmentID: "a1", Submitted: true, Score: 4, MaxScore: 5}, "alice")
+	service := newTestService(repo)
+
+	if _, err := service.Mine(context.Background()); !errors.Is(err, domain.ErrSignInRequired) {
+		t.Errorf("expected ErrSignInRequired, got: %v", err)
+	}
+	mine, err := service.Mine(utils.SetUserID(context.Background(), "alice"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(mine) != 2 || mine[0].ID != "a2" || mine[0].Status != domain.StatusMissed || mine[0].CanStart {
+		t.Fatalf("expected the missed assignment first, got %+v", mine)
+	}
+	if a1 := mine[1]; a1.Status != domain.StatusSubmitted || a1.AttemptsUsed != 1 || *a1.AttemptsLeft != 2 || *a1.BestScore != 4 || *a1.BestAttemptID != "t1" {
+		t.Errorf("expected a1 submitted with 4 points and 2 attempts left, got %+v", a1)
+	}
+}
@@ -0,0 +1,173 @@
+package domain
+
+import "time"
+
+// LatePolicy is how an assignment treats attempts started after its due date
+type LatePolicy string
+
+const (
+	// LatePolicyReject refuses late attempts
+	LatePolicyReject LatePolicy = "reject"
+	// LatePolicyAccept accepts late attempts and marks them late
+	LatePolicyAccept LatePolicy = "accept"
+	// LatePolicyPenalty accepts late attempts and takes LatePenalty percent
+	// off their score
+	LatePolicyPenalty LatePolicy = "penalty"
+)
+
+// Valid returns true if p is a known late policy
+func (p LatePolicy) Valid() bool {
+	return p == LatePolicyReject || p == LatePolicyAccept || p == LatePolicyPenalty
+}
+
+// Status is where a learner stands on an assignment
+type Status string
+
+const (
+	// StatusUpcoming is an assignment that has not opened yet
+	StatusUpcoming Status = "upcoming"
+	// StatusOpen is an assignment the learner can attempt before it is due
+	StatusOpen Status = "open"
+	// StatusInProgress is an assignment with an attempt the learner has not
+	// submitted yet
+	StatusInProgress Status = "in_progress"
+	// StatusSubmitted is an assignment with a submitted attempt
+	StatusSubmitted Status = "submitted"
+	// StatusOverdue is an assignment past due that still accepts late attempts
+	StatusOverdue Status = "overdue"
+	// StatusMissed is an assignment the learner can no longer attempt
+	// without having submitted it
+	StatusMissed Status = "missed"
+)
+
+// Assignment is a quiz set that users, directly or through their groups,
+// must attempt by a due date
+type Assignment struct {
+	ID          string     `db:"id"`
+	QuizSetID   string     `db:"quiz_set_id"`
+	Title       string     `db:"title"`
+	OpensAt     *time.Time `db:"opens_at"`
+	DueAt       time.Time  `db:"due_at"`
+	LatePolicy  LatePolicy `db:"late_policy"`
+	LatePenalty float64    `db:"late_penalty"`
+	// MaxAttempts limits the attempts of each learner; nil allows any number
+	MaxAttempts *int      `db:"max_attempts"`
+	CreatedBy   *string   `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	UserIDs     []string  `db:"-"`
+	GroupIDs    []string  `db:"-"`
+}
+
+// AttemptSummary is an attempt a learner started for an assignment
+type AttemptSummary struct {
+	ID           string     `db:"id"`
+	AssignmentID string     `db:"assignment_id"`
+	Submitted    bool       `db:"submitted"`
+	Late         bool       `db:"late"`
+	Score        float64    `db:"score"`
+	MaxScore     float64    `db:"max_score"`
+	StartedAt    time.Time  `db:"started_at"`
+	SubmittedAt  *time.Time `db:"submitted_at"`
+}
+
+// Progress is a learner's standing on an assignment
+type Progress struct {
+	Status       Status
+	AttemptsUsed int
+	// AttemptsLeft is nil when attempts are unlimited
+	AttemptsLeft *int
+	CanStart     bool
+	// Best is the submitted attempt with the highest graded score
+	Best *AttemptSummary
+	// BestScore is the score of Best after any late penalty
+	BestScore *float64
+}
+
+// IsOwnedBy returns true if the assignment was created by userID
+func (a *Assignment) IsOwnedBy(userID string) bool {
+	return a.CreatedBy != nil && *a.CreatedBy == userID
+}
+
+// IsLate returns true if an attempt started at t is late
+func (a *Assignment) IsLate(t time.Time) bool {
+	return t.After(a.DueAt)
+}
+
+// Admit decides whether a learner who already started used attempts may
+// start another at now, and returns whether it is late
+func (a *Assignment) Admit(now time.Time, used int) (bool, error) {
+	if a.OpensAt != nil && now.Before(*a.OpensAt) {
+		return false, ErrAssignmentNotOpen
+	}
+	if a.MaxAttempts != nil && used >= *a.MaxAttempts {
+		return false, ErrNoAttemptsLeft
+	}
+	late := a.IsLate(now)
+	if late && a.LatePolicy == LatePolicyReject {
+		return false, ErrAssignmentClosed
+	}
+	return late, nil
+}
+
+// Grade returns the score an attempt counts for, after the late penalty
+func (a *Assignment) Grade(attempt AttemptSummary) float64 {
+	if attempt.Late && a.LatePolicy == LatePolicyPenalty {
+		return attempt.Score * (1 - a.LatePenalty/100)
+	}
+	return attempt.Score
+}
+
+// Progress returns where a learner who started attempts stands at now
+func (a *Assignment) Progress(now time.Time, attempts []AttemptSummary) Progress {
+	p := Progress{AttemptsUsed: len(attempts)}
+	if a.MaxAttempts != nil {
+		left := max(*a.MaxAttempts-len(attempts), 0)
+		p.AttemptsLeft = &left
+	}
+	_, err := a.Admit(now, len(attempts))
+	p.CanStart = err == nil
+
+	inProgress := false
+	for i, attempt := range attempts {
+		if !attempt.Submitted {
+			inProgress = true
+			continue
+		}
+		score := a.Grade(attempt)
+		if p.BestScore == nil || score > *p.BestScore {
+			p.Best, p.BestScore = &attempts[i], &score
+		}
+	}
+
+	switch {
+	case p.Best != nil:
+		p.Status = StatusSubmitted
+	case inProgress:
+		p.Status = StatusInProgress
+	case a.OpensAt != nil && now.Before(*a.OpensAt):
+		p.Status = StatusUpcoming
+	case p.CanStart && a.IsLate(now):
+		p.Status = StatusOverdue
+	case p.CanStart:
+		p.Status = StatusOpen
+	default:
+		p.Status = StatusMissed
+	}
+	return p
+}
+
+// Group is a named set of users, such as a class
+type Group struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	CreatedBy *string   `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	UserIDs   []string  `db:"-"`
+}
+
+// IsOwnedBy returns true if the group was created by userID
+func (g *Group) IsOwnedBy(userID string) bool {
+	return g.CreatedBy != nil && *g.CreatedBy == userID
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func intPtr(n int) *int { return &n }
+
+func newAssignment(policy LatePolicy) *Assignment {
+	opensAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	return &Assignment{
+		ID:          "a1",
+		OpensAt:     &opensAt,
+		DueAt:       time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC),
+		LatePolicy:  policy,
+		LatePenalty: 20,
+		MaxAttempts: intPtr(2),
+	}
+}
+
+func TestAssignment_Admit(t *testing.T) {
+	before := time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC)
+	during := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	after := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		policy   LatePolicy
+		now      time.Time
+		used     int
+		wantLate bool
+		want     error
+	}{
+		{"not open yet", LatePolicyAccept, before, 0, false, ErrAssignmentNotOpen},
+		{"on time", LatePolicyReject, during, 1, false, nil},
+		{"out of attempts", LatePolicyAccept, during, 2, false, ErrNoAttemptsLeft},
+		{"late refused", LatePolicyReject, after, 0, false, ErrAssignmentClosed},
+		{"late accepted", LatePolicyAccept, after, 0, true, nil},
+		{"late with penalty", LatePolicyPenalty, after, 1, true, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			late, err := newAssignment(tc.policy).Admit(tc.now, tc.used)
+			if !errors.Is(err, tc.want) || late != tc.wantLate {
+				t.Errorf("expected late=%v and %v, got late=%v and %v", tc.wantLate, tc.want, late, err)
+			}
+		})
+	}
+}
+
+func TestAssignment_Progress(t *testing.T) {
+	during := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	after := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	submitted := func(id string, score float64, late bool) AttemptSummary {
+		return AttemptSummary{ID: id, Submitted: true, Score: score, MaxScore: 10, Late: late}
+	}
+
+	cases := []struct {
+		name     string
+		policy   LatePolicy
+		now      time.Time
+		attempts []AttemptSummary
+		want     Status
+		canStart bool
+	}{
+		{"upcoming", LatePolicyReject, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), nil, StatusUpcoming, false},
+		{"open", LatePolicyReject, during, nil, StatusOpen, true},
+		{"in progress", LatePolicyReject, during, []AttemptSummary{{ID: "t1"}}, StatusInProgress, true},
+		{"submitted", LatePolicyReject, during, []AttemptSummary{submitted("t1", 5, false)}, StatusSubmitted, true},
+		{"overdue", LatePolicyAccept, after, nil, StatusOverdue, true},
+		{"missed after due", LatePolicyReject, after, nil, StatusMissed, false},
+		{"in progress without attempts left", LatePolicyReject, during, []AttemptSummary{{ID: "t1"}, {ID: "t2"}}, StatusInProgress, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newAssignment(tc.policy).Progress(tc.now, tc.attempts)
+			if p.Status != tc.want || p.CanStart != tc.canStart {
+				t.Errorf("expected %s with can_start=%v, got %s with can_start=%v", tc.want, tc.canStart, p.Status, p.CanStart)
+			}
+		})
+	}
+}
+
+func TestAssignment_ProgressGradesLatePenalty(t *testing.T) {
+	a := newAssignment(LatePolicyPenalty)
+	attempts := []AttemptSummary{
+		{ID: "t1", Submitted: true, Score: 6, MaxScore: 10},
+		{ID: "t2", Submitted: true, Score: 7, MaxScore: 10, Late: true},
+	}
+
+	p := a.Progress(time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC), attempts)
+	if p.Best == nil || p.Best.ID != "t1" || *p.BestScore != 6 {
+		t.Errorf("expected t1 to beat the penalised 5.6 of t2, got %+v", p.Best)
+	}
+	if p.AttemptsUsed != 2 || p.AttemptsLeft == nil || *p.AttemptsLeft != 0 || p.CanStart {
+		t.Errorf("expected no attempts left, got %+v", p)
+	}
+}
@@ -0,0 +1,24 @@
+package domain
+
+import sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+
+var (
+	ErrAssignmentNotFound = sharedDomain.NewNotFoundError("Assignment not found")
+	ErrGroupNotFound      = sharedDomain.NewNotFoundError("Group not found")
+	ErrQuizSetNotFound    = sharedDomain.NewNotFoundError("Quiz set not found")
+	ErrSignInRequired     = sharedDomain.NewUnauthorizedError("Sign in to use assignments and groups")
+	ErrNotOwner           = sharedDomain.NewForbiddenError("Only the user who created an assignment or group, or an admin, may see or change it")
+	ErrInvalidTitle       = sharedDomain.NewValidationError("title is required")
+	ErrInvalidName        = sharedDomain.NewValidationError("name is required")
+	ErrInvalidTime        = sharedDomain.NewValidationError("opens_at and due_at must be RFC 3339 timestamps or local times such as 2025-03-01T09:00")
+	ErrDueDateRequired    = sharedDomain.NewValidationError("due_at is required")
+	ErrInvalidWindow      = sharedDomain.NewValidationError("due_at must be after opens_at")
+	ErrInvalidLatePolicy  = sharedDomain.NewValidationError("late_policy must be reject, accept or penalty")
+	ErrInvalidLatePenalty = sharedDomain.NewValidationError("late_penalty must be greater than 0 and at most 100 with the penalty policy, and is not allowed otherwise")
+	ErrInvalidMaxAttempts = sharedDomain.NewValidationError("max_attempts must be at least 1")
+	ErrNoAssignees        = sharedDomain.NewValidationError("Assign to at least one user or group")
+	ErrQuizSetMismatch    = sharedDomain.NewValidationError("The assignment is for another quiz set")
+	ErrAssignmentNotOpen  = sharedDomain.NewConflictError("The assignment has not opened yet")
+	ErrAssignmentClosed   = sharedDomain.NewConflictError("The assignment is past due and does not accept late attempts")
+	ErrNoAttemptsLeft     = sharedDomain.NewConflictError("No attempts are left on the assignment")
+)
@@ -0,0 +1,60 @@
+package domain
+
+import "context"
+
+// AssignmentRepository defines the interface for assignment data access
+type AssignmentRepository interface {
+	// GetByID returns an assignment with its users and groups
+	GetByID(ctx context.Context, id string) (*Assignment, error)
+
+	// List returns the assignments created by createdBy, or every
+	// assignment when it is nil, by due date
+	List(ctx context.Context, createdBy *string) ([]Assignment, error)
+
+	// ListForUser returns the assignments given to userID directly or
+	// through a group, only those of quizSetID when it is not nil
+	ListForUser(ctx context.Context, userID string, quizSetID *string) ([]Assignment, error)
+
+	// Create inserts an assignment with its users and groups
+	Create(ctx context.Context, assignment *Assignment) error
+
+	// Update replaces an assignment with its users and groups
+	Update(ctx context.Context, assignment *Assignment) error
+
+	// Delete removes an assignment; its attempts are kept
+	Delete(ctx context.Context, id string) error
+
+	// CountAttempts returns the number of attempts userID started for an
+	// assignment. Inside a transaction, attempts of the same learner on the
+	// assignment are counted one at a time until it ends.
+	CountAttempts(ctx context.Context, assignmentID, userID string) (int, error)
+
+	// ListAttempts returns the attempts userID started for the given
+	// assignments, oldest first
+	ListAttempts(ctx context.Context, assignmentIDs []string, userID string) ([]AttemptSummary, error)
+
+	// QuizSetExists returns true if the quiz set exists
+	QuizSetExists(ctx context.Context, quizSetID string) (bool, error)
+
+	// MissingGroupIDs returns the IDs that are not groups
+	MissingGroupIDs(ctx context.Context, ids []string) ([]string, error)
+}
+
+// GroupRepository defines the interface for group data access
+type GroupRepository interface {
+	// GetByID returns a group with its members
+	GetByID(ctx context.Context, id string) (*Group, error)
+
+	// List returns the groups created by createdBy, or every group when it
+	// is nil, by name
+	List(ctx context.Context, createdBy *string) ([]Group, error)
+
+	// Create inserts a group with its members
+	Create(ctx context.Context, group *Group) error
+
+	// Update replaces the name and members of a group
+	Update(ctx context.Context, group *Group) error
+
+	// Delete removes a group; assignments given to it no longer reach its members
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type postgresGroupRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresGroupRepository creates a new PostgreSQL group repository
+func NewPostgresGroupRepository(db *sqlx.DB) domain.GroupRepository {
+	return &postgresGroupRepository{db: db}
+}
+
+func (r *postgresGroupRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// GetByID returns a group with its members
+func (r *postgresGroupRepository) GetByID(ctx context.Context, id string) (*domain.Group, error) {
+	var group domain.Group
+	query := `SELECT id, name, created_by, created_at, updated_at FROM user_groups WHERE id::text = $1`
+	err := r.getQueryable(ctx).GetContext(ctx, &group, query, id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrGroupNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []domain.Group{group}
+	if err := r.loadMembers(ctx, groups); err != nil {
+		return nil, err
+	}
+	return &groups[0], nil
+}
+
+// List returns the groups created by createdBy, or every group when it is
+// nil, by name
+func (r *postgresGroupRepository) List(ctx context.Context, createdBy *string) ([]domain.Group, error) {
+	groups := []domain.Group{}
+	query := `SELECT id, name, created_by, created_at, updated_at FROM user_groups
+	           WHERE $1::text IS NULL OR created_by = $1
+	           ORDER BY name ASC, created_at ASC`
+	if err := r.getQueryable(ctx).SelectContext(ctx, &groups, query, createdBy); err != nil {
+		return nil, err
+	}
+	return groups, r.loadMembers(ctx, groups)
+}
+
+// loadMembers fills in the members of groups
+func (r *postgresGroupRepository) loadMembers(ctx context.Context, groups []domain.Group) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	ids := make([]string, len(groups))
+	for i := range groups {
+		ids[i] = groups[i].ID
+	}
+
+	var members []struct {
+		GroupID string `db:"group_id"`
+		UserID  string `db:"user_id"`
+	}
+	query := `SELECT group_id, user_id FROM user_group_members WHERE group_id::text = ANY($1) ORDER BY group_id, user_id`
+	if err := r.getQueryable(ctx).SelectContext(ctx, &members, query, pq.Array(ids)); err != nil {
+		return err
+	}
+
+	byID := make(map[string][]string, len(groups))
+	for _, m := range members {
+		byID[m.GroupID] = append(byID[m.GroupID], m.UserID)
+	}
+	for i := range groups {
+		groups[i].UserIDs = byID[groups[i].ID]
+		if groups[i].UserIDs == nil {
+			groups[i].UserIDs = []string{}
+		}
+	}
+	return nil
+}
+
+// Create inserts a group with its members
+func (r *postgresGroupRepository) Create(ctx context.Context, group *domain.Group) error {
+	query := `INSERT INTO user_groups (id, name, created_by, created_at, updated_at)
+	           VALUES ($1, $2, $3, NOW(), NOW())
+	           RETURNING created_at, updated_at`
+	if err := r.getQueryable(ctx).GetContext(ctx, group, query, group.ID, group.Name, group.CreatedBy); err != nil {
+		return err
+	}
+	return r.insertMembers(ctx, group)
+}
+
+// Update replaces the name and members of a group
+func (r *postgresGroupRepository) Update(ctx context.Context, group *domain.Group) error {
+	query := `UPDATE user_groups SET name = $2, updated_at = NOW() WHERE id = $1 RETURNING created_at, updated_at`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, group, query, group.ID, group.Name)
+	if err == sql.ErrNoRows {
+		return domain.ErrGroupNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.ExecContext(ctx, `DELETE FROM user_group_members WHERE group_id = $1`, group.ID); err != nil {
+		return err
+	}
+	return r.insertMembers(ctx, group)
+}
+
+// insertMembers stores the members of a group
+func (r *postgresGroupRepository) insertMembers(ctx context.Context, group *domain.Group) error {
+	query := `INSERT INTO user_group_members (group_id, user_id) SELECT $1, unnest($2::text[])`
+	_, err := r.getQueryable(ctx).ExecContext(ctx, query, group.ID, pq.Array(group.UserIDs))
+	return err
+}
+
+// Delete removes a group; assignments given to it no longer reach its members
+func (r *postgresGroupRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.getQueryable(ctx).ExecContext(ctx, `DELETE FROM user_groups WHERE id::text = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrGroupNotFound
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const assignmentColumns = `a.id, a.quiz_set_id, a.title, a.opens_at, a.due_at, a.late_policy, a.late_penalty, a.max_attempts, a.created_by, a.created_at, a.updated_at`
+
+type postgresAssignmentRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresAssignmentRepository creates a new PostgreSQL assignment repository
+func NewPostgresAssignmentRepository(db *sqlx.DB) domain.AssignmentRepository {
+	return &postgresAssignmentRepository{db: db}
+}
+
+func (r *postgresAssignmentRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// GetByID returns an assignment with its users and groups
+func (r *postgresAssignmentRepository) GetByID(ctx context.Context, id string) (*domain.Assignment, error) {
+	var assignment domain.Assignment
+	query := `SELECT ` + assignmentColumns + ` FROM assignments a WHERE a.id::text = $1`
+	err := r.getQueryable(ctx).GetContext(ctx, &assignment, query, id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrAssignmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := []domain.Assignment{assignment}
+	if err := r.loadAssignees(ctx, assignments); err != nil {
+		return nil, err
+	}
+	return &assignments[0], nil
+}
+
+// List returns the assignments created by createdBy, or every assignment
+// when it is nil, by due date
+func (r *postgresAssignmentRepository) List(ctx context.Context, createdBy *string) ([]domain.Assignment, error) {
+	assignments := []domain.Assignment{}
+	query := `SELECT ` + assignmentColumns + ` FROM assignments a
+	           WHERE $1::text IS NULL OR a.created_by = $1
+	           ORDER BY a.due_at ASC, a.created_at ASC`
+	if err := r.getQueryable(ctx).SelectContext(ctx, &assignments, query, createdBy); err != nil {
+		return nil, err
+	}
+	return assignments, r.loadAssignees(ctx, assignments)
+}
+
+// ListForUser returns the assignments given to userID directly or through a
+// group, by due date
+func (r *postgresAssignmentRepository) ListForUser(ctx context.Context, userID string, quizSetID *string) ([]domain.Assignment, error) {
+	assignments := []domain.Assignment{}
+	query := `SELECT ` + assignmentColumns + ` FROM assignments a
+	           WHERE ($2::text IS NULL OR a.quiz_set_id::text = $2)
+	             AND (EXISTS (SELECT 1 FROM assignment_users u WHERE u.assignment_id = a.id AND u.user_id = $1)
+	               OR EXISTS (SELECT 1 FROM assignment_groups g JOIN user_group_members m ON m.group_id = g.group_id
+	                          WHERE g.assignment_id = a.id AND m.user_id = $1))
+	           ORDER BY a.due_at ASC, a.created_at ASC`
+	if err := r.getQueryable(ctx).SelectContext(ctx, &assignments, query, userID, quizSetID); err != nil {
+		return nil, err
+	}
+	return assignments, r.loadAssignees(ctx, assignments)
+}
+
+// loadAssignees fills in the users and groups of assignments
+func (r *postgresAssignmentRepository) loadAssignees(ctx context.Context, assignments []domain.Assignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+	ids := make([]string, len(assignments))
+	for i := range assignments {
+		ids[i] = assignments[i].ID
+	}
+
+	var rows []struct {
+		AssignmentID string `db:"assignment_id"`
+		UserID       string `db:"user_id"`
+		GroupID      string `db:"group_id"`
+	}
+	query := `SELECT assignment_id, user_id, '' AS group_id FROM assignment_users WHERE assignment_id::text = ANY($1)
+	           UNION ALL
+	           SELECT assignment_id, '' AS user_id, group_id::text FROM assignment_groups WHERE assignment_id::text = ANY($1)
+	           ORDER BY 1, 2, 3`
+	if err := r.getQueryable(ctx).SelectContext(ctx, &rows, query, pq.Array(ids)); err != nil {
+		return err
+	}
+
+	users := make(map[string][]string, len(assignments))
+	groups := make(map[string][]string, len(assignments))
+	for _, row := range rows {
+		if row.GroupID != "" {
+			groups[row.AssignmentID] = append(groups[row.AssignmentID], row.GroupID)
+		} else {
+			users[row.AssignmentID] = append(users[row.AssignmentID], row.UserID)
+		}
+	}
+	for i := range assignments {
+		assignments[i].UserIDs = users[assignments[i].ID]
+		if assignments[i].UserIDs == nil {
+			assignments[i].UserIDs = []string{}
+		}
+		assignments[i].GroupIDs = groups[assignments[i].ID]
+		if assignments[i].GroupIDs == nil {
+			assignments[i].GroupIDs = []string{}
+		}
+	}
+	return nil
+}
+
+// Create inserts an assignment with its users and groups
+func (r *postgresAssignmentRepository) Create(ctx context.Context, assignment *domain.Assignment) error {
+	query := `INSERT INTO assignments (id, quiz_set_id, title, opens_at, due_at, late_policy, late_penalty, max_attempts, created_by, created_at, updated_at)
+	           VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+	           RETURNING created_at, updated_at`
+	err := r.getQueryable(ctx).GetContext(ctx, assignment, query, assignment.ID, assignment.QuizSetID, assignment.Title,
+		assignment.OpensAt, assignment.DueAt, assignment.LatePolicy, assignment.LatePenalty, assignment.MaxAttempts, assignment.CreatedBy)
+	if err != nil {
+		return err
+	}
+	return r.insertAssignees(ctx, assignment)
+}
+
+// Update replaces an assignment with its users and groups
+func (r *postgresAssignmentRepository) Update(ctx context.Context, assignment *domain.Assignment) error {
+	query := `UPDATE assignments SET quiz_set_id = $2, title = $3, opens_at = $4, due_at = $5, late_policy = $6,
+	                 late_penalty = $7, max_attempts = $8, updated_at = NOW()
+	           WHERE id = $1 RETURNING created_at, updated_at`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, assignment, query, assignment.ID, assignment.QuizSetID, assignment.Title,
+		assignment.OpensAt, assignment.DueAt, assignment.LatePolicy, assignment.LatePenalty, assignment.MaxAttempts)
+	if err == sql.ErrNoRows {
+		return domain.ErrAssignmentNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.ExecContext(ctx, `DELETE FROM assignment_users WHERE assignment_id = $1`, assignment.ID); err != nil {
+		return err
+	}
+	if _, err := q.ExecContext(ctx, `DELETE FROM assignment_groups WHERE assignment_id = $1`, assignment.ID); err != nil {
+		return err
+	}
+	return r.insertAssignees(ctx, assignment)
+}
+
+// insertAssignees stores the users and groups of an assignment
+func (r *postgresAssignmentRepository) insertAssignees(ctx context.Context, assignment *domain.Assignment) error {
+	q := r.getQueryable(ctx)
+	query := `INSERT INTO assignment_users (assignment_id, user_id) SELECT $1, unnest($2::text[])`
+	if _, err := q.ExecContext(ctx, query, assignment.ID, pq.Array(assignment.UserIDs)); err != nil {
+		return err
+	}
+	query = `INSERT INTO assignment_groups (assignment_id, group_id) SELECT $1, unnest($2::uuid[])`
+	_, err := q.ExecContext(ctx, query, assignment.ID, pq.Array(assignment.GroupIDs))
+	return err
+}
+
+// Delete removes an assignment; its attempts are kept
+func (r *postgresAssignmentRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.getQueryable(ctx).ExecContext(ctx, `DELETE FROM assignments WHERE id::text = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrAssignmentNotFound
+	}
+	return nil
+}
+
+// CountAttempts returns the number of attempts userID started for an
+// assignment. Inside a transaction it takes a lock on the learner and
+// assignment that is held until the transaction ends, so concurrent
+// starts cannot both see room for one more attempt.
+func (r *postgresAssignmentRepository) CountAttempts(ctx context.Context, assignmentID, userID string) (int, error) {
+	q := r.getQueryable(ctx)
+	if database.GetTx(ctx) != nil {
+		if _, err := q.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`, assignmentID, userID); err != nil {
+			return 0, err
+		}
+	}
+	var count int
+	query := `SELECT COUNT(*) FROM attempts WHERE assignment_id::text = $1 AND user_id = $2`
+	if err := q.GetContext(ctx, &count, query, assignmentID, userID); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListAttempts returns the attempts userID started for the given
+// assignments, oldest first
+func (r *postgresAssignmentRepository) ListAttempts(ctx context.Context, assignmentIDs []string, userID string) ([]domain.AttemptSummary, error) {
+	attempts := []domain.AttemptSummary{}
+	if len(assignmentIDs) == 0 {
+		return attempts, nil
+	}
+	query := `SELECT id, assignment_id, status = 'submitted' AS submitted, late, score, max_score, started_at, submitted_at
+	           FROM attempts WHERE assignment_id::text = ANY($1) AND user_id = $2
+	           ORDER BY started_at ASC`
+	if err := r.getQueryable(ctx).SelectContext(ctx, &attempts, query, pq.Array(assignmentIDs), userID); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// QuizSetExists returns true if the quiz set exists
+func (r *postgresAssignmentRepository) QuizSetExists(ctx context.Context, quizSetID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM quiz_sets WHERE id::text = $1)`
+	if err := r.getQueryable(ctx).GetContext(ctx, &exists, query, quizSetID); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// MissingGroupIDs returns the IDs that are not groups
+func (r *postgresAssignmentRepository) MissingGroupIDs(ctx context.Context, ids []string) ([]string, error) {
+	missing := []string{}
+	query := `SELECT t.id FROM unnest($1::text[]) AS t(id)
+	           WHERE NOT EXISTS (SELECT 1 FROM user_groups g WHERE g.id::text = t.id)`
+	if err := r.getQueryable(ctx).SelectContext(ctx, &missing, query, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
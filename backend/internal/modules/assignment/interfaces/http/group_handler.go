@@ -0,0 +1,87 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// GroupHandler handles HTTP requests for groups
+type GroupHandler struct {
+	service application.GroupService
+}
+
+// NewGroupHandler creates a new GroupHandler
+func NewGroupHandler(service application.GroupService) *GroupHandler {
+	return &GroupHandler{service: service}
+}
+
+// List handles GET /groups, which returns the groups the caller created,
+// or every group for admins
+func (h *GroupHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.service.List(r.Context())
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, items)
+}
+
+// Get handles GET /groups/{id}
+func (h *GroupHandler) Get(w http.ResponseWriter, r *http.Request) {
+	item, err := h.service.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, item)
+}
+
+// Create handles POST /groups
+func (h *GroupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req application.GroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	item, err := h.service.Create(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, item)
+}
+
+// Update handles PUT /groups/{id}
+func (h *GroupHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req application.GroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	item, err := h.service.Update(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, item)
+}
+
+// Delete handles DELETE /groups/{id}
+func (h *GroupHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.NoContent(w)
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// AssignmentHandler handles HTTP requests for assignments
+type AssignmentHandler struct {
+	service application.AssignmentService
+}
+
+// NewAssignmentHandler creates a new AssignmentHandler
+func NewAssignmentHandler(service application.AssignmentService) *AssignmentHandler {
+	return &AssignmentHandler{service: service}
+}
+
+// List handles GET /assignments, which returns the assignments the caller created,
+// or every assignment for admins
+func (h *AssignmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.service.List(r.Context())
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, items)
+}
+
+// Mine handles GET /assignments/mine, which returns the assignments given
+// to the caller with their status
+func (h *AssignmentHandler) Mine(w http.ResponseWriter, r *http.Request) {
+	assignments, err := h.service.Mine(r.Context())
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, assignments)
+}
+
+// Get handles GET /assignments/{id}
+func (h *AssignmentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	item, err := h.service.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, item)
+}
+
+// Create handles POST /assignments
+func (h *AssignmentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req application.AssignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	item, err := h.service.Create(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, item)
+}
+
+// Update handles PUT /assignments/{id}
+func (h *AssignmentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req application.AssignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	item, err := h.service.Update(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, item)
+}
+
+// Delete handles DELETE /assignments/{id}
+func (h *AssignmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.NoContent(w)
+}
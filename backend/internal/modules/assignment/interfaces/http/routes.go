@@ -0,0 +1,29 @@
+package http
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/application"
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterRoutes registers all assignment module routes
+func RegisterRoutes(r chi.Router, service application.AssignmentService, groups application.GroupService) {
+	handler := NewAssignmentHandler(service)
+	groupHandler := NewGroupHandler(groups)
+
+	r.Route("/assignments", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Post("/", handler.Create)
+		r.Get("/mine", handler.Mine)
+		r.Get("/{id}", handler.Get)
+		r.Put("/{id}", handler.Update)
+		r.Delete("/{id}", handler.Delete)
+	})
+
+	r.Route("/groups", func(r chi.Router) {
+		r.Get("/", groupHandler.List)
+		r.Post("/", groupHandler.Create)
+		r.Get("/{id}", groupHandler.Get)
+		r.Put("/{id}", groupHandler.Update)
+		r.Delete("/{id}", groupHandler.Delete)
+	})
+}
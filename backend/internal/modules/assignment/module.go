@@ -0,0 +1,38 @@
+package assignment
+
+import (
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment/infrastructure"
+	httpinterface "github.com/cananga-odorata/golang-template/internal/modules/assignment/interfaces/http"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Module represents the assignment module with all its dependencies
+type Module struct {
+	Service application.AssignmentService
+	Groups  application.GroupService
+}
+
+// NewModule initializes the assignment module with all dependencies. Times
+// without a UTC offset are read in location (nil means UTC). Service also
+// admits attempts for the attempt module.
+func NewModule(db *sqlx.DB, location *time.Location) *Module {
+	if location == nil {
+		location = time.UTC
+	}
+	txManager := database.NewTxManager(db)
+
+	return &Module{
+		Service: application.NewAssignmentService(infrastructure.NewPostgresAssignmentRepository(db), txManager, location),
+		Groups:  application.NewGroupService(infrastructure.NewPostgresGroupRepository(db), txManager),
+	}
+}
+
+// RegisterRoutes registers the module's HTTP routes
+func (m *Module) RegisterRoutes(r chi.Router) {
+	httpinterface.RegisterRoutes(r, m.Service, m.Groups)
+}
@@ -0,0 +1,196 @@
+package application
+
+import (
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+)
+
+// StartAttemptRequest DTO for starting an attempt. Fixed attempts take every
+// quiz of QuizSetID in set order. Adaptive attempts draw from QuizSetID, or
+// from all published quizzes without it, and end after MaxItems questions or
+// once the ability's standard error is at most TargetSE. AssignmentID starts
+// the attempt for an assignment, on its quiz set; without it, attempts on an
+// assigned quiz set count against the learner's assignment due soonest.
+type StartAttemptRequest struct {
+	QuizSetID    *string      `json:"quiz_set_id"`
+	AssignmentID *string      `json:"assignment_id"`
+	Mode         domain.Mode  `json:"mode"`
+	Model        domain.Model `json:"model"`
+	MaxItems     int          `json:"max_items"`
+	TargetSE     *float64     `json:"target_se"`
+}
+
+// AnswerRequest DTO for answering the current question of an attempt
+type AnswerRequest struct {
+	QuizID string `json:"quiz_id"`
+	Choice int    `json:"choice"`
+}
+
+// HintRequest DTO for revealing the next hint of the current question
+type HintRequest struct {
+	QuizID string `json:"quiz_id"`
+}
+
+// ItemParametersRequest DTO for calibrating a quiz for adaptive attempts
+type ItemParametersRequest struct {
+	Discrimination float64 `json:"discrimination"`
+	Difficulty     float64 `json:"difficulty"`
+}
+
+// AttemptResponse DTO for an attempt. Question is the question to answer
+// while the attempt is in progress; Answers and Ability are reported once it
+// is submitted. HintPenalty is the score correct answers lost to hints.
+type AttemptResponse struct {
+	ID           string            `json:"id"`
+	QuizSetID    *string           `json:"quiz_set_id,omitempty"`
+	UserID       *string           `json:"user_id,omitempty"`
+	AssignmentID *string           `json:"assignment_id,omitempty"`
+	Late         bool              `json:"late,omitempty"`
+	Mode         domain.Mode       `json:"mode"`
+	Model        *domain.Model     `json:"model,omitempty"`
+	MaxItems     int               `json:"max_items"`
+	TargetSE     *float64          `json:"target_se,omitempty"`
+	Status       domain.Status     `json:"status"`
+	Answered     int               `json:"answered"`
+	Score        float64           `json:"score"`
+	MaxScore     float64           `json:"max_score"`
+	HintsUsed    int               `json:"hints_used"`
+	HintPenalty  float64           `json:"hint_penalty"`
+	Ability      *AbilityResponse  `json:"ability,omitempty"`
+	Question     *QuestionResponse `json:"question,omitempty"`
+	Answers      []AnswerResponse  `json:"answers,omitempty"`
+	StartedAt    time.Time         `json:"started_at"`
+	SubmittedAt  *time.Time        `json:"submitted_at,omitempty"`
+}
+
+// AbilityResponse DTO for an ability estimate with its 95% confidence interval
+type AbilityResponse struct {
+	Estimate      float64 `json:"estimate"`
+	StandardError float64 `json:"standard_error"`
+	Lower         float64 `json:"lower"`
+	Upper         float64 `json:"upper"`
+}
+
+// QuestionResponse DTO for a question presented in an attempt, without its
+// answer, with the hints revealed so far and how many more can be revealed
+type QuestionResponse struct {
+	Position  int                    `json:"position"`
+	QuizID    string                 `json:"quiz_id"`
+	Question  string                 `json:"question"`
+	Choice1   string                 `json:"choice1"`
+	Choice2   string                 `json:"choice2"`
+	Choice3   string                 `json:"choice3"`
+	Choice4   string                 `json:"choice4"`
+	Hints     []RevealedHintResponse `json:"hints"`
+	HintsLeft int                    `json:"hints_left"`
+}
+
+// RevealedHintResponse DTO for a hint revealed in an attempt
+type RevealedHintResponse struct {
+	Hint    int     `json:"hint"`
+	Body    string  `json:"body"`
+	Penalty float64 `json:"penalty"`
+}
+
+// HintResponse DTO for a newly revealed hint. TotalPenalty is what the
+// hints revealed so far take off a correct answer.
+type HintResponse struct {
+	QuizID       string               `json:"quiz_id"`
+	Hint         RevealedHintResponse `json:"hint"`
+	HintsLeft    int                  `json:"hints_left"`
+	TotalPenalty float64              `json:"total_penalty"`
+}
+
+func toRevealedHintResponse(h domain.RevealedHint) RevealedHintResponse {
+	return RevealedHintResponse{Hint: h.Hint, Body: h.Body, Penalty: h.Penalty}
+}
+
+func toRevealedHintResponses(hints []domain.RevealedHint) []RevealedHintResponse {
+	responses := make([]RevealedHintResponse, len(hints))
+	for i, h := range hints {
+		responses[i] = toRevealedHintResponse(h)
+	}
+	return responses
+}
+
+// AnswerResponse DTO for a question of a submitted attempt. Answer is the
+// correct choice; Points is what the response earned after HintPenalty,
+// the penalties of the hints revealed for it.
+type AnswerResponse struct {
+	Position    int     `json:"position"`
+	QuizID      string  `json:"quiz_id"`
+	RevisionID  string  `json:"revision_id"`
+	Choice      *int    `json:"choice,omitempty"`
+	Correct     bool    `json:"correct"`
+	Answer      int     `json:"answer"`
+	Points      float64 `json:"points"`
+	HintsUsed   int     `json:"hints_used"`
+	HintPenalty float64 `json:"hint_penalty"`
+	TimeSpentMS int64   `json:"time_spent_ms"`
+}
+
+// ItemParametersResponse DTO for the item response parameters of a quiz
+type ItemParametersResponse struct {
+	QuizID         string     `json:"quiz_id"`
+	Discrimination float64    `json:"discrimination"`
+	Difficulty     float64    `json:"difficulty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+func toItemParametersResponse(p domain.ItemParameters) ItemParametersResponse {
+	return ItemParametersResponse{QuizID: p.QuizID, Discrimination: p.Discrimination, Difficulty: p.Difficulty, UpdatedAt: p.UpdatedAt}
+}
+
+// ItemStatsResponse DTO for the item analysis of a quiz from submitted
+// attempts. PValue, PointBiserial and AverageTimeMS are omitted until there
+// is enough data. Answer is only given to admins and the quiz's author.
+type ItemStatsResponse struct {
+	QuizID        string                `json:"quiz_id"`
+	Answer        *int                  `json:"answer,omitempty"`
+	Responses     int                   `json:"responses"`
+	Omitted       int                   `json:"omitted"`
+	PValue        *float64              `json:"p_value,omitempty"`
+	PointBiserial *float64              `json:"point_biserial,omitempty"`
+	AverageTimeMS *int64                `json:"average_time_ms,omitempty"`
+	Choices       []ChoiceStatsResponse `json:"choices"`
+}
+
+// ChoiceStatsResponse DTO for how often a choice was picked. Correct marks
+// the quiz's current answer; the others are distractors. Like the answer, it
+// is left out for users who may not see it.
+type ChoiceStatsResponse struct {
+	Choice  int     `json:"choice"`
+	Count   int     `json:"count"`
+	Rate    float64 `json:"rate"`
+	Correct *bool   `json:"correct,omitempty"`
+}
+
+// toItemStatsResponse renders the item analysis of item, with its answer
+// when showAnswer is true
+func toItemStatsResponse(item domain.Item, stats domain.ItemStats, showAnswer bool) ItemStatsResponse {
+	resp := ItemStatsResponse{
+		QuizID:        item.QuizID,
+		Responses:     stats.Responses,
+		Omitted:       stats.Omitted,
+		PValue:        stats.PValue,
+		PointBiserial: stats.PointBiserial,
+		Choices:       make([]ChoiceStatsResponse, domain.NumChoices),
+	}
+	if stats.AverageTime != nil {
+		ms := stats.AverageTime.Milliseconds()
+		resp.AverageTimeMS = &ms
+	}
+	for i := range resp.Choices {
+		choice := i + 1
+		resp.Choices[i] = ChoiceStatsResponse{Choice: choice, Count: stats.Choices[i], Rate: stats.ChoiceRate(choice)}
+		if showAnswer {
+			correct := choice == item.Answer
+			resp.Choices[i].Correct = &correct
+		}
+	}
+	if showAnswer {
+		resp.Answer = &item.Answer
+	}
+	return resp
+}
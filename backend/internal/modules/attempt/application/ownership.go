@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// authorizeChange returns nil if the current user may change a quiz written
+// by author, following the quiz module: admins may change every quiz and
+// authors their own, and quizzes without an author stay open to everyone
+func authorizeChange(ctx context.Context, author *string) error {
+	if author == nil || utils.IsAdmin(ctx) {
+		return nil
+	}
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return domain.ErrSignInRequired
+	}
+	if *author != userID {
+		return domain.ErrNotQuizOwner
+	}
+	return nil
+}
+
+// canSeeAnswer returns true if the current user may read the answer of a
+// quiz written by author: admins and the quiz's author
+func canSeeAnswer(ctx context.Context, author *string) bool {
+	if utils.IsAdmin(ctx) {
+		return true
+	}
+	userID, ok := utils.GetUserID(ctx)
+	return ok && author != nil && *author == userID
+}
@@ -0,0 +1,62 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// Item parameters are kept within the range abilities are estimated on
+const (
+	maxDiscrimination = 4.0
+	maxDifficulty     = 4.0
+)
+
+// ParameterService defines access to the item response parameters of quizzes
+type ParameterService interface {
+	Get(ctx context.Context, quizID string) (*ItemParametersResponse, error)
+	Set(ctx context.Context, quizID string, req ItemParametersRequest) (*ItemParametersResponse, error)
+}
+
+type parameterService struct {
+	items domain.ItemRepository
+}
+
+// NewParameterService creates a new ParameterService
+func NewParameterService(items domain.ItemRepository) ParameterService {
+	return &parameterService{items: items}
+}
+
+// Get returns the item response parameters of a quiz; uncalibrated quizzes
+// have discrimination 1 and difficulty 0
+func (s *parameterService) Get(ctx context.Context, quizID string) (*ItemParametersResponse, error) {
+	params, err := s.items.GetParameters(ctx, quizID)
+	if err != nil {
+		return nil, domain.ErrQuizNotFound
+	}
+	resp := toItemParametersResponse(*params)
+	return &resp, nil
+}
+
+// Set calibrates a quiz for adaptive attempts. Like other changes to a
+// quiz, it is limited to the quiz's author and admins.
+func (s *parameterService) Set(ctx context.Context, quizID string, req ItemParametersRequest) (*ItemParametersResponse, error) {
+	if req.Discrimination <= 0 || req.Discrimination > maxDiscrimination || req.Difficulty < -maxDifficulty || req.Difficulty > maxDifficulty {
+		return nil, domain.ErrInvalidParameters
+	}
+	author, err := s.items.QuizAuthor(ctx, quizID)
+	if err != nil {
+		return nil, domain.ErrQuizNotFound
+	}
+	if err := authorizeChange(ctx, author); err != nil {
+		return nil, err
+	}
+
+	params := &domain.ItemParameters{QuizID: quizID, Discrimination: req.Discrimination, Difficulty: req.Difficulty}
+	if err := s.items.SaveParameters(ctx, params); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to save item parameters", err)
+	}
+	resp := toItemParametersResponse(*params)
+	return &resp, nil
+}
@@ -0,0 +1,555 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// Adaptive attempt defaults and limits
+const (
+	DefaultMaxItems = 20
+	MaxItemsLimit   = 100
+	DefaultTargetSE = 0.3
+)
+
+// z95 is the normal quantile of a two-sided 95% confidence interval
+const z95 = 1.96
+
+// AttemptService defines the attempt business logic interface
+type AttemptService interface {
+	Start(ctx context.Context, req StartAttemptRequest) (*AttemptResponse, error)
+	Get(ctx context.Context, id string) (*AttemptResponse, error)
+	Answer(ctx context.Context, id string, req AnswerRequest) (*AttemptResponse, error)
+	Submit(ctx context.Context, id string) (*AttemptResponse, error)
+	Hint(ctx context.Context, id string, req HintRequest) (*HintResponse, error)
+}
+
+type attemptService struct {
+	attempts    domain.AttemptRepository
+	items       domain.ItemRepository
+	assignments domain.AssignmentGate
+	txManager   database.TxManager
+	events      *events.EventBus
+	now         func() time.Time
+}
+
+// NewAttemptService creates a new AttemptService. Attempts are admitted by
+// assignments, which may be nil when nothing is assigned. Submitted
+// attempts are published on bus, which may be nil.
+func NewAttemptService(attempts domain.AttemptRepository, items domain.ItemRepository, assignments domain.AssignmentGate, txManager database.TxManager, bus *events.EventBus) AttemptService {
+	return &attemptService{attempts: attempts, items: items, assignments: assignments, txManager: txManager, events: bus, now: time.Now}
+}
+
+// Start begins an attempt for the current user and presents its first
+// question. Attempts of signed-in learners on an assigned quiz set count
+// against the assignment, which may refuse them.
+func (s *attemptService) Start(ctx context.Context, req StartAttemptRequest) (*AttemptResponse, error) {
+	attempt := &domain.Attempt{
+		ID:        sharedDomain.NewID(),
+		Mode:      req.Mode,
+		Status:    domain.StatusInProgress,
+		StartedAt: s.now(),
+	}
+	if req.QuizSetID != nil {
+		if id := strings.TrimSpace(*req.QuizSetID); id != "" {
+			attempt.QuizSetID = &id
+		}
+	}
+	var assignmentID *string
+	if req.AssignmentID != nil {
+		if id := strings.TrimSpace(*req.AssignmentID); id != "" {
+			assignmentID = &id
+		}
+	}
+	if userID, ok := utils.GetUserID(ctx); ok {
+		attempt.UserID = &userID
+	}
+	if assignmentID != nil && attempt.UserID == nil {
+		return nil, domain.ErrAssignmentSignIn
+	}
+
+	maxItems := req.MaxItems
+	switch attempt.Mode {
+	case "", domain.ModeFixed:
+		attempt.Mode = domain.ModeFixed
+		if attempt.QuizSetID == nil && assignmentID == nil {
+			return nil, domain.ErrQuizSetRequired
+		}
+	case domain.ModeAdaptive:
+		model := req.Model
+		if model == "" {
+			model = domain.Model2PL
+		}
+		if !model.Valid() {
+			return nil, domain.ErrInvalidModel
+		}
+		if maxItems == 0 {
+			maxItems = DefaultMaxItems
+		}
+		if maxItems < 1 || maxItems > MaxItemsLimit {
+			return nil, domain.ErrInvalidMaxItems
+		}
+		targetSE := DefaultTargetSE
+		if req.TargetSE != nil {
+			targetSE = *req.TargetSE
+		}
+		if targetSE <= 0 || targetSE > 1 {
+			return nil, domain.ErrInvalidTargetSE
+		}
+		attempt.Model, attempt.TargetSE = &model, &targetSE
+	default:
+		return nil, domain.ErrInvalidMode
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.admit(ctx, attempt, assignmentID); err != nil {
+			return err
+		}
+
+		if attempt.QuizSetID != nil {
+			visible, err := s.items.QuizSetVisible(ctx, *attempt.QuizSetID)
+			if err != nil {
+				return sharedDomain.NewInternalError("Failed to check quiz set", err)
+			}
+			if !visible {
+				return domain.ErrQuizSetNotFound
+			}
+		}
+		pool, err := s.items.ListItems(ctx, attempt.QuizSetID)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to fetch quizzes", err)
+		}
+		if len(pool) == 0 {
+			return domain.ErrNoItems
+		}
+		attempt.MaxItems = len(pool)
+		if attempt.Mode == domain.ModeAdaptive {
+			attempt.MaxItems = min(maxItems, len(pool))
+		}
+
+		if err := s.attempts.Create(ctx, attempt); err != nil {
+			return sharedDomain.NewInternalError("Failed to create attempt", err)
+		}
+		_, err = s.present(ctx, attempt, pool)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.respond(ctx, attempt)
+}
+
+// admit counts a signed-in learner's attempt against the assignment it is
+// for, which also decides its quiz set when only the assignment was given
+func (s *attemptService) admit(ctx context.Context, attempt *domain.Attempt, assignmentID *string) error {
+	if attempt.UserID == nil {
+		return nil
+	}
+	if s.assignments == nil {
+		if assignmentID != nil {
+			return domain.ErrAssignmentNotFound
+		}
+		return nil
+	}
+
+	admission, err := s.assignments.Admit(ctx, *attempt.UserID, attempt.QuizSetID, assignmentID, attempt.StartedAt)
+	if err != nil {
+		return err
+	}
+	if admission != nil {
+		attempt.AssignmentID, attempt.QuizSetID, attempt.Late = &admission.AssignmentID, &admission.QuizSetID, admission.Late
+	}
+	return nil
+}
+
+// Get returns an attempt with its current question, or its results once submitted
+func (s *attemptService) Get(ctx context.Context, id string) (*AttemptResponse, error) {
+	attempt, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.respond(ctx, attempt)
+}
+
+// Answer records the response to the current question, updates the score
+// and ability estimate, then presents the next question or submits the
+// attempt when a stopping rule is met
+func (s *attemptService) Answer(ctx context.Context, id string, req AnswerRequest) (*AttemptResponse, error) {
+	if req.Choice < 1 || req.Choice > domain.NumChoices {
+		return nil, domain.ErrInvalidChoice
+	}
+
+	var attempt *domain.Attempt
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		attempt, err = s.load(ctx, id)
+		if err != nil {
+			return err
+		}
+		if attempt.IsSubmitted() {
+			return domain.ErrAttemptSubmitted
+		}
+		current := attempt.Current()
+		if current == nil || current.QuizID != strings.TrimSpace(req.QuizID) {
+			return domain.ErrNotCurrentQuiz
+		}
+
+		// Grade against the revision the learner was shown, not the quiz as
+		// it may have been edited since
+		revisions, err := s.items.GetRevisions(ctx, []string{current.RevisionID})
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to fetch quiz", err)
+		}
+		if len(revisions) == 0 {
+			return domain.ErrQuizNotFound
+		}
+		now := s.now()
+		correct := revisions[0].Answer == req.Choice
+		current.Choice, current.Correct, current.AnsweredAt = &req.Choice, &correct, &now
+		if err := s.attempts.RecordAnswer(ctx, current); err != nil {
+			if errors.Is(err, domain.ErrNotCurrentQuiz) {
+				return err
+			}
+			return sharedDomain.NewInternalError("Failed to record answer", err)
+		}
+
+		if err := s.score(ctx, attempt); err != nil {
+			return err
+		}
+		if !s.done(attempt) {
+			pool, err := s.items.ListItems(ctx, attempt.QuizSetID)
+			if err != nil {
+				return sharedDomain.NewInternalError("Failed to fetch quizzes", err)
+			}
+			presented, err := s.present(ctx, attempt, pool)
+			if err != nil {
+				return err
+			}
+			if presented {
+				return s.update(ctx, attempt)
+			}
+		}
+		return s.finish(ctx, attempt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.respond(ctx, attempt)
+}
+
+// Submit ends an attempt early. Unanswered questions of a fixed attempt
+// count as wrong; an adaptive attempt is scored on the questions answered.
+func (s *attemptService) Submit(ctx context.Context, id string) (*AttemptResponse, error) {
+	var attempt *domain.Attempt
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		attempt, err = s.load(ctx, id)
+		if err != nil {
+			return err
+		}
+		if attempt.IsSubmitted() {
+			return domain.ErrAttemptSubmitted
+		}
+		if err := s.score(ctx, attempt); err != nil {
+			return err
+		}
+		return s.finish(ctx, attempt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.respond(ctx, attempt)
+}
+
+// Hint reveals the next hint of the current question and records it, so its
+// penalty is taken off the point a correct answer earns
+func (s *attemptService) Hint(ctx context.Context, id string, req HintRequest) (*HintResponse, error) {
+	var resp *HintResponse
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		attempt, err := s.load(ctx, id)
+		if err != nil {
+			return err
+		}
+		if attempt.IsSubmitted() {
+			return domain.ErrAttemptSubmitted
+		}
+		current := attempt.Current()
+		if current == nil || current.QuizID != strings.TrimSpace(req.QuizID) {
+			return domain.ErrNotCurrentQuiz
+		}
+
+		hints, err := s.items.ListHints(ctx, current.QuizID)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to fetch hints", err)
+		}
+		next := len(current.Hints)
+		if next >= len(hints) {
+			return domain.ErrNoMoreHints
+		}
+		hint := domain.RevealedHint{
+			AttemptID:  attempt.ID,
+			Position:   current.Position,
+			Hint:       next + 1,
+			Body:       hints[next].Body,
+			Penalty:    hints[next].Penalty,
+			RevealedAt: s.now(),
+		}
+		if err := s.attempts.AddHint(ctx, &hint); err != nil {
+			return sharedDomain.NewInternalError("Failed to record hint", err)
+		}
+		current.Hints = append(current.Hints, hint)
+
+		resp = &HintResponse{
+			QuizID:       current.QuizID,
+			Hint:         toRevealedHintResponse(hint),
+			HintsLeft:    len(hints) - len(current.Hints),
+			TotalPenalty: current.HintPenalty(),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// load returns an attempt the current user may see: their own, one started
+// anonymously, or any attempt for admins
+func (s *attemptService) load(ctx context.Context, id string) (*domain.Attempt, error) {
+	attempt, err := s.attempts.GetByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrAttemptNotFound
+	}
+	if attempt.UserID != nil && !utils.IsAdmin(ctx) {
+		if userID, _ := utils.GetUserID(ctx); !attempt.IsOwnedBy(userID) {
+			return nil, domain.ErrNotYourAttempt
+		}
+	}
+	return attempt, nil
+}
+
+// present adds the next question from pool to the attempt: the next quiz in
+// order for fixed attempts, the most informative one at the current ability
+// estimate for adaptive attempts. It returns false if pool is used up.
+func (s *attemptService) present(ctx context.Context, attempt *domain.Attempt, pool []domain.Item) (bool, error) {
+	candidates := make([]domain.Item, 0, len(pool))
+	for _, item := range pool {
+		if !attempt.Presented(item.QuizID) {
+			candidates = append(candidates, item)
+		}
+	}
+	if len(candidates) == 0 {
+		return false, nil
+	}
+
+	next := candidates[0]
+	if attempt.Mode == domain.ModeAdaptive {
+		var ability float64
+		if attempt.Ability != nil {
+			ability = *attempt.Ability
+		}
+		next = candidates[domain.MostInformative(ability, *attempt.Model, candidates)]
+	}
+
+	answer := domain.Answer{
+		AttemptID:   attempt.ID,
+		Position:    len(attempt.Answers) + 1,
+		QuizID:      next.QuizID,
+		RevisionID:  next.RevisionID,
+		PresentedAt: s.now(),
+	}
+	if err := s.attempts.AddAnswer(ctx, &answer); err != nil {
+		return false, sharedDomain.NewInternalError("Failed to present question", err)
+	}
+	attempt.Answers = append(attempt.Answers, answer)
+	return true, nil
+}
+
+// score adds up the points of the answers, after hint penalties, and for
+// adaptive attempts estimates the ability from which answers were correct
+func (s *attemptService) score(ctx context.Context, attempt *domain.Attempt) error {
+	attempt.Score = 0
+	var answered []domain.Answer
+	for _, a := range attempt.Answers {
+		if a.AnsweredAt != nil {
+			answered = append(answered, a)
+			attempt.Score += a.Points()
+		}
+	}
+	if attempt.Mode != domain.ModeAdaptive {
+		return nil
+	}
+
+	ids := make([]string, len(answered))
+	for i, a := range answered {
+		ids[i] = a.QuizID
+	}
+	items, err := s.items.GetItems(ctx, ids)
+	if err != nil {
+		return sharedDomain.NewInternalError("Failed to fetch quizzes", err)
+	}
+	params := make(map[string]domain.ItemParameters, len(items))
+	for _, item := range items {
+		params[item.QuizID] = item.ItemParameters
+	}
+
+	responses := make([]domain.Response, len(answered))
+	for i, a := range answered {
+		p, ok := params[a.QuizID]
+		if !ok {
+			p = domain.DefaultItemParameters(a.QuizID)
+		}
+		disc, diff := attempt.Model.Parameters(p)
+		responses[i] = domain.Response{Discrimination: disc, Difficulty: diff, Correct: *a.Correct}
+	}
+	ability, se := domain.EstimateAbility(responses)
+	attempt.Ability, attempt.StandardError = &ability, &se
+	return nil
+}
+
+// done reports whether a stopping rule is met: enough questions answered,
+// or for adaptive attempts, the ability known precisely enough
+func (s *attemptService) done(attempt *domain.Attempt) bool {
+	if attempt.Answered() >= attempt.MaxItems {
+		return true
+	}
+	return attempt.Mode == domain.ModeAdaptive && attempt.StandardError != nil && *attempt.StandardError <= *attempt.TargetSE
+}
+
+// finish submits a scored attempt and publishes it once the transaction
+// carried by ctx commits
+func (s *attemptService) finish(ctx context.Context, attempt *domain.Attempt) error {
+	now := s.now()
+	attempt.Status, attempt.SubmittedAt = domain.StatusSubmitted, &now
+	attempt.MaxScore = float64(attempt.MaxItems)
+	if attempt.Mode == domain.ModeAdaptive {
+		attempt.MaxScore = float64(attempt.Answered())
+	}
+	if err := s.update(ctx, attempt); err != nil {
+		return err
+	}
+	s.publishSubmitted(ctx, attempt)
+	return nil
+}
+
+func (s *attemptService) update(ctx context.Context, attempt *domain.Attempt) error {
+	if err := s.attempts.Update(ctx, attempt); err != nil {
+		return sharedDomain.NewInternalError("Failed to update attempt", err)
+	}
+	return nil
+}
+
+// publishSubmitted publishes a submitted attempt after the transaction
+// commits, waiting for the leaderboard and certificate handlers
+func (s *attemptService) publishSubmitted(ctx context.Context, attempt *domain.Attempt) {
+	if s.events == nil {
+		return
+	}
+	event := events.AttemptSubmittedEvent{
+		AttemptID:   attempt.ID,
+		QuizSetID:   attempt.QuizSetID,
+		UserID:      attempt.UserID,
+		Mode:        string(attempt.Mode),
+		Score:       attempt.Score,
+		MaxScore:    attempt.MaxScore,
+		StartedAt:   attempt.StartedAt,
+		SubmittedAt: *attempt.SubmittedAt,
+	}
+	database.AfterCommit(ctx, func() {
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			slog.Error("Failed to publish submitted attempt", "attempt_id", attempt.ID, "error", err)
+		}
+	})
+}
+
+// respond renders an attempt with the text of its current question, or with
+// its answers and ability estimate once submitted. Questions and answers are
+// read from the revisions presented.
+func (s *attemptService) respond(ctx context.Context, attempt *domain.Attempt) (*AttemptResponse, error) {
+	resp := &AttemptResponse{
+		ID:           attempt.ID,
+		QuizSetID:    attempt.QuizSetID,
+		UserID:       attempt.UserID,
+		AssignmentID: attempt.AssignmentID,
+		Late:         attempt.Late,
+		Mode:         attempt.Mode,
+		Model:        attempt.Model,
+		MaxItems:     attempt.MaxItems,
+		TargetSE:     attempt.TargetSE,
+		Status:       attempt.Status,
+		Answered:     attempt.Answered(),
+		Score:        attempt.Score,
+		MaxScore:     attempt.MaxScore,
+		HintsUsed:    attempt.HintsUsed(),
+		HintPenalty:  attempt.HintDeduction(),
+		StartedAt:    attempt.StartedAt,
+		SubmittedAt:  attempt.SubmittedAt,
+	}
+
+	ids := make([]string, len(attempt.Answers))
+	for i, a := range attempt.Answers {
+		ids[i] = a.RevisionID
+	}
+	revisions, err := s.items.GetRevisions(ctx, ids)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quizzes", err)
+	}
+	byID := make(map[string]domain.Revision, len(revisions))
+	for _, r := range revisions {
+		byID[r.ID] = r
+	}
+
+	if !attempt.IsSubmitted() {
+		if current := attempt.Current(); current != nil {
+			hints, err := s.items.ListHints(ctx, current.QuizID)
+			if err != nil {
+				return nil, sharedDomain.NewInternalError("Failed to fetch hints", err)
+			}
+			item := byID[current.RevisionID]
+			resp.Question = &QuestionResponse{
+				Position:  current.Position,
+				QuizID:    current.QuizID,
+				Question:  item.Question,
+				Choice1:   item.Choice1,
+				Choice2:   item.Choice2,
+				Choice3:   item.Choice3,
+				Choice4:   item.Choice4,
+				Hints:     toRevealedHintResponses(current.Hints),
+				HintsLeft: max(0, len(hints)-len(current.Hints)),
+			}
+		}
+		return resp, nil
+	}
+
+	resp.Answers = make([]AnswerResponse, len(attempt.Answers))
+	for i, a := range attempt.Answers {
+		resp.Answers[i] = AnswerResponse{
+			Position:    a.Position,
+			QuizID:      a.QuizID,
+			RevisionID:  a.RevisionID,
+			Choice:      a.Choice,
+			Correct:     a.Correct != nil && *a.Correct,
+			Answer:      byID[a.RevisionID].Answer,
+			Points:      a.Points(),
+			HintsUsed:   len(a.Hints),
+			HintPenalty: a.HintPenalty(),
+			TimeSpentMS: a.TimeSpent().Milliseconds(),
+		}
+	}
+	if attempt.Ability != nil && attempt.StandardError != nil {
+		ability, se := *attempt.Ability, *attempt.StandardError
+		resp.Ability = &AbilityResponse{Estimate: ability, StandardError: se, Lower: ability - z95*se, Upper: ability + z95*se}
+	}
+	return resp, nil
+}
@@ -0,0 +1,629 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// passthroughTxManager runs fn without a real transaction
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// mockAttemptRepository is an in-memory implementation of domain.AttemptRepository
+type mockAttemptRepository struct {
+	attempts map[string]domain.Attempt
+}
+
+func newMockAttemptRepo() *mockAttemptRepository {
+	return &mockAttemptRepository{attempts: map[string]domain.Attempt{}}
+}
+
+func (m *mockAttemptRepository) GetByID(_ context.Context, id string) (*domain.Attempt, error) {
+	a, ok := m.attempts[id]
+	if !ok {
+		return nil, domain.ErrAttemptNotFound
+	}
+	a.Answers = append([]domain.Answer{}, a.Answers...)
+	return &a, nil
+}
+
+func (m *mockAttemptRepository) Create(_ context.Context, attempt *domain.Attempt) error {
+	m.attempts[attempt.ID] = *attempt
+	return nil
+}
+
+func (m *mockAttemptRepository) Update(_ context.Context, attempt *domain.Attempt) error {
+	stored := m.attempts[attempt.ID]
+	answers := stored.Answers
+	stored = *attempt
+	stored.Answers = answers
+	m.attempts[attempt.ID] = stored
+	return nil
+}
+
+func (m *mockAttemptRepository) AddAnswer(_ context.Context, answer *domain.Answer) error {
+	a := m.attempts[answer.AttemptID]
+	a.Answers = append(a.Answers, *answer)
+	m.attempts[answer.AttemptID] = a
+	return nil
+}
+
+func (m *mockAttemptRepository) RecordAnswer(_ context.Context, answer *domain.Answer) error {
+	a := m.attempts[answer.AttemptID]
+	for i := range a.Answers {
+		if a.Answers[i].Position == answer.Position && a.Answers[i].AnsweredAt == nil {
+			a.Answers[i] = *answer
+			return nil
+		}
+	}
+	return domain.ErrNotCurrentQuiz
+}
+
+func (m *mockAttemptRepository) AddHint(_ context.Context, hint *domain.RevealedHint) error {
+	a := m.attempts[hint.AttemptID]
+	for i := range a.Answers {
+		if a.Answers[i].Position == hint.Position {
+			a.Answers[i].Hints = append(append([]domain.RevealedHint{}, a.Answers[i].Hints...), *hint)
+		}
+	}
+	return nil
+}
+
+func (m *mockAttemptRepository) ListResponses(_ context.Context, quizID string) ([]domain.ItemResponse, error) {
+	responses := []domain.ItemResponse{}
+	for _, a := range m.attempts {
+		if !a.IsSubmitted() {
+			continue
+		}
+		for _, answer := range a.Answers {
+			if answer.QuizID != quizID {
+				continue
+			}
+			r := domain.ItemResponse{Choice: answer.Choice, Correct: answer.Correct != nil && *answer.Correct, TimeSpentMS: answer.TimeSpent().Milliseconds()}
+			for _, other := range a.Answers {
+				if other.QuizID != quizID && other.AnsweredAt != nil {
+					r.OtherAnswered++
+					if *other.Correct {
+						r.OtherCorrect++
+					}
+				}
+			}
+			responses = append(responses, r)
+		}
+	}
+	return responses, nil
+}
+
+// mockItemRepository serves items from memory. Quiz sets list item IDs in set order.
+type mockItemRepository struct {
+	items []domain.Item
+	// revisions are earlier revisions of the items
+	revisions []domain.Revision
+	sets      map[string][]string
+	hints     map[string][]domain.Hint
+}
+
+func (m *mockItemRepository) ListItems(_ context.Context, quizSetID *string) ([]domain.Item, error) {
+	if quizSetID == nil {
+		return m.items, nil
+	}
+	var out []domain.Item
+	for _, id := range m.sets[*quizSetID] {
+		for _, item := range m.items {
+			if item.QuizID == id {
+				out = append(out, item)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *mockItemRepository) GetItems(_ context.Context, quizIDs []string) ([]domain.Item, error) {
+	var out []domain.Item
+	for _, item := range m.items {
+		for _, id := range quizIDs {
+			if item.QuizID == id {
+				out = append(out, item)
+			}
+		}
+	}
+	return out, nil
+}
+
+// GetRevisions returns the revisions recorded in revisions, then the current
+// revision of each item
+func (m *mockItemRepository) GetRevisions(_ context.Context, revisionIDs []string) ([]domain.Revision, error) {
+	var out []domain.Revision
+	for _, id := range revisionIDs {
+		for _, r := range m.revisions {
+			if r.ID == id {
+				out = append(out, r)
+			}
+		}
+		for _, item := range m.items {
+			if item.RevisionID == id {
+				out = append(out, revisionOf(item))
+			}
+		}
+	}
+	return out, nil
+}
+
+func revisionOf(item domain.Item) domain.Revision {
+	return domain.Revision{ID: item.RevisionID, QuizID: item.QuizID, Question: item.Question,
+		Choice1: item.Choice1, Choice2: item.Choice2, Choice3: item.Choice3, Choice4: item.Choice4, Answer: item.Answer}
+}
+
+func (m *mockItemRepository) ListHints(_ context.Context, quizID string) ([]domain.Hint, error) {
+	return m.hints[quizID], nil
+}
+
+func (m *mockItemRepository) QuizSetVisible(_ context.Context, quizSetID string) (bool, error) {
+	_, ok := m.sets[quizSetID]
+	return ok, nil
+}
+
+func (m *mockItemRepository) GetParameters(_ context.Context, quizID string) (*domain.ItemParameters, error) {
+	for _, item := range m.items {
+		if item.QuizID == quizID {
+			params := item.ItemParameters
+			params.QuizID = quizID
+			return &params, nil
+		}
+	}
+	return nil, domain.ErrQuizNotFound
+}
+
+func (m *mockItemRepository) SaveParameters(_ context.Context, params *domain.ItemParameters) error {
+	for i := range m.items {
+		if m.items[i].QuizID == params.QuizID {
+			now := time.Now()
+			params.UpdatedAt = &now
+			m.items[i].ItemParameters = *params
+		}
+	}
+	return nil
+}
+
+func (m *mockItemRepository) QuizAuthor(_ context.Context, quizID string) (*string, error) {
+	for _, item := range m.items {
+		if item.QuizID == quizID {
+			return item.CreatedBy, nil
+		}
+	}
+	return nil, domain.ErrQuizNotFound
+}
+
+// newItems returns n items whose correct choice is 1, with difficulties
+// spread from -2 to 2
+func newItems(n int) []domain.Item {
+	items := make([]domain.Item, n)
+	for i := range items {
+		difficulty := -2 + 4*float64(i)/float64(max(n-1, 1))
+		items[i] = domain.Item{
+			QuizID:         fmt.Sprintf("q%d", i+1),
+			RevisionID:     fmt.Sprintf("r%d", i+1),
+			Question:       fmt.Sprintf("Question %d", i+1),
+			Answer:         1,
+			ItemParameters: domain.ItemParameters{Discrimination: 1, Difficulty: difficulty},
+		}
+	}
+	return items
+}
+
+func newTestService(items *mockItemRepository) (*attemptService, *mockAttemptRepository) {
+	repo := newMockAttemptRepo()
+	service := NewAttemptService(repo, items, nil, passthroughTxManager{}, nil).(*attemptService)
+	clock := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		clock = clock.Add(10 * time.Second)
+		return clock
+	}
+	return service, repo
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestStartAttempt_Validation(t *testing.T) {
+	service, _ := newTestService(&mockItemRepository{items: newItems(3), sets: map[string][]string{"s1": {"q1"}, "empty": {}}})
+	ctx := context.Background()
+	cases := []struct {
+		name string
+		req  StartAttemptRequest
+		want error
+	}{
+		{"fixed without set", StartAttemptRequest{}, domain.ErrQuizSetRequired},
+		{"unknown mode", StartAttemptRequest{Mode: "random"}, domain.ErrInvalidMode},
+		{"unknown model", StartAttemptRequest{Mode: domain.ModeAdaptive, Model: "3pl"}, domain.ErrInvalidModel},
+		{"too many items", StartAttemptRequest{Mode: domain.ModeAdaptive, MaxItems: 101}, domain.ErrInvalidMaxItems},
+		{"zero target", StartAttemptRequest{Mode: domain.ModeAdaptive, TargetSE: new(float64)}, domain.ErrInvalidTargetSE},
+		{"hidden set", StartAttemptRequest{QuizSetID: strPtr("missing")}, domain.ErrQuizSetNotFound},
+		{"empty set", StartAttemptRequest{QuizSetID: strPtr("empty")}, domain.ErrNoItems},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := service.Start(ctx, tc.req); !errors.Is(err, tc.want) {
+				t.Errorf("expected %v, got: %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestFixedAttempt_TakesSetInOrder(t *testing.T) {
+	items := &mockItemRepository{items: newItems(3), sets: map[string][]string{"s1": {"q3", "q1"}}}
+	service, repo := newTestService(items)
+	ctx := utils.SetUserID(context.Background(), "alice")
+
+	resp, err := service.Start(ctx, StartAttemptRequest{QuizSetID: strPtr("s1")})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Mode != domain.ModeFixed || resp.MaxItems != 2 || resp.Question == nil || resp.Question.QuizID != "q3" {
+		t.Fatalf("expected the first quiz of the set, got %+v", resp)
+	}
+	if resp.UserID == nil || *resp.UserID != "alice" {
+		t.Errorf("expected the attempt to belong to alice, got %v", resp.UserID)
+	}
+
+	if _, err := service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q1", Choice: 1}); !errors.Is(err, domain.ErrNotCurrentQuiz) {
+		t.Errorf("expected ErrNotCurrentQuiz for a later question, got: %v", err)
+	}
+	resp, err = service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q3", Choice: 1})
+	if err != nil || resp.Question == nil || resp.Question.QuizID != "q1" || resp.Score != 1 {
+		t.Fatalf("expected q1 next with score 1, got %+v, %v", resp, err)
+	}
+	resp, err = service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q1", Choice: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if resp.Status != domain.StatusSubmitted || resp.Score != 1 || resp.MaxScore != 2 || resp.Ability != nil {
+		t.Errorf("expected a submitted attempt scoring 1/2 without ability, got %+v", resp)
+	}
+	if len(resp.Answers) != 2 || resp.Answers[0].RevisionID != "r3" || !resp.Answers[0].Correct || resp.Answers[1].Correct ||
+		resp.Answers[1].Answer != 1 || resp.Answers[1].TimeSpentMS != 10000 {
+		t.Errorf("expected answers with revisions, correctness and time spent, got %+v", resp.Answers)
+	}
+	if stored := repo.attempts[resp.ID]; stored.Status != domain.StatusSubmitted || stored.SubmittedAt == nil {
+		t.Errorf("expected the submission stored, got %+v", stored)
+	}
+
+	if _, err := service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q1", Choice: 1}); !errors.Is(err, domain.ErrAttemptSubmitted) {
+		t.Errorf("expected ErrAttemptSubmitted, got: %v", err)
+	}
+}
+
+func TestFixedAttempt_GradesPresentedRevision(t *testing.T) {
+	items := &mockItemRepository{items: newItems(2), sets: map[string][]string{"s1": {"q1", "q2"}}}
+	service, _ := newTestService(items)
+	ctx := utils.SetUserID(context.Background(), "alice")
+
+	resp, err := service.Start(ctx, StartAttemptRequest{QuizSetID: strPtr("s1")})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// The author changes the answer of q1 while it is on screen
+	items.revisions = append(items.revisions, revisionOf(items.items[0]))
+	items.items[0].RevisionID, items.items[0].Question, items.items[0].Answer = "r1b", "Question 1, edited", 3
+
+	resp, err = service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q1", Choice: 1})
+	if err != nil || resp.Score != 1 {
+		t.Fatalf("expected the answer graded against the presented revision, got %+v, %v", resp, err)
+	}
+	resp, err = service.Submit(ctx, resp.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(resp.Answers) != 2 || resp.Answers[0].RevisionID != "r1" || !resp.Answers[0].Correct || resp.Answers[0].Answer != 1 {
+		t.Errorf("expected the answer of revision r1 reported, got %+v", resp.Answers)
+	}
+}
+
+func TestFixedAttempt_HintPenalties(t *testing.T) {
+	items := &mockItemRepository{items: newItems(3), sets: map[string][]string{"s1": {"q1", "q2", "q3"}}, hints: map[string][]domain.Hint{
+		"q1": {{QuizID: "q1", Position: 1, Body: "Not 4", Penalty: 0.25}, {QuizID: "q1", Position: 2, Body: "Not 2 or 3", Penalty: 0.5}},
+		"q2": {{QuizID: "q2", Position: 1, Body: "Think", Penalty: 0.5}},
+	}}
+	service, _ := newTestService(items)
+	ctx := utils.SetUserID(context.Background(), "alice")
+
+	resp, err := service.Start(ctx, StartAttemptRequest{QuizSetID: strPtr("s1")})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Question.HintsLeft != 2 || len(resp.Question.Hints) != 0 {
+		t.Errorf("expected 2 hints on offer, got %+v", resp.Question)
+	}
+	if _, err := service.Hint(ctx, resp.ID, HintRequest{QuizID: "q2"}); !errors.Is(err, domain.ErrNotCurrentQuiz) {
+		t.Errorf("expected ErrNotCurrentQuiz for a later question, got: %v", err)
+	}
+
+	for i, want := range []float64{0.25, 0.75} {
+		hint, err := service.Hint(ctx, resp.ID, HintRequest{QuizID: "q1"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if hint.Hint.Hint != i+1 || hint.HintsLeft != 1-i || hint.TotalPenalty != want {
+			t.Errorf("hint %d: expected a total penalty of %v, got %+v", i+1, want, hint)
+		}
+	}
+	if _, err := service.Hint(ctx, resp.ID, HintRequest{QuizID: "q1"}); !errors.Is(err, domain.ErrNoMoreHints) {
+		t.Errorf("expected ErrNoMoreHints, got: %v", err)
+	}
+	if resp, _ = service.Get(ctx, resp.ID); len(resp.Question.Hints) != 2 || resp.Question.Hints[1].Body != "Not 2 or 3" || resp.Question.HintsLeft != 0 {
+		t.Errorf("expected the revealed hints with the question, got %+v", resp.Question)
+	}
+
+	// q1 right after two hints, q2 wrong after one, q3 right without any
+	if resp, err = service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q1", Choice: 1}); err != nil || resp.Score != 0.25 {
+		t.Fatalf("expected a score of 0.25 after the first answer, got %+v, %v", resp, err)
+	}
+	if _, err := service.Hint(ctx, resp.ID, HintRequest{QuizID: "q2"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err := service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q2", Choice: 2}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp, err = service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q3", Choice: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if resp.Status != domain.StatusSubmitted || resp.Score != 1.25 || resp.MaxScore != 3 || resp.HintsUsed != 3 || resp.HintPenalty != 0.75 {
+		t.Errorf("expected 1.25/3 with 3 hints costing 0.75, got %+v", resp)
+	}
+	want := []struct {
+		points  float64
+		hints   int
+		penalty float64
+	}{{0.25, 2, 0.75}, {0, 1, 0.5}, {1, 0, 0}}
+	for i, w := range want {
+		if a := resp.Answers[i]; a.Points != w.points || a.HintsUsed != w.hints || a.HintPenalty != w.penalty {
+			t.Errorf("answer %d: expected %v points with %d hints costing %v, got %+v", i+1, w.points, w.hints, w.penalty, a)
+		}
+	}
+	if _, err := service.Hint(ctx, resp.ID, HintRequest{QuizID: "q3"}); !errors.Is(err, domain.ErrAttemptSubmitted) {
+		t.Errorf("expected ErrAttemptSubmitted, got: %v", err)
+	}
+}
+
+func TestAdaptiveAttempt_StopsAtMaxItems(t *testing.T) {
+	service, _ := newTestService(&mockItemRepository{items: newItems(9)})
+	ctx := context.Background()
+
+	resp, err := service.Start(ctx, StartAttemptRequest{Mode: domain.ModeAdaptive, MaxItems: 4, TargetSE: floatPtr(0.01)})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	// With no answers the ability is 0, so the middle item is most informative
+	if resp.Question.QuizID != "q5" || *resp.Model != domain.Model2PL {
+		t.Fatalf("expected q5 under the default 2PL model, got %+v", resp)
+	}
+
+	seen := map[string]bool{}
+	for resp.Status == domain.StatusInProgress {
+		quizID := resp.Question.QuizID
+		if seen[quizID] {
+			t.Fatalf("expected %s to be presented once", quizID)
+		}
+		seen[quizID] = true
+		if resp, err = service.Answer(ctx, resp.ID, AnswerRequest{QuizID: quizID, Choice: 1}); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if resp.Status == domain.StatusInProgress && resp.Question.QuizID <= quizID {
+			t.Errorf("expected harder questions after right answers, got %s after %s", resp.Question.QuizID, quizID)
+		}
+	}
+
+	if resp.Answered != 4 || resp.Score != 4 || resp.MaxScore != 4 {
+		t.Errorf("expected 4 right answers, got %+v", resp)
+	}
+	if resp.Ability == nil || resp.Ability.Estimate <= 0 || resp.Ability.Lower >= resp.Ability.Estimate || resp.Ability.Upper <= resp.Ability.Estimate {
+		t.Errorf("expected a positive ability with its confidence interval, got %+v", resp.Ability)
+	}
+}
+
+func TestAdaptiveAttempt_StopsAtTargetSE(t *testing.T) {
+	service, _ := newTestService(&mockItemRepository{items: newItems(30)})
+	ctx := context.Background()
+
+	resp, err := service.Start(ctx, StartAttemptRequest{Mode: domain.ModeAdaptive, Model: domain.Model1PL, MaxItems: 30, TargetSE: floatPtr(0.6)})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for choice := 1; resp.Status == domain.StatusInProgress; choice = 3 - choice {
+		if resp, err = service.Answer(ctx, resp.ID, AnswerRequest{QuizID: resp.Question.QuizID, Choice: choice}); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	if resp.Answered >= 30 || resp.Ability.StandardError > 0.6 {
+		t.Errorf("expected to stop early once the standard error reached 0.6, got %d answers and %+v", resp.Answered, resp.Ability)
+	}
+}
+
+func TestSubmitAttempt_Early(t *testing.T) {
+	service, _ := newTestService(&mockItemRepository{items: newItems(3), sets: map[string][]string{"s1": {"q1", "q2", "q3"}}})
+	ctx := context.Background()
+
+	resp, _ := service.Start(ctx, StartAttemptRequest{QuizSetID: strPtr("s1")})
+	service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q1", Choice: 1})
+	resp, err := service.Submit(ctx, resp.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Status != domain.StatusSubmitted || resp.Score != 1 || resp.MaxScore != 3 || len(resp.Answers) != 2 || resp.Answers[1].Choice != nil {
+		t.Errorf("expected 1/3 with the pending question unanswered, got %+v", resp)
+	}
+	if _, err := service.Submit(ctx, resp.ID); !errors.Is(err, domain.ErrAttemptSubmitted) {
+		t.Errorf("expected ErrAttemptSubmitted, got: %v", err)
+	}
+}
+
+func TestSubmitAttempt_PublishesBeforeResponding(t *testing.T) {
+	bus := events.NewEventBus()
+	var published []events.AttemptSubmittedEvent
+	bus.Subscribe(events.AttemptSubmittedEvent{}.Name(), func(_ context.Context, event events.Event) error {
+		published = append(published, event.(events.AttemptSubmittedEvent))
+		return nil
+	})
+	service, _ := newTestService(&mockItemRepository{items: newItems(2), sets: map[string][]string{"s1": {"q1", "q2"}}})
+	service.events = bus
+	ctx := context.Background()
+
+	resp, _ := service.Start(ctx, StartAttemptRequest{QuizSetID: strPtr("s1")})
+	service.Answer(ctx, resp.ID, AnswerRequest{QuizID: "q1", Choice: 1})
+	if len(published) != 0 {
+		t.Fatalf("expected nothing published before the attempt ends, got %+v", published)
+	}
+	if _, err := service.Submit(ctx, resp.ID); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(published) != 1 || published[0].AttemptID != resp.ID || published[0].Score != 1 || published[0].MaxScore != 2 {
+		t.Errorf("expected the submission published once handlers ran, got %+v", published)
+	}
+}
+
+func TestAttempt_OnlyOwnerOrAdmin(t *testing.T) {
+	service, _ := newTestService(&mockItemRepository{items: newItems(2)})
+	alice := utils.SetUserID(context.Background(), "alice")
+
+	resp, err := service.Start(alice, StartAttemptRequest{Mode: domain.ModeAdaptive})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	bob := utils.SetUserID(context.Background(), "bob")
+	if _, err := service.Get(bob, resp.ID); !errors.Is(err, domain.ErrNotYourAttempt) {
+		t.Errorf("expected ErrNotYourAttempt for another user, got: %v", err)
+	}
+	if _, err := service.Answer(context.Background(), resp.ID, AnswerRequest{QuizID: resp.Question.QuizID, Choice: 1}); !errors.Is(err, domain.ErrNotYourAttempt) {
+		t.Errorf("expected ErrNotYourAttempt when anonymous, got: %v", err)
+	}
+	if _, err := service.Get(utils.SetUserRole(bob, utils.RoleAdmin), resp.ID); err != nil {
+		t.Errorf("expected admins to see any attempt, got: %v", err)
+	}
+}
+
+// stubGate admits attempts of assigned users to a single assignment of "s1"
+type stubGate struct {
+	assigned map[string]bool
+	refuse   error
+	late     bool
+}
+
+func (g stubGate) Admit(_ context.Context, userID string, quizSetID, assignmentID *string, _ time.Time) (*domain.Admission, error) {
+	if !g.assigned[userID] {
+		if assignmentID != nil {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		return nil, nil
+	}
+	if g.refuse != nil {
+		return nil, g.refuse
+	}
+	return &domain.Admission{AssignmentID: "a1", QuizSetID: "s1", Late: g.late}, nil
+}
+
+func TestStartAttempt_Assignments(t *testing.T) {
+	items := &mockItemRepository{items: newItems(2), sets: map[string][]string{"s1": {"q1", "q2"}}}
+	service, repo := newTestService(items)
+	service.assignments = stubGate{assigned: map[string]bool{"alice": true}, late: true}
+	alice := utils.SetUserID(context.Background(), "alice")
+
+	resp, err := service.Start(alice, StartAttemptRequest{AssignmentID: strPtr("a1")})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.AssignmentID == nil || *resp.AssignmentID != "a1" || resp.QuizSetID == nil || *resp.QuizSetID != "s1" || !resp.Late {
+		t.Errorf("expected a late attempt on s1 for a1, got %+v", resp)
+	}
+	if stored := repo.attempts[resp.ID]; stored.AssignmentID == nil || !stored.Late {
+		t.Errorf("expected the assignment to be stored, got %+v", stored)
+	}
+
+	if _, err := service.Start(context.Background(), StartAttemptRequest{AssignmentID: strPtr("a1")}); !errors.Is(err, domain.ErrAssignmentSignIn) {
+		t.Errorf("expected ErrAssignmentSignIn for anonymous learners, got: %v", err)
+	}
+	bob := utils.SetUserID(context.Background(), "bob")
+	if _, err := service.Start(bob, StartAttemptRequest{AssignmentID: strPtr("a1")}); !errors.Is(err, domain.ErrAssignmentNotFound) {
+		t.Errorf("expected ErrAssignmentNotFound for learners not assigned, got: %v", err)
+	}
+	resp, err = service.Start(bob, StartAttemptRequest{QuizSetID: strPtr("s1")})
+	if err != nil || resp.AssignmentID != nil {
+		t.Errorf("expected an unassigned attempt for bob, got %+v, %v", resp, err)
+	}
+
+	refused := errors.New("no attempts left")
+	service.assignments = stubGate{assigned: map[string]bool{"alice": true}, refuse: refused}
+	before := len(repo.attempts)
+	if _, err := service.Start(alice, StartAttemptRequest{QuizSetID: strPtr("s1")}); !errors.Is(err, refused) {
+		t.Errorf("expected the gate's refusal, got: %v", err)
+	}
+	if len(repo.attempts) != before {
+		t.Error("expected a refused attempt not to be created")
+	}
+}
+
+func TestSetParameters(t *testing.T) {
+	items := &mockItemRepository{items: newItems(1)}
+	service := NewParameterService(items)
+	ctx := context.Background()
+
+	if _, err := service.Set(ctx, "q1", ItemParametersRequest{Discrimination: 0, Difficulty: 1}); !errors.Is(err, domain.ErrInvalidParameters) {
+		t.Errorf("expected ErrInvalidParameters, got: %v", err)
+	}
+	if _, err := service.Set(ctx, "missing", ItemParametersRequest{Discrimination: 1}); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got: %v", err)
+	}
+	resp, err := service.Set(ctx, "q1", ItemParametersRequest{Discrimination: 1.8, Difficulty: -0.5})
+	if err != nil || resp.Discrimination != 1.8 || resp.Difficulty != -0.5 || resp.UpdatedAt == nil {
+		t.Fatalf("expected stored parameters, got %+v, %v", resp, err)
+	}
+	if got, _ := service.Get(ctx, "q1"); got.Discrimination != 1.8 {
+		t.Errorf("expected parameters to be read back, got %+v", got)
+	}
+}
+
+func TestSetParameters_OwnerOrAdmin(t *testing.T) {
+	items := &mockItemRepository{items: newItems(1)}
+	items.items[0].CreatedBy = strPtr("alice")
+	service := NewParameterService(items)
+	req := ItemParametersRequest{Discrimination: 1.5, Difficulty: 0.5}
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"anonymous", context.Background(), domain.ErrSignInRequired},
+		{"other user", utils.SetUserID(context.Background(), "bob"), domain.ErrNotQuizOwner},
+		{"author", utils.SetUserID(context.Background(), "alice"), nil},
+		{"admin", utils.SetUserRole(utils.SetUserID(context.Background(), "bob"), utils.RoleAdmin), nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			items.items[0].ItemParameters = domain.ItemParameters{Discrimination: 1}
+			_, err := service.Set(tc.ctx, "q1", req)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got: %v", tc.want, err)
+			}
+			if saved := items.items[0].Discrimination == 1.5; saved != (tc.want == nil) {
+				t.Errorf("expected parameters saved %v, got %+v", tc.want == nil, items.items[0].ItemParameters)
+			}
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 { return &f }
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// StatsService defines item analysis of quizzes from attempt data
+type StatsService interface {
+	Get(ctx context.Context, quizID string) (*ItemStatsResponse, error)
+}
+
+type statsService struct {
+	attempts domain.AttemptRepository
+	items    domain.ItemRepository
+}
+
+// NewStatsService creates a new StatsService
+func NewStatsService(attempts domain.AttemptRepository, items domain.ItemRepository) StatsService {
+	return &statsService{attempts: attempts, items: items}
+}
+
+// Get returns the difficulty, discrimination, choice rates and average time
+// of a quiz over every submitted attempt that presented it. Which choice is
+// correct is only shown to admins and the quiz's author.
+func (s *statsService) Get(ctx context.Context, quizID string) (*ItemStatsResponse, error) {
+	items, err := s.items.GetItems(ctx, []string{quizID})
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quiz", err)
+	}
+	if len(items) == 0 {
+		return nil, domain.ErrQuizNotFound
+	}
+
+	responses, err := s.attempts.ListResponses(ctx, quizID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch responses", err)
+	}
+	resp := toItemStatsResponse(items[0], domain.AnalyzeItem(responses), canSeeAnswer(ctx, items[0].CreatedBy))
+	return &resp, nil
+}
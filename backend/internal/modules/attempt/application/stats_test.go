@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+func TestItemStats_FromSubmittedAttempts(t *testing.T) {
+	items := &mockItemRepository{items: newItems(2), sets: map[string][]string{"s1": {"q1", "q2"}}}
+	service, repo := newTestService(items)
+	stats := NewStatsService(repo, items)
+	ctx := context.Background()
+
+	// Two learners finish the set; a third is still on the first question
+	for _, choices := range [][]int{{1, 1}, {3, 2}} {
+		resp, err := service.Start(ctx, StartAttemptRequest{QuizSetID: strPtr("s1")})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		for i, choice := range choices {
+			if _, err := service.Answer(ctx, resp.ID, AnswerRequest{QuizID: items.items[i].QuizID, Choice: choice}); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+		}
+	}
+	if _, err := service.Start(ctx, StartAttemptRequest{QuizSetID: strPtr("s1")}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	items.items[0].CreatedBy = strPtr("alice")
+	resp, err := stats.Get(utils.SetUserID(ctx, "alice"), "q1")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Answer == nil || *resp.Answer != 1 || resp.Responses != 2 || resp.Omitted != 0 || resp.PValue == nil || *resp.PValue != 0.5 {
+		t.Errorf("expected 2 responses with p-value 0.5, got %+v", resp)
+	}
+	if resp.PointBiserial == nil || *resp.PointBiserial != 1 {
+		t.Errorf("expected a perfect point-biserial, got %v", resp.PointBiserial)
+	}
+	if len(resp.Choices) != domain.NumChoices || !*resp.Choices[0].Correct || resp.Choices[0].Rate != 0.5 || resp.Choices[2].Count != 1 || *resp.Choices[2].Correct {
+		t.Errorf("unexpected choice rates %+v", resp.Choices)
+	}
+	if resp.AverageTimeMS == nil || *resp.AverageTimeMS != 10000 {
+		t.Errorf("expected an average time of 10s, got %v", resp.AverageTimeMS)
+	}
+
+	if _, err := stats.Get(ctx, "missing"); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got: %v", err)
+	}
+}
+
+func TestItemStats_AnswerOnlyForAuthorAndAdmins(t *testing.T) {
+	items := &mockItemRepository{items: newItems(1)}
+	items.items[0].CreatedBy = strPtr("alice")
+	_, repo := newTestService(items)
+	stats := NewStatsService(repo, items)
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{"anonymous", context.Background(), false},
+		{"other user", utils.SetUserID(context.Background(), "bob"), false},
+		{"author", utils.SetUserID(context.Background(), "alice"), true},
+		{"admin", utils.SetUserRole(utils.SetUserID(context.Background(), "carol"), utils.RoleAdmin), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := stats.Get(tc.ctx, "q1")
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if (resp.Answer != nil) != tc.want {
+				t.Errorf("expected answer shown %v, got %v", tc.want, resp.Answer)
+			}
+			for _, c := range resp.Choices {
+				if (c.Correct != nil) != tc.want {
+					t.Errorf("expected correct flags shown %v, got %+v", tc.want, c)
+				}
+			}
+		})
+	}
+}
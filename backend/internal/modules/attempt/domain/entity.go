@@ -0,0 +1,220 @@
+package domain
+
+import "time"
+
+// Mode is how an attempt chooses its questions
+type Mode string
+
+const (
+	// ModeFixed presents the quizzes of a quiz set in set order
+	ModeFixed Mode = "fixed"
+	// ModeAdaptive presents the quiz that tells most about the learner's
+	// current ability estimate
+	ModeAdaptive Mode = "adaptive"
+)
+
+// Status is the state of an attempt
+type Status string
+
+const (
+	StatusInProgress Status = "in_progress"
+	StatusSubmitted  Status = "submitted"
+)
+
+// Attempt is one learner's run through a quiz set or the quiz pool
+type Attempt struct {
+	ID        string  `json:"id" db:"id"`
+	QuizSetID *string `json:"quiz_set_id,omitempty" db:"quiz_set_id"`
+	UserID    *string `json:"user_id,omitempty" db:"user_id"`
+	// AssignmentID is the assignment the attempt counts against
+	AssignmentID *string `json:"assignment_id,omitempty" db:"assignment_id"`
+	// Late is true if the attempt was started after its assignment was due
+	Late bool `json:"late" db:"late"`
+	Mode Mode `json:"mode" db:"mode"`
+	// Model is the item response model of an adaptive attempt
+	Model *Model `json:"model,omitempty" db:"model"`
+	// MaxItems is the number of questions after which the attempt ends
+	MaxItems int `json:"max_items" db:"max_items"`
+	// TargetSE ends an adaptive attempt once the ability is known this precisely
+	TargetSE      *float64   `json:"target_se,omitempty" db:"target_se"`
+	Status        Status     `json:"status" db:"status"`
+	Ability       *float64   `json:"ability,omitempty" db:"ability"`
+	StandardError *float64   `json:"standard_error,omitempty" db:"standard_error"`
+	Score         float64    `json:"score" db:"score"`
+	MaxScore      float64    `json:"max_score" db:"max_score"`
+	StartedAt     time.Time  `json:"started_at" db:"started_at"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty" db:"submitted_at"`
+	Answers       []Answer   `json:"answers" db:"-"`
+}
+
+// Admission is an attempt admitted to an assignment
+type Admission struct {
+	AssignmentID string
+	QuizSetID    string
+	Late         bool
+}
+
+// Answer is a question presented in an attempt and the learner's response
+type Answer struct {
+	AttemptID string `json:"attempt_id" db:"attempt_id"`
+	Position  int    `json:"position" db:"position"`
+	QuizID    string `json:"quiz_id" db:"quiz_id"`
+	// RevisionID is the quiz revision the learner saw
+	RevisionID  string     `json:"revision_id" db:"revision_id"`
+	Choice      *int       `json:"choice,omitempty" db:"choice"`
+	Correct     *bool      `json:"correct,omitempty" db:"correct"`
+	PresentedAt time.Time  `json:"presented_at" db:"presented_at"`
+	AnsweredAt  *time.Time `json:"answered_at,omitempty" db:"answered_at"`
+	// Hints are the hints revealed for the question, in order
+	Hints []RevealedHint `json:"hints" db:"-"`
+}
+
+// Hint is one of the progressive hints of a quiz
+type Hint struct {
+	QuizID   string  `db:"quiz_id"`
+	Position int     `db:"position"`
+	Body     string  `db:"body"`
+	Penalty  float64 `db:"penalty"`
+}
+
+// RevealedHint is a hint revealed for a question of an attempt. Its body and
+// penalty are copied from the quiz when it is revealed.
+type RevealedHint struct {
+	AttemptID string `json:"attempt_id" db:"attempt_id"`
+	// Position is the position of the question in the attempt
+	Position   int       `json:"position" db:"position"`
+	Hint       int       `json:"hint" db:"hint"`
+	Body       string    `json:"body" db:"body"`
+	Penalty    float64   `json:"penalty" db:"penalty"`
+	RevealedAt time.Time `json:"revealed_at" db:"revealed_at"`
+}
+
+// IsSubmitted returns true if the attempt has ended
+func (a *Attempt) IsSubmitted() bool {
+	return a.Status == StatusSubmitted
+}
+
+// IsOwnedBy returns true if the attempt was started by userID
+func (a *Attempt) IsOwnedBy(userID string) bool {
+	return a.UserID != nil && *a.UserID == userID
+}
+
+// Current returns the question waiting for an answer, or nil
+func (a *Attempt) Current() *Answer {
+	if n := len(a.Answers); n > 0 && a.Answers[n-1].AnsweredAt == nil {
+		return &a.Answers[n-1]
+	}
+	return nil
+}
+
+// Answered returns the number of questions answered
+func (a *Attempt) Answered() int {
+	n := 0
+	for _, ans := range a.Answers {
+		if ans.AnsweredAt != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Presented returns true if the quiz has been presented in the attempt
+func (a *Attempt) Presented(quizID string) bool {
+	for _, ans := range a.Answers {
+		if ans.QuizID == quizID {
+			return true
+		}
+	}
+	return false
+}
+
+// HintsUsed returns the number of hints revealed in the attempt
+func (a *Attempt) HintsUsed() int {
+	n := 0
+	for _, ans := range a.Answers {
+		n += len(ans.Hints)
+	}
+	return n
+}
+
+// HintDeduction returns the points correct answers lost to hints
+func (a *Attempt) HintDeduction() float64 {
+	var total float64
+	for _, ans := range a.Answers {
+		if ans.Correct != nil && *ans.Correct {
+			total += 1 - ans.Points()
+		}
+	}
+	return total
+}
+
+// HintPenalty returns the penalties of the hints revealed for the question
+func (a *Answer) HintPenalty() float64 {
+	var total float64
+	for _, h := range a.Hints {
+		total += h.Penalty
+	}
+	return total
+}
+
+// Points returns what the answer earns: nothing when it is wrong or
+// unanswered, otherwise one point less the penalties of the hints revealed
+// for it, but never less than nothing
+func (a *Answer) Points() float64 {
+	if a.Correct == nil || !*a.Correct {
+		return 0
+	}
+	return max(0, 1-a.HintPenalty())
+}
+
+// TimeSpent returns how long the learner took to answer, or zero if the
+// question is unanswered
+func (a *Answer) TimeSpent() time.Duration {
+	if a.AnsweredAt == nil {
+		return 0
+	}
+	return a.AnsweredAt.Sub(a.PresentedAt)
+}
+
+// Item is a quiz as presented in attempts, with its item response parameters
+type Item struct {
+	QuizID     string `db:"quiz_id"`
+	RevisionID string `db:"revision_id"`
+	Question   string `db:"question"`
+	Choice1    string `db:"choice1"`
+	Choice2    string `db:"choice2"`
+	Choice3    string `db:"choice3"`
+	Choice4    string `db:"choice4"`
+	Answer     int    `db:"answer"`
+	// CreatedBy is the quiz's author, nil for quizzes without one
+	CreatedBy *string `db:"created_by"`
+	ItemParameters
+}
+
+// Revision is a quiz's content as it was presented in an attempt. Answers
+// are graded and reported against it, so editing the quiz does not change
+// attempts that already presented it.
+type Revision struct {
+	ID       string `db:"id"`
+	QuizID   string `db:"quiz_id"`
+	Question string `db:"question"`
+	Choice1  string `db:"choice1"`
+	Choice2  string `db:"choice2"`
+	Choice3  string `db:"choice3"`
+	Choice4  string `db:"choice4"`
+	Answer   int    `db:"answer"`
+}
+
+// ItemParameters are the item response theory parameters of a quiz
+type ItemParameters struct {
+	QuizID         string  `json:"quiz_id" db:"-"`
+	Discrimination float64 `json:"discrimination" db:"discrimination"`
+	Difficulty     float64 `json:"difficulty" db:"difficulty"`
+	// UpdatedAt is nil for quizzes that have not been calibrated
+	UpdatedAt *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// DefaultItemParameters are used for quizzes that have not been calibrated
+func DefaultItemParameters(quizID string) ItemParameters {
+	return ItemParameters{QuizID: quizID, Discrimination: 1, Difficulty: 0}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+
+var (
+	ErrAttemptNotFound    = sharedDomain.NewNotFoundError("Attempt not found")
+	ErrQuizNotFound       = sharedDomain.NewNotFoundError("Quiz not found")
+	ErrAssignmentNotFound = sharedDomain.NewNotFoundError("Assignment not found")
+	ErrQuizSetNotFound    = sharedDomain.NewNotFoundError("Quiz set not found")
+	ErrNotYourAttempt     = sharedDomain.NewForbiddenError("Only the learner who started an attempt or an admin may see or answer it")
+	ErrInvalidMode        = sharedDomain.NewValidationError("mode must be fixed or adaptive")
+	ErrInvalidModel       = sharedDomain.NewValidationError("model must be 1pl or 2pl")
+	ErrQuizSetRequired    = sharedDomain.NewValidationError("quiz_set_id is required for fixed attempts")
+	ErrInvalidMaxItems    = sharedDomain.NewValidationError("max_items must be between 1 and 100")
+	ErrInvalidTargetSE    = sharedDomain.NewValidationError("target_se must be greater than 0 and at most 1")
+	ErrNoItems            = sharedDomain.NewValidationError("There are no published quizzes with an answer to attempt")
+	ErrInvalidChoice      = sharedDomain.NewValidationError("choice must be between 1 and 4")
+	ErrNotCurrentQuiz     = sharedDomain.NewConflictError("Only the current question of the attempt can be answered")
+	ErrAttemptSubmitted   = sharedDomain.NewConflictError("Attempt has already been submitted")
+	ErrNoMoreHints        = sharedDomain.NewConflictError("The current question has no more hints")
+	ErrAssignmentSignIn   = sharedDomain.NewUnauthorizedError("Sign in to attempt an assignment")
+	ErrInvalidParameters  = sharedDomain.NewValidationError("discrimination must be greater than 0 and at most 4, and difficulty between -4 and 4")
+	ErrSignInRequired     = sharedDomain.NewUnauthorizedError("Sign in to calibrate quizzes written by other users")
+	ErrNotQuizOwner       = sharedDomain.NewForbiddenError("Only the quiz's author or an admin can calibrate it")
+)
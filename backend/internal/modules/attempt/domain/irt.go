@@ -0,0 +1,107 @@
+package domain
+
+import "math"
+
+// Model is the item response theory model used to estimate ability
+type Model string
+
+const (
+	// Model1PL (Rasch) uses only the difficulty of each quiz
+	Model1PL Model = "1pl"
+	// Model2PL also uses how sharply each quiz discriminates between abilities
+	Model2PL Model = "2pl"
+)
+
+// Valid reports whether m is a known model
+func (m Model) Valid() bool {
+	return m == Model1PL || m == Model2PL
+}
+
+// Parameters returns the discrimination and difficulty of the item under m
+func (m Model) Parameters(p ItemParameters) (a, b float64) {
+	if m == Model1PL {
+		return 1, p.Difficulty
+	}
+	return p.Discrimination, p.Difficulty
+}
+
+// Probability returns the chance that a learner of ability theta answers an
+// item with discrimination a and difficulty b correctly
+func Probability(theta, a, b float64) float64 {
+	return 1 / (1 + math.Exp(-a*(theta-b)))
+}
+
+// Information returns the Fisher information an item gives about ability theta
+func Information(theta, a, b float64) float64 {
+	p := Probability(theta, a, b)
+	return a * a * p * (1 - p)
+}
+
+// Response is an answered item used for ability estimation
+type Response struct {
+	Discrimination float64
+	Difficulty     float64
+	Correct        bool
+}
+
+// Ability estimation uses a standard normal prior evaluated on a grid, so
+// the estimate stays finite when every answer is right or wrong
+const (
+	abilityMin  = -4.0
+	abilityMax  = 4.0
+	abilityStep = 0.05
+)
+
+// EstimateAbility returns the expected a posteriori ability given the
+// responses and its standard error. Without responses it returns the prior:
+// ability 0 with standard error 1.
+func EstimateAbility(responses []Response) (ability, standardError float64) {
+	n := int(math.Round((abilityMax-abilityMin)/abilityStep)) + 1
+	thetas := make([]float64, n)
+	logWeights := make([]float64, n)
+	maxLog := math.Inf(-1)
+	for i := range thetas {
+		theta := abilityMin + float64(i)*abilityStep
+		logW := -theta * theta / 2
+		for _, r := range responses {
+			p := Probability(theta, r.Discrimination, r.Difficulty)
+			if r.Correct {
+				logW += math.Log(p)
+			} else {
+				logW += math.Log(1 - p)
+			}
+		}
+		thetas[i], logWeights[i] = theta, logW
+		maxLog = math.Max(maxLog, logW)
+	}
+
+	var sum, mean float64
+	weights := make([]float64, n)
+	for i, logW := range logWeights {
+		weights[i] = math.Exp(logW - maxLog)
+		sum += weights[i]
+		mean += weights[i] * thetas[i]
+	}
+	mean /= sum
+
+	var variance float64
+	for i, w := range weights {
+		d := thetas[i] - mean
+		variance += w * d * d
+	}
+	return mean, math.Sqrt(variance / sum)
+}
+
+// MostInformative returns the index of the item that tells most about
+// ability theta under model m, or -1 if there are no items. Ties go to the
+// earlier item.
+func MostInformative(theta float64, m Model, items []Item) int {
+	best, bestInfo := -1, -1.0
+	for i, item := range items {
+		a, b := m.Parameters(item.ItemParameters)
+		if info := Information(theta, a, b); info > bestInfo {
+			best, bestInfo = i, info
+		}
+	}
+	return best
+}
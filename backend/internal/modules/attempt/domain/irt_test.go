@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProbability(t *testing.T) {
+	if p := Probability(0, 1, 0); p != 0.5 {
+		t.Errorf("expected 0.5 at the item's difficulty, got %v", p)
+	}
+	if Probability(1, 1, 0) <= Probability(0, 1, 0) {
+		t.Error("expected higher ability to answer correctly more often")
+	}
+	if Probability(1, 2, 0) <= Probability(1, 1, 0) {
+		t.Error("expected a sharper item to separate abilities more")
+	}
+}
+
+func TestInformation_PeaksAtDifficulty(t *testing.T) {
+	if got := Information(1, 2, 1); got != 1 {
+		t.Errorf("expected a²/4 = 1 at the difficulty, got %v", got)
+	}
+	if Information(0, 2, 1) >= Information(1, 2, 1) {
+		t.Error("expected less information away from the difficulty")
+	}
+}
+
+func TestEstimateAbility(t *testing.T) {
+	ability, se := EstimateAbility(nil)
+	if math.Abs(ability) > 1e-9 || math.Abs(se-1) > 0.01 {
+		t.Errorf("expected the standard normal prior, got %v ± %v", ability, se)
+	}
+
+	right := []Response{{1, 0, true}, {1, 0.5, true}, {1, 1, true}}
+	wrong := []Response{{1, 0, false}, {1, -0.5, false}, {1, -1, false}}
+	up, upSE := EstimateAbility(right)
+	down, _ := EstimateAbility(wrong)
+	if up <= 0 || down >= 0 {
+		t.Errorf("expected all right above 0 and all wrong below, got %v and %v", up, down)
+	}
+	if upSE >= 1 {
+		t.Errorf("expected answers to shrink the standard error, got %v", upSE)
+	}
+
+	mixed := []Response{{1, 0, true}, {1, 0, false}}
+	if ability, _ := EstimateAbility(mixed); math.Abs(ability) > 1e-6 {
+		t.Errorf("expected one right and one wrong at difficulty 0 to stay at 0, got %v", ability)
+	}
+}
+
+func TestMostInformative(t *testing.T) {
+	items := []Item{
+		{QuizID: "easy", ItemParameters: ItemParameters{Discrimination: 1, Difficulty: -2}},
+		{QuizID: "medium", ItemParameters: ItemParameters{Discrimination: 1, Difficulty: 0.4}},
+		{QuizID: "sharp", ItemParameters: ItemParameters{Discrimination: 2.5, Difficulty: 1.5}},
+	}
+	if got := items[MostInformative(0.5, Model1PL, items)].QuizID; got != "medium" {
+		t.Errorf("expected the closest difficulty under 1PL, got %s", got)
+	}
+	if got := items[MostInformative(1, Model2PL, items)].QuizID; got != "sharp" {
+		t.Errorf("expected the sharper item under 2PL, got %s", got)
+	}
+	if MostInformative(0, Model2PL, nil) != -1 {
+		t.Error("expected -1 without items")
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AttemptRepository defines the interface for attempt data access
+type AttemptRepository interface {
+	// GetByID returns an attempt with its answers in presentation order and
+	// the hints revealed for each
+	GetByID(ctx context.Context, id string) (*Attempt, error)
+
+	// Create inserts a new attempt without answers
+	Create(ctx context.Context, attempt *Attempt) error
+
+	// Update stores the status, ability estimate and score of an attempt
+	Update(ctx context.Context, attempt *Attempt) error
+
+	// AddAnswer records that a question was presented
+	AddAnswer(ctx context.Context, answer *Answer) error
+
+	// RecordAnswer stores the response to a presented question. It fails
+	// with ErrNotCurrentQuiz if the question was already answered.
+	RecordAnswer(ctx context.Context, answer *Answer) error
+
+	// AddHint records that a hint was revealed for a question
+	AddHint(ctx context.Context, hint *RevealedHint) error
+
+	// ListResponses returns every presentation of a quiz in submitted attempts
+	ListResponses(ctx context.Context, quizID string) ([]ItemResponse, error)
+}
+
+// ItemRepository defines the interface for reading quizzes as attempt items
+type ItemRepository interface {
+	// ListItems returns the published quizzes inside their schedule that have
+	// an answer: the quizzes of a visible quiz set in set order, or every
+	// such quiz in display order when quizSetID is nil
+	ListItems(ctx context.Context, quizSetID *string) ([]Item, error)
+
+	// GetItems returns the given quizzes whatever their status, including
+	// ones in the trash
+	GetItems(ctx context.Context, quizIDs []string) ([]Item, error)
+
+	// GetRevisions returns the given quiz revisions
+	GetRevisions(ctx context.Context, revisionIDs []string) ([]Revision, error)
+
+	// ListHints returns the hints of a quiz in position order
+	ListHints(ctx context.Context, quizID string) ([]Hint, error)
+
+	// QuizSetVisible returns true if the quiz set exists and is inside its schedule
+	QuizSetVisible(ctx context.Context, quizSetID string) (bool, error)
+
+	// GetParameters returns the item response parameters of a quiz that is
+	// not in the trash, or the defaults if it has none
+	GetParameters(ctx context.Context, quizID string) (*ItemParameters, error)
+
+	// SaveParameters creates or replaces the item response parameters of a quiz
+	SaveParameters(ctx context.Context, params *ItemParameters) error
+
+	// QuizAuthor returns the author of a quiz that is not in the trash, nil
+	// for quizzes without one, or ErrQuizNotFound
+	QuizAuthor(ctx context.Context, quizID string) (*string, error)
+}
+
+// AssignmentGate admits attempts of learners against the assignments they
+// were given
+type AssignmentGate interface {
+	// Admit decides whether userID may start an attempt at now, on the
+	// assignment assignmentID when it is given or else on the assignments
+	// of quizSetID. It returns nil when the attempt is not assigned, and
+	// fails when every assignment it could count against refuses it. It
+	// runs inside the transaction creating the attempt.
+	Admit(ctx context.Context, userID string, quizSetID, assignmentID *string, now time.Time) (*Admission, error)
+}
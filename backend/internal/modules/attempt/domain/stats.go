@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// NumChoices is the number of choices every quiz carries
+const NumChoices = 4
+
+// ItemResponse is one presentation of a quiz in a submitted attempt, as read for
+// item analysis. Choice is nil when the learner submitted without answering.
+type ItemResponse struct {
+	Choice  *int `db:"choice"`
+	Correct bool `db:"correct"`
+	// OtherAnswered and OtherCorrect count the attempt's other answered
+	// questions and how many of them were correct
+	OtherAnswered int   `db:"other_answered"`
+	OtherCorrect  int   `db:"other_correct"`
+	TimeSpentMS   int64 `db:"time_spent_ms"`
+}
+
+// ItemStats is the classical item analysis of one quiz
+type ItemStats struct {
+	// Responses counts answered presentations, Omitted the unanswered ones
+	Responses int
+	Omitted   int
+	// PValue is the share of correct answers, nil without responses
+	PValue *float64
+	// PointBiserial correlates answering correctly with the share of the
+	// attempt's other questions answered correctly; nil when either does not vary
+	PointBiserial *float64
+	// Choices counts the responses per choice, in choice order
+	Choices [NumChoices]int
+	// AverageTime is the mean time to answer, nil without responses
+	AverageTime *time.Duration
+}
+
+// ChoiceRate returns the share of responses that picked choice (1-based)
+func (s ItemStats) ChoiceRate(choice int) float64 {
+	if s.Responses == 0 {
+		return 0
+	}
+	return float64(s.Choices[choice-1]) / float64(s.Responses)
+}
+
+// AnalyzeItem computes the item statistics of a quiz from its responses
+func AnalyzeItem(responses []ItemResponse) ItemStats {
+	var stats ItemStats
+	var correct int
+	var total time.Duration
+	var xs, ys []float64
+	for _, r := range responses {
+		if r.Choice == nil {
+			stats.Omitted++
+			continue
+		}
+		stats.Responses++
+		if *r.Choice >= 1 && *r.Choice <= NumChoices {
+			stats.Choices[*r.Choice-1]++
+		}
+		x := 0.0
+		if r.Correct {
+			correct++
+			x = 1
+		}
+		total += time.Duration(r.TimeSpentMS) * time.Millisecond
+		// Attempts with no other answers say nothing about discrimination
+		if r.OtherAnswered > 0 {
+			xs = append(xs, x)
+			ys = append(ys, float64(r.OtherCorrect)/float64(r.OtherAnswered))
+		}
+	}
+	if stats.Responses == 0 {
+		return stats
+	}
+
+	p := float64(correct) / float64(stats.Responses)
+	stats.PValue = &p
+	average := total / time.Duration(stats.Responses)
+	stats.AverageTime = &average
+	stats.PointBiserial = correlation(xs, ys)
+	return stats
+}
+
+// correlation returns the Pearson correlation of xs and ys, or nil if there
+// are fewer than two pairs or either does not vary
+func correlation(xs, ys []float64) *float64 {
+	n := float64(len(xs))
+	if len(xs) < 2 {
+		return nil
+	}
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return nil
+	}
+	r := cov / math.Sqrt(varX*varY)
+	return &r
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func choice(c int) *int { return &c }
+
+func TestAnalyzeItem(t *testing.T) {
+	responses := []ItemResponse{
+		{Choice: choice(1), Correct: true, OtherAnswered: 4, OtherCorrect: 4, TimeSpentMS: 1000},
+		{Choice: choice(1), Correct: true, OtherAnswered: 4, OtherCorrect: 3, TimeSpentMS: 2000},
+		{Choice: choice(2), Correct: false, OtherAnswered: 4, OtherCorrect: 1, TimeSpentMS: 3000},
+		{Choice: choice(3), Correct: false, OtherAnswered: 2, OtherCorrect: 0, TimeSpentMS: 6000},
+		{Choice: nil},
+	}
+
+	stats := AnalyzeItem(responses)
+
+	if stats.Responses != 4 || stats.Omitted != 1 {
+		t.Errorf("expected 4 responses and 1 omission, got %d and %d", stats.Responses, stats.Omitted)
+	}
+	if stats.PValue == nil || *stats.PValue != 0.5 {
+		t.Errorf("expected p-value 0.5, got %v", stats.PValue)
+	}
+	if stats.Choices != [NumChoices]int{2, 1, 1, 0} || stats.ChoiceRate(1) != 0.5 || stats.ChoiceRate(4) != 0 {
+		t.Errorf("unexpected choice counts %v", stats.Choices)
+	}
+	if stats.AverageTime == nil || *stats.AverageTime != 3*time.Second {
+		t.Errorf("expected an average of 3s, got %v", stats.AverageTime)
+	}
+	// Rest scores 1, 0.75, 0.25, 0 against correctness 1, 1, 0, 0
+	if stats.PointBiserial == nil || math.Abs(*stats.PointBiserial-0.9487) > 1e-4 {
+		t.Errorf("expected a point-biserial of about 0.95, got %v", stats.PointBiserial)
+	}
+}
+
+func TestAnalyzeItem_NotEnoughData(t *testing.T) {
+	stats := AnalyzeItem(nil)
+	if stats.PValue != nil || stats.PointBiserial != nil || stats.AverageTime != nil {
+		t.Errorf("expected no statistics without responses, got %+v", stats)
+	}
+
+	// Everybody was right, so correctness does not discriminate
+	stats = AnalyzeItem([]ItemResponse{
+		{Choice: choice(2), Correct: true, OtherAnswered: 3, OtherCorrect: 1},
+		{Choice: choice(2), Correct: true, OtherAnswered: 3, OtherCorrect: 3},
+	})
+	if stats.PValue == nil || *stats.PValue != 1 || stats.PointBiserial != nil {
+		t.Errorf("expected p-value 1 without point-biserial, got %+v", stats)
+	}
+}
@@ -0,0 +1,135 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	quizDomain "github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// itemColumns reads a quiz as an item; uncalibrated quizzes get the default parameters
+const itemColumns = `q.id AS quiz_id, q.revision_id, q.question, q.choice1, q.choice2, q.choice3, q.choice4, q.answer, q.created_by,
+	COALESCE(p.discrimination, 1) AS discrimination, COALESCE(p.difficulty, 0) AS difficulty`
+
+// itemCondition selects the quizzes that can be attempted: published,
+// inside their schedule and with an answer
+const itemCondition = `q.deleted_at IS NULL AND q.answer BETWEEN 1 AND 4 AND ` + quizDomain.VisibleCondition
+
+// quizSetVisibleCondition is the SQL condition for quiz sets inside their schedule
+const quizSetVisibleCondition = `((publish_at IS NULL OR publish_at <= NOW()) AND (unpublish_at IS NULL OR unpublish_at > NOW()))`
+
+type postgresItemRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresItemRepository creates a new PostgreSQL attempt item repository
+func NewPostgresItemRepository(db *sqlx.DB) domain.ItemRepository {
+	return &postgresItemRepository{db: db}
+}
+
+func (r *postgresItemRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// ListItems returns the quizzes that can be attempted, in set order for a
+// quiz set and in display order otherwise
+func (r *postgresItemRepository) ListItems(ctx context.Context, quizSetID *string) ([]domain.Item, error) {
+	items := []domain.Item{}
+	q := r.getQueryable(ctx)
+	if quizSetID == nil {
+		query := `SELECT ` + itemColumns + ` FROM quizzes q LEFT JOIN quiz_item_parameters p ON p.quiz_id = q.id
+		           WHERE ` + itemCondition + ` ORDER BY q.display_order ASC`
+		err := q.SelectContext(ctx, &items, query)
+		return items, err
+	}
+
+	query := `SELECT ` + itemColumns + ` FROM quiz_set_items i JOIN quizzes q ON q.id = i.quiz_id
+	           LEFT JOIN quiz_item_parameters p ON p.quiz_id = q.id
+	           WHERE i.quiz_set_id::text = $1 AND ` + itemCondition + ` ORDER BY i.position ASC`
+	err := q.SelectContext(ctx, &items, query, *quizSetID)
+	return items, err
+}
+
+// GetItems returns the given quizzes whatever their status, including ones in the trash
+func (r *postgresItemRepository) GetItems(ctx context.Context, quizIDs []string) ([]domain.Item, error) {
+	items := []domain.Item{}
+	query := `SELECT ` + itemColumns + ` FROM quizzes q LEFT JOIN quiz_item_parameters p ON p.quiz_id = q.id
+	           WHERE q.id::text = ANY($1)`
+	q := r.getQueryable(ctx)
+	err := q.SelectContext(ctx, &items, query, pq.Array(quizIDs))
+	return items, err
+}
+
+// GetRevisions returns the given quiz revisions
+func (r *postgresItemRepository) GetRevisions(ctx context.Context, revisionIDs []string) ([]domain.Revision, error) {
+	revisions := []domain.Revision{}
+	query := `SELECT id, quiz_id, question, choice1, choice2, choice3, choice4, answer
+	           FROM quiz_revisions WHERE id::text = ANY($1)`
+	q := r.getQueryable(ctx)
+	err := q.SelectContext(ctx, &revisions, query, pq.Array(revisionIDs))
+	return revisions, err
+}
+
+// ListHints returns the hints of a quiz in position order
+func (r *postgresItemRepository) ListHints(ctx context.Context, quizID string) ([]domain.Hint, error) {
+	hints := []domain.Hint{}
+	query := `SELECT quiz_id, position, body, penalty FROM quiz_hints WHERE quiz_id::text = $1 ORDER BY position ASC`
+	q := r.getQueryable(ctx)
+	err := q.SelectContext(ctx, &hints, query, quizID)
+	return hints, err
+}
+
+// QuizSetVisible returns true if the quiz set exists and is inside its schedule
+func (r *postgresItemRepository) QuizSetVisible(ctx context.Context, quizSetID string) (bool, error) {
+	var visible bool
+	query := `SELECT EXISTS (SELECT 1 FROM quiz_sets WHERE id::text = $1 AND ` + quizSetVisibleCondition + `)`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &visible, query, quizSetID)
+	return visible, err
+}
+
+// GetParameters returns the item response parameters of a quiz that is not
+// in the trash, or the defaults if it has none
+func (r *postgresItemRepository) GetParameters(ctx context.Context, quizID string) (*domain.ItemParameters, error) {
+	var params domain.ItemParameters
+	query := `SELECT COALESCE(p.discrimination, 1) AS discrimination, COALESCE(p.difficulty, 0) AS difficulty, p.updated_at
+	           FROM quizzes q LEFT JOIN quiz_item_parameters p ON p.quiz_id = q.id
+	           WHERE q.id::text = $1 AND q.deleted_at IS NULL`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &params, query, quizID)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrQuizNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	params.QuizID = quizID
+	return &params, nil
+}
+
+// QuizAuthor returns the author of a quiz that is not in the trash
+func (r *postgresItemRepository) QuizAuthor(ctx context.Context, quizID string) (*string, error) {
+	var author *string
+	query := `SELECT created_by FROM quizzes WHERE id::text = $1 AND deleted_at IS NULL`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &author, query, quizID)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrQuizNotFound
+	}
+	return author, err
+}
+
+// SaveParameters creates or replaces the item response parameters of a quiz
+func (r *postgresItemRepository) SaveParameters(ctx context.Context, params *domain.ItemParameters) error {
+	query := `INSERT INTO quiz_item_parameters (quiz_id, discrimination, difficulty, updated_at)
+	           VALUES ($1, $2, $3, NOW())
+	           ON CONFLICT (quiz_id) DO UPDATE SET discrimination = EXCLUDED.discrimination,
+	               difficulty = EXCLUDED.difficulty, updated_at = EXCLUDED.updated_at
+	           RETURNING updated_at`
+	q := r.getQueryable(ctx)
+	return q.GetContext(ctx, &params.UpdatedAt, query, params.QuizID, params.Discrimination, params.Difficulty)
+}
@@ -0,0 +1,146 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+const attemptColumns = `id, quiz_set_id, user_id, assignment_id, late, mode, model, max_items, target_se, status, ability, standard_error, score, max_score, started_at, submitted_at`
+
+type postgresAttemptRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresAttemptRepository creates a new PostgreSQL attempt repository
+func NewPostgresAttemptRepository(db *sqlx.DB) domain.AttemptRepository {
+	return &postgresAttemptRepository{db: db}
+}
+
+func (r *postgresAttemptRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// GetByID returns an attempt with its answers in presentation order. Inside
+// a transaction the attempt is locked until it ends, so answers to the same
+// attempt are recorded one at a time.
+func (r *postgresAttemptRepository) GetByID(ctx context.Context, id string) (*domain.Attempt, error) {
+	var attempt domain.Attempt
+	query := `SELECT ` + attemptColumns + ` FROM attempts WHERE id::text = $1`
+	if database.GetTx(ctx) != nil {
+		query += ` FOR UPDATE`
+	}
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &attempt, query, id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrAttemptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	attempt.Answers = []domain.Answer{}
+	query = `SELECT attempt_id, position, quiz_id, revision_id, choice, correct, presented_at, answered_at
+	          FROM attempt_answers WHERE attempt_id = $1 ORDER BY position ASC`
+	if err := q.SelectContext(ctx, &attempt.Answers, query, attempt.ID); err != nil {
+		return nil, err
+	}
+
+	hints := []domain.RevealedHint{}
+	query = `SELECT attempt_id, position, hint, body, penalty, revealed_at
+	          FROM attempt_hints WHERE attempt_id = $1 ORDER BY position ASC, hint ASC`
+	if err := q.SelectContext(ctx, &hints, query, attempt.ID); err != nil {
+		return nil, err
+	}
+	for _, h := range hints {
+		if i := h.Position - 1; i >= 0 && i < len(attempt.Answers) {
+			attempt.Answers[i].Hints = append(attempt.Answers[i].Hints, h)
+		}
+	}
+	return &attempt, nil
+}
+
+// Create inserts a new attempt without answers
+func (r *postgresAttemptRepository) Create(ctx context.Context, attempt *domain.Attempt) error {
+	query := `INSERT INTO attempts (` + attemptColumns + `)
+	           VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query, attempt.ID, attempt.QuizSetID, attempt.UserID, attempt.AssignmentID, attempt.Late, attempt.Mode, attempt.Model,
+		attempt.MaxItems, attempt.TargetSE, attempt.Status, attempt.Ability, attempt.StandardError, attempt.Score,
+		attempt.MaxScore, attempt.StartedAt, attempt.SubmittedAt)
+	return err
+}
+
+// Update stores the status, ability estimate and score of an attempt
+func (r *postgresAttemptRepository) Update(ctx context.Context, attempt *domain.Attempt) error {
+	query := `UPDATE attempts SET status = $2, ability = $3, standard_error = $4, score = $5, max_score = $6, submitted_at = $7
+	           WHERE id = $1`
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, attempt.ID, attempt.Status, attempt.Ability, attempt.StandardError,
+		attempt.Score, attempt.MaxScore, attempt.SubmittedAt)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrAttemptNotFound
+	}
+	return nil
+}
+
+// AddAnswer records that a question was presented
+func (r *postgresAttemptRepository) AddAnswer(ctx context.Context, answer *domain.Answer) error {
+	query := `INSERT INTO attempt_answers (attempt_id, position, quiz_id, revision_id, presented_at)
+	           VALUES ($1, $2, $3, $4, $5)`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query, answer.AttemptID, answer.Position, answer.QuizID, answer.RevisionID, answer.PresentedAt)
+	return err
+}
+
+// RecordAnswer stores the response to a presented question that has not
+// been answered yet
+func (r *postgresAttemptRepository) RecordAnswer(ctx context.Context, answer *domain.Answer) error {
+	query := `UPDATE attempt_answers SET choice = $3, correct = $4, answered_at = $5
+	           WHERE attempt_id = $1 AND position = $2 AND answered_at IS NULL`
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, answer.AttemptID, answer.Position, answer.Choice, answer.Correct, answer.AnsweredAt)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotCurrentQuiz
+	}
+	return nil
+}
+
+// AddHint records that a hint was revealed for a question
+func (r *postgresAttemptRepository) AddHint(ctx context.Context, hint *domain.RevealedHint) error {
+	query := `INSERT INTO attempt_hints (attempt_id, position, hint, body, penalty, revealed_at)
+	           VALUES ($1, $2, $3, $4, $5, $6)`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query, hint.AttemptID, hint.Position, hint.Hint, hint.Body, hint.Penalty, hint.RevealedAt)
+	return err
+}
+
+// ListResponses returns every presentation of a quiz in submitted attempts,
+// with the attempt's other answered questions and how many were correct
+func (r *postgresAttemptRepository) ListResponses(ctx context.Context, quizID string) ([]domain.ItemResponse, error) {
+	responses := []domain.ItemResponse{}
+	query := `SELECT a.choice, COALESCE(a.correct, FALSE) AS correct,
+	                 COUNT(o.quiz_id) AS other_answered, COUNT(o.quiz_id) FILTER (WHERE o.correct) AS other_correct,
+	                 COALESCE(FLOOR(EXTRACT(EPOCH FROM a.answered_at - a.presented_at) * 1000), 0)::bigint AS time_spent_ms
+	           FROM attempt_answers a
+	           JOIN attempts t ON t.id = a.attempt_id AND t.status = 'submitted'
+	           LEFT JOIN attempt_answers o ON o.attempt_id = a.attempt_id AND o.quiz_id <> a.quiz_id AND o.answered_at IS NOT NULL
+	           WHERE a.quiz_id = $1
+	           GROUP BY a.attempt_id, a.position`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &responses, query, quizID); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
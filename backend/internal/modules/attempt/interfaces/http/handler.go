@@ -0,0 +1,93 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// AttemptHandler handles HTTP requests for attempts
+type AttemptHandler struct {
+	service application.AttemptService
+}
+
+// NewAttemptHandler creates a new AttemptHandler
+func NewAttemptHandler(service application.AttemptService) *AttemptHandler {
+	return &AttemptHandler{service: service}
+}
+
+// Start handles POST /attempts
+func (h *AttemptHandler) Start(w http.ResponseWriter, r *http.Request) {
+	var req application.StartAttemptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	attempt, err := h.service.Start(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, attempt)
+}
+
+// Get handles GET /attempts/{id}
+func (h *AttemptHandler) Get(w http.ResponseWriter, r *http.Request) {
+	attempt, err := h.service.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, attempt)
+}
+
+// Answer handles POST /attempts/{id}/answers
+func (h *AttemptHandler) Answer(w http.ResponseWriter, r *http.Request) {
+	var req application.AnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	attempt, err := h.service.Answer(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, attempt)
+}
+
+// Submit handles POST /attempts/{id}/submit
+func (h *AttemptHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	attempt, err := h.service.Submit(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, attempt)
+}
+
+// Hint handles POST /attempts/{id}/hints
+func (h *AttemptHandler) Hint(w http.ResponseWriter, r *http.Request) {
+	var req application.HintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	hint, err := h.service.Hint(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, hint)
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// ParameterHandler handles HTTP requests for quiz item response parameters
+type ParameterHandler struct {
+	service application.ParameterService
+}
+
+// NewParameterHandler creates a new ParameterHandler
+func NewParameterHandler(service application.ParameterService) *ParameterHandler {
+	return &ParameterHandler{service: service}
+}
+
+// Get handles GET /quizzes/{id}/irt
+func (h *ParameterHandler) Get(w http.ResponseWriter, r *http.Request) {
+	params, err := h.service.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, params)
+}
+
+// Put handles PUT /quizzes/{id}/irt
+func (h *ParameterHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var req application.ItemParametersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	params, err := h.service.Set(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, params)
+}
@@ -0,0 +1,26 @@
+package http
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/application"
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterRoutes registers all attempt module routes. Item response
+// parameters and item analysis live under the quiz they describe.
+func RegisterRoutes(r chi.Router, attempts application.AttemptService, parameters application.ParameterService, stats application.StatsService) {
+	handler := NewAttemptHandler(attempts)
+	parameterHandler := NewParameterHandler(parameters)
+	statsHandler := NewStatsHandler(stats)
+
+	r.Get("/quizzes/{id}/irt", parameterHandler.Get)
+	r.Put("/quizzes/{id}/irt", parameterHandler.Put)
+	r.Get("/quizzes/{id}/stats", statsHandler.Get)
+
+	r.Route("/attempts", func(r chi.Router) {
+		r.Post("/", handler.Start)
+		r.Get("/{id}", handler.Get)
+		r.Post("/{id}/answers", handler.Answer)
+		r.Post("/{id}/hints", handler.Hint)
+		r.Post("/{id}/submit", handler.Submit)
+	})
+}
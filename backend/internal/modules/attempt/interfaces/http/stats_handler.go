@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// StatsHandler handles HTTP requests for quiz item analysis
+type StatsHandler struct {
+	service application.StatsService
+}
+
+// NewStatsHandler creates a new StatsHandler
+func NewStatsHandler(service application.StatsService) *StatsHandler {
+	return &StatsHandler{service: service}
+}
+
+// Get handles GET /quizzes/{id}/stats
+func (h *StatsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, stats)
+}
@@ -0,0 +1,39 @@
+package attempt
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt/infrastructure"
+	httpinterface "github.com/cananga-odorata/golang-template/internal/modules/attempt/interfaces/http"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Module represents the attempt module with all its dependencies
+type Module struct {
+	Service    application.AttemptService
+	Parameters application.ParameterService
+	Stats      application.StatsService
+}
+
+// NewModule initializes the attempt module with all dependencies. Attempts
+// are admitted by assignments, which may be nil. Submitted attempts are
+// published on bus, which may be nil.
+func NewModule(db *sqlx.DB, bus *events.EventBus, assignments domain.AssignmentGate) *Module {
+	attempts := infrastructure.NewPostgresAttemptRepository(db)
+	items := infrastructure.NewPostgresItemRepository(db)
+	txManager := database.NewTxManager(db)
+
+	return &Module{
+		Service:    application.NewAttemptService(attempts, items, assignments, txManager, bus),
+		Parameters: application.NewParameterService(items),
+		Stats:      application.NewStatsService(attempts, items),
+	}
+}
+
+// RegisterRoutes registers the module's HTTP routes
+func (m *Module) RegisterRoutes(r chi.Router) {
+	httpinterface.RegisterRoutes(r, m.Service, m.Parameters, m.Stats)
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// ListCertificatesRequest DTO for one page of every certificate, narrowed
+// to a learner or a quiz set when given
+type ListCertificatesRequest struct {
+	UserID    string
+	QuizSetID string
+	Page      int
+	PageSize  int
+}
+
+// CertificatePage is one page of certificates
+type CertificatePage struct {
+	Items      []CertificateResponse
+	Pagination sharedDomain.Pagination
+}
+
+// RevokeRequest DTO for revoking a certificate
+type RevokeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// CertificateResponse DTO for a certificate as its holder and admins see it
+type CertificateResponse struct {
+	ID           string        `json:"id"`
+	Code         string        `json:"code"`
+	Status       domain.Status `json:"status"`
+	AttemptID    *string       `json:"attempt_id,omitempty"`
+	QuizSetID    *string       `json:"quiz_set_id,omitempty"`
+	QuizSetTitle string        `json:"quiz_set_title"`
+	UserID       string        `json:"user_id"`
+	Score        float64       `json:"score"`
+	MaxScore     float64       `json:"max_score"`
+	Percent      float64       `json:"percent"`
+	PassMark     float64       `json:"pass_mark"`
+	IssuedAt     time.Time     `json:"issued_at"`
+	RevokedAt    *time.Time    `json:"revoked_at,omitempty"`
+	RevokedBy    *string       `json:"revoked_by,omitempty"`
+	RevokeReason string        `json:"revoke_reason,omitempty"`
+}
+
+// VerificationResponse DTO for the public check of a verification code. It
+// names what was passed and when, but not who holds the certificate or how
+// they scored; HolderMatches answers whether a given user holds it.
+type VerificationResponse struct {
+	Code          string        `json:"code"`
+	Status        domain.Status `json:"status"`
+	Valid         bool          `json:"valid"`
+	QuizSetTitle  string        `json:"quiz_set_title"`
+	IssuedAt      time.Time     `json:"issued_at"`
+	RevokedAt     *time.Time    `json:"revoked_at,omitempty"`
+	HolderMatches *bool         `json:"holder_matches,omitempty"`
+}
+
+// PrintResult holds a rendered certificate
+type PrintResult struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+func toCertificateResponse(c domain.Certificate, loc *time.Location) CertificateResponse {
+	resp := CertificateResponse{
+		ID:           c.ID,
+		Code:         c.Code,
+		Status:       c.Status(),
+		AttemptID:    c.AttemptID,
+		QuizSetID:    c.QuizSetID,
+		QuizSetTitle: c.QuizSetTitle,
+		UserID:       c.UserID,
+		Score:        c.Score,
+		MaxScore:     c.MaxScore,
+		Percent:      c.Percent(),
+		PassMark:     c.PassMark,
+		IssuedAt:     c.IssuedAt.In(loc),
+		RevokedBy:    c.RevokedBy,
+		RevokeReason: c.RevokeReason,
+	}
+	if c.RevokedAt != nil {
+		revokedAt := c.RevokedAt.In(loc)
+		resp.RevokedAt = &revokedAt
+	}
+	return resp
+}
@@ -0,0 +1,251 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	attemptDomain "github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// certifiedMode is the attempt mode that earns certificates; adaptive
+// attempts ask each learner different questions, so their scores say
+// nothing about the whole quiz set
+const certifiedMode = string(attemptDomain.ModeFixed)
+
+// CertificateService defines the certificate business logic interface
+type CertificateService interface {
+	Issue(ctx context.Context, event events.AttemptSubmittedEvent) error
+	Mine(ctx context.Context) ([]CertificateResponse, error)
+	List(ctx context.Context, req ListCertificatesRequest) (*CertificatePage, error)
+	Get(ctx context.Context, id string) (*CertificateResponse, error)
+	Render(ctx context.Context, id string) (*PrintResult, error)
+	Verify(ctx context.Context, code, holder string) (*VerificationResponse, error)
+	Revoke(ctx context.Context, id string, req RevokeRequest) (*CertificateResponse, error)
+}
+
+type certificateService struct {
+	repo     domain.CertificateRepository
+	renderer domain.CertificateRenderer
+	location *time.Location
+	now      func() time.Time
+	newCode  func() (string, error)
+}
+
+// NewCertificateService creates a new CertificateService. Dates are
+// rendered in loc.
+func NewCertificateService(repo domain.CertificateRepository, renderer domain.CertificateRenderer, loc *time.Location) CertificateService {
+	return &certificateService{repo: repo, renderer: renderer, location: loc, now: time.Now, newCode: domain.NewCode}
+}
+
+// Issue awards a certificate for a submitted fixed attempt by a signed-in
+// learner that reaches the pass mark of its quiz set. Learners already
+// holding a valid certificate for the set are not issued another.
+func (s *certificateService) Issue(ctx context.Context, event events.AttemptSubmittedEvent) error {
+	if event.QuizSetID == nil || event.UserID == nil || event.Mode != certifiedMode {
+		return nil
+	}
+	set, err := s.repo.GetQuizSet(ctx, *event.QuizSetID)
+	if err != nil {
+		return sharedDomain.NewInternalError("Failed to fetch quiz set", err)
+	}
+	if set == nil || set.PassMark == nil || !domain.Passes(event.Score, event.MaxScore, *set.PassMark) {
+		return nil
+	}
+
+	code, err := s.newCode()
+	if err != nil {
+		return sharedDomain.NewInternalError("Failed to generate verification code", err)
+	}
+	certificate := &domain.Certificate{
+		ID:           sharedDomain.NewID(),
+		Code:         code,
+		AttemptID:    &event.AttemptID,
+		QuizSetID:    &set.ID,
+		QuizSetTitle: set.Title,
+		UserID:       *event.UserID,
+		Score:        event.Score,
+		MaxScore:     event.MaxScore,
+		PassMark:     *set.PassMark,
+		IssuedAt:     event.SubmittedAt,
+	}
+	if _, err := s.repo.Issue(ctx, certificate); err != nil {
+		return sharedDomain.NewInternalError("Failed to issue certificate", err)
+	}
+	return nil
+}
+
+// Mine returns the certificates of the current user, newest first
+func (s *certificateService) Mine(ctx context.Context) ([]CertificateResponse, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrSignInRequired
+	}
+	filter := domain.Filter{UserID: &userID}
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to count certificates", err)
+	}
+	certificates, err := s.repo.List(ctx, filter, 0, int(total))
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch certificates", err)
+	}
+
+	responses := make([]CertificateResponse, len(certificates))
+	for i, c := range certificates {
+		responses[i] = toCertificateResponse(c, s.location)
+	}
+	return responses, nil
+}
+
+// List returns one page of every certificate, newest first, to admins
+func (s *certificateService) List(ctx context.Context, req ListCertificatesRequest) (*CertificatePage, error) {
+	if !utils.IsAdmin(ctx) {
+		return nil, domain.ErrAdminOnly
+	}
+	var filter domain.Filter
+	if userID := strings.TrimSpace(req.UserID); userID != "" {
+		filter.UserID = &userID
+	}
+	if quizSetID := strings.TrimSpace(req.QuizSetID); quizSetID != "" {
+		filter.QuizSetID = &quizSetID
+	}
+
+	pagination := sharedDomain.NewPagination(req.Page, req.PageSize)
+	var err error
+	pagination.Total, err = s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to count certificates", err)
+	}
+	certificates, err := s.repo.List(ctx, filter, pagination.Offset(), pagination.Limit())
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch certificates", err)
+	}
+
+	page := &CertificatePage{Items: make([]CertificateResponse, len(certificates)), Pagination: pagination}
+	for i, c := range certificates {
+		page.Items[i] = toCertificateResponse(c, s.location)
+	}
+	return page, nil
+}
+
+// Get returns a certificate to its holder or an admin
+func (s *certificateService) Get(ctx context.Context, id string) (*CertificateResponse, error) {
+	certificate, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toCertificateResponse(*certificate, s.location)
+	return &resp, nil
+}
+
+// Render returns the printable certificate to its holder or an admin
+func (s *certificateService) Render(ctx context.Context, id string) (*PrintResult, error) {
+	certificate, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	certificate.IssuedAt = certificate.IssuedAt.In(s.location)
+	if certificate.RevokedAt != nil {
+		revokedAt := certificate.RevokedAt.In(s.location)
+		certificate.RevokedAt = &revokedAt
+	}
+
+	var buf bytes.Buffer
+	if err := s.renderer.Render(&buf, certificate); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to render certificate", err)
+	}
+	return &PrintResult{
+		Filename:    "certificate-" + certificate.Code + s.renderer.FileExtension(),
+		ContentType: s.renderer.ContentType(),
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// Verify tells anyone holding a verification code whether it belongs to a
+// certificate that still stands. With holder, it also answers whether that
+// user holds the certificate, without revealing who does.
+func (s *certificateService) Verify(ctx context.Context, code, holder string) (*VerificationResponse, error) {
+	normalized, ok := domain.NormalizeCode(code)
+	if !ok {
+		return nil, domain.ErrCertificateNotFound
+	}
+	certificate, err := s.repo.GetByCode(ctx, normalized)
+	if err != nil {
+		if errors.Is(err, domain.ErrCertificateNotFound) {
+			return nil, err
+		}
+		return nil, sharedDomain.NewInternalError("Failed to fetch certificate", err)
+	}
+
+	resp := &VerificationResponse{
+		Code:         certificate.Code,
+		Status:       certificate.Status(),
+		Valid:        certificate.Status() == domain.StatusValid,
+		QuizSetTitle: certificate.QuizSetTitle,
+		IssuedAt:     certificate.IssuedAt.In(s.location),
+	}
+	if certificate.RevokedAt != nil {
+		revokedAt := certificate.RevokedAt.In(s.location)
+		resp.RevokedAt = &revokedAt
+	}
+	if holder = strings.TrimSpace(holder); holder != "" {
+		matches := certificate.IsOwnedBy(holder)
+		resp.HolderMatches = &matches
+	}
+	return resp, nil
+}
+
+// Revoke withdraws a certificate; it stays verifiable as revoked, and its
+// holder can earn a new one by passing again
+func (s *certificateService) Revoke(ctx context.Context, id string, req RevokeRequest) (*CertificateResponse, error) {
+	if !utils.IsAdmin(ctx) {
+		return nil, domain.ErrAdminOnly
+	}
+	certificate, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if certificate.RevokedAt != nil {
+		return nil, domain.ErrAlreadyRevoked
+	}
+
+	now := s.now()
+	adminID := utils.MustGetUserID(ctx)
+	certificate.RevokedAt, certificate.RevokedBy, certificate.RevokeReason = &now, &adminID, strings.TrimSpace(req.Reason)
+	if err := s.repo.Revoke(ctx, certificate); err != nil {
+		if errors.Is(err, domain.ErrAlreadyRevoked) {
+			return nil, err
+		}
+		return nil, sharedDomain.NewInternalError("Failed to revoke certificate", err)
+	}
+
+	resp := toCertificateResponse(*certificate, s.location)
+	return &resp, nil
+}
+
+// load returns a certificate the current user may see
+func (s *certificateService) load(ctx context.Context, id string) (*domain.Certificate, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrSignInRequired
+	}
+	certificate, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrCertificateNotFound) {
+			return nil, err
+		}
+		return nil, sharedDomain.NewInternalError("Failed to fetch certificate", err)
+	}
+	if !utils.IsAdmin(ctx) && !certificate.IsOwnedBy(userID) {
+		return nil, domain.ErrNotYourCertificate
+	}
+	return certificate, nil
+}
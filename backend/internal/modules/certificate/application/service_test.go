@@ -0,0 +1,261 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// mockCertificateRepository keeps certificates and quiz sets in memory
+type mockCertificateRepository struct {
+	certificates map[string]domain.Certificate
+	quizSets     map[string]domain.QuizSet
+}
+
+func newMockRepo() *mockCertificateRepository {
+	passMark := 70.0
+	return &mockCertificateRepository{
+		certificates: map[string]domain.Certificate{},
+		quizSets: map[string]domain.QuizSet{
+			"compliance": {ID: "compliance", Title: "Compliance 2026", PassMark: &passMark},
+			"practice":   {ID: "practice", Title: "Practice"},
+		},
+	}
+}
+
+func (m *mockCertificateRepository) GetQuizSet(_ context.Context, id string) (*domain.QuizSet, error) {
+	set, ok := m.quizSets[id]
+	if !ok {
+		return nil, nil
+	}
+	return &set, nil
+}
+
+func (m *mockCertificateRepository) Issue(_ context.Context, c *domain.Certificate) (bool, error) {
+	for _, existing := range m.certificates {
+		if existing.RevokedAt == nil && existing.UserID == c.UserID && *existing.QuizSetID == *c.QuizSetID {
+			return false, nil
+		}
+	}
+	m.certificates[c.ID] = *c
+	return true, nil
+}
+
+func (m *mockCertificateRepository) GetByID(_ context.Context, id string) (*domain.Certificate, error) {
+	c, ok := m.certificates[id]
+	if !ok {
+		return nil, domain.ErrCertificateNotFound
+	}
+	return &c, nil
+}
+
+func (m *mockCertificateRepository) GetByCode(_ context.Context, code string) (*domain.Certificate, error) {
+	for _, c := range m.certificates {
+		if c.Code == code {
+			return &c, nil
+		}
+	}
+	return nil, domain.ErrCertificateNotFound
+}
+
+func (m *mockCertificateRepository) matching(filter domain.Filter) []domain.Certificate {
+	var out []domain.Certificate
+	for _, c := range m.certificates {
+		if (filter.UserID == nil || c.UserID == *filter.UserID) && (filter.QuizSetID == nil || *c.QuizSetID == *filter.QuizSetID) {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].IssuedAt.After(out[j].IssuedAt) })
+	return out
+}
+
+func (m *mockCertificateRepository) Count(_ context.Context, filter domain.Filter) (int64, error) {
+	return int64(len(m.matching(filter))), nil
+}
+
+func (m *mockCertificateRepository) List(_ context.Context, filter domain.Filter, offset, limit int) ([]domain.Certificate, error) {
+	out := m.matching(filter)
+	if offset > len(out) {
+		offset = len(out)
+	}
+	return out[offset:min(offset+limit, len(out))], nil
+}
+
+func (m *mockCertificateRepository) Revoke(_ context.Context, c *domain.Certificate) error {
+	stored := m.certificates[c.ID]
+	if stored.RevokedAt != nil {
+		return domain.ErrAlreadyRevoked
+	}
+	m.certificates[c.ID] = *c
+	return nil
+}
+
+// stubRenderer writes the code of the certificate it renders
+type stubRenderer struct{}
+
+func (stubRenderer) ContentType() string   { return "application/pdf" }
+func (stubRenderer) FileExtension() string { return ".pdf" }
+
+func (stubRenderer) Render(w io.Writer, c *domain.Certificate) error {
+	_, err := io.WriteString(w, c.Code)
+	return err
+}
+
+func newTestService(repo *mockCertificateRepository) *certificateService {
+	service := NewCertificateService(repo, stubRenderer{}, time.UTC).(*certificateService)
+	n := 0
+	codes := []string{"AAAA-BBBB-CCCC", "DDDD-EEEE-FFFF", "GGGG-HHHH-JJJJ"}
+	service.newCode = func() (string, error) {
+		n++
+		return codes[n-1], nil
+	}
+	return service
+}
+
+func submitted(attemptID, quizSetID, userID string, score float64) events.AttemptSubmittedEvent {
+	return events.AttemptSubmittedEvent{
+		AttemptID:   attemptID,
+		QuizSetID:   &quizSetID,
+		UserID:      &userID,
+		Mode:        "fixed",
+		Score:       score,
+		MaxScore:    10,
+		SubmittedAt: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestIssue(t *testing.T) {
+	repo := newMockRepo()
+	service := newTestService(repo)
+	ctx := context.Background()
+
+	adaptive := submitted("t0", "compliance", "alice", 10)
+	adaptive.Mode = "adaptive"
+	for _, event := range []events.AttemptSubmittedEvent{
+		submitted("t1", "compliance", "alice", 6),
+		submitted("t2", "practice", "alice", 10),
+		adaptive,
+		{AttemptID: "t3", QuizSetID: adaptive.QuizSetID, Mode: "fixed", Score: 10, MaxScore: 10},
+	} {
+		if err := service.Issue(ctx, event); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	if len(repo.certificates) != 0 {
+		t.Fatalf("expected no certificates for failed, uncertified, adaptive or anonymous attempts, got %d", len(repo.certificates))
+	}
+
+	if err := service.Issue(ctx, submitted("t4", "compliance", "alice", 7)); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := service.Issue(ctx, submitted("t5", "compliance", "alice", 9)); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	mine, err := service.Mine(utils.SetUserID(ctx, "alice"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(mine) != 1 || mine[0].Code != "AAAA-BBBB-CCCC" || mine[0].QuizSetTitle != "Compliance 2026" || mine[0].Percent != 70 || *mine[0].AttemptID != "t4" {
+		t.Errorf("expected one certificate for the first pass, got %+v", mine)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	repo := newMockRepo()
+	service := newTestService(repo)
+	ctx := context.Background()
+	if err := service.Issue(ctx, submitted("t1", "compliance", "alice", 8)); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	resp, err := service.Verify(ctx, "aaaabbbbcccc", "")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !resp.Valid || resp.Status != domain.StatusValid || resp.QuizSetTitle != "Compliance 2026" || resp.HolderMatches != nil {
+		t.Errorf("expected a valid certificate without holder check, got %+v", resp)
+	}
+	if resp, _ := service.Verify(ctx, "AAAA-BBBB-CCCC", "bob"); resp == nil || resp.HolderMatches == nil || *resp.HolderMatches {
+		t.Errorf("expected bob not to hold the certificate, got %+v", resp)
+	}
+	if resp, _ := service.Verify(ctx, "AAAA-BBBB-CCCC", "alice"); resp == nil || resp.HolderMatches == nil || !*resp.HolderMatches {
+		t.Errorf("expected alice to hold the certificate, got %+v", resp)
+	}
+	for _, code := range []string{"DDDD-EEEE-FFFF", "not a code"} {
+		if _, err := service.Verify(ctx, code, ""); !errors.Is(err, domain.ErrCertificateNotFound) {
+			t.Errorf("%q: expected ErrCertificateNotFound, got: %v", code, err)
+		}
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	repo := newMockRepo()
+	service := newTestService(repo)
+	service.now = func() time.Time { return time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC) }
+	alice := utils.SetUserID(context.Background(), "alice")
+	admin := utils.SetUserRole(utils.SetUserID(context.Background(), "hr"), utils.RoleAdmin)
+	if err := service.Issue(alice, submitted("t1", "compliance", "alice", 8)); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	id := repo.matching(domain.Filter{})[0].ID
+
+	if _, err := service.Revoke(alice, id, RevokeRequest{}); !errors.Is(err, domain.ErrAdminOnly) {
+		t.Errorf("expected ErrAdminOnly, got: %v", err)
+	}
+	resp, err := service.Revoke(admin, id, RevokeRequest{Reason: " Policy breach "})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Status != domain.StatusRevoked || *resp.RevokedBy != "hr" || resp.RevokeReason != "Policy breach" {
+		t.Errorf("expected a certificate revoked by hr, got %+v", resp)
+	}
+	if _, err := service.Revoke(admin, id, RevokeRequest{}); !errors.Is(err, domain.ErrAlreadyRevoked) {
+		t.Errorf("expected ErrAlreadyRevoked, got: %v", err)
+	}
+	if verification, err := service.Verify(context.Background(), "AAAA-BBBB-CCCC", ""); err != nil || verification.Valid || verification.RevokedAt == nil {
+		t.Errorf("expected the code to verify as revoked, got %+v, %v", verification, err)
+	}
+
+	retake := submitted("t2", "compliance", "alice", 9)
+	retake.SubmittedAt = retake.SubmittedAt.AddDate(0, 0, 2)
+	if err := service.Issue(alice, retake); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if page, err := service.List(admin, ListCertificatesRequest{UserID: "alice"}); err != nil || page.Pagination.Total != 2 || page.Items[0].Status != domain.StatusValid {
+		t.Errorf("expected a new valid certificate after passing again, got %+v, %v", page, err)
+	}
+}
+
+func TestCertificate_OnlyHolderOrAdmin(t *testing.T) {
+	repo := newMockRepo()
+	service := newTestService(repo)
+	if err := service.Issue(context.Background(), submitted("t1", "compliance", "alice", 8)); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	id := repo.matching(domain.Filter{})[0].ID
+
+	if _, err := service.Get(context.Background(), id); !errors.Is(err, domain.ErrSignInRequired) {
+		t.Errorf("expected ErrSignInRequired, got: %v", err)
+	}
+	bob := utils.SetUserID(context.Background(), "bob")
+	if _, err := service.Render(bob, id); !errors.Is(err, domain.ErrNotYourCertificate) {
+		t.Errorf("expected ErrNotYourCertificate, got: %v", err)
+	}
+	if _, err := service.List(bob, ListCertificatesRequest{}); !errors.Is(err, domain.ErrAdminOnly) {
+		t.Errorf("expected ErrAdminOnly, got: %v", err)
+	}
+	result, err := service.Render(utils.SetUserID(context.Background(), "alice"), id)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if result.Filename != "certificate-AAAA-BBBB-CCCC.pdf" || string(result.Data) != "AAAA-BBBB-CCCC" {
+		t.Errorf("expected the rendered certificate, got %q with %q", result.Filename, result.Data)
+	}
+}
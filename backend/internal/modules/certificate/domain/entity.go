@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+)
+
+// Status is whether a certificate still stands
+type Status string
+
+const (
+	StatusValid   Status = "valid"
+	StatusRevoked Status = "revoked"
+)
+
+// Certificate is proof that a learner passed a quiz set. The quiz set title,
+// scores and pass mark are kept as they were when it was issued.
+type Certificate struct {
+	ID           string     `db:"id"`
+	Code         string     `db:"code"`
+	AttemptID    *string    `db:"attempt_id"`
+	QuizSetID    *string    `db:"quiz_set_id"`
+	QuizSetTitle string     `db:"quiz_set_title"`
+	UserID       string     `db:"user_id"`
+	Score        float64    `db:"score"`
+	MaxScore     float64    `db:"max_score"`
+	PassMark     float64    `db:"pass_mark"`
+	IssuedAt     time.Time  `db:"issued_at"`
+	RevokedAt    *time.Time `db:"revoked_at"`
+	RevokedBy    *string    `db:"revoked_by"`
+	RevokeReason string     `db:"revoke_reason"`
+}
+
+// Status returns whether the certificate is valid or revoked
+func (c *Certificate) Status() Status {
+	if c.RevokedAt != nil {
+		return StatusRevoked
+	}
+	return StatusValid
+}
+
+// IsOwnedBy returns true if the certificate was issued to userID
+func (c *Certificate) IsOwnedBy(userID string) bool {
+	return c.UserID == userID
+}
+
+// Percent returns the score as a percentage of the maximum score
+func (c *Certificate) Percent() float64 {
+	if c.MaxScore == 0 {
+		return 0
+	}
+	return c.Score / c.MaxScore * 100
+}
+
+// Passes returns true if score reaches passMark percent of maxScore
+func Passes(score, maxScore, passMark float64) bool {
+	// Scores are counts of correct answers, so allow for rounding in the
+	// percentage, e.g. 29 of 100 against a pass mark of 29
+	return maxScore > 0 && score*100 >= passMark*maxScore-1e-9
+}
+
+// QuizSet is what issuing certificates needs to know about a quiz set
+type QuizSet struct {
+	ID    string `db:"id"`
+	Title string `db:"title"`
+	// PassMark is nil when the set awards no certificates
+	PassMark *float64 `db:"pass_mark"`
+}
+
+// Filter narrows a listing of certificates; nil fields match everything
+type Filter struct {
+	UserID    *string
+	QuizSetID *string
+}
+
+// Verification codes are 12 characters from an alphabet without the easily
+// confused 0, 1, I and O, printed in groups of four: 60 random bits
+const (
+	codeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	codeLength   = 12
+	codeGroup    = 4
+)
+
+// NewCode returns a random verification code such as 7KQM-3XPA-W9TD
+func NewCode() (string, error) {
+	b := make([]byte, codeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = codeAlphabet[int(b[i])%len(codeAlphabet)]
+	}
+	return group(string(b)), nil
+}
+
+// NormalizeCode returns raw as it is stored, ignoring case, spaces and
+// dashes, or false if it cannot be a verification code
+func NormalizeCode(raw string) (string, bool) {
+	raw = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(raw))
+	if len(raw) != codeLength {
+		return "", false
+	}
+	for _, r := range raw {
+		if !strings.ContainsRune(codeAlphabet, r) {
+			return "", false
+		}
+	}
+	return group(raw), true
+}
+
+func group(code string) string {
+	var sb strings.Builder
+	for i := 0; i < len(code); i += codeGroup {
+		if i > 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteString(code[i : i+codeGroup])
+	}
+	return sb.String()
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code, err := NewCode()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(code) != 14 || strings.Count(code, "-") != 2 {
+			t.Fatalf("expected a code like XXXX-XXXX-XXXX, got %q", code)
+		}
+		if normalized, ok := NormalizeCode(code); !ok || normalized != code {
+			t.Fatalf("expected %q to normalize to itself, got %q, %v", code, normalized, ok)
+		}
+		if seen[code] {
+			t.Fatalf("expected unique codes, got %q twice", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	cases := map[string]string{
+		"7kqm3xpaw9td":     "7KQM-3XPA-W9TD",
+		" 7KQM 3XPA-W9TD ": "7KQM-3XPA-W9TD",
+		"7KQM-3XPA-W9T":    "",
+		"7KQM-3XPA-W9T0":   "",
+	}
+	for raw, want := range cases {
+		got, ok := NormalizeCode(raw)
+		if ok != (want != "") || got != want {
+			t.Errorf("%q: expected %q, got %q, %v", raw, want, got, ok)
+		}
+	}
+}
+
+func TestPasses(t *testing.T) {
+	cases := []struct {
+		score, maxScore, passMark float64
+		want                      bool
+	}{
+		{7, 10, 70, true},
+		{6, 10, 70, false},
+		{29, 100, 29, true},
+		{10, 10, 100, true},
+		{0, 0, 50, false},
+	}
+	for _, tc := range cases {
+		if got := Passes(tc.score, tc.maxScore, tc.passMark); got != tc.want {
+			t.Errorf("%v/%v against %v%%: expected %v, got %v", tc.score, tc.maxScore, tc.passMark, tc.want, got)
+		}
+	}
+}
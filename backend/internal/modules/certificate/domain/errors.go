@@ -0,0 +1,11 @@
+package domain
+
+import sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+
+var (
+	ErrCertificateNotFound = sharedDomain.NewNotFoundError("Certificate not found")
+	ErrSignInRequired      = sharedDomain.NewUnauthorizedError("Sign in to see your certificates")
+	ErrNotYourCertificate  = sharedDomain.NewForbiddenError("Only the learner a certificate was issued to or an admin may see it")
+	ErrAdminOnly           = sharedDomain.NewForbiddenError("Only admins may list or revoke certificates")
+	ErrAlreadyRevoked      = sharedDomain.NewConflictError("Certificate has already been revoked")
+)
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// CertificateRepository defines the interface for certificate data access
+type CertificateRepository interface {
+	// GetQuizSet returns the title and pass mark of a quiz set, or nil if it
+	// does not exist
+	GetQuizSet(ctx context.Context, id string) (*QuizSet, error)
+
+	// Issue stores a certificate unless its learner already holds a valid
+	// one for the quiz set, and reports whether it was stored
+	Issue(ctx context.Context, certificate *Certificate) (bool, error)
+
+	// GetByID returns a certificate by its ID
+	GetByID(ctx context.Context, id string) (*Certificate, error)
+
+	// GetByCode returns a certificate by its verification code
+	GetByCode(ctx context.Context, code string) (*Certificate, error)
+
+	// Count returns the number of certificates matching filter
+	Count(ctx context.Context, filter Filter) (int64, error)
+
+	// List returns limit certificates matching filter, newest first,
+	// skipping offset
+	List(ctx context.Context, filter Filter, offset, limit int) ([]Certificate, error)
+
+	// Revoke stores the revocation of a certificate that is still valid. It
+	// fails with ErrAlreadyRevoked otherwise.
+	Revoke(ctx context.Context, certificate *Certificate) error
+}
+
+// CertificateRenderer renders certificates to a printable document
+type CertificateRenderer interface {
+	ContentType() string
+	FileExtension() string
+	Render(w io.Writer, certificate *Certificate) error
+}
@@ -0,0 +1,77 @@
+// Package printing renders certificates as PDF documents
+package printing
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/domain"
+	"github.com/cananga-odorata/golang-template/pkg/pdf"
+)
+
+// Page layout in points, on A4 landscape
+const (
+	pageWidth   = pdf.A4Height
+	pageHeight  = pdf.A4Width
+	margin      = 36.0
+	borderInset = 10.0
+)
+
+type pdfRenderer struct {
+	font       pdf.Font
+	verifyPath string
+}
+
+// NewPDFRenderer creates a CertificateRenderer producing A4 landscape PDF
+// documents that tell readers to check the code at verifyPath followed by
+// the code. A nil font falls back to Helvetica, which cannot show Thai text.
+func NewPDFRenderer(font pdf.Font, verifyPath string) domain.CertificateRenderer {
+	return &pdfRenderer{font: font, verifyPath: verifyPath}
+}
+
+func (r *pdfRenderer) ContentType() string   { return "application/pdf" }
+func (r *pdfRenderer) FileExtension() string { return ".pdf" }
+
+// Render writes a one-page certificate. Revoked certificates are marked as such.
+func (r *pdfRenderer) Render(w io.Writer, c *domain.Certificate) error {
+	doc := pdf.New(pageWidth, pageHeight, r.font)
+	doc.SetTitle("Certificate " + c.Code)
+	font := doc.Font()
+	page := doc.AddPage()
+
+	page.Rect(margin, margin, pageWidth-2*margin, pageHeight-2*margin, 2)
+	page.Rect(margin+borderInset, margin+borderInset, pageWidth-2*(margin+borderInset), pageHeight-2*(margin+borderInset), 0.5)
+
+	y := 140.0
+	centered := func(size, spacing float64, s string) {
+		page.Text((pageWidth-font.Width(s, size))/2, y, size, s)
+		y += spacing
+	}
+
+	centered(30, 56, "Certificate of Completion")
+	centered(13, 34, "This certifies that")
+	centered(22, 38, c.UserID)
+	centered(13, 34, "has passed")
+	for _, line := range pdf.WrapText(font, 20, pageWidth-2*(margin+4*borderInset), c.QuizSetTitle) {
+		centered(20, 28, line)
+	}
+	y += 6
+	centered(13, 22, fmt.Sprintf("with a score of %s%% (pass mark %s%%)", percent(c.Percent()), percent(c.PassMark)))
+	centered(13, 22, "Issued "+c.IssuedAt.Format("2 January 2006"))
+	if c.RevokedAt != nil {
+		centered(13, 22, "REVOKED "+c.RevokedAt.Format("2 January 2006"))
+	}
+
+	y = pageHeight - margin - 3*borderInset - 18
+	centered(10, 14, "Verification code "+c.Code)
+	centered(8, 0, "Check this certificate at "+r.verifyPath+c.Code)
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+// percent formats a percentage with at most one decimal
+func percent(p float64) string {
+	return strconv.FormatFloat(float64(int(p*10+0.5))/10, 'f', -1, 64)
+}
@@ -0,0 +1,117 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+const certificateColumns = `id, code, attempt_id, quiz_set_id, quiz_set_title, user_id, score, max_score, pass_mark, issued_at, revoked_at, revoked_by, revoke_reason`
+
+// filterCondition matches the certificates of domain.Filter given as $1 and $2
+const filterCondition = `($1::text IS NULL OR user_id = $1) AND ($2::text IS NULL OR quiz_set_id::text = $2)`
+
+type postgresCertificateRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresCertificateRepository creates a new PostgreSQL certificate repository
+func NewPostgresCertificateRepository(db *sqlx.DB) domain.CertificateRepository {
+	return &postgresCertificateRepository{db: db}
+}
+
+func (r *postgresCertificateRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// GetQuizSet returns the title and pass mark of a quiz set, or nil if it
+// does not exist
+func (r *postgresCertificateRepository) GetQuizSet(ctx context.Context, id string) (*domain.QuizSet, error) {
+	var set domain.QuizSet
+	query := `SELECT id, title, pass_mark FROM quiz_sets WHERE id::text = $1`
+	err := r.getQueryable(ctx).GetContext(ctx, &set, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// Issue stores a certificate unless its learner already holds a valid one
+// for the quiz set
+func (r *postgresCertificateRepository) Issue(ctx context.Context, c *domain.Certificate) (bool, error) {
+	query := `INSERT INTO certificates (id, code, attempt_id, quiz_set_id, quiz_set_title, user_id, score, max_score, pass_mark, issued_at)
+	           VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	           ON CONFLICT (quiz_set_id, user_id) WHERE revoked_at IS NULL DO NOTHING`
+	result, err := r.getQueryable(ctx).ExecContext(ctx, query, c.ID, c.Code, c.AttemptID, c.QuizSetID, c.QuizSetTitle,
+		c.UserID, c.Score, c.MaxScore, c.PassMark, c.IssuedAt)
+	if err != nil {
+		return false, err
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// GetByID returns a certificate by its ID
+func (r *postgresCertificateRepository) GetByID(ctx context.Context, id string) (*domain.Certificate, error) {
+	return r.get(ctx, `id::text = $1`, id)
+}
+
+// GetByCode returns a certificate by its verification code
+func (r *postgresCertificateRepository) GetByCode(ctx context.Context, code string) (*domain.Certificate, error) {
+	return r.get(ctx, `code = $1`, code)
+}
+
+func (r *postgresCertificateRepository) get(ctx context.Context, condition string, arg string) (*domain.Certificate, error) {
+	var c domain.Certificate
+	query := `SELECT ` + certificateColumns + ` FROM certificates WHERE ` + condition
+	err := r.getQueryable(ctx).GetContext(ctx, &c, query, arg)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrCertificateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Count returns the number of certificates matching filter
+func (r *postgresCertificateRepository) Count(ctx context.Context, filter domain.Filter) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM certificates WHERE ` + filterCondition
+	if err := r.getQueryable(ctx).GetContext(ctx, &count, query, filter.UserID, filter.QuizSetID); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// List returns limit certificates matching filter, newest first, skipping offset
+func (r *postgresCertificateRepository) List(ctx context.Context, filter domain.Filter, offset, limit int) ([]domain.Certificate, error) {
+	certificates := []domain.Certificate{}
+	query := `SELECT ` + certificateColumns + ` FROM certificates WHERE ` + filterCondition + `
+	           ORDER BY issued_at DESC, id ASC LIMIT $3 OFFSET $4`
+	if err := r.getQueryable(ctx).SelectContext(ctx, &certificates, query, filter.UserID, filter.QuizSetID, limit, offset); err != nil {
+		return nil, err
+	}
+	return certificates, nil
+}
+
+// Revoke stores the revocation of a certificate that is still valid
+func (r *postgresCertificateRepository) Revoke(ctx context.Context, c *domain.Certificate) error {
+	query := `UPDATE certificates SET revoked_at = $2, revoked_by = $3, revoke_reason = $4
+	           WHERE id = $1 AND revoked_at IS NULL`
+	result, err := r.getQueryable(ctx).ExecContext(ctx, query, c.ID, c.RevokedAt, c.RevokedBy, c.RevokeReason)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrAlreadyRevoked
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// CertificateHandler handles HTTP requests for certificates
+type CertificateHandler struct {
+	service application.CertificateService
+}
+
+// NewCertificateHandler creates a new CertificateHandler
+func NewCertificateHandler(service application.CertificateService) *CertificateHandler {
+	return &CertificateHandler{service: service}
+}
+
+// List handles GET /certificates?user_id=&quiz_set_id=&page=&page_size= for admins
+func (h *CertificateHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := application.ListCertificatesRequest{UserID: query.Get("user_id"), QuizSetID: query.Get("quiz_set_id")}
+	for param, target := range map[string]*int{"page": &req.Page, "page_size": &req.PageSize} {
+		if raw := query.Get(param); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 {
+				dto.Error(w, http.StatusBadRequest, "INVALID_PAGINATION", "Query parameter '"+param+"' must be a positive number")
+				return
+			}
+			*target = n
+		}
+	}
+
+	page, err := h.service.List(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	resp := dto.NewPaginatedResponse(page.Items, page.Pagination)
+	if page.Pagination.HasNext() {
+		resp.Links.Next = dto.PageURL(r, map[string]string{"page": strconv.Itoa(page.Pagination.Page + 1)})
+	}
+	if page.Pagination.HasPrev() {
+		resp.Links.Prev = dto.PageURL(r, map[string]string{"page": strconv.Itoa(page.Pagination.Page - 1)})
+	}
+
+	dto.SetLinkHeader(w, resp.Links)
+	dto.OK(w, resp)
+}
+
+// Mine handles GET /certificates/mine
+func (h *CertificateHandler) Mine(w http.ResponseWriter, r *http.Request) {
+	certificates, err := h.service.Mine(r.Context())
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, certificates)
+}
+
+// Get handles GET /certificates/{id}
+func (h *CertificateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	certificate, err := h.service.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, certificate)
+}
+
+// PDF handles GET /certificates/{id}/certificate.pdf
+func (h *CertificateHandler) PDF(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.Render(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", result.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": result.Filename}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(result.Data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(result.Data)
+}
+
+// Verify handles GET /certificates/verify/{code}?user_id=, which anyone may call
+func (h *CertificateHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	verification, err := h.service.Verify(r.Context(), chi.URLParam(r, "code"), r.URL.Query().Get("user_id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, verification)
+}
+
+// Revoke handles POST /certificates/{id}/revoke with an optional reason
+func (h *CertificateHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	var req application.RevokeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	certificate, err := h.service.Revoke(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, certificate)
+}
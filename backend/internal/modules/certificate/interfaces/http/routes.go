@@ -0,0 +1,23 @@
+package http
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/application"
+	"github.com/go-chi/chi/v5"
+)
+
+// VerifyPath is where certificates can be checked, followed by their code
+const VerifyPath = "/api/v1/certificates/verify/"
+
+// RegisterRoutes registers all certificate module routes
+func RegisterRoutes(r chi.Router, service application.CertificateService) {
+	handler := NewCertificateHandler(service)
+
+	r.Route("/certificates", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Get("/mine", handler.Mine)
+		r.Get("/verify/{code}", handler.Verify)
+		r.Get("/{id}", handler.Get)
+		r.Get("/{id}/certificate.pdf", handler.PDF)
+		r.Post("/{id}/revoke", handler.Revoke)
+	})
+}
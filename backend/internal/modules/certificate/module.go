@@ -0,0 +1,68 @@
+package certificate
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/infrastructure"
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate/infrastructure/printing"
+	httpinterface "github.com/cananga-odorata/golang-template/internal/modules/certificate/interfaces/http"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/pkg/pdf"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Module represents the certificate module with all its dependencies
+type Module struct {
+	Service application.CertificateService
+}
+
+// Options configures the certificate module
+type Options struct {
+	// FontPath optionally points to a TrueType font used for certificates
+	FontPath string
+	// Location is the time zone dates are shown in; nil means UTC
+	Location *time.Location
+	// Events delivers submitted attempts, which earn certificates when they
+	// pass; nil issues none
+	Events *events.EventBus
+}
+
+// NewModule initializes the certificate module with all dependencies
+func NewModule(db *sqlx.DB, opts Options) *Module {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	repo := infrastructure.NewPostgresCertificateRepository(db)
+	renderer := printing.NewPDFRenderer(pdf.LoadFont(opts.FontPath), httpinterface.VerifyPath)
+
+	m := &Module{
+		Service: application.NewCertificateService(repo, renderer, opts.Location),
+	}
+	if opts.Events != nil {
+		opts.Events.Subscribe(events.AttemptSubmittedEvent{}.Name(), m.issueCertificate)
+	}
+	return m
+}
+
+// issueCertificate awards a certificate for a passing attempt. Failures are
+// logged here and not returned, so that they do not keep the other handlers
+// from running.
+func (m *Module) issueCertificate(ctx context.Context, event events.Event) error {
+	submitted, ok := event.(events.AttemptSubmittedEvent)
+	if !ok {
+		return nil
+	}
+	if err := m.Service.Issue(ctx, submitted); err != nil {
+		slog.Error("Failed to issue certificate", "attempt_id", submitted.AttemptID, "error", err)
+	}
+	return nil
+}
+
+// RegisterRoutes registers the module's HTTP routes
+func (m *Module) RegisterRoutes(r chi.Router) {
+	httpinterface.RegisterRoutes(r, m.Service)
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/comment/domain"
+)
+
+// CreateCommentRequest DTO for commenting on a quiz. Without ParentID the
+// comment starts a thread, which Choice may anchor to one choice; with it the
+// comment replies to that thread. Users are mentioned with @user in Body.
+type CreateCommentRequest struct {
+	Body     string  `json:"body"`
+	Choice   *int    `json:"choice"`
+	ParentID *string `json:"parent_id"`
+}
+
+// ListThreadsRequest DTO for listing the threads of a quiz. A nil Resolved
+// lists every thread.
+type ListThreadsRequest struct {
+	Resolved *bool
+}
+
+// CommentResponse DTO for one comment
+type CommentResponse struct {
+	ID         string     `json:"id"`
+	QuizID     string     `json:"quiz_id"`
+	ParentID   *string    `json:"parent_id,omitempty"`
+	Choice     *int       `json:"choice,omitempty"`
+	Body       string     `json:"body"`
+	AuthorID   *string    `json:"author_id,omitempty"`
+	Mentions   []string   `json:"mentions"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy *string    `json:"resolved_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// ThreadResponse DTO for a thread: its first comment and the replies, oldest first
+type ThreadResponse struct {
+	CommentResponse
+	Replies []CommentResponse `json:"replies"`
+}
+
+// ThreadListResponse DTO for the threads of a quiz. Unresolved counts every
+// unresolved thread of the quiz, whatever the filter.
+type ThreadListResponse struct {
+	Threads    []ThreadResponse `json:"threads"`
+	Unresolved int              `json:"unresolved"`
+}
+
+func toCommentResponse(c domain.Comment) CommentResponse {
+	mentions := c.Mentions
+	if mentions == nil {
+		mentions = []string{}
+	}
+	return CommentResponse{
+		ID:         c.ID,
+		QuizID:     c.QuizID,
+		ParentID:   c.ParentID,
+		Choice:     c.Choice,
+		Body:       c.Body,
+		AuthorID:   c.AuthorID,
+		Mentions:   mentions,
+		ResolvedAt: c.ResolvedAt,
+		ResolvedBy: c.ResolvedBy,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
@@ -0,0 +1,219 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/comment/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// CommentService defines the reviewer comment business logic interface
+type CommentService interface {
+	ListThreads(ctx context.Context, quizID string, req ListThreadsRequest) (*ThreadListResponse, error)
+	Create(ctx context.Context, quizID string, req CreateCommentRequest) (*CommentResponse, error)
+	Resolve(ctx context.Context, id string) (*CommentResponse, error)
+	Reopen(ctx context.Context, id string) (*CommentResponse, error)
+	Mentions(ctx context.Context, unresolvedOnly bool) ([]CommentResponse, error)
+}
+
+type commentService struct {
+	repo   domain.CommentRepository
+	events *events.EventBus
+	now    func() time.Time
+}
+
+// NewCommentService creates a new CommentService. Mentions are published on
+// bus, which may be nil.
+func NewCommentService(repo domain.CommentRepository, bus *events.EventBus) CommentService {
+	return &commentService{repo: repo, events: bus, now: time.Now}
+}
+
+// ListThreads returns the threads of a quiz with their replies, oldest first
+func (s *commentService) ListThreads(ctx context.Context, quizID string, req ListThreadsRequest) (*ThreadListResponse, error) {
+	if err := s.checkQuiz(ctx, quizID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.ListByQuizID(ctx, quizID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch comments", err)
+	}
+
+	resp := &ThreadListResponse{Threads: []ThreadResponse{}}
+	index := map[string]int{}
+	for _, c := range comments {
+		if !c.IsThread() {
+			continue
+		}
+		if !c.IsResolved() {
+			resp.Unresolved++
+		}
+		if req.Resolved != nil && c.IsResolved() != *req.Resolved {
+			continue
+		}
+		index[c.ID] = len(resp.Threads)
+		resp.Threads = append(resp.Threads, ThreadResponse{CommentResponse: toCommentResponse(c), Replies: []CommentResponse{}})
+	}
+	for _, c := range comments {
+		if c.IsThread() {
+			continue
+		}
+		if i, ok := index[*c.ParentID]; ok {
+			resp.Threads[i].Replies = append(resp.Threads[i].Replies, toCommentResponse(c))
+		}
+	}
+	return resp, nil
+}
+
+// Create adds a comment by the current user to a quiz, either starting a
+// thread or replying to one, and publishes a CommentMentionedEvent for every
+// user it mentions
+func (s *commentService) Create(ctx context.Context, quizID string, req CreateCommentRequest) (*CommentResponse, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrSignInRequired
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, domain.ErrEmptyComment
+	}
+	if req.Choice != nil && (*req.Choice < 1 || *req.Choice > 4) {
+		return nil, domain.ErrInvalidChoice
+	}
+	if err := s.checkQuiz(ctx, quizID); err != nil {
+		return nil, err
+	}
+
+	comment := &domain.Comment{
+		ID:       sharedDomain.NewID(),
+		QuizID:   quizID,
+		Choice:   req.Choice,
+		Body:     body,
+		AuthorID: &userID,
+		Mentions: domain.ParseMentions(body),
+	}
+	if req.ParentID != nil {
+		if req.Choice != nil {
+			return nil, domain.ErrReplyAnchored
+		}
+		parent, err := s.repo.GetByID(ctx, *req.ParentID)
+		if err != nil || !parent.IsThread() || parent.QuizID != quizID {
+			return nil, domain.ErrInvalidParent
+		}
+		comment.ParentID = &parent.ID
+	}
+	if err := s.repo.Create(ctx, comment); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to create comment", err)
+	}
+
+	if s.events != nil {
+		for _, user := range comment.Mentions {
+			s.events.PublishAsync(context.WithoutCancel(ctx), events.CommentMentionedEvent{
+				CommentID: comment.ID,
+				QuizID:    quizID,
+				UserID:    user,
+				AuthorID:  comment.AuthorID,
+			})
+		}
+	}
+
+	resp := toCommentResponse(*comment)
+	return &resp, nil
+}
+
+// Resolve marks a thread resolved by the current user. Only the thread's
+// author, the quiz's author or an admin may resolve it.
+func (s *commentService) Resolve(ctx context.Context, id string) (*CommentResponse, error) {
+	now := s.now()
+	return s.setResolved(ctx, id, &now, true)
+}
+
+// Reopen marks a resolved thread unresolved again. The same users who may
+// resolve a thread may reopen it.
+func (s *commentService) Reopen(ctx context.Context, id string) (*CommentResponse, error) {
+	return s.setResolved(ctx, id, nil, false)
+}
+
+func (s *commentService) setResolved(ctx context.Context, id string, resolvedAt *time.Time, resolve bool) (*CommentResponse, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrSignInRequired
+	}
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrCommentNotFound
+	}
+	if !comment.IsThread() {
+		return nil, domain.ErrNotThread
+	}
+	if err := s.authorizeResolve(ctx, comment, userID); err != nil {
+		return nil, err
+	}
+
+	var resolvedBy *string
+	if resolve {
+		resolvedBy = &userID
+	}
+
+	if err := s.repo.SetResolved(ctx, id, resolvedAt, resolvedBy); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to update comment", err)
+	}
+
+	comment.ResolvedAt, comment.ResolvedBy = resolvedAt, resolvedBy
+	resp := toCommentResponse(*comment)
+	return &resp, nil
+}
+
+// Mentions returns the comments that mention the current user, newest first
+func (s *commentService) Mentions(ctx context.Context, unresolvedOnly bool) ([]CommentResponse, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrSignInRequired
+	}
+
+	comments, err := s.repo.ListMentioning(ctx, userID, unresolvedOnly)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch comments", err)
+	}
+
+	responses := make([]CommentResponse, len(comments))
+	for i, c := range comments {
+		responses[i] = toCommentResponse(c)
+	}
+	return responses, nil
+}
+
+// authorizeResolve returns nil if userID may resolve or reopen thread: its
+// author, the author of its quiz and admins may
+func (s *commentService) authorizeResolve(ctx context.Context, thread *domain.Comment, userID string) error {
+	if utils.IsAdmin(ctx) || (thread.AuthorID != nil && *thread.AuthorID == userID) {
+		return nil
+	}
+	quizAuthor, err := s.repo.QuizAuthor(ctx, thread.QuizID)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) {
+			return err
+		}
+		return sharedDomain.NewInternalError("Failed to check quiz", err)
+	}
+	if quizAuthor == nil || *quizAuthor != userID {
+		return domain.ErrCannotResolve
+	}
+	return nil
+}
+
+func (s *commentService) checkQuiz(ctx context.Context, quizID string) error {
+	exists, err := s.repo.QuizExists(ctx, quizID)
+	if err != nil {
+		return sharedDomain.NewInternalError("Failed to check quiz", err)
+	}
+	if !exists {
+		return domain.ErrQuizNotFound
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/comment/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// mockCommentRepository is an in-memory implementation of domain.CommentRepository
+type mockCommentRepository struct {
+	comments []domain.Comment
+	quizIDs  map[string]bool
+	// authors maps quiz IDs to the users who created them
+	authors map[string]string
+}
+
+func newMockRepo(quizIDs ...string) *mockCommentRepository {
+	m := &mockCommentRepository{quizIDs: map[string]bool{}}
+	for _, id := range quizIDs {
+		m.quizIDs[id] = true
+	}
+	return m
+}
+
+func (m *mockCommentRepository) ListByQuizID(_ context.Context, quizID string) ([]domain.Comment, error) {
+	out := []domain.Comment{}
+	for _, c := range m.comments {
+		if c.QuizID == quizID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockCommentRepository) ListMentioning(_ context.Context, userID string, unresolvedOnly bool) ([]domain.Comment, error) {
+	out := []domain.Comment{}
+	for i := len(m.comments) - 1; i >= 0; i-- {
+		c := m.comments[i]
+		thread := c
+		if c.ParentID != nil {
+			parent, _ := m.GetByID(context.Background(), *c.ParentID)
+			thread = *parent
+		}
+		if unresolvedOnly && thread.IsResolved() {
+			continue
+		}
+		for _, u := range c.Mentions {
+			if u == userID {
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *mockCommentRepository) GetByID(_ context.Context, id string) (*domain.Comment, error) {
+	for _, c := range m.comments {
+		if c.ID == id {
+			return &c, nil
+		}
+	}
+	return nil, domain.ErrCommentNotFound
+}
+
+func (m *mockCommentRepository) Create(_ context.Context, comment *domain.Comment) error {
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = comment.CreatedAt
+	m.comments = append(m.comments, *comment)
+	return nil
+}
+
+func (m *mockCommentRepository) SetResolved(_ context.Context, id string, resolvedAt *time.Time, resolvedBy *string) error {
+	for i := range m.comments {
+		if m.comments[i].ID == id {
+			m.comments[i].ResolvedAt, m.comments[i].ResolvedBy = resolvedAt, resolvedBy
+			return nil
+		}
+	}
+	return domain.ErrCommentNotFound
+}
+
+func (m *mockCommentRepository) QuizExists(_ context.Context, quizID string) (bool, error) {
+	return m.quizIDs[quizID], nil
+}
+
+func (m *mockCommentRepository) QuizAuthor(_ context.Context, quizID string) (*string, error) {
+	if !m.quizIDs[quizID] {
+		return nil, domain.ErrQuizNotFound
+	}
+	if author, ok := m.authors[quizID]; ok {
+		return &author, nil
+	}
+	return nil, nil
+}
+
+func intPtr(i int) *int { return &i }
+
+// ============ Test Cases ============
+
+func TestComments_Threads(t *testing.T) {
+	repo := newMockRepo("q1", "q2")
+	service := NewCommentService(repo, nil)
+	ctx := utils.SetUserID(context.Background(), "reviewer-1")
+
+	first, err := service.Create(ctx, "q1", CreateCommentRequest{Body: " Choice B is also correct ", Choice: intPtr(2)})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if first.Body != "Choice B is also correct" || *first.Choice != 2 || *first.AuthorID != "reviewer-1" {
+		t.Errorf("unexpected comment %+v", first)
+	}
+	reply, err := service.Create(utils.SetUserID(context.Background(), "author-1"), "q1", CreateCommentRequest{Body: "Agreed", ParentID: &first.ID})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if *reply.AuthorID != "author-1" {
+		t.Errorf("expected the reply by author-1, got %v", *reply.AuthorID)
+	}
+	second, _ := service.Create(ctx, "q1", CreateCommentRequest{Body: "Typo in the question"})
+	service.Create(ctx, "q2", CreateCommentRequest{Body: "Other quiz"})
+
+	list, err := service.ListThreads(context.Background(), "q1", ListThreadsRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if list.Unresolved != 2 || len(list.Threads) != 2 {
+		t.Fatalf("expected 2 unresolved threads, got %+v", list)
+	}
+	if len(list.Threads[0].Replies) != 1 || list.Threads[0].Replies[0].ID != reply.ID || len(list.Threads[1].Replies) != 0 {
+		t.Errorf("expected the reply under the first thread, got %+v", list.Threads)
+	}
+
+	// Resolving counts down, and the filter picks threads by state
+	resolved, err := service.Resolve(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resolved.ResolvedAt == nil || *resolved.ResolvedBy != "reviewer-1" {
+		t.Errorf("expected the thread to be resolved by the reviewer, got %+v", resolved)
+	}
+	open := false
+	list, _ = service.ListThreads(context.Background(), "q1", ListThreadsRequest{Resolved: &open})
+	if list.Unresolved != 1 || len(list.Threads) != 1 || list.Threads[0].ID != first.ID {
+		t.Errorf("expected only the open thread, got %+v", list)
+	}
+
+	reopened, err := service.Reopen(ctx, second.ID)
+	if err != nil || reopened.ResolvedAt != nil || reopened.ResolvedBy != nil {
+		t.Errorf("expected the thread to be reopened, got %+v %v", reopened, err)
+	}
+}
+
+func TestComments_ValidationErrors(t *testing.T) {
+	repo := newMockRepo("q1", "q2")
+	service := NewCommentService(repo, nil)
+	ctx := utils.SetUserID(context.Background(), "reviewer-1")
+	thread, _ := service.Create(ctx, "q1", CreateCommentRequest{Body: "Thread"})
+	reply, _ := service.Create(ctx, "q1", CreateCommentRequest{Body: "Reply", ParentID: &thread.ID})
+	missing := "missing"
+
+	cases := map[string]struct {
+		quizID string
+		req    CreateCommentRequest
+		want   error
+	}{
+		"empty body":      {"q1", CreateCommentRequest{Body: "  "}, domain.ErrEmptyComment},
+		"choice too high": {"q1", CreateCommentRequest{Body: "x", Choice: intPtr(5)}, domain.ErrInvalidChoice},
+		"unknown quiz":    {"q9", CreateCommentRequest{Body: "x"}, domain.ErrQuizNotFound},
+		"unknown parent":  {"q1", CreateCommentRequest{Body: "x", ParentID: &missing}, domain.ErrInvalidParent},
+		"reply to reply":  {"q1", CreateCommentRequest{Body: "x", ParentID: &reply.ID}, domain.ErrInvalidParent},
+		"other quiz":      {"q2", CreateCommentRequest{Body: "x", ParentID: &thread.ID}, domain.ErrInvalidParent},
+		"anchored reply":  {"q1", CreateCommentRequest{Body: "x", ParentID: &thread.ID, Choice: intPtr(1)}, domain.ErrReplyAnchored},
+	}
+	for name, tc := range cases {
+		if _, err := service.Create(ctx, tc.quizID, tc.req); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", name, tc.want, err)
+		}
+	}
+
+	if _, err := service.Resolve(ctx, reply.ID); !errors.Is(err, domain.ErrNotThread) {
+		t.Errorf("expected ErrNotThread, got %v", err)
+	}
+	if _, err := service.Resolve(ctx, "missing"); !errors.Is(err, domain.ErrCommentNotFound) {
+		t.Errorf("expected ErrCommentNotFound, got %v", err)
+	}
+	if _, err := service.ListThreads(context.Background(), "q9", ListThreadsRequest{}); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got %v", err)
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	cases := map[string][]string{
+		"@alice please check":                   {"alice"},
+		"cc @bob.smith, @alice and @bob.smith.": {"bob.smith", "alice"},
+		"(@carol) @d":                           {"carol", "d"},
+		"mail me at dave@example.com":           {},
+		"no mentions @":                         {},
+	}
+	for body, want := range cases {
+		if got := domain.ParseMentions(body); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: expected %v, got %v", body, want, got)
+		}
+	}
+}
+
+func TestComments_Mentions(t *testing.T) {
+	bus := events.NewEventBus()
+	received := make(chan events.CommentMentionedEvent, 4)
+	bus.Subscribe(events.CommentMentionedEvent{}.Name(), func(_ context.Context, e events.Event) error {
+		received <- e.(events.CommentMentionedEvent)
+		return nil
+	})
+
+	repo := newMockRepo("q1")
+	service := NewCommentService(repo, bus)
+	reviewer, alice := utils.SetUserID(context.Background(), "reviewer-1"), utils.SetUserID(context.Background(), "alice")
+	thread, err := service.Create(reviewer, "q1", CreateCommentRequest{Body: "@alice can you check choice C?"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(thread.Mentions, []string{"alice"}) {
+		t.Errorf("expected alice to be mentioned, got %v", thread.Mentions)
+	}
+
+	select {
+	case e := <-received:
+		if e.UserID != "alice" || e.CommentID != thread.ID || e.QuizID != "q1" {
+			t.Errorf("unexpected event %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a mention event")
+	}
+
+	if comments, _ := service.Mentions(alice, true); len(comments) != 1 {
+		t.Errorf("expected 1 open mention, got %+v", comments)
+	}
+	if comments, _ := service.Mentions(reviewer, true); len(comments) != 0 {
+		t.Errorf("expected only the signed-in user's mentions, got %+v", comments)
+	}
+	service.Resolve(reviewer, thread.ID)
+	if comments, _ := service.Mentions(alice, true); len(comments) != 0 {
+		t.Errorf("expected no open mentions once resolved, got %+v", comments)
+	}
+	if comments, _ := service.Mentions(alice, false); len(comments) != 1 {
+		t.Errorf("expected resolved mentions to be listed on request, got %+v", comments)
+	}
+}
+
+func TestComments_SignInRequired(t *testing.T) {
+	repo := newMockRepo("q1")
+	service := NewCommentService(repo, nil)
+	thread, _ := service.Create(utils.SetUserID(context.Background(), "reviewer-1"), "q1", CreateCommentRequest{Body: "Thread"})
+
+	if _, err := service.Create(context.Background(), "q1", CreateCommentRequest{Body: "Anonymous"}); !errors.Is(err, domain.ErrSignInRequired) {
+		t.Errorf("expected ErrSignInRequired to comment, got %v", err)
+	}
+	if _, err := service.Resolve(context.Background(), thread.ID); !errors.Is(err, domain.ErrSignInRequired) {
+		t.Errorf("expected ErrSignInRequired to resolve, got %v", err)
+	}
+	if _, err := service.Mentions(context.Background(), false); !errors.Is(err, domain.ErrSignInRequired) {
+		t.Errorf("expected ErrSignInRequired for mentions, got %v", err)
+	}
+	if len(repo.comments) != 1 || repo.comments[0].IsResolved() {
+		t.Error("expected nothing to change")
+	}
+}
+
+func TestComments_ResolvePermissions(t *testing.T) {
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"thread author", utils.SetUserID(context.Background(), "reviewer-1"), nil},
+		{"quiz author", utils.SetUserID(context.Background(), "owner"), nil},
+		{"admin", utils.SetUserRole(utils.SetUserID(context.Background(), "bob"), utils.RoleAdmin), nil},
+		{"other user", utils.SetUserID(context.Background(), "bob"), domain.ErrCannotResolve},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newMockRepo("q1")
+			repo.authors = map[string]string{"q1": "owner"}
+			service := NewCommentService(repo, nil)
+			thread, _ := service.Create(utils.SetUserID(context.Background(), "reviewer-1"), "q1", CreateCommentRequest{Body: "Thread"})
+
+			if _, err := service.Resolve(tc.ctx, thread.ID); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v to resolve, got: %v", tc.want, err)
+			}
+			repo.comments[0].ResolvedAt = &repo.comments[0].CreatedAt
+			if _, err := service.Reopen(tc.ctx, thread.ID); !errors.Is(err, tc.want) {
+				t.Errorf("expected %v to reopen, got: %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// Comment is a reviewer's remark on a quiz. A comment without a parent
+// starts a thread; replies belong to the thread's first comment.
+type Comment struct {
+	ID       string  `json:"id" db:"id"`
+	QuizID   string  `json:"quiz_id" db:"quiz_id"`
+	ParentID *string `json:"parent_id,omitempty" db:"parent_id"`
+	// Choice anchors a thread to one of the quiz choices (1-4)
+	Choice     *int       `json:"choice,omitempty" db:"choice"`
+	Body       string     `json:"body" db:"body"`
+	AuthorID   *string    `json:"author_id,omitempty" db:"author_id"`
+	Mentions   []string   `json:"mentions" db:"-"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+	ResolvedBy *string    `json:"resolved_by,omitempty" db:"resolved_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsThread returns true if the comment starts a thread
+func (c *Comment) IsThread() bool {
+	return c.ParentID == nil
+}
+
+// IsResolved returns true if the thread has been resolved
+func (c *Comment) IsResolved() bool {
+	return c.ResolvedAt != nil
+}
+
+// mentionPattern matches @user mentions. The mention must start the body or
+// follow a space or punctuation, so e-mail addresses are not mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([\w][\w.-]*[\w]|[\w])`)
+
+// ParseMentions returns the users mentioned in body with @user, in order of
+// first mention
+func ParseMentions(body string) []string {
+	mentions := []string{}
+	seen := map[string]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			mentions = append(mentions, m[1])
+		}
+	}
+	return mentions
+}
@@ -0,0 +1,15 @@
+package domain
+
+import sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+
+var (
+	ErrCommentNotFound = sharedDomain.NewNotFoundError("Comment not found")
+	ErrQuizNotFound    = sharedDomain.NewNotFoundError("Quiz not found")
+	ErrEmptyComment    = sharedDomain.NewValidationError("Comment body is required")
+	ErrInvalidChoice   = sharedDomain.NewValidationError("choice must be between 1 and 4")
+	ErrInvalidParent   = sharedDomain.NewValidationError("parent_id must be a thread on the same quiz")
+	ErrReplyAnchored   = sharedDomain.NewValidationError("Replies cannot be anchored to a choice; the thread's anchor applies")
+	ErrNotThread       = sharedDomain.NewValidationError("Only threads can be resolved or reopened, not replies")
+	ErrSignInRequired  = sharedDomain.NewUnauthorizedError("Sign in to comment on quizzes")
+	ErrCannotResolve   = sharedDomain.NewForbiddenError("Only the thread's author, the quiz's author or an admin can resolve or reopen it")
+)
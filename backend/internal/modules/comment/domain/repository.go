@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CommentRepository defines the interface for quiz comment data access
+type CommentRepository interface {
+	// ListByQuizID returns the comments of a quiz, oldest first
+	ListByQuizID(ctx context.Context, quizID string) ([]Comment, error)
+
+	// ListMentioning returns the comments that mention a user, newest first.
+	// With unresolvedOnly, comments in resolved threads are left out.
+	ListMentioning(ctx context.Context, userID string, unresolvedOnly bool) ([]Comment, error)
+
+	// GetByID returns a comment by its ID
+	GetByID(ctx context.Context, id string) (*Comment, error)
+
+	// Create inserts a new comment
+	Create(ctx context.Context, comment *Comment) error
+
+	// SetResolved marks a thread resolved at the given time by the given
+	// user, or reopens it when resolvedAt is nil
+	SetResolved(ctx context.Context, id string, resolvedAt *time.Time, resolvedBy *string) error
+
+	// QuizExists returns true if the quiz exists and is not in the trash
+	QuizExists(ctx context.Context, quizID string) (bool, error)
+
+	// QuizAuthor returns the user who created a quiz, nil if it has no
+	// author, or ErrQuizNotFound
+	QuizAuthor(ctx context.Context, quizID string) (*string, error)
+}
@@ -0,0 +1,131 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/comment/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const commentColumns = `c.id, c.quiz_id, c.parent_id, c.choice, c.body, c.author_id, c.mentions, c.resolved_at, c.resolved_by, c.created_at, c.updated_at`
+
+// commentRow is a comment as stored, with its mentions as a Postgres array
+type commentRow struct {
+	domain.Comment
+	Mentions pq.StringArray `db:"mentions"`
+}
+
+func toComments(rows []commentRow) []domain.Comment {
+	comments := make([]domain.Comment, len(rows))
+	for i, row := range rows {
+		comments[i] = row.Comment
+		comments[i].Mentions = []string(row.Mentions)
+	}
+	return comments
+}
+
+type postgresCommentRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresCommentRepository creates a new PostgreSQL quiz comment repository
+func NewPostgresCommentRepository(db *sqlx.DB) domain.CommentRepository {
+	return &postgresCommentRepository{db: db}
+}
+
+func (r *postgresCommentRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// ListByQuizID returns the comments of a quiz, oldest first
+func (r *postgresCommentRepository) ListByQuizID(ctx context.Context, quizID string) ([]domain.Comment, error) {
+	var rows []commentRow
+	query := `SELECT ` + commentColumns + ` FROM quiz_comments c WHERE c.quiz_id = $1 ORDER BY c.created_at ASC, c.id ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &rows, query, quizID); err != nil {
+		return nil, err
+	}
+	return toComments(rows), nil
+}
+
+// ListMentioning returns the comments that mention a user, newest first.
+// With unresolvedOnly, comments in resolved threads are left out.
+func (r *postgresCommentRepository) ListMentioning(ctx context.Context, userID string, unresolvedOnly bool) ([]domain.Comment, error) {
+	var rows []commentRow
+	query := `SELECT ` + commentColumns + ` FROM quiz_comments c
+	           LEFT JOIN quiz_comments t ON t.id = c.parent_id
+	           WHERE c.mentions @> ARRAY[$1]::text[]
+	             AND (NOT $2 OR COALESCE(t.resolved_at, c.resolved_at) IS NULL)
+	           ORDER BY c.created_at DESC, c.id DESC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &rows, query, userID, unresolvedOnly); err != nil {
+		return nil, err
+	}
+	return toComments(rows), nil
+}
+
+// GetByID returns a comment by its ID
+func (r *postgresCommentRepository) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	var row commentRow
+	query := `SELECT ` + commentColumns + ` FROM quiz_comments c WHERE c.id = $1`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &row, query, id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	comment := toComments([]commentRow{row})[0]
+	return &comment, nil
+}
+
+// Create inserts a new comment
+func (r *postgresCommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	query := `INSERT INTO quiz_comments (id, quiz_id, parent_id, choice, body, author_id, mentions, created_at, updated_at)
+	           VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+	           RETURNING created_at, updated_at`
+	q := r.getQueryable(ctx)
+	return q.QueryRowxContext(ctx, query, comment.ID, comment.QuizID, comment.ParentID, comment.Choice, comment.Body,
+		comment.AuthorID, pq.Array(comment.Mentions)).Scan(&comment.CreatedAt, &comment.UpdatedAt)
+}
+
+// SetResolved marks a thread resolved, or reopens it when resolvedAt is nil
+func (r *postgresCommentRepository) SetResolved(ctx context.Context, id string, resolvedAt *time.Time, resolvedBy *string) error {
+	query := `UPDATE quiz_comments SET resolved_at = $2, resolved_by = $3, updated_at = NOW() WHERE id = $1`
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, id, resolvedAt, resolvedBy)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+// QuizExists returns true if the quiz exists and is not in the trash
+func (r *postgresCommentRepository) QuizExists(ctx context.Context, quizID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM quizzes WHERE id::text = $1 AND deleted_at IS NULL)`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &exists, query, quizID)
+	return exists, err
+}
+
+// QuizAuthor returns the user who created a quiz, nil if it has no author
+func (r *postgresCommentRepository) QuizAuthor(ctx context.Context, quizID string) (*string, error) {
+	var author *string
+	query := `SELECT created_by FROM quizzes WHERE id::text = $1 AND deleted_at IS NULL`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &author, query, quizID)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrQuizNotFound
+	}
+	return author, err
+}
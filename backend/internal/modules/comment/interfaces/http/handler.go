@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/comment/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// CommentHandler handles HTTP requests for reviewer comments
+type CommentHandler struct {
+	service application.CommentService
+}
+
+// NewCommentHandler creates a new CommentHandler
+func NewCommentHandler(service application.CommentService) *CommentHandler {
+	return &CommentHandler{service: service}
+}
+
+// List handles GET /quizzes/{id}/comments?resolved=true|false
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	var req application.ListThreadsRequest
+	if raw := r.URL.Query().Get("resolved"); raw != "" {
+		resolved, err := strconv.ParseBool(raw)
+		if err != nil {
+			dto.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'resolved' must be true or false")
+			return
+		}
+		req.Resolved = &resolved
+	}
+
+	threads, err := h.service.ListThreads(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, threads)
+}
+
+// Create handles POST /quizzes/{id}/comments
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req application.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	comment, err := h.service.Create(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, comment)
+}
+
+// Resolve handles POST /comments/{id}/resolve
+func (h *CommentHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	comment, err := h.service.Resolve(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, comment)
+}
+
+// Reopen handles POST /comments/{id}/reopen
+func (h *CommentHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	comment, err := h.service.Reopen(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, comment)
+}
+
+// Mentions handles GET /comments/mentions?unresolved=true for the signed-in user
+func (h *CommentHandler) Mentions(w http.ResponseWriter, r *http.Request) {
+	unresolvedOnly, _ := strconv.ParseBool(r.URL.Query().Get("unresolved"))
+
+	comments, err := h.service.Mentions(r.Context(), unresolvedOnly)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, comments)
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/modules/comment/application"
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterRoutes registers all comment module routes. Threads are listed and
+// created under their quiz; resolving works on the comment itself.
+func RegisterRoutes(r chi.Router, service application.CommentService) {
+	handler := NewCommentHandler(service)
+
+	r.Get("/quizzes/{id}/comments", handler.List)
+	r.Post("/quizzes/{id}/comments", handler.Create)
+
+	r.Route("/comments", func(r chi.Router) {
+		r.Get("/mentions", handler.Mentions)
+		r.Post("/{id}/resolve", handler.Resolve)
+		r.Post("/{id}/reopen", handler.Reopen)
+	})
+}
@@ -0,0 +1,30 @@
+package comment
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/modules/comment/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/comment/infrastructure"
+	httpinterface "github.com/cananga-odorata/golang-template/internal/modules/comment/interfaces/http"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Module represents the reviewer comment module with all its dependencies
+type Module struct {
+	Service application.CommentService
+}
+
+// NewModule initializes the comment module with all dependencies. Mentions
+// are published on bus, which may be nil.
+func NewModule(db *sqlx.DB, bus *events.EventBus) *Module {
+	repo := infrastructure.NewPostgresCommentRepository(db)
+
+	return &Module{
+		Service: application.NewCommentService(repo, bus),
+	}
+}
+
+// RegisterRoutes registers the module's HTTP routes
+func (m *Module) RegisterRoutes(r chi.Router) {
+	httpinterface.RegisterRoutes(r, m.Service)
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// GetLeaderboardRequest DTO for reading one page of a leaderboard. Window
+// defaults to all_time; Week picks a past weekly board and defaults to the
+// current week.
+type GetLeaderboardRequest struct {
+	Window   domain.Window
+	Week     string
+	Page     int
+	PageSize int
+}
+
+// LeaderboardPage is one page of a leaderboard. Me is the caller's own
+// entry, wherever it ranks, when the caller is signed in and has one.
+type LeaderboardPage struct {
+	Window     domain.Window
+	Period     string
+	Items      []EntryResponse
+	Pagination sharedDomain.Pagination
+	Me         *EntryResponse
+}
+
+// EntryResponse DTO for a ranked leaderboard entry. UserID is left out for
+// users who opted out of public display, except for themselves and admins.
+type EntryResponse struct {
+	Rank        int       `json:"rank"`
+	UserID      *string   `json:"user_id,omitempty"`
+	Hidden      bool      `json:"hidden,omitempty"`
+	Score       float64   `json:"score"`
+	MaxScore    float64   `json:"max_score"`
+	DurationMS  int64     `json:"duration_ms"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// PreferencesRequest DTO for changing the caller's leaderboard preferences
+type PreferencesRequest struct {
+	Hidden bool `json:"hidden"`
+}
+
+// PreferencesResponse DTO for the caller's leaderboard preferences
+type PreferencesResponse struct {
+	Hidden bool `json:"hidden"`
+}
+
+func toEntryResponse(e domain.Entry, revealed bool) EntryResponse {
+	resp := EntryResponse{
+		Rank:        e.Rank,
+		Hidden:      e.Hidden,
+		Score:       e.Score,
+		MaxScore:    e.MaxScore,
+		DurationMS:  e.DurationMS,
+		SubmittedAt: e.SubmittedAt,
+	}
+	if !e.Hidden || revealed {
+		userID := e.UserID
+		resp.UserID = &userID
+	}
+	return resp
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	attemptDomain "github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// rankedMode is the attempt mode leaderboards rank; adaptive attempts ask
+// each learner different questions, so their scores are not comparable
+const rankedMode = string(attemptDomain.ModeFixed)
+
+// LeaderboardService defines the leaderboard business logic interface
+type LeaderboardService interface {
+	Get(ctx context.Context, quizSetID string, req GetLeaderboardRequest) (*LeaderboardPage, error)
+	Record(ctx context.Context, event events.AttemptSubmittedEvent) error
+	GetPreferences(ctx context.Context) (*PreferencesResponse, error)
+	SetPreferences(ctx context.Context, req PreferencesRequest) (*PreferencesResponse, error)
+}
+
+type leaderboardService struct {
+	repo     domain.LeaderboardRepository
+	location *time.Location
+	now      func() time.Time
+}
+
+// NewLeaderboardService creates a new LeaderboardService. Weeks start on
+// Monday in location.
+func NewLeaderboardService(repo domain.LeaderboardRepository, location *time.Location) LeaderboardService {
+	return &leaderboardService{repo: repo, location: location, now: time.Now}
+}
+
+// Get returns one page of a quiz set's leaderboard, best first, with the
+// caller's own entry
+func (s *leaderboardService) Get(ctx context.Context, quizSetID string, req GetLeaderboardRequest) (*LeaderboardPage, error) {
+	window := req.Window
+	if window == "" {
+		window = domain.WindowAllTime
+	}
+	if !window.Valid() {
+		return nil, domain.ErrInvalidWindow
+	}
+	period := window.Period(s.now(), s.location)
+	if window == domain.WindowWeekly && req.Week != "" {
+		if !domain.ValidWeek(req.Week) {
+			return nil, domain.ErrInvalidWeek
+		}
+		period = req.Week
+	}
+
+	exists, err := s.repo.QuizSetExists(ctx, quizSetID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quiz set", err)
+	}
+	if !exists {
+		return nil, domain.ErrQuizSetNotFound
+	}
+
+	pagination := sharedDomain.NewPagination(req.Page, req.PageSize)
+	pagination.Total, err = s.repo.Count(ctx, quizSetID, period)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to count leaderboard entries", err)
+	}
+	entries, err := s.repo.List(ctx, quizSetID, period, pagination.Offset(), pagination.Limit())
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch leaderboard", err)
+	}
+
+	userID, signedIn := utils.GetUserID(ctx)
+	admin := utils.IsAdmin(ctx)
+	page := &LeaderboardPage{Window: window, Period: period, Items: make([]EntryResponse, len(entries)), Pagination: pagination}
+	for i, e := range entries {
+		page.Items[i] = toEntryResponse(e, admin || (signedIn && e.UserID == userID))
+	}
+
+	if signedIn {
+		me, err := s.repo.GetEntry(ctx, quizSetID, period, userID)
+		if err != nil {
+			return nil, sharedDomain.NewInternalError("Failed to fetch leaderboard entry", err)
+		}
+		if me != nil {
+			resp := toEntryResponse(*me, true)
+			page.Me = &resp
+		}
+	}
+	return page, nil
+}
+
+// Record ranks a submitted fixed attempt by a signed-in learner on the
+// all-time and weekly leaderboards of its quiz set, keeping each learner's
+// best attempt per board
+func (s *leaderboardService) Record(ctx context.Context, event events.AttemptSubmittedEvent) error {
+	if event.QuizSetID == nil || event.UserID == nil || event.Mode != rankedMode {
+		return nil
+	}
+
+	for _, window := range domain.Windows {
+		entry := &domain.Entry{
+			QuizSetID:   *event.QuizSetID,
+			Period:      window.Period(event.SubmittedAt, s.location),
+			UserID:      *event.UserID,
+			AttemptID:   event.AttemptID,
+			Score:       event.Score,
+			MaxScore:    event.MaxScore,
+			DurationMS:  event.SubmittedAt.Sub(event.StartedAt).Milliseconds(),
+			SubmittedAt: event.SubmittedAt,
+		}
+		if err := s.repo.Record(ctx, entry); err != nil {
+			return sharedDomain.NewInternalError("Failed to record leaderboard entry", err)
+		}
+	}
+	return nil
+}
+
+// GetPreferences returns the caller's leaderboard preferences
+func (s *leaderboardService) GetPreferences(ctx context.Context) (*PreferencesResponse, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrSignInRequired
+	}
+	hidden, err := s.repo.IsHidden(ctx, userID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch leaderboard preferences", err)
+	}
+	return &PreferencesResponse{Hidden: hidden}, nil
+}
+
+// SetPreferences changes whether the caller is named on leaderboards; hidden
+// users keep their rank but are listed without their user ID
+func (s *leaderboardService) SetPreferences(ctx context.Context, req PreferencesRequest) (*PreferencesResponse, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrSignInRequired
+	}
+	if err := s.repo.SetHidden(ctx, userID, req.Hidden); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to save leaderboard preferences", err)
+	}
+	return &PreferencesResponse{Hidden: req.Hidden}, nil
+}
@@ -0,0 +1,280 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// mockLeaderboardRepository keeps entries in memory and ranks them like the
+// PostgreSQL repository
+type mockLeaderboardRepository struct {
+	entries  map[string]domain.Entry // by quiz set, period and user
+	hidden   map[string]bool
+	quizSets map[string]bool
+}
+
+func newMockRepo(quizSetIDs ...string) *mockLeaderboardRepository {
+	m := &mockLeaderboardRepository{entries: map[string]domain.Entry{}, hidden: map[string]bool{}, quizSets: map[string]bool{}}
+	for _, id := range quizSetIDs {
+		m.quizSets[id] = true
+	}
+	return m
+}
+
+func (m *mockLeaderboardRepository) Record(_ context.Context, entry *domain.Entry) error {
+	key := entry.QuizSetID + "/" + entry.Period + "/" + entry.UserID
+	if current, ok := m.entries[key]; ok && !entry.Beats(&current) {
+		return nil
+	}
+	m.entries[key] = *entry
+	return nil
+}
+
+func (m *mockLeaderboardRepository) ranked(quizSetID, period string) []domain.Entry {
+	var entries []domain.Entry
+	for _, e := range m.entries {
+		if e.QuizSetID == quizSetID && e.Period == period {
+			e.Hidden = m.hidden[e.UserID]
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Beats(&entries[j]) || entries[j].Beats(&entries[i]) {
+			return entries[i].Beats(&entries[j])
+		}
+		if !entries[i].SubmittedAt.Equal(entries[j].SubmittedAt) {
+			return entries[i].SubmittedAt.Before(entries[j].SubmittedAt)
+		}
+		return entries[i].UserID < entries[j].UserID
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if i > 0 && !entries[i-1].Beats(&entries[i]) {
+			entries[i].Rank = entries[i-1].Rank
+		}
+	}
+	return entries
+}
+
+func (m *mockLeaderboardRepository) Count(_ context.Context, quizSetID, period string) (int64, error) {
+	return int64(len(m.ranked(quizSetID, period))), nil
+}
+
+func (m *mockLeaderboardRepository) List(_ context.Context, quizSetID, period string, offset, limit int) ([]domain.Entry, error) {
+	entries := m.ranked(quizSetID, period)
+	if offset > len(entries) {
+		offset = len(entries)
+	}
+	return entries[offset:min(offset+limit, len(entries))], nil
+}
+
+func (m *mockLeaderboardRepository) GetEntry(_ context.Context, quizSetID, period, userID string) (*domain.Entry, error) {
+	for _, e := range m.ranked(quizSetID, period) {
+		if e.UserID == userID {
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockLeaderboardRepository) QuizSetExists(_ context.Context, quizSetID string) (bool, error) {
+	return m.quizSets[quizSetID], nil
+}
+
+func (m *mockLeaderboardRepository) IsHidden(_ context.Context, userID string) (bool, error) {
+	return m.hidden[userID], nil
+}
+
+func (m *mockLeaderboardRepository) SetHidden(_ context.Context, userID string, hidden bool) error {
+	m.hidden[userID] = hidden
+	return nil
+}
+
+// monday is the start of ISO week 2026-W42 in UTC
+var monday = time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+func newTestService(repo *mockLeaderboardRepository) *leaderboardService {
+	service := NewLeaderboardService(repo, time.UTC).(*leaderboardService)
+	service.now = func() time.Time { return monday.Add(48 * time.Hour) }
+	return service
+}
+
+// submitted returns a fixed attempt on s1 by user that took minutes and
+// ended at submittedAt
+func submitted(user string, score float64, minutes int, submittedAt time.Time) events.AttemptSubmittedEvent {
+	setID := "s1"
+	return events.AttemptSubmittedEvent{
+		AttemptID:   user + submittedAt.Format(time.RFC3339),
+		QuizSetID:   &setID,
+		UserID:      &user,
+		Mode:        "fixed",
+		Score:       score,
+		MaxScore:    10,
+		StartedAt:   submittedAt.Add(-time.Duration(minutes) * time.Minute),
+		SubmittedAt: submittedAt,
+	}
+}
+
+func userIDs(items []EntryResponse) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		if item.UserID != nil {
+			ids[i] = *item.UserID
+		}
+	}
+	return ids
+}
+
+func TestRecord_KeepsBestAttemptPerWindow(t *testing.T) {
+	repo := newMockRepo("s1")
+	service := newTestService(repo)
+	ctx := context.Background()
+	lastWeek := monday.Add(-24 * time.Hour)
+
+	attempts := []events.AttemptSubmittedEvent{
+		submitted("alice", 9, 10, lastWeek),
+		submitted("alice", 7, 5, monday.Add(time.Hour)),
+		submitted("bob", 8, 12, monday.Add(2*time.Hour)),
+		submitted("carol", 8, 9, monday.Add(3*time.Hour)),
+		submitted("bob", 8, 9, monday.Add(4*time.Hour)),
+	}
+	for _, event := range attempts {
+		if err := service.Record(ctx, event); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	// Adaptive and anonymous attempts are not ranked
+	adaptive := submitted("dave", 10, 1, monday)
+	adaptive.Mode = "adaptive"
+	anonymous := submitted("erin", 10, 1, monday)
+	anonymous.UserID = nil
+	for _, event := range []events.AttemptSubmittedEvent{adaptive, anonymous} {
+		if err := service.Record(ctx, event); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	allTime, err := service.Get(ctx, "s1", GetLeaderboardRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := userIDs(allTime.Items); len(got) != 3 || got[0] != "alice" || got[1] != "carol" || got[2] != "bob" {
+		t.Fatalf("expected alice, carol, bob all-time, got %v", got)
+	}
+	// bob's faster retry ties carol, who got there first
+	if allTime.Items[0].Score != 9 || allTime.Items[1].Rank != 2 || allTime.Items[2].Rank != 2 || allTime.Items[2].DurationMS != 9*60000 {
+		t.Errorf("unexpected all-time entries %+v", allTime.Items)
+	}
+
+	weekly, err := service.Get(ctx, "s1", GetLeaderboardRequest{Window: domain.WindowWeekly})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if weekly.Period != "2026-W42" {
+		t.Errorf("expected the current week, got %q", weekly.Period)
+	}
+	if got := userIDs(weekly.Items); len(got) != 3 || got[0] != "carol" || got[1] != "bob" || got[2] != "alice" {
+		t.Errorf("expected carol, bob, alice this week, got %v", got)
+	}
+
+	previous, err := service.Get(ctx, "s1", GetLeaderboardRequest{Window: domain.WindowWeekly, Week: "2026-W41"})
+	if err != nil || len(previous.Items) != 1 || previous.Items[0].Score != 9 {
+		t.Errorf("expected only alice's 9 last week, got %+v, %v", previous, err)
+	}
+}
+
+func TestGet_PaginationAndOwnRank(t *testing.T) {
+	repo := newMockRepo("s1")
+	service := newTestService(repo)
+	for i, user := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		if err := service.Record(context.Background(), submitted(user, float64(10-i), 5, monday)); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	ctx := utils.SetUserID(context.Background(), "u5")
+	page, err := service.Get(ctx, "s1", GetLeaderboardRequest{Page: 2, PageSize: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := userIDs(page.Items); len(got) != 2 || got[0] != "u3" || got[1] != "u4" || page.Items[0].Rank != 3 {
+		t.Errorf("expected u3 and u4 ranked 3 and 4 on page 2, got %+v", page.Items)
+	}
+	if page.Pagination.Total != 5 || !page.Pagination.HasNext() {
+		t.Errorf("expected 5 entries over 3 pages, got %+v", page.Pagination)
+	}
+	if page.Me == nil || page.Me.Rank != 5 || *page.Me.UserID != "u5" {
+		t.Errorf("expected the caller ranked 5th, got %+v", page.Me)
+	}
+
+	anonymous, err := service.Get(context.Background(), "s1", GetLeaderboardRequest{})
+	if err != nil || anonymous.Me != nil {
+		t.Errorf("expected no own entry for anonymous callers, got %+v, %v", anonymous, err)
+	}
+}
+
+func TestPreferences_HideUserFromOthers(t *testing.T) {
+	repo := newMockRepo("s1")
+	service := newTestService(repo)
+	for i, user := range []string{"alice", "bob"} {
+		if err := service.Record(context.Background(), submitted(user, float64(6-i), 5, monday)); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+
+	if _, err := service.SetPreferences(context.Background(), PreferencesRequest{Hidden: true}); !errors.Is(err, domain.ErrSignInRequired) {
+		t.Errorf("expected ErrSignInRequired, got: %v", err)
+	}
+	alice := utils.SetUserID(context.Background(), "alice")
+	if prefs, err := service.SetPreferences(alice, PreferencesRequest{Hidden: true}); err != nil || !prefs.Hidden {
+		t.Fatalf("expected alice to be hidden, got %+v, %v", prefs, err)
+	}
+	if prefs, err := service.GetPreferences(alice); err != nil || !prefs.Hidden {
+		t.Errorf("expected the preference to be stored, got %+v, %v", prefs, err)
+	}
+
+	page, _ := service.Get(utils.SetUserID(context.Background(), "bob"), "s1", GetLeaderboardRequest{})
+	if len(page.Items) != 2 || page.Items[0].UserID != nil || !page.Items[0].Hidden || page.Items[0].Rank != 1 {
+		t.Errorf("expected alice to keep her rank without her user ID, got %+v", page.Items)
+	}
+
+	page, _ = service.Get(alice, "s1", GetLeaderboardRequest{})
+	if page.Items[0].UserID == nil || page.Me == nil || page.Me.UserID == nil {
+		t.Errorf("expected alice to see herself, got %+v", page)
+	}
+
+	admin := utils.SetUserRole(context.Background(), utils.RoleAdmin)
+	page, _ = service.Get(admin, "s1", GetLeaderboardRequest{})
+	if page.Items[0].UserID == nil {
+		t.Errorf("expected admins to see hidden users, got %+v", page.Items[0])
+	}
+}
+
+func TestGet_Validation(t *testing.T) {
+	service := newTestService(newMockRepo("s1"))
+	ctx := context.Background()
+	cases := []struct {
+		name    string
+		setID   string
+		req     GetLeaderboardRequest
+		wantErr error
+	}{
+		{"unknown window", "s1", GetLeaderboardRequest{Window: "monthly"}, domain.ErrInvalidWindow},
+		{"invalid week", "s1", GetLeaderboardRequest{Window: domain.WindowWeekly, Week: "last"}, domain.ErrInvalidWeek},
+		{"unknown quiz set", "missing", GetLeaderboardRequest{}, domain.ErrQuizSetNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := service.Get(ctx, tc.setID, tc.req); !errors.Is(err, tc.wantErr) {
+				t.Errorf("expected %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Window is the span of time a leaderboard ranks attempts over
+type Window string
+
+const (
+	WindowAllTime Window = "all_time"
+	WindowWeekly  Window = "weekly"
+)
+
+// Windows lists every window; each submitted attempt is ranked in all of them
+var Windows = []Window{WindowAllTime, WindowWeekly}
+
+// Valid returns true if w is a known window
+func (w Window) Valid() bool {
+	return w == WindowAllTime || w == WindowWeekly
+}
+
+// allTimePeriod is the period of the all-time leaderboard
+const allTimePeriod = "all"
+
+var weekPattern = regexp.MustCompile(`^\d{4}-W(0[1-9]|[1-4]\d|5[0-3])$`)
+
+// Period returns the period of window that t falls in: "all" for the
+// all-time board and the ISO week in loc, e.g. "2026-W42", for weekly boards
+func (w Window) Period(t time.Time, loc *time.Location) string {
+	if w == WindowAllTime {
+		return allTimePeriod
+	}
+	year, week := t.In(loc).ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// ValidWeek returns true if week is an ISO week such as "2026-W42"
+func ValidWeek(week string) bool {
+	return weekPattern.MatchString(week)
+}
+
+// Entry is the best attempt of a user on one leaderboard. Rank is only set
+// when entries are read in ranking order; entries with the same score and
+// duration share a rank.
+type Entry struct {
+	QuizSetID   string    `db:"quiz_set_id"`
+	Period      string    `db:"period"`
+	UserID      string    `db:"user_id"`
+	AttemptID   string    `db:"attempt_id"`
+	Score       float64   `db:"score"`
+	MaxScore    float64   `db:"max_score"`
+	DurationMS  int64     `db:"duration_ms"`
+	SubmittedAt time.Time `db:"submitted_at"`
+	Rank        int       `db:"rank"`
+	// Hidden is true when the user opted out of public display
+	Hidden bool `db:"hidden"`
+}
+
+// Beats returns true if e ranks above other: a higher score, or the same
+// score completed in less time
+func (e *Entry) Beats(other *Entry) bool {
+	if e.Score != other.Score {
+		return e.Score > other.Score
+	}
+	return e.DurationMS < other.DurationMS
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindow_Period(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	// Sunday evening in UTC is already Monday of the next week in Bangkok
+	at := time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC)
+
+	if got := WindowAllTime.Period(at, bangkok); got != "all" {
+		t.Errorf("expected the all-time period, got %q", got)
+	}
+	if got := WindowWeekly.Period(at, time.UTC); got != "2026-W42" {
+		t.Errorf("expected 2026-W42 in UTC, got %q", got)
+	}
+	if got := WindowWeekly.Period(at, bangkok); got != "2026-W43" {
+		t.Errorf("expected 2026-W43 in Bangkok, got %q", got)
+	}
+	// ISO weeks belong to the year that holds their Thursday
+	if got := WindowWeekly.Period(time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC), time.UTC); got != "2026-W53" {
+		t.Errorf("expected 2026-W53, got %q", got)
+	}
+}
+
+func TestValidWeek(t *testing.T) {
+	for _, week := range []string{"2026-W01", "2026-W42", "2026-W53"} {
+		if !ValidWeek(week) {
+			t.Errorf("expected %q to be valid", week)
+		}
+	}
+	for _, week := range []string{"", "all", "2026-W00", "2026-W54", "2026-42", "2026-W4"} {
+		if ValidWeek(week) {
+			t.Errorf("expected %q to be invalid", week)
+		}
+	}
+}
+
+func TestEntry_Beats(t *testing.T) {
+	best := &Entry{Score: 8, DurationMS: 60000}
+	if !best.Beats(&Entry{Score: 7, DurationMS: 1000}) {
+		t.Error("expected a higher score to win")
+	}
+	if !best.Beats(&Entry{Score: 8, DurationMS: 90000}) {
+		t.Error("expected the faster of equal scores to win")
+	}
+	if best.Beats(&Entry{Score: 8, DurationMS: 60000}) {
+		t.Error("expected an equal entry not to win")
+	}
+}
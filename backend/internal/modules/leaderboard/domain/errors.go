@@ -0,0 +1,10 @@
+package domain
+
+import sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+
+var (
+	ErrQuizSetNotFound = sharedDomain.NewNotFoundError("Quiz set not found")
+	ErrInvalidWindow   = sharedDomain.NewValidationError("window must be all_time or weekly")
+	ErrInvalidWeek     = sharedDomain.NewValidationError("week must be an ISO week such as 2026-W42")
+	ErrSignInRequired  = sharedDomain.NewUnauthorizedError("Sign in to change your leaderboard preferences")
+)
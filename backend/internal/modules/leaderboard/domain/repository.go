@@ -0,0 +1,30 @@
+package domain
+
+import "context"
+
+// LeaderboardRepository defines the interface for leaderboard data access
+type LeaderboardRepository interface {
+	// Record stores entry unless the user already has an entry on the same
+	// leaderboard that it does not beat
+	Record(ctx context.Context, entry *Entry) error
+
+	// Count returns the number of entries on a leaderboard
+	Count(ctx context.Context, quizSetID, period string) (int64, error)
+
+	// List returns limit entries of a leaderboard in ranking order, skipping
+	// offset, with their rank and whether their user is hidden
+	List(ctx context.Context, quizSetID, period string, offset, limit int) ([]Entry, error)
+
+	// GetEntry returns the ranked entry of a user on a leaderboard, or nil
+	// if the user has none
+	GetEntry(ctx context.Context, quizSetID, period, userID string) (*Entry, error)
+
+	// QuizSetExists returns true if the quiz set exists
+	QuizSetExists(ctx context.Context, quizSetID string) (bool, error)
+
+	// IsHidden returns true if the user opted out of public display
+	IsHidden(ctx context.Context, userID string) (bool, error)
+
+	// SetHidden stores whether the user opts out of public display
+	SetHidden(ctx context.Context, userID string, hidden bool) error
+}
@@ -0,0 +1,113 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// rankedEntries ranks the entries of one leaderboard ($1 quiz set, $2
+// period); entries with the same score and duration share a rank
+const rankedEntries = `SELECT e.quiz_set_id, e.period, e.user_id, e.attempt_id, e.score, e.max_score, e.duration_ms, e.submitted_at,
+	       RANK() OVER (ORDER BY e.score DESC, e.duration_ms ASC) AS rank, COALESCE(p.hidden, FALSE) AS hidden
+	FROM leaderboard_entries e LEFT JOIN leaderboard_preferences p ON p.user_id = e.user_id
+	WHERE e.quiz_set_id::text = $1 AND e.period = $2`
+
+// rankingOrder lists ranked entries best first; earlier submissions come
+// first among entries sharing a rank
+const rankingOrder = `ORDER BY rank ASC, submitted_at ASC, user_id ASC`
+
+type postgresLeaderboardRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresLeaderboardRepository creates a new PostgreSQL leaderboard repository
+func NewPostgresLeaderboardRepository(db *sqlx.DB) domain.LeaderboardRepository {
+	return &postgresLeaderboardRepository{db: db}
+}
+
+func (r *postgresLeaderboardRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// Record stores an entry unless the user's current entry on the same
+// leaderboard ranks at least as high
+func (r *postgresLeaderboardRepository) Record(ctx context.Context, entry *domain.Entry) error {
+	query := `INSERT INTO leaderboard_entries (quiz_set_id, period, user_id, attempt_id, score, max_score, duration_ms, submitted_at)
+	           VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	           ON CONFLICT (quiz_set_id, period, user_id) DO UPDATE SET attempt_id = EXCLUDED.attempt_id,
+	               score = EXCLUDED.score, max_score = EXCLUDED.max_score, duration_ms = EXCLUDED.duration_ms,
+	               submitted_at = EXCLUDED.submitted_at
+	           WHERE EXCLUDED.score > leaderboard_entries.score
+	              OR (EXCLUDED.score = leaderboard_entries.score AND EXCLUDED.duration_ms < leaderboard_entries.duration_ms)`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query, entry.QuizSetID, entry.Period, entry.UserID, entry.AttemptID,
+		entry.Score, entry.MaxScore, entry.DurationMS, entry.SubmittedAt)
+	return err
+}
+
+// Count returns the number of entries on a leaderboard
+func (r *postgresLeaderboardRepository) Count(ctx context.Context, quizSetID, period string) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM leaderboard_entries WHERE quiz_set_id::text = $1 AND period = $2`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &count, query, quizSetID, period)
+	return count, err
+}
+
+// List returns limit entries of a leaderboard in ranking order, skipping offset
+func (r *postgresLeaderboardRepository) List(ctx context.Context, quizSetID, period string, offset, limit int) ([]domain.Entry, error) {
+	entries := []domain.Entry{}
+	query := `SELECT * FROM (` + rankedEntries + `) ranked ` + rankingOrder + ` OFFSET $3 LIMIT $4`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &entries, query, quizSetID, period, offset, limit); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetEntry returns the ranked entry of a user on a leaderboard, or nil if
+// the user has none
+func (r *postgresLeaderboardRepository) GetEntry(ctx context.Context, quizSetID, period, userID string) (*domain.Entry, error) {
+	var entry domain.Entry
+	query := `SELECT * FROM (` + rankedEntries + `) ranked WHERE user_id = $3`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &entry, query, quizSetID, period, userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// QuizSetExists returns true if the quiz set exists
+func (r *postgresLeaderboardRepository) QuizSetExists(ctx context.Context, quizSetID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM quiz_sets WHERE id::text = $1)`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &exists, query, quizSetID)
+	return exists, err
+}
+
+// IsHidden returns true if the user opted out of public display
+func (r *postgresLeaderboardRepository) IsHidden(ctx context.Context, userID string) (bool, error) {
+	var hidden bool
+	query := `SELECT COALESCE((SELECT hidden FROM leaderboard_preferences WHERE user_id = $1), FALSE)`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &hidden, query, userID)
+	return hidden, err
+}
+
+// SetHidden stores whether the user opts out of public display
+func (r *postgresLeaderboardRepository) SetHidden(ctx context.Context, userID string, hidden bool) error {
+	query := `INSERT INTO leaderboard_preferences (user_id, hidden, updated_at) VALUES ($1, $2, NOW())
+	           ON CONFLICT (user_id) DO UPDATE SET hidden = EXCLUDED.hidden, updated_at = EXCLUDED.updated_at`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query, userID, hidden)
+	return err
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// LeaderboardHandler handles HTTP requests for leaderboards
+type LeaderboardHandler struct {
+	service application.LeaderboardService
+}
+
+// NewLeaderboardHandler creates a new LeaderboardHandler
+func NewLeaderboardHandler(service application.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{service: service}
+}
+
+// leaderboardResponse is one page of a leaderboard with the caller's own entry
+type leaderboardResponse struct {
+	dto.PaginatedResponse[application.EntryResponse]
+	Window domain.Window              `json:"window"`
+	Period string                     `json:"period"`
+	Me     *application.EntryResponse `json:"me"`
+}
+
+// Get handles GET /quiz-sets/{id}/leaderboard?window=&week=&page=&page_size=
+func (h *LeaderboardHandler) Get(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := application.GetLeaderboardRequest{Window: domain.Window(query.Get("window")), Week: query.Get("week")}
+	for param, target := range map[string]*int{"page": &req.Page, "page_size": &req.PageSize} {
+		if raw := query.Get(param); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 {
+				dto.Error(w, http.StatusBadRequest, "INVALID_PAGINATION", "Query parameter '"+param+"' must be a positive number")
+				return
+			}
+			*target = n
+		}
+	}
+
+	page, err := h.service.Get(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	resp := leaderboardResponse{PaginatedResponse: dto.NewPaginatedResponse(page.Items, page.Pagination), Window: page.Window, Period: page.Period, Me: page.Me}
+	if page.Pagination.HasNext() {
+		resp.Links.Next = dto.PageURL(r, map[string]string{"page": strconv.Itoa(page.Pagination.Page + 1)})
+	}
+	if page.Pagination.HasPrev() {
+		resp.Links.Prev = dto.PageURL(r, map[string]string{"page": strconv.Itoa(page.Pagination.Page - 1)})
+	}
+
+	dto.SetLinkHeader(w, resp.Links)
+	dto.OK(w, resp)
+}
+
+// GetPreferences handles GET /leaderboards/preferences
+func (h *LeaderboardHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	prefs, err := h.service.GetPreferences(r.Context())
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, prefs)
+}
+
+// SetPreferences handles PUT /leaderboards/preferences
+func (h *LeaderboardHandler) SetPreferences(w http.ResponseWriter, r *http.Request) {
+	var req application.PreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	prefs, err := h.service.SetPreferences(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, prefs)
+}
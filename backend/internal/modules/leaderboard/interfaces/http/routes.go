@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard/application"
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterRoutes registers all leaderboard module routes. Leaderboards live
+// under their quiz set; preferences belong to the signed-in user.
+func RegisterRoutes(r chi.Router, service application.LeaderboardService) {
+	handler := NewLeaderboardHandler(service)
+
+	r.Get("/quiz-sets/{id}/leaderboard", handler.Get)
+
+	r.Route("/leaderboards", func(r chi.Router) {
+		r.Get("/preferences", handler.GetPreferences)
+		r.Put("/preferences", handler.SetPreferences)
+	})
+}
@@ -0,0 +1,55 @@
+package leaderboard
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard/infrastructure"
+	httpinterface "github.com/cananga-odorata/golang-template/internal/modules/leaderboard/interfaces/http"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Module represents the leaderboard module with all its dependencies
+type Module struct {
+	Service application.LeaderboardService
+}
+
+// NewModule initializes the leaderboard module with all dependencies. Weekly
+// boards follow weeks in location (nil means UTC). Attempts submitted on bus
+// are ranked as they arrive.
+func NewModule(db *sqlx.DB, bus *events.EventBus, location *time.Location) *Module {
+	if location == nil {
+		location = time.UTC
+	}
+	repo := infrastructure.NewPostgresLeaderboardRepository(db)
+
+	m := &Module{
+		Service: application.NewLeaderboardService(repo, location),
+	}
+	if bus != nil {
+		bus.Subscribe(events.AttemptSubmittedEvent{}.Name(), m.recordAttempt)
+	}
+	return m
+}
+
+// recordAttempt ranks a submitted attempt. Failures are logged here and not
+// returned, so that they do not keep the other handlers from running.
+func (m *Module) recordAttempt(ctx context.Context, event events.Event) error {
+	submitted, ok := event.(events.AttemptSubmittedEvent)
+	if !ok {
+		return nil
+	}
+	if err := m.Service.Record(ctx, submitted); err != nil {
+		slog.Error("Failed to update leaderboards", "attempt_id", submitted.AttemptID, "error", err)
+	}
+	return nil
+}
+
+// RegisterRoutes registers the module's HTTP routes
+func (m *Module) RegisterRoutes(r chi.Router) {
+	httpinterface.RegisterRoutes(r, m.Service)
+}
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/live/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// CreateSessionRequest DTO for opening a live session. Without QuizSetID
+// every published quiz is asked; TimeLimitSeconds defaults to 20.
+type CreateSessionRequest struct {
+	QuizSetID        *string `json:"quiz_set_id"`
+	TimeLimitSeconds int     `json:"time_limit_seconds"`
+}
+
+// CreateSessionResponse DTO for a new live session. Players join with Code;
+// HostToken lets its holder run the session and is only returned here.
+type CreateSessionResponse struct {
+	Code             string `json:"code"`
+	HostToken        string `json:"host_token"`
+	Questions        int    `json:"questions"`
+	TimeLimitSeconds int    `json:"time_limit_seconds"`
+}
+
+// JoinRequest identifies a connection to a live session: the host by the
+// host token, players by name
+type JoinRequest struct {
+	HostToken string
+	Name      string
+}
+
+// IncomingMessage is a message from a connection. Hosts send "next" and
+// "end"; players send "answer" with a choice.
+type IncomingMessage struct {
+	Type   string `json:"type"`
+	Choice int    `json:"choice"`
+}
+
+// Incoming message types
+const (
+	MessageNext   = "next"
+	MessageEnd    = "end"
+	MessageAnswer = "answer"
+)
+
+// Roles of a connection
+const (
+	RoleHost   = "host"
+	RolePlayer = "player"
+)
+
+// Reasons a session ended early
+const (
+	EndedByHost     = "host_ended"
+	EndedByShutdown = "shutdown"
+	EndedIdle       = "idle"
+)
+
+// JoinedMessage confirms a connection
+type JoinedMessage struct {
+	Type        string `json:"type"`
+	Role        string `json:"role"`
+	Name        string `json:"name,omitempty"`
+	Code        string `json:"code"`
+	Questions   int    `json:"questions"`
+	TimeLimitMS int64  `json:"time_limit_ms"`
+}
+
+// LobbyMessage lists the connected players before the first question
+type LobbyMessage struct {
+	Type    string   `json:"type"`
+	Players []string `json:"players"`
+}
+
+// QuestionMessage asks a question, without its answer
+type QuestionMessage struct {
+	Type        string    `json:"type"`
+	Index       int       `json:"index"`
+	Total       int       `json:"total"`
+	QuizID      string    `json:"quiz_id"`
+	Question    string    `json:"question"`
+	Choices     []string  `json:"choices"`
+	TimeLimitMS int64     `json:"time_limit_ms"`
+	Deadline    time.Time `json:"deadline"`
+}
+
+// AnswerAcceptedMessage confirms a player's answer; points are revealed on
+// the scoreboard
+type AnswerAcceptedMessage struct {
+	Type  string `json:"type"`
+	Index int    `json:"index"`
+}
+
+// ProgressMessage tells hosts how many connected players answered
+type ProgressMessage struct {
+	Type     string `json:"type"`
+	Index    int    `json:"index"`
+	Answered int    `json:"answered"`
+	Players  int    `json:"players"`
+}
+
+// ScoreboardMessage reveals the answer and the standings after a question
+type ScoreboardMessage struct {
+	Type      string            `json:"type"`
+	Index     int               `json:"index"`
+	Total     int               `json:"total"`
+	Answer    int               `json:"answer"`
+	Last      bool              `json:"last"`
+	Standings []domain.Standing `json:"standings"`
+}
+
+// FinishedMessage carries the final standings before the session closes
+type FinishedMessage struct {
+	Type      string            `json:"type"`
+	Standings []domain.Standing `json:"standings"`
+}
+
+// EndedMessage tells connections the session closed before finishing
+type EndedMessage struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// ErrorMessage reports a rejected message, or a rejected join before the
+// connection closes
+type ErrorMessage struct {
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorMessageFor describes err for a connection
+func ErrorMessageFor(err error) ErrorMessage {
+	msg := ErrorMessage{Type: "error", Code: string(sharedDomain.ErrCodeInternal), Message: "Internal server error"}
+	var appErr *sharedDomain.AppError
+	if errors.As(err, &appErr) {
+		msg.Code, msg.Message = string(appErr.Code), appErr.Message
+	}
+	return msg
+}
+
+func toQuestionMessage(s *domain.Session) QuestionMessage {
+	q := s.CurrentQuestion()
+	return QuestionMessage{
+		Type:        "question",
+		Index:       s.Current,
+		Total:       len(s.Questions),
+		QuizID:      q.QuizID,
+		Question:    q.Question,
+		Choices:     q.Choices(),
+		TimeLimitMS: s.TimeLimit.Milliseconds(),
+		Deadline:    s.Deadline,
+	}
+}
+
+func toScoreboardMessage(s *domain.Session) ScoreboardMessage {
+	return ScoreboardMessage{
+		Type:      "scoreboard",
+		Index:     s.Current,
+		Total:     len(s.Questions),
+		Answer:    s.CurrentQuestion().Answer,
+		Last:      s.IsLastQuestion(),
+		Standings: s.Standings(),
+	}
+}
@@ -0,0 +1,495 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/live/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// Time limits of a question
+const (
+	DefaultTimeLimit = 20 * time.Second
+	MinTimeLimit     = 5 * time.Second
+	MaxTimeLimit     = 120 * time.Second
+)
+
+// IdleTimeout is how long a session without connections is kept
+const IdleTimeout = time.Hour
+
+// Limits on open sessions, which are kept in memory
+const (
+	MaxSessions        = 1000
+	MaxSessionsPerHost = 3
+)
+
+// codeDigits is the length of join codes
+const codeDigits = 6
+
+// codeAttempts is how many random join codes are tried before giving up
+const codeAttempts = 20
+
+// Client is a connection to a live session
+type Client interface {
+	// Send queues a message for the connection without blocking
+	Send(msg interface{})
+	// Close ends the connection once the queued messages are sent
+	Close()
+}
+
+// SessionService defines the live session business logic interface.
+// Sessions live in memory and end when the process stops.
+type SessionService interface {
+	Create(ctx context.Context, req CreateSessionRequest) (*CreateSessionResponse, error)
+	Join(code string, req JoinRequest, client Client) (*Participant, error)
+	RunExpiry(ctx context.Context, interval time.Duration)
+	Shutdown(ctx context.Context) error
+}
+
+type sessionService struct {
+	repo      domain.QuestionRepository
+	now       func() time.Time
+	afterFunc func(d time.Duration, f func()) *time.Timer
+
+	mu       sync.Mutex
+	sessions map[string]*liveSession
+	closing  bool
+	// participants counts open connections so Shutdown can wait for them
+	participants sync.WaitGroup
+}
+
+// NewSessionService creates a new SessionService
+func NewSessionService(repo domain.QuestionRepository) SessionService {
+	return &sessionService{repo: repo, now: time.Now, afterFunc: time.AfterFunc, sessions: map[string]*liveSession{}}
+}
+
+// liveSession guards a session and the connections to it
+type liveSession struct {
+	service *sessionService
+	// hostID is the user who created the session
+	hostID string
+
+	mu           sync.Mutex
+	session      *domain.Session
+	hosts        map[Client]bool
+	players      map[Client]string
+	timer        *time.Timer
+	ended        bool
+	lastActivity time.Time
+}
+
+// Participant is a connection joined to a live session
+type Participant struct {
+	live   *liveSession
+	client Client
+	role   string
+	name   string
+	once   sync.Once
+}
+
+// Create opens a session in the lobby with the questions to ask, in display
+// order. Only signed-in users may host, and each only MaxSessionsPerHost
+// sessions at a time.
+func (s *sessionService) Create(ctx context.Context, req CreateSessionRequest) (*CreateSessionResponse, error) {
+	hostID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrHostSignIn
+	}
+	timeLimit := DefaultTimeLimit
+	if req.TimeLimitSeconds != 0 {
+		timeLimit = time.Duration(req.TimeLimitSeconds) * time.Second
+		if timeLimit < MinTimeLimit || timeLimit > MaxTimeLimit {
+			return nil, domain.ErrInvalidTimeLimit
+		}
+	}
+	if req.QuizSetID != nil {
+		exists, err := s.repo.QuizSetExists(ctx, *req.QuizSetID)
+		if err != nil {
+			return nil, sharedDomain.NewInternalError("Failed to fetch quiz set", err)
+		}
+		if !exists {
+			return nil, domain.ErrQuizSetNotFound
+		}
+	}
+	questions, err := s.repo.ListQuestions(ctx, req.QuizSetID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch questions", err)
+	}
+	if len(questions) == 0 {
+		return nil, domain.ErrNoQuestions
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to create host token", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closing {
+		return nil, domain.ErrShuttingDown
+	}
+	if len(s.sessions) >= MaxSessions {
+		return nil, domain.ErrTooManySessions
+	}
+	hosted := 0
+	for _, live := range s.sessions {
+		if live.hostID == hostID {
+			hosted++
+		}
+	}
+	if hosted >= MaxSessionsPerHost {
+		return nil, domain.ErrTooManyHosted
+	}
+	code, err := s.newCode()
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to create join code", err)
+	}
+	s.sessions[code] = &liveSession{
+		service:      s,
+		hostID:       hostID,
+		session:      domain.NewSession(code, token, questions, timeLimit),
+		hosts:        map[Client]bool{},
+		players:      map[Client]string{},
+		lastActivity: s.now(),
+	}
+
+	return &CreateSessionResponse{Code: code, HostToken: token, Questions: len(questions), TimeLimitSeconds: int(timeLimit / time.Second)}, nil
+}
+
+// Join connects a host or a player to a session and sends them its current state
+func (s *sessionService) Join(code string, req JoinRequest, client Client) (*Participant, error) {
+	s.mu.Lock()
+	live, ok := s.sessions[code]
+	if s.closing {
+		s.mu.Unlock()
+		return nil, domain.ErrShuttingDown
+	}
+	if ok {
+		s.participants.Add(1)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	p, err := live.join(req, client)
+	if err != nil {
+		s.participants.Done()
+		return nil, err
+	}
+	return p, nil
+}
+
+// RunExpiry drops sessions that have had no connections for IdleTimeout
+// every interval until ctx is done
+func (s *sessionService) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.expire()
+		}
+	}
+}
+
+func (s *sessionService) expire() {
+	s.mu.Lock()
+	sessions := make([]*liveSession, 0, len(s.sessions))
+	for _, live := range s.sessions {
+		sessions = append(sessions, live)
+	}
+	s.mu.Unlock()
+
+	cutoff := s.now().Add(-IdleTimeout)
+	for _, live := range sessions {
+		live.mu.Lock()
+		if len(live.hosts) == 0 && len(live.players) == 0 && live.lastActivity.Before(cutoff) {
+			live.end(EndedIdle)
+		}
+		live.mu.Unlock()
+	}
+}
+
+// Shutdown ends every session, telling its connections why, and waits until
+// they have all disconnected or ctx is done. New sessions and connections
+// are refused from then on.
+func (s *sessionService) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closing = true
+	sessions := s.sessions
+	s.sessions = map[string]*liveSession{}
+	s.mu.Unlock()
+
+	for _, live := range sessions {
+		live.mu.Lock()
+		live.end(EndedByShutdown)
+		live.mu.Unlock()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.participants.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// remove forgets a session. It may be called with the session's lock held,
+// so s.mu is never held while taking a session's lock.
+func (s *sessionService) remove(code string) {
+	s.mu.Lock()
+	delete(s.sessions, code)
+	s.mu.Unlock()
+}
+
+// newCode returns a join code no open session uses, trying codeAttempts
+// random codes. s.mu must be held.
+func (s *sessionService) newCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < codeDigits; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	for i := 0; i < codeAttempts; i++ {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", err
+		}
+		code := fmt.Sprintf("%0*d", codeDigits, n.Int64())
+		if _, taken := s.sessions[code]; !taken {
+			return code, nil
+		}
+	}
+	return "", errors.New("no free join code found")
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Handle applies a message from the participant's connection. Rejected
+// messages are answered with an error message.
+func (p *Participant) Handle(msg IncomingMessage) {
+	live := p.live
+	live.mu.Lock()
+	defer live.mu.Unlock()
+	if live.ended {
+		return
+	}
+	live.lastActivity = live.service.now()
+
+	var err error
+	switch {
+	case msg.Type == MessageNext && p.role == RoleHost:
+		err = live.next()
+	case msg.Type == MessageEnd && p.role == RoleHost:
+		live.end(EndedByHost)
+	case msg.Type == MessageAnswer && p.role == RolePlayer:
+		err = live.answer(p, msg.Choice)
+	case msg.Type == MessageNext || msg.Type == MessageEnd:
+		err = domain.ErrNotHost
+	case msg.Type == MessageAnswer:
+		err = domain.ErrNotAPlayer
+	default:
+		err = domain.ErrUnknownMessage
+	}
+	if err != nil {
+		p.client.Send(ErrorMessageFor(err))
+	}
+}
+
+// Leave disconnects the participant; players keep their score and can
+// rejoin under the same name
+func (p *Participant) Leave() {
+	p.once.Do(func() {
+		live := p.live
+		live.mu.Lock()
+		delete(live.hosts, p.client)
+		delete(live.players, p.client)
+		live.lastActivity = live.service.now()
+		if p.role == RolePlayer && !live.ended {
+			live.session.Leave(p.name)
+			if live.session.State == domain.StateLobby {
+				live.broadcast(live.lobbyMessage())
+			}
+		}
+		live.mu.Unlock()
+		live.service.participants.Done()
+	})
+}
+
+func (l *liveSession) join(req JoinRequest, client Client) (*Participant, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.ended {
+		return nil, domain.ErrSessionNotFound
+	}
+	s := l.session
+
+	p := &Participant{live: l, client: client}
+	if req.HostToken != "" {
+		if subtle.ConstantTimeCompare([]byte(req.HostToken), []byte(s.HostToken)) != 1 {
+			return nil, domain.ErrInvalidHostToken
+		}
+		p.role = RoleHost
+		l.hosts[client] = true
+	} else {
+		player, err := s.Join(req.Name)
+		if err != nil {
+			return nil, err
+		}
+		p.role, p.name = RolePlayer, player.Name
+		l.players[client] = player.Name
+	}
+	l.lastActivity = l.service.now()
+
+	client.Send(JoinedMessage{Type: "joined", Role: p.role, Name: p.name, Code: s.Code, Questions: len(s.Questions), TimeLimitMS: s.TimeLimit.Milliseconds()})
+	switch s.State {
+	case domain.StateLobby:
+		if p.role == RolePlayer {
+			l.broadcast(l.lobbyMessage())
+		} else {
+			client.Send(l.lobbyMessage())
+		}
+	case domain.StateQuestion:
+		client.Send(toQuestionMessage(s))
+		if p.role == RoleHost {
+			client.Send(l.progressMessage())
+		}
+	case domain.StateScoreboard:
+		client.Send(toScoreboardMessage(s))
+	}
+	return p, nil
+}
+
+// next moves the session on and tells every connection. l.mu must be held.
+func (l *liveSession) next() error {
+	s := l.session
+	if err := s.Next(l.service.now()); err != nil {
+		return err
+	}
+
+	switch s.State {
+	case domain.StateQuestion:
+		index := s.Current
+		l.stopTimer()
+		l.timer = l.service.afterFunc(s.TimeLimit, func() { l.timeUp(index) })
+		l.broadcast(toQuestionMessage(s))
+		l.sendHosts(l.progressMessage())
+	case domain.StateScoreboard:
+		l.stopTimer()
+		l.broadcast(toScoreboardMessage(s))
+	case domain.StateFinished:
+		l.broadcast(FinishedMessage{Type: "finished", Standings: s.Standings()})
+		l.close()
+	}
+	return nil
+}
+
+// timeUp closes question index when its time limit passes
+func (l *liveSession) timeUp(index int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := l.session
+	if l.ended || s.State != domain.StateQuestion || s.Current != index {
+		return
+	}
+	if err := s.CloseQuestion(); err == nil {
+		l.broadcast(toScoreboardMessage(s))
+	}
+}
+
+// answer records a player's answer; the question closes early once every
+// connected player answered. l.mu must be held.
+func (l *liveSession) answer(p *Participant, choice int) error {
+	s := l.session
+	if _, err := s.Answer(p.name, choice, l.service.now()); err != nil {
+		return err
+	}
+	p.client.Send(AnswerAcceptedMessage{Type: "answer_accepted", Index: s.Current})
+
+	progress := l.progressMessage()
+	l.sendHosts(progress)
+	if progress.Answered == progress.Players {
+		return l.next()
+	}
+	return nil
+}
+
+// end tells every connection the session ended early and closes them. l.mu must be held.
+func (l *liveSession) end(reason string) {
+	if l.ended {
+		return
+	}
+	l.broadcast(EndedMessage{Type: "ended", Reason: reason})
+	l.close()
+}
+
+// close stops the session and closes every connection. l.mu must be held.
+func (l *liveSession) close() {
+	l.ended = true
+	l.stopTimer()
+	for client := range l.hosts {
+		client.Close()
+	}
+	for client := range l.players {
+		client.Close()
+	}
+	l.service.remove(l.session.Code)
+}
+
+func (l *liveSession) stopTimer() {
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+}
+
+func (l *liveSession) lobbyMessage() LobbyMessage {
+	msg := LobbyMessage{Type: "lobby", Players: []string{}}
+	for _, p := range l.session.Players() {
+		if p.Connected {
+			msg.Players = append(msg.Players, p.Name)
+		}
+	}
+	return msg
+}
+
+func (l *liveSession) progressMessage() ProgressMessage {
+	answered, players := l.session.Answered()
+	return ProgressMessage{Type: "progress", Index: l.session.Current, Answered: answered, Players: players}
+}
+
+func (l *liveSession) broadcast(msg interface{}) {
+	l.sendHosts(msg)
+	for client := range l.players {
+		client.Send(msg)
+	}
+}
+
+func (l *liveSession) sendHosts(msg interface{}) {
+	for client := range l.hosts {
+		client.Send(msg)
+	}
+}
@@ -0,0 +1,306 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/live/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+type mockQuestionRepository struct {
+	questions []domain.Question
+	quizSets  map[string]bool
+}
+
+func (m *mockQuestionRepository) ListQuestions(_ context.Context, _ *string) ([]domain.Question, error) {
+	return m.questions, nil
+}
+
+func (m *mockQuestionRepository) QuizSetExists(_ context.Context, id string) (bool, error) {
+	return m.quizSets[id], nil
+}
+
+// fakeClient records the messages sent to a connection
+type fakeClient struct {
+	mu       sync.Mutex
+	messages []interface{}
+	closed   bool
+}
+
+func (c *fakeClient) Send(msg interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, msg)
+}
+
+func (c *fakeClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
+
+func (c *fakeClient) last() interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.messages) == 0 {
+		return nil
+	}
+	return c.messages[len(c.messages)-1]
+}
+
+// host is the signed-in user hosting sessions in tests
+var host = utils.SetUserID(context.Background(), "hana")
+
+// testService runs timers only when fire is called
+type testService struct {
+	*sessionService
+	clock  time.Time
+	timers []func()
+}
+
+func newTestService(t *testing.T) *testService {
+	t.Helper()
+	repo := &mockQuestionRepository{
+		questions: []domain.Question{
+			{QuizID: "q1", Question: "1+1?", Choice1: "1", Choice2: "2", Choice3: "3", Choice4: "4", Answer: 2},
+			{QuizID: "q2", Question: "2+2?", Choice1: "1", Choice2: "2", Choice3: "3", Choice4: "4", Answer: 4},
+		},
+		quizSets: map[string]bool{"set-1": true},
+	}
+	ts := &testService{sessionService: NewSessionService(repo).(*sessionService), clock: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	ts.now = func() time.Time { return ts.clock }
+	ts.afterFunc = func(_ time.Duration, f func()) *time.Timer {
+		ts.timers = append(ts.timers, f)
+		return time.NewTimer(time.Hour)
+	}
+	return ts
+}
+
+func (ts *testService) fire() {
+	ts.timers[len(ts.timers)-1]()
+}
+
+func TestSessionService_Create(t *testing.T) {
+	ts := newTestService(t)
+	ctx := host
+
+	resp, err := ts.Create(ctx, CreateSessionRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(resp.Code) != 6 || resp.HostToken == "" || resp.Questions != 2 || resp.TimeLimitSeconds != 20 {
+		t.Errorf("unexpected session %+v", resp)
+	}
+
+	if _, err := ts.Create(ctx, CreateSessionRequest{TimeLimitSeconds: 3}); !errors.Is(err, domain.ErrInvalidTimeLimit) {
+		t.Errorf("expected ErrInvalidTimeLimit, got: %v", err)
+	}
+	missing := "missing"
+	if _, err := ts.Create(ctx, CreateSessionRequest{QuizSetID: &missing}); !errors.Is(err, domain.ErrQuizSetNotFound) {
+		t.Errorf("expected ErrQuizSetNotFound, got: %v", err)
+	}
+	ts.repo.(*mockQuestionRepository).questions = nil
+	if _, err := ts.Create(ctx, CreateSessionRequest{}); !errors.Is(err, domain.ErrNoQuestions) {
+		t.Errorf("expected ErrNoQuestions, got: %v", err)
+	}
+}
+
+func TestSessionService_CreateLimits(t *testing.T) {
+	ts := newTestService(t)
+
+	if _, err := ts.Create(context.Background(), CreateSessionRequest{}); !errors.Is(err, domain.ErrHostSignIn) {
+		t.Errorf("expected ErrHostSignIn, got: %v", err)
+	}
+	for i := 0; i < MaxSessionsPerHost; i++ {
+		if _, err := ts.Create(host, CreateSessionRequest{}); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	if _, err := ts.Create(host, CreateSessionRequest{}); !errors.Is(err, domain.ErrTooManyHosted) {
+		t.Errorf("expected ErrTooManyHosted, got: %v", err)
+	}
+	other := utils.SetUserID(context.Background(), "omar")
+	if _, err := ts.Create(other, CreateSessionRequest{}); err != nil {
+		t.Errorf("expected another user to host, got: %v", err)
+	}
+
+	for i := len(ts.sessions); i < MaxSessions; i++ {
+		ts.sessions[fmt.Sprintf("filler-%d", i)] = &liveSession{hostID: fmt.Sprintf("user-%d", i)}
+	}
+	if _, err := ts.Create(utils.SetUserID(context.Background(), "pia"), CreateSessionRequest{}); !errors.Is(err, domain.ErrTooManySessions) {
+		t.Errorf("expected ErrTooManySessions, got: %v", err)
+	}
+}
+
+func TestSessionService_Join(t *testing.T) {
+	ts := newTestService(t)
+	resp, _ := ts.Create(host, CreateSessionRequest{})
+
+	if _, err := ts.Join("000000x", JoinRequest{Name: "Alice"}, &fakeClient{}); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound, got: %v", err)
+	}
+	if _, err := ts.Join(resp.Code, JoinRequest{HostToken: "wrong"}, &fakeClient{}); !errors.Is(err, domain.ErrInvalidHostToken) {
+		t.Errorf("expected ErrInvalidHostToken, got: %v", err)
+	}
+
+	host := &fakeClient{}
+	if _, err := ts.Join(resp.Code, JoinRequest{HostToken: resp.HostToken}, host); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	alice := &fakeClient{}
+	player, err := ts.Join(resp.Code, JoinRequest{Name: "Alice"}, alice)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if joined, ok := alice.messages[0].(JoinedMessage); !ok || joined.Role != RolePlayer || joined.Name != "Alice" {
+		t.Errorf("expected a joined message, got %+v", alice.messages[0])
+	}
+	if lobby, ok := host.last().(LobbyMessage); !ok || len(lobby.Players) != 1 || lobby.Players[0] != "Alice" {
+		t.Errorf("expected the host to see Alice in the lobby, got %+v", host.last())
+	}
+	if _, err := ts.Join(resp.Code, JoinRequest{Name: "alice"}, &fakeClient{}); !errors.Is(err, domain.ErrNameTaken) {
+		t.Errorf("expected ErrNameTaken, got: %v", err)
+	}
+
+	player.Leave()
+	player.Leave()
+	if lobby, ok := host.last().(LobbyMessage); !ok || len(lobby.Players) != 0 {
+		t.Errorf("expected an empty lobby after Alice left, got %+v", host.last())
+	}
+	if _, err := ts.Join(resp.Code, JoinRequest{Name: "alice"}, &fakeClient{}); err != nil {
+		t.Errorf("expected the name reclaimed, got: %v", err)
+	}
+}
+
+func TestSessionService_Game(t *testing.T) {
+	ts := newTestService(t)
+	resp, _ := ts.Create(host, CreateSessionRequest{})
+	host := &fakeClient{}
+	hostP, _ := ts.Join(resp.Code, JoinRequest{HostToken: resp.HostToken}, host)
+	alice, bob := &fakeClient{}, &fakeClient{}
+	aliceP, _ := ts.Join(resp.Code, JoinRequest{Name: "Alice"}, alice)
+	bobP, _ := ts.Join(resp.Code, JoinRequest{Name: "Bob"}, bob)
+
+	aliceP.Handle(IncomingMessage{Type: MessageNext})
+	if msg := alice.last(); msg != ErrorMessageFor(domain.ErrNotHost) {
+		t.Errorf("expected ErrNotHost, got %+v", msg)
+	}
+	hostP.Handle(IncomingMessage{Type: MessageAnswer, Choice: 2})
+	if msg := host.last(); msg != ErrorMessageFor(domain.ErrNotAPlayer) {
+		t.Errorf("expected ErrNotAPlayer, got %+v", msg)
+	}
+
+	// First question closes early once everyone answered
+	hostP.Handle(IncomingMessage{Type: MessageNext})
+	if q, ok := alice.last().(QuestionMessage); !ok || q.Index != 0 || q.QuizID != "q1" || len(q.Choices) != 4 {
+		t.Fatalf("expected the first question, got %+v", alice.last())
+	}
+	ts.clock = ts.clock.Add(5 * time.Second)
+	aliceP.Handle(IncomingMessage{Type: MessageAnswer, Choice: 2})
+	if _, ok := alice.last().(AnswerAcceptedMessage); !ok {
+		t.Errorf("expected the answer accepted, got %+v", alice.last())
+	}
+	if p, ok := host.last().(ProgressMessage); !ok || p.Answered != 1 || p.Players != 2 {
+		t.Errorf("expected progress 1/2, got %+v", host.last())
+	}
+	bobP.Handle(IncomingMessage{Type: MessageAnswer, Choice: 1})
+	board, ok := bob.last().(ScoreboardMessage)
+	if !ok || board.Answer != 2 || board.Last {
+		t.Fatalf("expected the first scoreboard, got %+v", bob.last())
+	}
+	if board.Standings[0].Name != "Alice" || board.Standings[0].Points != 875 || board.Standings[1].Score != 0 {
+		t.Errorf("unexpected standings %+v", board.Standings)
+	}
+
+	// Second question closes when its time is up
+	hostP.Handle(IncomingMessage{Type: MessageNext})
+	aliceP.Handle(IncomingMessage{Type: MessageAnswer, Choice: 4})
+	ts.fire()
+	if board, ok := bob.last().(ScoreboardMessage); !ok || board.Index != 1 || !board.Last {
+		t.Fatalf("expected the last scoreboard, got %+v", bob.last())
+	}
+	// A stale timer for the first question changes nothing
+	ts.timers[0]()
+	if _, ok := bob.last().(ScoreboardMessage); !ok {
+		t.Errorf("expected no message from a stale timer, got %+v", bob.last())
+	}
+
+	hostP.Handle(IncomingMessage{Type: MessageNext})
+	finished, ok := alice.last().(FinishedMessage)
+	if !ok || finished.Standings[0].Name != "Alice" || finished.Standings[0].Score != 1875 {
+		t.Fatalf("expected the final standings, got %+v", alice.last())
+	}
+	if !host.closed || !alice.closed || !bob.closed {
+		t.Error("expected every connection closed")
+	}
+	if _, err := ts.Join(resp.Code, JoinRequest{Name: "Carol"}, &fakeClient{}); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected the finished session gone, got: %v", err)
+	}
+}
+
+func TestSessionService_ExpireIdle(t *testing.T) {
+	ts := newTestService(t)
+	idle, _ := ts.Create(host, CreateSessionRequest{})
+	active, _ := ts.Create(host, CreateSessionRequest{})
+	ts.Join(active.Code, JoinRequest{HostToken: active.HostToken}, &fakeClient{})
+
+	ts.clock = ts.clock.Add(IdleTimeout + time.Minute)
+	ts.expire()
+
+	if _, err := ts.Join(idle.Code, JoinRequest{Name: "Alice"}, &fakeClient{}); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected the idle session gone, got: %v", err)
+	}
+	if _, err := ts.Join(active.Code, JoinRequest{Name: "Alice"}, &fakeClient{}); err != nil {
+		t.Errorf("expected the active session kept, got: %v", err)
+	}
+}
+
+func TestSessionService_Shutdown(t *testing.T) {
+	ts := newTestService(t)
+	resp, _ := ts.Create(host, CreateSessionRequest{})
+	alice := &fakeClient{}
+	aliceP, _ := ts.Join(resp.Code, JoinRequest{Name: "Alice"}, alice)
+
+	done := make(chan error, 1)
+	go func() { done <- ts.Shutdown(context.Background()) }()
+
+	// Shutdown waits for the connection to go away
+	deadline := time.Now().Add(time.Second)
+	for !func() bool { alice.mu.Lock(); defer alice.mu.Unlock(); return alice.closed }() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the connection closed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("expected Shutdown to wait, got: %v", err)
+	default:
+	}
+	if ended, ok := alice.last().(EndedMessage); !ok || ended.Reason != EndedByShutdown {
+		t.Errorf("expected an ended message, got %+v", alice.last())
+	}
+
+	aliceP.Leave()
+	if err := <-done; err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if _, err := ts.Create(host, CreateSessionRequest{}); !errors.Is(err, domain.ErrShuttingDown) {
+		t.Errorf("expected ErrShuttingDown, got: %v", err)
+	}
+
+	// A connection that never leaves makes Shutdown give up with ctx
+	ts2 := newTestService(t)
+	resp2, _ := ts2.Create(host, CreateSessionRequest{})
+	ts2.Join(resp2.Code, JoinRequest{Name: "Bob"}, &fakeClient{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := ts2.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got: %v", err)
+	}
+}
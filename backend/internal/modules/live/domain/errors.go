@@ -0,0 +1,26 @@
+package domain
+
+import sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+
+var (
+	ErrSessionNotFound  = sharedDomain.NewNotFoundError("Live session not found")
+	ErrQuizSetNotFound  = sharedDomain.NewNotFoundError("Quiz set not found")
+	ErrNoQuestions      = sharedDomain.NewValidationError("There are no published quizzes with an answer to ask")
+	ErrInvalidTimeLimit = sharedDomain.NewValidationError("time_limit_seconds must be between 5 and 120")
+	ErrInvalidName      = sharedDomain.NewValidationError("name is required and must be at most 32 characters")
+	ErrInvalidHostToken = sharedDomain.NewForbiddenError("Invalid host token")
+	ErrNameTaken        = sharedDomain.NewConflictError("Another player in this session uses that name")
+	ErrSessionFull      = sharedDomain.NewConflictError("The session is full")
+	ErrSessionFinished  = sharedDomain.NewConflictError("The session has finished")
+	ErrShuttingDown     = sharedDomain.NewConflictError("Live sessions are shutting down")
+	ErrHostSignIn       = sharedDomain.NewUnauthorizedError("Sign in to host a live session")
+	ErrTooManyHosted    = sharedDomain.NewConflictError("You already host the most live sessions allowed; end one first")
+	ErrTooManySessions  = sharedDomain.NewConflictError("Too many live sessions are open; try again later")
+	ErrNotHost          = sharedDomain.NewForbiddenError("Only the host can run the session")
+	ErrNotAPlayer       = sharedDomain.NewForbiddenError("Only players can answer")
+	ErrNoOpenQuestion   = sharedDomain.NewConflictError("No question is open")
+	ErrTooLate          = sharedDomain.NewConflictError("Time is up for this question")
+	ErrAlreadyAnswered  = sharedDomain.NewConflictError("You already answered this question")
+	ErrInvalidChoice    = sharedDomain.NewValidationError("choice must be between 1 and 4")
+	ErrUnknownMessage   = sharedDomain.NewValidationError("Unknown message type")
+)
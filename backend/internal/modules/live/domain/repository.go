@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// QuestionRepository defines the interface for reading quizzes to ask in
+// live sessions
+type QuestionRepository interface {
+	// ListQuestions returns the published quizzes inside their schedule that
+	// have an answer, in display order; with quizSetID, only the quizzes of
+	// that quiz set
+	ListQuestions(ctx context.Context, quizSetID *string) ([]Question, error)
+
+	// QuizSetExists returns true if the quiz set exists
+	QuizSetExists(ctx context.Context, quizSetID string) (bool, error)
+}
@@ -0,0 +1,254 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// State is the stage a live session is in
+type State string
+
+const (
+	// StateLobby waits for players before the first question
+	StateLobby State = "lobby"
+	// StateQuestion accepts answers to the current question until its deadline
+	StateQuestion State = "question"
+	// StateScoreboard shows the standings after a question
+	StateScoreboard State = "scoreboard"
+	// StateFinished shows the final standings; the session takes no more input
+	StateFinished State = "finished"
+)
+
+// Limits of a session
+const (
+	MaxPlayers    = 500
+	MaxNameLength = 32
+	// MaxPoints is awarded for a correct answer given immediately; a correct
+	// answer at the deadline earns half
+	MaxPoints = 1000
+)
+
+// Question is a quiz asked in a live session
+type Question struct {
+	QuizID   string `db:"id"`
+	Question string `db:"question"`
+	Choice1  string `db:"choice1"`
+	Choice2  string `db:"choice2"`
+	Choice3  string `db:"choice3"`
+	Choice4  string `db:"choice4"`
+	Answer   int    `db:"answer"`
+}
+
+// Choices returns the question's choices in order
+func (q Question) Choices() []string {
+	return []string{q.Choice1, q.Choice2, q.Choice3, q.Choice4}
+}
+
+// Player is a participant of a live session, known by name
+type Player struct {
+	Name  string
+	Score int
+	// Points, Choice and Correct describe the answer to the current question
+	Points  int
+	Choice  int
+	Correct bool
+	// Connected is false while the player's connection is gone; the name
+	// can then be reclaimed
+	Connected bool
+	joinOrder int
+}
+
+// Standing is a player's place on the scoreboard. Players with the same
+// score share a rank.
+type Standing struct {
+	Rank    int    `json:"rank"`
+	Name    string `json:"name"`
+	Score   int    `json:"score"`
+	Points  int    `json:"points"`
+	Correct bool   `json:"correct"`
+}
+
+// Session is the in-memory state of a live session. It is not safe for
+// concurrent use.
+type Session struct {
+	Code      string
+	HostToken string
+	Questions []Question
+	TimeLimit time.Duration
+	State     State
+	// Current is the index of the current question, -1 in the lobby
+	Current  int
+	OpenedAt time.Time
+	Deadline time.Time
+
+	players map[string]*Player
+}
+
+// NewSession creates a session in the lobby
+func NewSession(code, hostToken string, questions []Question, timeLimit time.Duration) *Session {
+	return &Session{
+		Code:      code,
+		HostToken: hostToken,
+		Questions: questions,
+		TimeLimit: timeLimit,
+		State:     StateLobby,
+		Current:   -1,
+		players:   map[string]*Player{},
+	}
+}
+
+// Join connects a player by name. A known name can be reclaimed while its
+// player is disconnected, keeping the score.
+func (s *Session) Join(name string) (*Player, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return nil, ErrInvalidName
+	}
+	if s.State == StateFinished {
+		return nil, ErrSessionFinished
+	}
+	if p, ok := s.players[strings.ToLower(name)]; ok {
+		if p.Connected {
+			return nil, ErrNameTaken
+		}
+		p.Connected = true
+		return p, nil
+	}
+	if len(s.players) >= MaxPlayers {
+		return nil, ErrSessionFull
+	}
+	p := &Player{Name: name, Connected: true, joinOrder: len(s.players)}
+	s.players[strings.ToLower(name)] = p
+	return p, nil
+}
+
+// Leave marks a player as disconnected
+func (s *Session) Leave(name string) {
+	if p, ok := s.players[strings.ToLower(name)]; ok {
+		p.Connected = false
+	}
+}
+
+// Players returns the players in joining order
+func (s *Session) Players() []*Player {
+	players := make([]*Player, 0, len(s.players))
+	for _, p := range s.players {
+		players = append(players, p)
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].joinOrder < players[j].joinOrder })
+	return players
+}
+
+// CurrentQuestion returns the question being asked or last asked, or nil in the lobby
+func (s *Session) CurrentQuestion() *Question {
+	if s.Current < 0 || s.Current >= len(s.Questions) {
+		return nil
+	}
+	return &s.Questions[s.Current]
+}
+
+// IsLastQuestion returns true if the current question is the last one
+func (s *Session) IsLastQuestion() bool {
+	return s.Current == len(s.Questions)-1
+}
+
+// Next moves the session on: from the lobby or a scoreboard to the next
+// question, or to the end after the last scoreboard. Asking for the next
+// step while a question is open closes it.
+func (s *Session) Next(now time.Time) error {
+	switch s.State {
+	case StateQuestion:
+		return s.CloseQuestion()
+	case StateFinished:
+		return ErrSessionFinished
+	}
+	if s.IsLastQuestion() {
+		s.State = StateFinished
+		return nil
+	}
+
+	s.Current++
+	s.State = StateQuestion
+	s.OpenedAt, s.Deadline = now, now.Add(s.TimeLimit)
+	for _, p := range s.players {
+		p.Points, p.Choice, p.Correct = 0, 0, false
+	}
+	return nil
+}
+
+// Answer records a player's choice for the open question and returns the
+// points it earned: more the sooner a correct answer comes
+func (s *Session) Answer(name string, choice int, now time.Time) (int, error) {
+	p, ok := s.players[strings.ToLower(name)]
+	if !ok {
+		return 0, ErrNotAPlayer
+	}
+	if s.State != StateQuestion {
+		return 0, ErrNoOpenQuestion
+	}
+	if !now.Before(s.Deadline) {
+		return 0, ErrTooLate
+	}
+	if choice < 1 || choice > 4 {
+		return 0, ErrInvalidChoice
+	}
+	if p.Choice != 0 {
+		return 0, ErrAlreadyAnswered
+	}
+
+	p.Choice = choice
+	p.Correct = choice == s.Questions[s.Current].Answer
+	if p.Correct {
+		elapsed := now.Sub(s.OpenedAt).Seconds() / s.TimeLimit.Seconds()
+		p.Points = int(math.Round(MaxPoints * (1 - elapsed/2)))
+		p.Score += p.Points
+	}
+	return p.Points, nil
+}
+
+// Answered returns how many connected players answered the open question,
+// and how many connected players there are
+func (s *Session) Answered() (answered, connected int) {
+	for _, p := range s.players {
+		if !p.Connected {
+			continue
+		}
+		connected++
+		if p.Choice != 0 {
+			answered++
+		}
+	}
+	return answered, connected
+}
+
+// CloseQuestion stops accepting answers to the open question
+func (s *Session) CloseQuestion() error {
+	if s.State != StateQuestion {
+		return ErrNoOpenQuestion
+	}
+	s.State = StateScoreboard
+	return nil
+}
+
+// Standings returns every player ordered by score, then by name
+func (s *Session) Standings() []Standing {
+	players := s.Players()
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].Score != players[j].Score {
+			return players[i].Score > players[j].Score
+		}
+		return strings.ToLower(players[i].Name) < strings.ToLower(players[j].Name)
+	})
+
+	standings := make([]Standing, len(players))
+	for i, p := range players {
+		standings[i] = Standing{Rank: i + 1, Name: p.Name, Score: p.Score, Points: p.Points, Correct: p.Correct}
+		if i > 0 && p.Score == players[i-1].Score {
+			standings[i].Rank = standings[i-1].Rank
+		}
+	}
+	return standings
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestSession() *Session {
+	questions := []Question{
+		{QuizID: "q1", Question: "1+1?", Choice1: "1", Choice2: "2", Choice3: "3", Choice4: "4", Answer: 2},
+		{QuizID: "q2", Question: "2+2?", Choice1: "1", Choice2: "2", Choice3: "3", Choice4: "4", Answer: 4},
+	}
+	return NewSession("123456", "token", questions, 20*time.Second)
+}
+
+func TestSession_Join(t *testing.T) {
+	s := newTestSession()
+
+	if _, err := s.Join("  Alice "); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if _, err := s.Join("alice"); !errors.Is(err, ErrNameTaken) {
+		t.Errorf("expected ErrNameTaken, got: %v", err)
+	}
+	for _, name := range []string{"", "   ", "abcdefghijklmnopqrstuvwxyzabcdefg"} {
+		if _, err := s.Join(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("expected ErrInvalidName for %q, got: %v", name, err)
+		}
+	}
+
+	// A disconnected player's name can be reclaimed with the score
+	s.Players()[0].Score = 500
+	s.Leave("ALICE")
+	p, err := s.Join("Alice")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if p.Score != 500 || !p.Connected {
+		t.Errorf("expected the player reclaimed, got %+v", p)
+	}
+
+	s.State = StateFinished
+	if _, err := s.Join("Bob"); !errors.Is(err, ErrSessionFinished) {
+		t.Errorf("expected ErrSessionFinished, got: %v", err)
+	}
+}
+
+func TestSession_Flow(t *testing.T) {
+	s := newTestSession()
+	s.Join("Alice")
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, err := s.Answer("Alice", 2, start); !errors.Is(err, ErrNoOpenQuestion) {
+		t.Errorf("expected ErrNoOpenQuestion in the lobby, got: %v", err)
+	}
+
+	if err := s.Next(start); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if s.State != StateQuestion || s.Current != 0 || !s.Deadline.Equal(start.Add(20*time.Second)) {
+		t.Fatalf("expected the first question open, got %s %d %v", s.State, s.Current, s.Deadline)
+	}
+
+	if _, err := s.Answer("Bob", 2, start); !errors.Is(err, ErrNotAPlayer) {
+		t.Errorf("expected ErrNotAPlayer, got: %v", err)
+	}
+	if _, err := s.Answer("Alice", 5, start); !errors.Is(err, ErrInvalidChoice) {
+		t.Errorf("expected ErrInvalidChoice, got: %v", err)
+	}
+	if _, err := s.Answer("Alice", 2, start.Add(20*time.Second)); !errors.Is(err, ErrTooLate) {
+		t.Errorf("expected ErrTooLate at the deadline, got: %v", err)
+	}
+	points, err := s.Answer("Alice", 2, start.Add(10*time.Second))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if points != 750 {
+		t.Errorf("expected 750 points halfway through, got %d", points)
+	}
+	if _, err := s.Answer("Alice", 2, start.Add(11*time.Second)); !errors.Is(err, ErrAlreadyAnswered) {
+		t.Errorf("expected ErrAlreadyAnswered, got: %v", err)
+	}
+
+	// Next while a question is open closes it
+	if err := s.Next(start); err != nil || s.State != StateScoreboard {
+		t.Fatalf("expected the scoreboard, got %s, %v", s.State, err)
+	}
+	if err := s.Next(start.Add(time.Minute)); err != nil || s.Current != 1 {
+		t.Fatalf("expected the second question, got %d, %v", s.Current, err)
+	}
+	if p := s.Players()[0]; p.Points != 0 || p.Choice != 0 || p.Score != 750 {
+		t.Errorf("expected the answer reset and the score kept, got %+v", p)
+	}
+	if points, _ := s.Answer("Alice", 3, start.Add(time.Minute)); points != 0 {
+		t.Errorf("expected no points for a wrong answer, got %d", points)
+	}
+	s.CloseQuestion()
+	if !s.IsLastQuestion() {
+		t.Error("expected the last question")
+	}
+	if err := s.Next(start); err != nil || s.State != StateFinished {
+		t.Fatalf("expected the session finished, got %s, %v", s.State, err)
+	}
+	if err := s.Next(start); !errors.Is(err, ErrSessionFinished) {
+		t.Errorf("expected ErrSessionFinished, got: %v", err)
+	}
+}
+
+func TestSession_Standings(t *testing.T) {
+	s := newTestSession()
+	for _, name := range []string{"carol", "Bob", "alice", "dave"} {
+		s.Join(name)
+	}
+	scores := map[string]int{"carol": 900, "Bob": 500, "alice": 900, "dave": 100}
+	for _, p := range s.Players() {
+		p.Score = scores[p.Name]
+	}
+
+	want := []Standing{{Rank: 1, Name: "alice", Score: 900}, {Rank: 1, Name: "carol", Score: 900}, {Rank: 3, Name: "Bob", Score: 500}, {Rank: 4, Name: "dave", Score: 100}}
+	got := s.Standings()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d standings, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("standing %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
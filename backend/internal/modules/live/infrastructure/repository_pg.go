@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/live/domain"
+	quizDomain "github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// questionColumns reads a quiz as a live session question
+const questionColumns = `q.id, q.question, q.choice1, q.choice2, q.choice3, q.choice4, q.answer`
+
+// questionCondition selects the quizzes that can be asked: published, inside
+// their schedule and with an answer
+const questionCondition = `q.deleted_at IS NULL AND q.answer BETWEEN 1 AND 4 AND ` + quizDomain.VisibleCondition
+
+type postgresQuestionRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresQuestionRepository creates a new PostgreSQL live question repository
+func NewPostgresQuestionRepository(db *sqlx.DB) domain.QuestionRepository {
+	return &postgresQuestionRepository{db: db}
+}
+
+func (r *postgresQuestionRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// ListQuestions returns the quizzes that can be asked in display order,
+// limited to a quiz set when quizSetID is given
+func (r *postgresQuestionRepository) ListQuestions(ctx context.Context, quizSetID *string) ([]domain.Question, error) {
+	questions := []domain.Question{}
+	query := `SELECT ` + questionColumns + ` FROM quizzes q WHERE ` + questionCondition + ` ORDER BY q.display_order ASC, q.id ASC`
+	args := []interface{}{}
+	if quizSetID != nil {
+		query = `SELECT ` + questionColumns + ` FROM quizzes q
+		          WHERE ` + questionCondition + ` AND EXISTS (SELECT 1 FROM quiz_set_items i WHERE i.quiz_id = q.id AND i.quiz_set_id::text = $1)
+		          ORDER BY q.display_order ASC, q.id ASC`
+		args = append(args, *quizSetID)
+	}
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &questions, query, args...); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// QuizSetExists returns true if the quiz set exists
+func (r *postgresQuestionRepository) QuizSetExists(ctx context.Context, quizSetID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM quiz_sets WHERE id::text = $1)`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &exists, query, quizSetID)
+	return exists, err
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/pkg/websocket"
+)
+
+// Connection timing
+const (
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = pongWait * 9 / 10
+	// sendQueue is how many messages may wait for a slow connection before
+	// it is dropped
+	sendQueue = 32
+)
+
+// wsClient is an application.Client writing JSON messages to a WebSocket
+// from its own goroutine
+type wsClient struct {
+	conn      *websocket.Conn
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	// stopped is closed once the writer has closed the connection
+	stopped chan struct{}
+}
+
+func newWSClient(conn *websocket.Conn) *wsClient {
+	c := &wsClient{conn: conn, send: make(chan []byte, sendQueue), done: make(chan struct{}), stopped: make(chan struct{})}
+	go c.writeLoop()
+	return c
+}
+
+// Send queues a message; a connection too slow to keep up is closed
+func (c *wsClient) Send(msg interface{}) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("Failed to encode live session message", "error", err)
+		return
+	}
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+	select {
+	case c.send <- data:
+	default:
+		c.Close()
+	}
+}
+
+// Close ends the connection once the queued messages are written
+func (c *wsClient) Close() {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
+func (c *wsClient) writeLoop() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+		close(c.stopped)
+	}()
+
+	for {
+		select {
+		case data := <-c.send:
+			if err := c.write(data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WritePing(); err != nil {
+				return
+			}
+		case <-c.done:
+			for {
+				select {
+				case data := <-c.send:
+					if err := c.write(data); err != nil {
+						return
+					}
+				default:
+					c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+					c.conn.WriteClose(websocket.CloseNormal, "")
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *wsClient) write(data []byte) error {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
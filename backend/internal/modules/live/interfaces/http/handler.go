@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/live/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/cananga-odorata/golang-template/pkg/websocket"
+	"github.com/go-chi/chi/v5"
+)
+
+// SessionHandler handles HTTP and WebSocket requests for live sessions
+type SessionHandler struct {
+	service  application.SessionService
+	upgrader websocket.Upgrader
+}
+
+// NewSessionHandler creates a new SessionHandler accepting WebSocket
+// connections from the API's own origin and allowedOrigins
+func NewSessionHandler(service application.SessionService, allowedOrigins []string) *SessionHandler {
+	return &SessionHandler{service: service, upgrader: websocket.Upgrader{AllowedOrigins: allowedOrigins}}
+}
+
+// Create handles POST /live-sessions
+func (h *SessionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req application.CreateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	session, err := h.service.Create(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, session)
+}
+
+// Connect handles GET /live-sessions/{code}/ws?host_token= for the host and
+// ?name= for players. Join errors are sent as an error message before the
+// connection closes.
+func (h *SessionHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r)
+	if err != nil {
+		return
+	}
+	client := newWSClient(conn)
+	defer func() {
+		client.Close()
+		<-client.stopped
+	}()
+
+	query := r.URL.Query()
+	participant, err := h.service.Join(chi.URLParam(r, "code"), application.JoinRequest{HostToken: query.Get("host_token"), Name: query.Get("name")}, client)
+	if err != nil {
+		client.Send(application.ErrorMessageFor(err))
+		return
+	}
+	defer participant.Leave()
+
+	conn.PongHandler = func() { conn.SetReadDeadline(time.Now().Add(pongWait)) }
+	for {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg application.IncomingMessage
+		if err := json.Unmarshal(message, &msg); err != nil {
+			client.Send(application.ErrorMessage{Type: "error", Code: "INVALID_JSON", Message: "Invalid message payload"})
+			continue
+		}
+		participant.Handle(msg)
+	}
+}
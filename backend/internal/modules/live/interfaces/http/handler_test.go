@@ -0,0 +1,178 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/live/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/live/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+	"github.com/cananga-odorata/golang-template/pkg/websocket"
+	"github.com/go-chi/chi/v5"
+)
+
+type mockQuestionRepository struct{}
+
+func (mockQuestionRepository) ListQuestions(_ context.Context, _ *string) ([]domain.Question, error) {
+	return []domain.Question{{QuizID: "q1", Question: "1+1?", Choice1: "1", Choice2: "2", Choice3: "3", Choice4: "4", Answer: 2}}, nil
+}
+
+func (mockQuestionRepository) QuizSetExists(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, application.SessionService) {
+	t.Helper()
+	service := application.NewSessionService(mockQuestionRepository{})
+	r := chi.NewRouter()
+	// X-User-ID stands in for the authentication middleware
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := r.Header.Get("X-User-ID"); id != "" {
+				r = r.WithContext(utils.SetUserID(r.Context(), id))
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	RegisterRoutes(r, service, []string{"https://app.example.com"})
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, service
+}
+
+// post creates a session, signed in as userID unless it is empty
+func post(t *testing.T, srv *httptest.Server, userID, body string) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/live-sessions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	return resp
+}
+
+func dial(t *testing.T, srv *httptest.Server, path string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.Dial("ws://"+strings.TrimPrefix(srv.URL, "http://")+path, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+// read returns the next message of the given type, skipping others
+func read(t *testing.T, conn *websocket.Conn, messageType string) map[string]interface{} {
+	t.Helper()
+	for {
+		var msg map[string]interface{}
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("expected a %s message, got: %v", messageType, err)
+		}
+		if msg["type"] == messageType {
+			return msg
+		}
+	}
+}
+
+func TestSessionHandler_Create(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	resp := post(t, srv, "hana", `{"time_limit_seconds": 30}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", resp.StatusCode)
+	}
+	var body struct {
+		Data application.CreateSessionResponse `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	if body.Data.Code == "" || body.Data.HostToken == "" || body.Data.TimeLimitSeconds != 30 {
+		t.Errorf("unexpected session %+v", body.Data)
+	}
+
+	resp = post(t, srv, "hana", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("expected an empty body to use the defaults, got %d", resp.StatusCode)
+	}
+
+	resp = post(t, srv, "", "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected anonymous hosts to be refused with 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestSessionHandler_Connect(t *testing.T) {
+	srv, service := newTestServer(t)
+	session, err := service.Create(utils.SetUserID(context.Background(), "hana"), application.CreateSessionRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	host := dial(t, srv, "/live-sessions/"+session.Code+"/ws?host_token="+session.HostToken)
+	if msg := read(t, host, "joined"); msg["role"] != "host" {
+		t.Errorf("expected the host role, got %v", msg)
+	}
+	read(t, host, "lobby")
+	player := dial(t, srv, "/live-sessions/"+session.Code+"/ws?name=Alice")
+	read(t, player, "joined")
+	if msg := read(t, host, "lobby"); len(msg["players"].([]interface{})) != 1 {
+		t.Errorf("expected Alice in the lobby, got %v", msg)
+	}
+
+	player.WriteMessage(websocket.TextMessage, []byte("not json"))
+	if msg := read(t, player, "error"); msg["message"] != "Invalid message payload" {
+		t.Errorf("expected an invalid payload error, got %v", msg)
+	}
+
+	host.WriteJSON(application.IncomingMessage{Type: application.MessageNext})
+	if msg := read(t, player, "question"); msg["quiz_id"] != "q1" {
+		t.Errorf("expected the question, got %v", msg)
+	}
+	player.WriteJSON(application.IncomingMessage{Type: application.MessageAnswer, Choice: 2})
+	read(t, player, "answer_accepted")
+	read(t, player, "scoreboard")
+
+	host.WriteJSON(application.IncomingMessage{Type: application.MessageNext})
+	read(t, player, "finished")
+	var closeErr *websocket.CloseError
+	if _, _, err := player.ReadMessage(); !errors.As(err, &closeErr) {
+		t.Errorf("expected the connection closed, got: %v", err)
+	}
+}
+
+func TestSessionHandler_ConnectRejected(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	conn := dial(t, srv, "/live-sessions/999999/ws?name=Alice")
+	if msg := read(t, conn, "error"); msg["code"] != "NOT_FOUND" {
+		t.Errorf("expected a not found error, got %v", msg)
+	}
+	var closeErr *websocket.CloseError
+	if _, _, err := conn.ReadMessage(); !errors.As(err, &closeErr) {
+		t.Errorf("expected the connection closed, got: %v", err)
+	}
+
+	url := "ws://" + strings.TrimPrefix(srv.URL, "http://") + "/live-sessions/999999/ws?name=Alice"
+	if _, resp, err := websocket.Dial(url, http.Header{"Origin": {"https://evil.example.com"}}); !errors.Is(err, websocket.ErrBadHandshake) || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected a foreign origin refused with 403, got: %v", err)
+	}
+	conn, _, err := websocket.Dial(url, http.Header{"Origin": {"https://app.example.com"}})
+	if err != nil {
+		t.Fatalf("expected an allowed origin to connect, got: %v", err)
+	}
+	conn.Close()
+}
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/modules/live/application"
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterRoutes registers all live session routes. Browsers may connect
+// from allowedOrigins besides the API's own.
+func RegisterRoutes(r chi.Router, service application.SessionService, allowedOrigins []string) {
+	handler := NewSessionHandler(service, allowedOrigins)
+
+	r.Route("/live-sessions", func(r chi.Router) {
+		r.Post("/", handler.Create)
+		r.Get("/{code}/ws", handler.Connect)
+	})
+}
@@ -0,0 +1,44 @@
+package live
+
+import (
+	"context"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/live/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/live/infrastructure"
+	httpinterface "github.com/cananga-odorata/golang-template/internal/modules/live/interfaces/http"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Module represents the live session module with all its dependencies
+type Module struct {
+	Service        application.SessionService
+	allowedOrigins []string
+}
+
+// NewModule initializes the live session module with all dependencies.
+// Browsers on allowedOrigins may connect besides the API's own origin.
+func NewModule(db *sqlx.DB, allowedOrigins []string) *Module {
+	repo := infrastructure.NewPostgresQuestionRepository(db)
+
+	return &Module{
+		Service:        application.NewSessionService(repo),
+		allowedOrigins: allowedOrigins,
+	}
+}
+
+// RegisterRoutes registers the module's HTTP routes
+func (m *Module) RegisterRoutes(r chi.Router) {
+	httpinterface.RegisterRoutes(r, m.Service, m.allowedOrigins)
+}
+
+// RunExpiry drops sessions nobody is connected to every interval until ctx is done
+func (m *Module) RunExpiry(ctx context.Context, interval time.Duration) {
+	m.Service.RunExpiry(ctx, interval)
+}
+
+// Shutdown ends every live session and waits for its connections to close
+func (m *Module) Shutdown(ctx context.Context) error {
+	return m.Service.Shutdown(ctx)
+}
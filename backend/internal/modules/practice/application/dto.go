@@ -0,0 +1,93 @@
+package application
+
+import (
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/practice/domain"
+)
+
+// NextRequest DTO for the quizzes to practise now
+type NextRequest struct {
+	QuizSetID string
+	Limit     int
+}
+
+// AnswerRequest DTO for answering a practice question. Quality optionally
+// rates the recall from 0 to 5; a correct answer is rated 4 and a wrong one
+// 1 when it is left out.
+type AnswerRequest struct {
+	QuizID  string `json:"quiz_id"`
+	Choice  int    `json:"choice"`
+	Quality *int   `json:"quality,omitempty"`
+}
+
+// CardResponse DTO for the schedule of a quiz
+type CardResponse struct {
+	Ease           float64    `json:"ease"`
+	IntervalDays   int        `json:"interval_days"`
+	Repetitions    int        `json:"repetitions"`
+	Lapses         int        `json:"lapses"`
+	Reviews        int        `json:"reviews"`
+	DueAt          time.Time  `json:"due_at"`
+	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
+}
+
+// PracticeItem DTO for a question to practise, without its answer. Card is
+// left out for quizzes that were never practised.
+type PracticeItem struct {
+	QuizID   string        `json:"quiz_id"`
+	Question string        `json:"question"`
+	Choice1  string        `json:"choice1"`
+	Choice2  string        `json:"choice2"`
+	Choice3  string        `json:"choice3"`
+	Choice4  string        `json:"choice4"`
+	New      bool          `json:"new"`
+	Card     *CardResponse `json:"card,omitempty"`
+}
+
+// NextResponse DTO for the quizzes to practise now: due reviews first, then
+// new quizzes. Due counts every review due now, and NextDueAt tells when
+// the next one falls due once they are done.
+type NextResponse struct {
+	Items     []PracticeItem `json:"items"`
+	Due       int64          `json:"due"`
+	NextDueAt *time.Time     `json:"next_due_at,omitempty"`
+}
+
+// AnswerResponse DTO for a graded practice answer with the new schedule
+type AnswerResponse struct {
+	QuizID  string       `json:"quiz_id"`
+	Correct bool         `json:"correct"`
+	Answer  int          `json:"answer"`
+	Quality int          `json:"quality"`
+	Card    CardResponse `json:"card"`
+}
+
+func toCardResponse(c domain.Card) CardResponse {
+	return CardResponse{
+		Ease:           c.Ease,
+		IntervalDays:   c.IntervalDays,
+		Repetitions:    c.Repetitions,
+		Lapses:         c.Lapses,
+		Reviews:        c.Reviews,
+		DueAt:          c.DueAt,
+		LastReviewedAt: c.LastReviewedAt,
+	}
+}
+
+func toPracticeItem(item domain.Item, card *domain.Card) PracticeItem {
+	resp := PracticeItem{
+		QuizID:   item.QuizID,
+		Question: item.Question,
+		Choice1:  item.Choice1,
+		Choice2:  item.Choice2,
+		Choice3:  item.Choice3,
+		Choice4:  item.Choice4,
+		New:      card == nil,
+	}
+	if card != nil {
+		c := toCardResponse(*card)
+		resp.Card = &c
+	}
+	return resp
+}
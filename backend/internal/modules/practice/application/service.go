@@ -0,0 +1,181 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	attemptDomain "github.com/cananga-odorata/golang-template/internal/modules/attempt/domain"
+	"github.com/cananga-odorata/golang-template/internal/modules/practice/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 50
+)
+
+// PracticeService defines the spaced-repetition practice business logic interface
+type PracticeService interface {
+	Next(ctx context.Context, req NextRequest) (*NextResponse, error)
+	Answer(ctx context.Context, req AnswerRequest) (*AnswerResponse, error)
+}
+
+type practiceService struct {
+	repo      domain.PracticeRepository
+	txManager database.TxManager
+	now       func() time.Time
+}
+
+// NewPracticeService creates a new PracticeService
+func NewPracticeService(repo domain.PracticeRepository, txManager database.TxManager) PracticeService {
+	return &practiceService{repo: repo, txManager: txManager, now: time.Now}
+}
+
+// Next returns the caller's quizzes to practise now: the reviews that are
+// due, most overdue first, then quizzes never practised to fill the limit
+func (s *practiceService) Next(ctx context.Context, req NextRequest) (*NextResponse, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrSignInRequired
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit < 1 || limit > maxLimit {
+		return nil, domain.ErrInvalidLimit
+	}
+	quizSetID, err := s.quizSet(ctx, req.QuizSetID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	resp := &NextResponse{Items: []PracticeItem{}}
+	resp.Due, err = s.repo.CountDue(ctx, userID, quizSetID, now)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to count due reviews", err)
+	}
+	cards, err := s.repo.ListDue(ctx, userID, quizSetID, now, limit)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch due reviews", err)
+	}
+	if len(cards) > 0 {
+		quizIDs := make([]string, len(cards))
+		for i, c := range cards {
+			quizIDs[i] = c.QuizID
+		}
+		items, err := s.repo.GetItems(ctx, quizIDs)
+		if err != nil {
+			return nil, sharedDomain.NewInternalError("Failed to fetch quizzes", err)
+		}
+		byID := make(map[string]domain.Item, len(items))
+		for _, item := range items {
+			byID[item.QuizID] = item
+		}
+		for i := range cards {
+			if item, ok := byID[cards[i].QuizID]; ok {
+				resp.Items = append(resp.Items, toPracticeItem(item, &cards[i]))
+			}
+		}
+	}
+
+	if len(resp.Items) < limit {
+		items, err := s.repo.ListNew(ctx, userID, quizSetID, limit-len(resp.Items))
+		if err != nil {
+			return nil, sharedDomain.NewInternalError("Failed to fetch new quizzes", err)
+		}
+		for _, item := range items {
+			resp.Items = append(resp.Items, toPracticeItem(item, nil))
+		}
+	}
+
+	if resp.Due == 0 {
+		resp.NextDueAt, err = s.repo.NextDue(ctx, userID, quizSetID, now)
+		if err != nil {
+			return nil, sharedDomain.NewInternalError("Failed to fetch the next review", err)
+		}
+	}
+	return resp, nil
+}
+
+// Answer grades the caller's answer to a practice question and reschedules
+// the quiz: remembered quizzes come back after a growing interval, forgotten
+// ones within the session
+func (s *practiceService) Answer(ctx context.Context, req AnswerRequest) (*AnswerResponse, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrSignInRequired
+	}
+	if req.Choice < 1 || req.Choice > attemptDomain.NumChoices {
+		return nil, domain.ErrInvalidChoice
+	}
+
+	items, err := s.repo.GetItems(ctx, []string{strings.TrimSpace(req.QuizID)})
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quiz", err)
+	}
+	if len(items) == 0 {
+		return nil, domain.ErrQuizNotFound
+	}
+	item := items[0]
+	correct := req.Choice == item.Answer
+
+	quality := domain.WrongQuality
+	if correct {
+		quality = domain.CorrectQuality
+	}
+	if req.Quality != nil {
+		quality = *req.Quality
+		if correct != (quality >= domain.PassingQuality) || quality < domain.MinQuality || quality > domain.MaxQuality {
+			return nil, domain.ErrInvalidQuality
+		}
+	}
+
+	var card *domain.Card
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		now := s.now()
+		card, err = s.repo.GetCard(ctx, userID, item.QuizID)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to fetch practice card", err)
+		}
+		if card == nil {
+			card = domain.NewCard(userID, item.QuizID, now)
+		}
+		card.Review(quality, now)
+		if err := s.repo.SaveCard(ctx, card); err != nil {
+			return sharedDomain.NewInternalError("Failed to save practice card", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnswerResponse{
+		QuizID:  item.QuizID,
+		Correct: correct,
+		Answer:  item.Answer,
+		Quality: quality,
+		Card:    toCardResponse(*card),
+	}, nil
+}
+
+// quizSet returns the quiz set to practise, or nil to practise every quiz
+func (s *practiceService) quizSet(ctx context.Context, quizSetID string) (*string, error) {
+	quizSetID = strings.TrimSpace(quizSetID)
+	if quizSetID == "" {
+		return nil, nil
+	}
+	visible, err := s.repo.QuizSetVisible(ctx, quizSetID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quiz set", err)
+	}
+	if !visible {
+		return nil, domain.ErrQuizSetNotFound
+	}
+	return &quizSetID, nil
+}
@@ -0,0 +1,265 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/practice/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// passthroughTxManager runs fn without a real transaction
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// mockPracticeRepository keeps quizzes, quiz sets and cards in memory
+type mockPracticeRepository struct {
+	items []domain.Item
+	sets  map[string][]string
+	cards map[string]domain.Card
+}
+
+func newMockRepo() *mockPracticeRepository {
+	return &mockPracticeRepository{
+		items: []domain.Item{
+			{QuizID: "q1", Question: "1+1?", Answer: 2},
+			{QuizID: "q2", Question: "2+2?", Answer: 4},
+			{QuizID: "q3", Question: "3+3?", Answer: 1},
+		},
+		sets:  map[string][]string{"s1": {"q3", "q1"}},
+		cards: map[string]domain.Card{},
+	}
+}
+
+func (m *mockPracticeRepository) inScope(quizSetID *string, quizID string) bool {
+	if quizSetID == nil {
+		return true
+	}
+	for _, id := range m.sets[*quizSetID] {
+		if id == quizID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockPracticeRepository) GetItems(_ context.Context, quizIDs []string) ([]domain.Item, error) {
+	items := []domain.Item{}
+	for _, item := range m.items {
+		for _, id := range quizIDs {
+			if item.QuizID == id {
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
+
+func (m *mockPracticeRepository) ListNew(_ context.Context, userID string, quizSetID *string, limit int) ([]domain.Item, error) {
+	ids := []string{}
+	if quizSetID != nil {
+		ids = m.sets[*quizSetID]
+	} else {
+		for _, item := range m.items {
+			ids = append(ids, item.QuizID)
+		}
+	}
+	items := []domain.Item{}
+	for _, id := range ids {
+		if _, practised := m.cards[userID+"/"+id]; !practised && len(items) < limit {
+			found, _ := m.GetItems(context.Background(), []string{id})
+			items = append(items, found...)
+		}
+	}
+	return items, nil
+}
+
+func (m *mockPracticeRepository) userCards(userID string, quizSetID *string) []domain.Card {
+	cards := []domain.Card{}
+	for _, c := range m.cards {
+		if c.UserID == userID && m.inScope(quizSetID, c.QuizID) {
+			cards = append(cards, c)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].DueAt.Before(cards[j].DueAt) })
+	return cards
+}
+
+func (m *mockPracticeRepository) ListDue(_ context.Context, userID string, quizSetID *string, now time.Time, limit int) ([]domain.Card, error) {
+	due := []domain.Card{}
+	for _, c := range m.userCards(userID, quizSetID) {
+		if c.IsDue(now) && len(due) < limit {
+			due = append(due, c)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockPracticeRepository) CountDue(ctx context.Context, userID string, quizSetID *string, now time.Time) (int64, error) {
+	due, _ := m.ListDue(ctx, userID, quizSetID, now, len(m.cards))
+	return int64(len(due)), nil
+}
+
+func (m *mockPracticeRepository) NextDue(_ context.Context, userID string, quizSetID *string, now time.Time) (*time.Time, error) {
+	for _, c := range m.userCards(userID, quizSetID) {
+		if !c.IsDue(now) {
+			return &c.DueAt, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockPracticeRepository) QuizSetVisible(_ context.Context, quizSetID string) (bool, error) {
+	_, ok := m.sets[quizSetID]
+	return ok, nil
+}
+
+func (m *mockPracticeRepository) GetCard(_ context.Context, userID, quizID string) (*domain.Card, error) {
+	c, ok := m.cards[userID+"/"+quizID]
+	if !ok {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+func (m *mockPracticeRepository) SaveCard(_ context.Context, card *domain.Card) error {
+	m.cards[card.UserID+"/"+card.QuizID] = *card
+	return nil
+}
+
+func newTestService(repo *mockPracticeRepository, now *time.Time) PracticeService {
+	service := NewPracticeService(repo, passthroughTxManager{}).(*practiceService)
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+func quizIDs(resp *NextResponse) []string {
+	ids := make([]string, len(resp.Items))
+	for i, item := range resp.Items {
+		ids[i] = item.QuizID
+	}
+	return ids
+}
+
+func TestPractice_SchedulesAnswers(t *testing.T) {
+	repo := newMockRepo()
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	service := newTestService(repo, &now)
+	ctx := utils.SetUserID(context.Background(), "alice")
+
+	next, err := service.Next(ctx, NextRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := quizIDs(next); len(got) != 3 || !next.Items[0].New || next.Due != 0 || next.NextDueAt != nil {
+		t.Fatalf("expected every quiz as new, got %v (%+v)", got, next)
+	}
+
+	right, err := service.Answer(ctx, AnswerRequest{QuizID: "q1", Choice: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !right.Correct || right.Quality != domain.CorrectQuality || right.Card.IntervalDays != 1 {
+		t.Errorf("expected a correct answer due again tomorrow, got %+v", right)
+	}
+	wrong, err := service.Answer(ctx, AnswerRequest{QuizID: "q2", Choice: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if wrong.Correct || wrong.Answer != 4 || !wrong.Card.DueAt.Equal(now.Add(domain.RelearnDelay)) {
+		t.Errorf("expected a wrong answer due again shortly, got %+v", wrong)
+	}
+
+	next, _ = service.Next(ctx, NextRequest{})
+	if got := quizIDs(next); len(got) != 1 || got[0] != "q3" || next.NextDueAt == nil || !next.NextDueAt.Equal(wrong.Card.DueAt) {
+		t.Errorf("expected only the unpractised quiz and the next review time, got %v (%+v)", got, next)
+	}
+
+	now = now.Add(time.Hour)
+	next, _ = service.Next(ctx, NextRequest{})
+	if got := quizIDs(next); len(got) != 2 || got[0] != "q2" || next.Items[0].New || next.Items[0].Card == nil || next.Due != 1 {
+		t.Errorf("expected the missed quiz first, then the new one, got %v (%+v)", got, next)
+	}
+
+	now = now.AddDate(0, 0, 1)
+	next, _ = service.Next(ctx, NextRequest{Limit: 1})
+	if got := quizIDs(next); len(got) != 1 || got[0] != "q2" || next.Due != 2 {
+		t.Errorf("expected the most overdue quiz within the limit, got %v (%+v)", got, next)
+	}
+
+	next, _ = service.Next(utils.SetUserID(context.Background(), "bob"), NextRequest{})
+	if len(next.Items) != 3 || next.Due != 0 {
+		t.Errorf("expected schedules to be kept per learner, got %+v", next)
+	}
+}
+
+func TestPractice_QuizSet(t *testing.T) {
+	repo := newMockRepo()
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	service := newTestService(repo, &now)
+	ctx := utils.SetUserID(context.Background(), "alice")
+
+	next, err := service.Next(ctx, NextRequest{QuizSetID: "s1"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := quizIDs(next); len(got) != 2 || got[0] != "q3" || got[1] != "q1" {
+		t.Errorf("expected the quizzes of the set in set order, got %v", got)
+	}
+	if _, err := service.Next(ctx, NextRequest{QuizSetID: "missing"}); !errors.Is(err, domain.ErrQuizSetNotFound) {
+		t.Errorf("expected ErrQuizSetNotFound, got: %v", err)
+	}
+}
+
+func TestPractice_Validation(t *testing.T) {
+	repo := newMockRepo()
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	service := newTestService(repo, &now)
+	ctx := utils.SetUserID(context.Background(), "alice")
+	quality := func(q int) *int { return &q }
+
+	if _, err := service.Next(context.Background(), NextRequest{}); !errors.Is(err, domain.ErrSignInRequired) {
+		t.Errorf("expected ErrSignInRequired, got: %v", err)
+	}
+	if _, err := service.Next(ctx, NextRequest{Limit: 51}); !errors.Is(err, domain.ErrInvalidLimit) {
+		t.Errorf("expected ErrInvalidLimit, got: %v", err)
+	}
+
+	cases := map[string]struct {
+		req  AnswerRequest
+		want error
+	}{
+		"signed out":       {AnswerRequest{QuizID: "q1", Choice: 2}, domain.ErrSignInRequired},
+		"choice":           {AnswerRequest{QuizID: "q1", Choice: 5}, domain.ErrInvalidChoice},
+		"unknown quiz":     {AnswerRequest{QuizID: "zzz", Choice: 1}, domain.ErrQuizNotFound},
+		"correct as wrong": {AnswerRequest{QuizID: "q1", Choice: 2, Quality: quality(2)}, domain.ErrInvalidQuality},
+		"wrong as correct": {AnswerRequest{QuizID: "q1", Choice: 1, Quality: quality(3)}, domain.ErrInvalidQuality},
+		"out of range":     {AnswerRequest{QuizID: "q1", Choice: 2, Quality: quality(6)}, domain.ErrInvalidQuality},
+	}
+	for name, tc := range cases {
+		c := ctx
+		if name == "signed out" {
+			c = context.Background()
+		}
+		if _, err := service.Answer(c, tc.req); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got: %v", name, tc.want, err)
+		}
+	}
+	if len(repo.cards) != 0 {
+		t.Errorf("expected nothing scheduled, got %d cards", len(repo.cards))
+	}
+
+	resp, err := service.Answer(ctx, AnswerRequest{QuizID: "q1", Choice: 2, Quality: quality(5)})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Quality != 5 || resp.Card.Ease <= domain.DefaultEase {
+		t.Errorf("expected an easy recall to raise the ease, got %+v", resp)
+	}
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Quality grades how well a learner recalled a quiz, from 0 (blackout) to 5
+// (perfect recall), as in SM-2. Grades of PassingQuality and above count as
+// remembered.
+const (
+	MinQuality     = 0
+	PassingQuality = 3
+	MaxQuality     = 5
+
+	// CorrectQuality and WrongQuality grade answers the learner did not rate
+	CorrectQuality = 4
+	WrongQuality   = 1
+)
+
+const (
+	// DefaultEase is the ease of a card that was never reviewed
+	DefaultEase = 2.5
+	// MinEase keeps hard cards from being reviewed ever more often
+	MinEase = 1.3
+	// RelearnDelay is how soon a forgotten card is due again, so it comes
+	// back within the same practice session
+	RelearnDelay = 10 * time.Minute
+)
+
+// Card is the spaced-repetition schedule of a quiz for one learner
+type Card struct {
+	UserID string `db:"user_id"`
+	QuizID string `db:"quiz_id"`
+	// Ease multiplies the interval after each successful review
+	Ease float64 `db:"ease"`
+	// IntervalDays is the number of days until the next review; it is 0
+	// while the card is new or being relearned
+	IntervalDays int `db:"interval_days"`
+	// Repetitions counts the successful reviews since the card was last forgotten
+	Repetitions int `db:"repetitions"`
+	// Lapses counts how often the card was forgotten after it was learned
+	Lapses         int        `db:"lapses"`
+	Reviews        int        `db:"reviews"`
+	DueAt          time.Time  `db:"due_at"`
+	LastReviewedAt *time.Time `db:"last_reviewed_at"`
+}
+
+// NewCard returns the schedule of a quiz the learner has not practised yet,
+// due at now
+func NewCard(userID, quizID string, now time.Time) *Card {
+	return &Card{UserID: userID, QuizID: quizID, Ease: DefaultEase, DueAt: now}
+}
+
+// IsDue returns true if the card should be reviewed at now
+func (c *Card) IsDue(now time.Time) bool {
+	return !c.DueAt.After(now)
+}
+
+// Review reschedules the card after it was reviewed at now with quality.
+// Following SM-2, the first two successful reviews are 1 and 6 days apart
+// and each later interval is the previous one times the ease, which grows
+// with easy recalls and shrinks with hard ones. A forgotten card starts its
+// repetitions over and is due again after RelearnDelay.
+func (c *Card) Review(quality int, now time.Time) {
+	quality = max(MinQuality, min(MaxQuality, quality))
+
+	miss := float64(MaxQuality - quality)
+	c.Ease = max(MinEase, c.Ease+0.1-miss*(0.08+miss*0.02))
+	c.Reviews++
+	c.LastReviewedAt = &now
+
+	if quality < PassingQuality {
+		if c.Repetitions > 0 {
+			c.Lapses++
+		}
+		c.Repetitions = 0
+		c.IntervalDays = 0
+		c.DueAt = now.Add(RelearnDelay)
+		return
+	}
+
+	switch c.Repetitions {
+	case 0:
+		c.IntervalDays = 1
+	case 1:
+		c.IntervalDays = 6
+	default:
+		c.IntervalDays = int(math.Round(float64(c.IntervalDays) * c.Ease))
+	}
+	c.Repetitions++
+	c.DueAt = now.AddDate(0, 0, c.IntervalDays)
+}
+
+// Item is a quiz that can be practised: published, inside its schedule and
+// with an answer
+type Item struct {
+	QuizID   string `db:"quiz_id"`
+	Question string `db:"question"`
+	Choice1  string `db:"choice1"`
+	Choice2  string `db:"choice2"`
+	Choice3  string `db:"choice3"`
+	Choice4  string `db:"choice4"`
+	Answer   int    `db:"answer"`
+}
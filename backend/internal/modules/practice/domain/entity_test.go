@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCardReview_Intervals(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	card := NewCard("alice", "q1", now)
+	if !card.IsDue(now) || card.Ease != DefaultEase {
+		t.Fatalf("expected a new card due now with the default ease, got %+v", card)
+	}
+
+	for i, want := range []int{1, 6, 15, 38} {
+		card.Review(CorrectQuality, now)
+		if card.IntervalDays != want || card.Repetitions != i+1 || !card.DueAt.Equal(now.AddDate(0, 0, want)) {
+			t.Fatalf("review %d: expected an interval of %d days, got %+v", i+1, want, card)
+		}
+		now = card.DueAt
+	}
+	if card.Ease != DefaultEase || card.Reviews != 4 || !card.LastReviewedAt.Equal(now.AddDate(0, 0, -38)) {
+		t.Errorf("expected the ease to stay at %v after good recalls, got %+v", DefaultEase, card)
+	}
+}
+
+func TestCardReview_Ease(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	for quality, want := range map[int]float64{5: 2.6, 4: 2.5, 3: 2.36, 2: 2.18, 0: 1.7} {
+		card := NewCard("alice", "q1", now)
+		card.Review(quality, now)
+		if math.Abs(card.Ease-want) > 1e-9 {
+			t.Errorf("quality %d: expected ease %v, got %v", quality, want, card.Ease)
+		}
+	}
+
+	card := NewCard("alice", "q1", now)
+	for range 10 {
+		card.Review(0, now)
+	}
+	if card.Ease != MinEase {
+		t.Errorf("expected the ease to stop at %v, got %v", MinEase, card.Ease)
+	}
+}
+
+func TestCardReview_Lapse(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	card := NewCard("alice", "q1", now)
+
+	card.Review(WrongQuality, now)
+	if card.Lapses != 0 || card.IntervalDays != 0 || !card.DueAt.Equal(now.Add(RelearnDelay)) {
+		t.Errorf("expected a new card answered wrong to come back after %v without a lapse, got %+v", RelearnDelay, card)
+	}
+
+	card.Review(CorrectQuality, now)
+	card.Review(CorrectQuality, now)
+	card.Review(WrongQuality, now)
+	if card.Lapses != 1 || card.Repetitions != 0 || card.IntervalDays != 0 || !card.DueAt.Equal(now.Add(RelearnDelay)) {
+		t.Errorf("expected a forgotten card to start over, got %+v", card)
+	}
+	card.Review(CorrectQuality, now)
+	if card.IntervalDays != 1 {
+		t.Errorf("expected a relearned card to be due the next day, got %d days", card.IntervalDays)
+	}
+}
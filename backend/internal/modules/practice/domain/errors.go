@@ -0,0 +1,12 @@
+package domain
+
+import sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+
+var (
+	ErrQuizNotFound    = sharedDomain.NewNotFoundError("Quiz not found")
+	ErrQuizSetNotFound = sharedDomain.NewNotFoundError("Quiz set not found")
+	ErrSignInRequired  = sharedDomain.NewUnauthorizedError("Sign in to practise")
+	ErrInvalidChoice   = sharedDomain.NewValidationError("choice must be between 1 and 4")
+	ErrInvalidQuality  = sharedDomain.NewValidationError("quality must be 3 to 5 for a correct answer and 0 to 2 for a wrong one")
+	ErrInvalidLimit    = sharedDomain.NewValidationError("limit must be between 1 and 50")
+)
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PracticeRepository defines the interface for practice data access. Only
+// quizzes that can be practised are read; quizSetID narrows them to the
+// quizzes of a quiz set when it is not nil.
+type PracticeRepository interface {
+	// GetItems returns the given quizzes that can be practised
+	GetItems(ctx context.Context, quizIDs []string) ([]Item, error)
+
+	// ListNew returns up to limit quizzes the user has no card for, in set
+	// order for a quiz set and in display order otherwise
+	ListNew(ctx context.Context, userID string, quizSetID *string, limit int) ([]Item, error)
+
+	// ListDue returns up to limit cards of the user due at now, most
+	// overdue first
+	ListDue(ctx context.Context, userID string, quizSetID *string, now time.Time, limit int) ([]Card, error)
+
+	// CountDue returns the number of cards of the user due at now
+	CountDue(ctx context.Context, userID string, quizSetID *string, now time.Time) (int64, error)
+
+	// NextDue returns when the user's next card that is not due at now
+	// falls due, or nil if there is none
+	NextDue(ctx context.Context, userID string, quizSetID *string, now time.Time) (*time.Time, error)
+
+	// QuizSetVisible returns true if the quiz set exists and is inside its schedule
+	QuizSetVisible(ctx context.Context, quizSetID string) (bool, error)
+
+	// GetCard returns the user's card for a quiz, locked until the
+	// transaction ends, or nil if there is none
+	GetCard(ctx context.Context, userID, quizID string) (*Card, error)
+
+	// SaveCard creates or replaces a card
+	SaveCard(ctx context.Context, card *Card) error
+}
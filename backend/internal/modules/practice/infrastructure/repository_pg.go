@@ -0,0 +1,141 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/practice/domain"
+	quizDomain "github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const itemColumns = `q.id AS quiz_id, q.question, q.choice1, q.choice2, q.choice3, q.choice4, q.answer`
+
+const cardColumns = `c.user_id, c.quiz_id, c.ease, c.interval_days, c.repetitions, c.lapses, c.reviews, c.due_at, c.last_reviewed_at`
+
+// itemCondition selects the quizzes that can be practised: published,
+// inside their schedule and with an answer
+const itemCondition = `q.deleted_at IS NULL AND q.answer BETWEEN 1 AND 4 AND ` + quizDomain.VisibleCondition
+
+// inQuizSet narrows quizzes to the quiz set given as $2, or keeps them all when it is NULL
+const inQuizSet = `($2::text IS NULL OR EXISTS (SELECT 1 FROM quiz_set_items i WHERE i.quiz_id = q.id AND i.quiz_set_id::text = $2))`
+
+// userCards are the cards of the user given as $1 on quizzes that can be
+// practised, narrowed by inQuizSet
+const userCards = `FROM practice_cards c JOIN quizzes q ON q.id = c.quiz_id
+	WHERE c.user_id = $1 AND ` + itemCondition + ` AND ` + inQuizSet
+
+// quizSetVisibleCondition is the SQL condition for quiz sets inside their schedule
+const quizSetVisibleCondition = `((publish_at IS NULL OR publish_at <= NOW()) AND (unpublish_at IS NULL OR unpublish_at > NOW()))`
+
+type postgresPracticeRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresPracticeRepository creates a new PostgreSQL practice repository
+func NewPostgresPracticeRepository(db *sqlx.DB) domain.PracticeRepository {
+	return &postgresPracticeRepository{db: db}
+}
+
+func (r *postgresPracticeRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// GetItems returns the given quizzes that can be practised
+func (r *postgresPracticeRepository) GetItems(ctx context.Context, quizIDs []string) ([]domain.Item, error) {
+	items := []domain.Item{}
+	query := `SELECT ` + itemColumns + ` FROM quizzes q WHERE q.id::text = ANY($1) AND ` + itemCondition
+	q := r.getQueryable(ctx)
+	err := q.SelectContext(ctx, &items, query, pq.Array(quizIDs))
+	return items, err
+}
+
+// ListNew returns quizzes the user has not practised yet, in set order for
+// a quiz set and in display order otherwise
+func (r *postgresPracticeRepository) ListNew(ctx context.Context, userID string, quizSetID *string, limit int) ([]domain.Item, error) {
+	items := []domain.Item{}
+	unpractised := `NOT EXISTS (SELECT 1 FROM practice_cards c WHERE c.user_id = $1 AND c.quiz_id = q.id)`
+	q := r.getQueryable(ctx)
+	if quizSetID == nil {
+		query := `SELECT ` + itemColumns + ` FROM quizzes q
+		           WHERE ` + itemCondition + ` AND ` + unpractised + `
+		           ORDER BY q.display_order ASC LIMIT $2`
+		err := q.SelectContext(ctx, &items, query, userID, limit)
+		return items, err
+	}
+
+	query := `SELECT ` + itemColumns + ` FROM quiz_set_items i JOIN quizzes q ON q.id = i.quiz_id
+	           WHERE i.quiz_set_id::text = $2 AND ` + itemCondition + ` AND ` + unpractised + `
+	           ORDER BY i.position ASC LIMIT $3`
+	err := q.SelectContext(ctx, &items, query, userID, *quizSetID, limit)
+	return items, err
+}
+
+// ListDue returns the user's cards due at now, most overdue first
+func (r *postgresPracticeRepository) ListDue(ctx context.Context, userID string, quizSetID *string, now time.Time, limit int) ([]domain.Card, error) {
+	cards := []domain.Card{}
+	query := `SELECT ` + cardColumns + ` ` + userCards + ` AND c.due_at <= $3
+	           ORDER BY c.due_at ASC, c.quiz_id ASC LIMIT $4`
+	q := r.getQueryable(ctx)
+	err := q.SelectContext(ctx, &cards, query, userID, quizSetID, now, limit)
+	return cards, err
+}
+
+// CountDue returns the number of the user's cards due at now
+func (r *postgresPracticeRepository) CountDue(ctx context.Context, userID string, quizSetID *string, now time.Time) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) ` + userCards + ` AND c.due_at <= $3`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &count, query, userID, quizSetID, now)
+	return count, err
+}
+
+// NextDue returns when the user's next card that is not due at now falls due
+func (r *postgresPracticeRepository) NextDue(ctx context.Context, userID string, quizSetID *string, now time.Time) (*time.Time, error) {
+	var next *time.Time
+	query := `SELECT MIN(c.due_at) ` + userCards + ` AND c.due_at > $3`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &next, query, userID, quizSetID, now)
+	return next, err
+}
+
+// QuizSetVisible returns true if the quiz set exists and is inside its schedule
+func (r *postgresPracticeRepository) QuizSetVisible(ctx context.Context, quizSetID string) (bool, error) {
+	var visible bool
+	query := `SELECT EXISTS (SELECT 1 FROM quiz_sets WHERE id::text = $1 AND ` + quizSetVisibleCondition + `)`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &visible, query, quizSetID)
+	return visible, err
+}
+
+// GetCard returns the user's card for a quiz, locked until the transaction ends
+func (r *postgresPracticeRepository) GetCard(ctx context.Context, userID, quizID string) (*domain.Card, error) {
+	var card domain.Card
+	query := `SELECT ` + cardColumns + ` FROM practice_cards c
+	           WHERE c.user_id = $1 AND c.quiz_id::text = $2 FOR UPDATE`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &card, query, userID, quizID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// SaveCard creates or replaces a card
+func (r *postgresPracticeRepository) SaveCard(ctx context.Context, card *domain.Card) error {
+	query := `INSERT INTO practice_cards (user_id, quiz_id, ease, interval_days, repetitions, lapses, reviews, due_at, last_reviewed_at)
+	           VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	           ON CONFLICT (user_id, quiz_id) DO UPDATE SET ease = EXCLUDED.ease, interval_days = EXCLUDED.interval_days,
+	               repetitions = EXCLUDED.repetitions, lapses = EXCLUDED.lapses, reviews = EXCLUDED.reviews,
+	               due_at = EXCLUDED.due_at, last_reviewed_at = EXCLUDED.last_reviewed_at`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query, card.UserID, card.QuizID, card.Ease, card.IntervalDays,
+		card.Repetitions, card.Lapses, card.Reviews, card.DueAt, card.LastReviewedAt)
+	return err
+}
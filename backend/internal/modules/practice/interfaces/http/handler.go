@@ -0,0 +1,60 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/practice/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/practice/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+)
+
+// PracticeHandler handles HTTP requests for spaced-repetition practice
+type PracticeHandler struct {
+	service application.PracticeService
+}
+
+// NewPracticeHandler creates a new PracticeHandler
+func NewPracticeHandler(service application.PracticeService) *PracticeHandler {
+	return &PracticeHandler{service: service}
+}
+
+// Next handles GET /practice/next?quiz_set_id=&limit=
+func (h *PracticeHandler) Next(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := application.NextRequest{QuizSetID: query.Get("quiz_set_id")}
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			dto.ErrorFromAppError(w, domain.ErrInvalidLimit)
+			return
+		}
+		req.Limit = n
+	}
+
+	next, err := h.service.Next(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, next)
+}
+
+// Answer handles POST /practice/answers
+func (h *PracticeHandler) Answer(w http.ResponseWriter, r *http.Request) {
+	var req application.AnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	resp, err := h.service.Answer(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, resp)
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/modules/practice/application"
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterRoutes registers all practice module routes
+func RegisterRoutes(r chi.Router, service application.PracticeService) {
+	handler := NewPracticeHandler(service)
+
+	r.Route("/practice", func(r chi.Router) {
+		r.Get("/next", handler.Next)
+		r.Post("/answers", handler.Answer)
+	})
+}
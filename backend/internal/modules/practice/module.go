@@ -0,0 +1,30 @@
+package practice
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/practice/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/practice/infrastructure"
+	httpinterface "github.com/cananga-odorata/golang-template/internal/modules/practice/interfaces/http"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Module represents the practice module with all its dependencies
+type Module struct {
+	Service application.PracticeService
+}
+
+// NewModule initializes the practice module with all dependencies
+func NewModule(db *sqlx.DB) *Module {
+	repo := infrastructure.NewPostgresPracticeRepository(db)
+	txManager := database.NewTxManager(db)
+
+	return &Module{
+		Service: application.NewPracticeService(repo, txManager),
+	}
+}
+
+// RegisterRoutes registers the module's HTTP routes
+func (m *Module) RegisterRoutes(r chi.Router) {
+	httpinterface.RegisterRoutes(r, m.Service)
+}
@@ -0,0 +1,192 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+)
+
+// DefaultMaxBatchSize is used when no maximum batch size is configured
+const DefaultMaxBatchSize = 100
+
+// BatchService defines bulk quiz operations. Every batch is atomic: either
+// all items are applied or none are, and the per-item results say why.
+type BatchService interface {
+	Create(ctx context.Context, req BatchCreateRequest) (*BatchResponse, error)
+	Delete(ctx context.Context, req BatchDeleteRequest) (*BatchResponse, error)
+}
+
+type batchService struct {
+	repo      domain.QuizRepository
+	revisions domain.RevisionRepository
+	txManager database.TxManager
+	maxSize   int
+	detector  duplicateDetector
+	events    *events.EventBus
+}
+
+// NewBatchService creates a new BatchService accepting at most maxSize items
+// per batch. Created questions are checked for duplicates at least
+// duplicateThreshold similar. Changes are published on bus, which may be nil.
+func NewBatchService(repo domain.QuizRepository, revisions domain.RevisionRepository, txManager database.TxManager, maxSize int, duplicateThreshold float64, bus *events.EventBus) BatchService {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxBatchSize
+	}
+	return &batchService{repo: repo, revisions: revisions, txManager: txManager, maxSize: maxSize,
+		detector: newDuplicateDetector(repo, duplicateThreshold), events: bus}
+}
+
+// Create validates all quizzes first, then appends them after the current
+// last quiz in request order. Each quiz is checked for duplicates like a
+// single create, following its own on_duplicate; a blocked duplicate fails
+// the batch.
+func (s *batchService) Create(ctx context.Context, req BatchCreateRequest) (*BatchResponse, error) {
+	if err := s.checkSize(len(req.Quizzes)); err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchItemResult, len(req.Quizzes))
+	quizzes := make([]*domain.Quiz, len(req.Quizzes))
+	policies := make([]DuplicatePolicy, len(req.Quizzes))
+	failed := false
+	for i, item := range req.Quizzes {
+		results[i] = BatchItemResult{Index: i, Status: BatchStatusRejected}
+		quiz, err := newQuizContent(item.Question, item.Choice1, item.Choice2, item.Choice3, item.Choice4, item.Answer)
+		if err == nil {
+			policies[i], err = item.OnDuplicate.validate()
+		}
+		if err != nil {
+			results[i].Status, results[i].Error = BatchStatusFailed, toBatchItemError(err)
+			failed = true
+			continue
+		}
+		quizzes[i] = quiz
+	}
+	if failed {
+		return nil, domain.ErrBatchRejected.WithDetails(BatchResponse{Results: results})
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		maxOrder, err := s.repo.GetMaxDisplayOrder(ctx)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to get max display order", err)
+		}
+
+		for i, quiz := range quizzes {
+			// Earlier quizzes of the batch are already created in this transaction
+			duplicates, err := s.detector.find(ctx, quiz.Question)
+			if err != nil {
+				return err
+			}
+			if len(duplicates) > 0 {
+				results[i].Duplicates = duplicates
+			}
+			if len(duplicates) > 0 && policies[i] == DuplicatePolicyBlock {
+				results[i].Status, results[i].Error = BatchStatusFailed, toBatchItemError(domain.ErrDuplicateQuiz)
+				failed = true
+				continue
+			}
+
+			quiz.ID = sharedDomain.NewID()
+			quiz.DisplayOrder = maxOrder + 1 + i
+			quiz.CreatedBy = currentUser(ctx)
+			quiz.UpdatedBy = quiz.CreatedBy
+
+			if err := saveRevision(ctx, s.revisions, quiz, domain.RevisionActionCreate, nil); err != nil {
+				return err
+			}
+			if err := s.repo.Create(ctx, quiz); err != nil {
+				return sharedDomain.NewInternalError("Failed to create quiz", err)
+			}
+			publishChange(ctx, s.events, events.QuizCreatedEvent{QuizID: quiz.ID, UserID: quiz.CreatedBy})
+		}
+		if failed {
+			return domain.ErrBatchRejected.WithDetails(BatchResponse{Results: results})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, quiz := range quizzes {
+		resp := toQuizResponse(ctx, *quiz)
+		results[i] = BatchItemResult{Index: i, ID: quiz.ID, Status: BatchStatusCreated, Quiz: &resp, Duplicates: results[i].Duplicates}
+	}
+	return &BatchResponse{Results: results}, nil
+}
+
+// Delete moves all quizzes to the trash and renumbers the remaining quizzes once
+func (s *batchService) Delete(ctx context.Context, req BatchDeleteRequest) (*BatchResponse, error) {
+	if err := s.checkSize(len(req.IDs)); err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchItemResult, len(req.IDs))
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		seen := make(map[string]bool, len(req.IDs))
+		failed := false
+		for i, id := range req.IDs {
+			results[i] = BatchItemResult{Index: i, ID: id, Status: BatchStatusRejected}
+			if seen[id] {
+				results[i].Status, results[i].Error = BatchStatusFailed, toBatchItemError(domain.ErrDuplicateID)
+				failed = true
+			} else if quiz, err := s.repo.GetByID(ctx, id); err != nil {
+				results[i].Status, results[i].Error = BatchStatusFailed, toBatchItemError(domain.ErrQuizNotFound)
+				failed = true
+			} else if err := authorizeChange(ctx, quiz); err != nil {
+				results[i].Status, results[i].Error = BatchStatusFailed, toBatchItemError(err)
+				failed = true
+			}
+			seen[id] = true
+		}
+		if failed {
+			return domain.ErrBatchRejected.WithDetails(BatchResponse{Results: results})
+		}
+
+		for _, id := range req.IDs {
+			if err := s.repo.Delete(ctx, id); err != nil {
+				return sharedDomain.NewInternalError("Failed to delete quiz", err)
+			}
+		}
+		if err := s.repo.RenumberDisplayOrders(ctx); err != nil {
+			return sharedDomain.NewInternalError("Failed to renumber quizzes", err)
+		}
+		userID := currentUser(ctx)
+		for _, id := range req.IDs {
+			publishChange(ctx, s.events, events.QuizDeletedEvent{QuizID: id, UserID: userID})
+		}
+		publishChange(ctx, s.events, events.QuizzesReorderedEvent{UserID: userID})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Status = BatchStatusDeleted
+	}
+	return &BatchResponse{Results: results}, nil
+}
+
+func (s *batchService) checkSize(n int) error {
+	if n == 0 {
+		return domain.ErrEmptyBatch
+	}
+	if n > s.maxSize {
+		return domain.ErrBatchTooLarge.WithDetails(map[string]interface{}{"max_size": s.maxSize, "size": n})
+	}
+	return nil
+}
+
+func toBatchItemError(err error) *BatchItemError {
+	var appErr *sharedDomain.AppError
+	if errors.As(err, &appErr) {
+		return &BatchItemError{Code: string(appErr.Code), Message: appErr.Message}
+	}
+	return &BatchItemError{Code: string(sharedDomain.ErrCodeInternal), Message: err.Error()}
+}
@@ -0,0 +1,183 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+func validQuizRequest(n int) CreateQuizRequest {
+	return CreateQuizRequest{
+		Question: fmt.Sprintf("Question %d", n),
+		Choice1:  "A",
+		Choice2:  "B",
+		Choice3:  "C",
+		Choice4:  "D",
+	}
+}
+
+func batchRejection(t *testing.T, err error) BatchResponse {
+	t.Helper()
+	var appErr *sharedDomain.AppError
+	if !errors.As(err, &appErr) || appErr.Message != domain.ErrBatchRejected.Message {
+		t.Fatalf("expected batch rejection, got %v", err)
+	}
+	return appErr.Details.(BatchResponse)
+}
+
+// ============ Test Cases ============
+
+func TestBatchCreate_AppendsInOrder(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{{ID: "a", DisplayOrder: 1}}
+	revisions := newMockRevisionRepo()
+	service := NewBatchService(repo, revisions, passthroughTxManager{}, 10, 0, nil)
+
+	resp, err := service.Create(context.Background(), BatchCreateRequest{
+		Quizzes: []CreateQuizRequest{validQuizRequest(1), validQuizRequest(2), validQuizRequest(3)},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(resp.Results) != 3 || len(repo.quizzes) != 4 || len(revisions.revisions) != 3 {
+		t.Fatalf("expected 3 quizzes and revisions created, got %d results", len(resp.Results))
+	}
+	for i, r := range resp.Results {
+		if r.Index != i || r.Status != BatchStatusCreated || r.Quiz == nil || r.ID != r.Quiz.ID {
+			t.Errorf("unexpected result %+v", r)
+			continue
+		}
+		if r.Quiz.DisplayOrder != i+2 {
+			t.Errorf("item %d: expected display_order %d, got %d", i, i+2, r.Quiz.DisplayOrder)
+		}
+	}
+}
+
+func TestBatchCreate_InvalidItemRejectsBatch(t *testing.T) {
+	repo := newMockRepo()
+	service := NewBatchService(repo, newMockRevisionRepo(), passthroughTxManager{}, 10, 0, nil)
+
+	invalid := validQuizRequest(2)
+	invalid.Answer = 7
+	_, err := service.Create(context.Background(), BatchCreateRequest{
+		Quizzes: []CreateQuizRequest{validQuizRequest(1), invalid},
+	})
+
+	details := batchRejection(t, err)
+	if details.Results[0].Status != BatchStatusRejected || details.Results[0].Error != nil {
+		t.Errorf("expected valid item to be rejected without error, got %+v", details.Results[0])
+	}
+	if details.Results[1].Status != BatchStatusFailed || details.Results[1].Error.Message != domain.ErrInvalidAnswer.Message {
+		t.Errorf("expected invalid answer error, got %+v", details.Results[1])
+	}
+	if len(repo.quizzes) != 0 {
+		t.Errorf("expected nothing created, got %d", len(repo.quizzes))
+	}
+}
+
+func TestBatchCreate_ChecksDuplicates(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{{ID: "a", Question: "Question 1", DisplayOrder: 1}}
+	service := NewBatchService(repo, newMockRevisionRepo(), passthroughTxManager{}, 10, 0, nil)
+
+	resp, err := service.Create(context.Background(), BatchCreateRequest{
+		Quizzes: []CreateQuizRequest{validQuizRequest(1), validQuizRequest(2)},
+	})
+	if err != nil {
+		t.Fatalf("expected duplicates to be reported by default, got: %v", err)
+	}
+	if len(resp.Results[0].Duplicates) != 1 || resp.Results[0].Duplicates[0].ID != "a" || len(resp.Results[1].Duplicates) != 0 {
+		t.Errorf("expected only the first item reported as a duplicate of a, got %+v", resp.Results)
+	}
+
+	// Earlier items of the same batch count as existing quizzes
+	blocked := validQuizRequest(3)
+	blocked.OnDuplicate = DuplicatePolicyBlock
+	_, err = service.Create(context.Background(), BatchCreateRequest{
+		Quizzes: []CreateQuizRequest{validQuizRequest(3), blocked},
+	})
+	details := batchRejection(t, err)
+	if details.Results[0].Status != BatchStatusRejected {
+		t.Errorf("expected the first item rejected, got %+v", details.Results[0])
+	}
+	if r := details.Results[1]; r.Status != BatchStatusFailed || r.Error.Message != domain.ErrDuplicateQuiz.Message || len(r.Duplicates) != 1 {
+		t.Errorf("expected the blocked duplicate to fail with its match, got %+v", r)
+	}
+
+	invalid := validQuizRequest(4)
+	invalid.OnDuplicate = "ignore"
+	_, err = service.Create(context.Background(), BatchCreateRequest{Quizzes: []CreateQuizRequest{invalid}})
+	if r := batchRejection(t, err).Results[0]; r.Status != BatchStatusFailed || r.Error.Message != domain.ErrInvalidDuplicatePolicy.Message {
+		t.Errorf("expected an invalid on_duplicate to fail the item, got %+v", r)
+	}
+}
+
+func TestBatch_SizeLimits(t *testing.T) {
+	service := NewBatchService(newMockRepo(), newMockRevisionRepo(), passthroughTxManager{}, 2, 0, nil)
+
+	if _, err := service.Create(context.Background(), BatchCreateRequest{}); !errors.Is(err, domain.ErrEmptyBatch) {
+		t.Errorf("expected ErrEmptyBatch, got %v", err)
+	}
+
+	_, err := service.Delete(context.Background(), BatchDeleteRequest{IDs: []string{"a", "b", "c"}})
+	var appErr *sharedDomain.AppError
+	if !errors.As(err, &appErr) || appErr.Message != domain.ErrBatchTooLarge.Message {
+		t.Fatalf("expected ErrBatchTooLarge, got %v", err)
+	}
+	if appErr.Details.(map[string]interface{})["max_size"] != 2 {
+		t.Errorf("expected max_size in details, got %v", appErr.Details)
+	}
+}
+
+func TestBatchDelete_RenumbersOnce(t *testing.T) {
+	repo := newMockRepo()
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		repo.quizzes = append(repo.quizzes, domain.Quiz{ID: id, DisplayOrder: i + 1})
+	}
+	service := NewBatchService(repo, newMockRevisionRepo(), passthroughTxManager{}, 10, 0, nil)
+
+	resp, err := service.Delete(context.Background(), BatchDeleteRequest{IDs: []string{"d", "b"}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for _, r := range resp.Results {
+		if r.Status != BatchStatusDeleted {
+			t.Errorf("unexpected result %+v", r)
+		}
+	}
+	if repo.renumbered != 1 || len(repo.trash) != 2 {
+		t.Errorf("expected one renumbering pass and 2 trashed quizzes, got %d and %d", repo.renumbered, len(repo.trash))
+	}
+	want := map[string]int{"a": 1, "c": 2, "e": 3}
+	for _, q := range repo.quizzes {
+		if want[q.ID] != q.DisplayOrder {
+			t.Errorf("quiz %s: expected display_order %d, got %d", q.ID, want[q.ID], q.DisplayOrder)
+		}
+	}
+}
+
+func TestBatchDelete_UnknownAndDuplicateIDsRejectBatch(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{{ID: "a", DisplayOrder: 1}, {ID: "b", DisplayOrder: 2}}
+	service := NewBatchService(repo, newMockRevisionRepo(), passthroughTxManager{}, 10, 0, nil)
+
+	_, err := service.Delete(context.Background(), BatchDeleteRequest{IDs: []string{"a", "missing", "a"}})
+
+	details := batchRejection(t, err)
+	statuses := []string{details.Results[0].Status, details.Results[1].Status, details.Results[2].Status}
+	if statuses[0] != BatchStatusRejected || statuses[1] != BatchStatusFailed || statuses[2] != BatchStatusFailed {
+		t.Errorf("unexpected statuses %v", statuses)
+	}
+	if details.Results[2].Error.Message != domain.ErrDuplicateID.Message {
+		t.Errorf("expected duplicate error, got %+v", details.Results[2].Error)
+	}
+	if len(repo.quizzes) != 2 || repo.renumbered != 0 {
+		t.Errorf("expected nothing deleted, got %d quizzes", len(repo.quizzes))
+	}
+}
@@ -0,0 +1,168 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// CloneService defines copying quizzes into new drafts
+type CloneService interface {
+	Clone(ctx context.Context, id string) (*CloneQuizResponse, error)
+	CloneQuizzes(ctx context.Context, ids []string) (map[string]string, error)
+}
+
+type cloneService struct {
+	repo         domain.QuizRepository
+	revisions    domain.RevisionRepository
+	media        domain.MediaRepository
+	translations domain.TranslationRepository
+	hints        domain.HintRepository
+	txManager    database.TxManager
+	events       *events.EventBus
+}
+
+// NewCloneService creates a new CloneService. Clones are published on bus,
+// which may be nil.
+func NewCloneService(repo domain.QuizRepository, revisions domain.RevisionRepository, media domain.MediaRepository, translations domain.TranslationRepository, hints domain.HintRepository, txManager database.TxManager, bus *events.EventBus) CloneService {
+	return &cloneService{repo: repo, revisions: revisions, media: media, translations: translations, hints: hints, txManager: txManager, events: bus}
+}
+
+// Clone copies a quiz, its media, translations and hints into a new draft
+// after the current last quiz
+func (s *cloneService) Clone(ctx context.Context, id string) (*CloneQuizResponse, error) {
+	var clone *domain.Quiz
+	var ids map[string]string
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		ids, err = s.CloneQuizzes(ctx, []string{id})
+		if err != nil {
+			return err
+		}
+		clone, err = s.repo.GetByID(ctx, ids[id])
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to fetch cloned quiz", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloneQuizResponse{Quiz: toQuizResponse(ctx, *clone), IDMap: ids}, nil
+}
+
+// CloneQuizzes copies quizzes, their media, translations and hints into new
+// drafts appended in the given order; repeated IDs are copied once. Since a
+// clone carries the answer, only the authors of every quiz and admins may
+// clone them. The clones belong to the current user and have no schedule.
+// It returns the new ID of every copied quiz and media file keyed by the old one.
+func (s *cloneService) CloneQuizzes(ctx context.Context, ids []string) (map[string]string, error) {
+	if _, ok := utils.GetUserID(ctx); !ok && !utils.IsAdmin(ctx) {
+		return nil, domain.ErrCloneSignIn
+	}
+
+	newIDs := make(map[string]string, len(ids))
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		sources := make([]*domain.Quiz, 0, len(ids))
+		for _, id := range ids {
+			if _, ok := newIDs[id]; ok {
+				continue
+			}
+			quiz, err := s.repo.GetByID(ctx, id)
+			if err != nil {
+				return domain.ErrQuizNotFound
+			}
+			if !canSeeAnswer(ctx, *quiz) {
+				return domain.ErrCloneNotOwner
+			}
+			sources = append(sources, quiz)
+			newIDs[id] = sharedDomain.NewID()
+		}
+
+		maxOrder, err := s.repo.GetMaxDisplayOrder(ctx)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to get max display order", err)
+		}
+
+		for i, source := range sources {
+			if err := s.clone(ctx, source, newIDs, maxOrder+1+i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newIDs, nil
+}
+
+// clone copies one quiz to the ID already chosen for it in newIDs, adding
+// the IDs of its copied media
+func (s *cloneService) clone(ctx context.Context, source *domain.Quiz, newIDs map[string]string, order int) error {
+	quiz := &domain.Quiz{
+		ID:           newIDs[source.ID],
+		Question:     source.Question,
+		Choice1:      source.Choice1,
+		Choice2:      source.Choice2,
+		Choice3:      source.Choice3,
+		Choice4:      source.Choice4,
+		Answer:       source.Answer,
+		DisplayOrder: order,
+		Status:       domain.StatusDraft,
+		CreatedBy:    currentUser(ctx),
+	}
+	quiz.UpdatedBy = quiz.CreatedBy
+
+	if err := saveRevision(ctx, s.revisions, quiz, domain.RevisionActionCreate, nil); err != nil {
+		return err
+	}
+	if err := s.repo.Create(ctx, quiz); err != nil {
+		return sharedDomain.NewInternalError("Failed to create quiz", err)
+	}
+
+	media, err := s.media.ListByQuizIDs(ctx, []string{source.ID})
+	if err != nil {
+		return sharedDomain.NewInternalError("Failed to fetch quiz media", err)
+	}
+	for _, m := range media {
+		oldID := m.ID
+		m.ID, m.QuizID = sharedDomain.NewID(), quiz.ID
+		if err := s.media.Create(ctx, &m); err != nil {
+			return sharedDomain.NewInternalError("Failed to store quiz media", err)
+		}
+		newIDs[oldID] = m.ID
+	}
+
+	translations, err := s.translations.ListByQuizID(ctx, source.ID)
+	if err != nil {
+		return sharedDomain.NewInternalError("Failed to fetch translations", err)
+	}
+	for _, t := range translations {
+		// Translations that were up to date stay up to date on the copy
+		if t.SourceRevisionID == source.RevisionID {
+			t.SourceRevisionID = quiz.RevisionID
+		}
+		t.QuizID = quiz.ID
+		if err := s.translations.Upsert(ctx, &t); err != nil {
+			return sharedDomain.NewInternalError("Failed to save translation", err)
+		}
+	}
+
+	hints, err := s.hints.ListByQuizID(ctx, source.ID)
+	if err != nil {
+		return sharedDomain.NewInternalError("Failed to fetch hints", err)
+	}
+	if len(hints) > 0 {
+		if err := s.hints.Replace(ctx, quiz.ID, hints); err != nil {
+			return sharedDomain.NewInternalError("Failed to save hints", err)
+		}
+	}
+	publishChange(ctx, s.events, events.QuizCreatedEvent{QuizID: quiz.ID, UserID: quiz.CreatedBy})
+	return nil
+}
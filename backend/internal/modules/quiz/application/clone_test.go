@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+func TestCloneQuiz_CopiesContentMediaTranslationsAndHints(t *testing.T) {
+	alice := "alice"
+	repo := newMockRepo()
+	repo.getMaxOrderResp = 2
+	repo.quizzes = []domain.Quiz{{ID: "q1", Question: "Q", Choice1: "a", Choice2: "b", Choice3: "c", Choice4: "d", Answer: 3,
+		DisplayOrder: 1, RevisionID: "r1", Status: domain.StatusPublished, CreatedBy: &alice}}
+	media := &mockMediaRepository{media: []domain.Media{{ID: "m1", QuizID: "q1", Filename: "a.png", Data: []byte("png")}}}
+	translations := &mockTranslationRepository{quizzes: repo, translations: []domain.Translation{
+		{QuizID: "q1", Locale: "en", Question: "Q en", SourceRevisionID: "r1"},
+		{QuizID: "q1", Locale: "ja", Question: "Q ja", SourceRevisionID: "r0"},
+	}}
+	hints := &mockHintRepository{hints: []domain.Hint{{QuizID: "q1", Position: 1, Body: "Think", Penalty: 0.5}}}
+	service := NewCloneService(repo, newMockRevisionRepo(), media, translations, hints, passthroughTxManager{}, nil)
+
+	resp, err := service.Clone(signedIn("alice", "user"), "q1")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	clone := resp.Quiz
+	if clone.ID == "q1" || resp.IDMap["q1"] != clone.ID {
+		t.Fatalf("expected id_map to point q1 at the clone, got %v for %s", resp.IDMap, clone.ID)
+	}
+	if clone.Question != "Q" || clone.Choice3 != "c" || clone.Answer != 3 || clone.DisplayOrder != 3 {
+		t.Errorf("expected copied content after the last quiz, got %+v", clone)
+	}
+	if clone.Status != domain.StatusDraft || clone.CreatedBy == nil || *clone.CreatedBy != "alice" || clone.RevisionID == "" {
+		t.Errorf("expected a new draft by alice with its own revision, got %+v", clone)
+	}
+
+	if len(media.media) != 2 || media.media[1].QuizID != clone.ID || media.media[1].ID != resp.IDMap["m1"] || string(media.media[1].Data) != "png" {
+		t.Errorf("expected media copied to the clone, got %+v and %v", media.media, resp.IDMap)
+	}
+
+	copied, _ := translations.ListByQuizID(context.Background(), clone.ID)
+	if len(copied) != 2 {
+		t.Fatalf("expected 2 copied translations, got %d", len(copied))
+	}
+	if copied[0].SourceRevisionID != clone.RevisionID || copied[1].SourceRevisionID != "r0" {
+		t.Errorf("expected only the current translation to stay current, got %+v", copied)
+	}
+
+	if copiedHints, _ := hints.ListByQuizID(context.Background(), clone.ID); len(copiedHints) != 1 || copiedHints[0].Body != "Think" || copiedHints[0].QuizID != clone.ID {
+		t.Errorf("expected the hint copied to the clone, got %+v", copiedHints)
+	}
+}
+
+func TestCloneQuizzes_KeepsOrder(t *testing.T) {
+	repo := newMockRepo()
+	repo.getMaxOrderResp = 2
+	repo.quizzes = []domain.Quiz{{ID: "q1", Question: "Q1", DisplayOrder: 1}, {ID: "q2", Question: "Q2", DisplayOrder: 2}}
+	service := NewCloneService(repo, newMockRevisionRepo(), &mockMediaRepository{}, &mockTranslationRepository{quizzes: repo}, &mockHintRepository{}, passthroughTxManager{}, nil)
+
+	ids, err := service.CloneQuizzes(signedIn("admin", utils.RoleAdmin), []string{"q2", "q1", "q2"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(repo.quizzes) != 4 {
+		t.Fatalf("expected 2 clones, got %d quizzes", len(repo.quizzes))
+	}
+	if repo.quizzes[2].ID != ids["q2"] || repo.quizzes[2].DisplayOrder != 3 || repo.quizzes[3].ID != ids["q1"] || repo.quizzes[3].DisplayOrder != 4 {
+		t.Errorf("expected clones of q2 then q1 after the last quiz, got %+v", repo.quizzes[2:])
+	}
+}
+
+func TestCloneQuiz_NotFound(t *testing.T) {
+	repo := newMockRepo()
+	service := NewCloneService(repo, newMockRevisionRepo(), &mockMediaRepository{}, &mockTranslationRepository{quizzes: repo}, &mockHintRepository{}, passthroughTxManager{}, nil)
+
+	if _, err := service.Clone(signedIn("bob", "user"), "missing"); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got: %v", err)
+	}
+}
+
+func TestCloneQuiz_AuthorOrAdmin(t *testing.T) {
+	repo := ownedQuizRepo()
+	service := NewCloneService(repo, newMockRevisionRepo(), &mockMediaRepository{}, &mockTranslationRepository{quizzes: repo}, &mockHintRepository{}, passthroughTxManager{}, nil)
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		ids  []string
+		want error
+	}{
+		{"anonymous", context.Background(), []string{"q1"}, domain.ErrCloneSignIn},
+		{"other user", signedIn("bob", "user"), []string{"q1"}, domain.ErrCloneNotOwner},
+		{"reviewer", signedIn("rita", utils.RoleReviewer), []string{"q1"}, domain.ErrCloneNotOwner},
+		{"author with an unowned quiz", signedIn("alice", "user"), []string{"q1", "q2"}, domain.ErrCloneNotOwner},
+		{"author", signedIn("alice", "user"), []string{"q1"}, nil},
+		{"admin", signedIn("admin", utils.RoleAdmin), []string{"q1", "q2"}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := service.CloneQuizzes(tc.ctx, tc.ids); !errors.Is(err, tc.want) {
+				t.Errorf("expected %v, got: %v", tc.want, err)
+			}
+		})
+	}
+}
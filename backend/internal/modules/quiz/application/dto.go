@@ -1,21 +1,336 @@
 package application
 
-// CreateQuizRequest DTO for creating a new quiz
+import (
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/listquery"
+)
+
+// CreateQuizRequest DTO for creating a new quiz. OnDuplicate decides whether
+// a question duplicating an existing one is created with a warning (the
+// default) or refused.
 type CreateQuizRequest struct {
+	Question    string          `json:"question"`
+	Choice1     string          `json:"choice1"`
+	Choice2     string          `json:"choice2"`
+	Choice3     string          `json:"choice3"`
+	Choice4     string          `json:"choice4"`
+	Answer      int             `json:"answer,omitempty"`
+	OnDuplicate DuplicatePolicy `json:"on_duplicate,omitempty"`
+}
+
+// CreateQuizResponse DTO for a created quiz and the existing quizzes it duplicates
+type CreateQuizResponse struct {
+	QuizResponse
+	Duplicates []DuplicateMatch `json:"duplicates,omitempty"`
+}
+
+// CloneQuizResponse DTO for a cloned quiz. IDMap holds the new ID of the
+// quiz and of each of its media files, keyed by the old one.
+type CloneQuizResponse struct {
+	Quiz  QuizResponse      `json:"quiz"`
+	IDMap map[string]string `json:"id_map"`
+}
+
+// DuplicateMatch DTO for an existing quiz that a question duplicates
+type DuplicateMatch struct {
+	ID         string  `json:"id"`
+	Question   string  `json:"question"`
+	Similarity float64 `json:"similarity"`
+	Exact      bool    `json:"exact"`
+}
+
+// DuplicateCluster DTO for a group of quizzes with duplicate questions.
+// Exact is true when all questions are identical after normalization.
+type DuplicateCluster struct {
+	Exact         bool           `json:"exact"`
+	MinSimilarity float64        `json:"min_similarity"`
+	Quizzes       []QuizResponse `json:"quizzes"`
+}
+
+// UpdateQuizRequest DTO for replacing the content of a quiz
+type UpdateQuizRequest struct {
 	Question string `json:"question"`
 	Choice1  string `json:"choice1"`
 	Choice2  string `json:"choice2"`
 	Choice3  string `json:"choice3"`
 	Choice4  string `json:"choice4"`
+	Answer   int    `json:"answer,omitempty"`
 }
 
 // QuizResponse DTO for quiz responses
 type QuizResponse struct {
-	ID           string `json:"id"`
-	Question     string `json:"question"`
-	Choice1      string `json:"choice1"`
-	Choice2      string `json:"choice2"`
-	Choice3      string `json:"choice3"`
-	Choice4      string `json:"choice4"`
-	DisplayOrder int    `json:"display_order"`
+	ID           string        `json:"id"`
+	Question     string        `json:"question"`
+	Choice1      string        `json:"choice1"`
+	Choice2      string        `json:"choice2"`
+	Choice3      string        `json:"choice3"`
+	Choice4      string        `json:"choice4"`
+	Answer       int           `json:"answer,omitempty"`
+	DisplayOrder int           `json:"display_order"`
+	RevisionID   string        `json:"revision_id"`
+	Status       domain.Status `json:"status"`
+	CreatedBy    *string       `json:"created_by,omitempty"`
+	UpdatedBy    *string       `json:"updated_by,omitempty"`
+	sharedDomain.Schedule
+	// UnresolvedComments and PValue are set in the management listings
+	UnresolvedComments *int     `json:"unresolved_comments,omitempty"`
+	PValue             *float64 `json:"p_value,omitempty"`
+	// Locale, Explanation and TranslationOutdated are set on localized quizzes
+	Locale              string `json:"locale,omitempty"`
+	Explanation         string `json:"explanation,omitempty"`
+	TranslationOutdated bool   `json:"translation_outdated,omitempty"`
+}
+
+// ListQuizzesRequest DTO for reading one page of the quiz list. When Cursor
+// is set, Page is ignored. A nil Query lists every quiz by display_order.
+// Visible limits the list to published quizzes inside their schedule, as
+// the public listing does; other lists report unresolved reviewer threads.
+// Author limits the list to the quizzes created by a user, "me" being the
+// signed-in user.
+type ListQuizzesRequest struct {
+	Page     int
+	PageSize int
+	Cursor   string
+	Query    *listquery.Query
+	Visible  bool
+	Author   string
+}
+
+// QuizPage holds one page of the quiz list. Pagination.Page is 0 when the
+// page was read with a cursor.
+type QuizPage struct {
+	Items      []QuizResponse
+	Pagination sharedDomain.Pagination
+	NextCursor string
+	PrevCursor string
+}
+
+// ImportResult DTO for the outcome of an import
+type ImportResult struct {
+	Imported   []QuizResponse       `json:"imported"`
+	Skipped    []domain.ImportIssue `json:"skipped"`
+	Duplicates []ImportDuplicate    `json:"duplicates,omitempty"`
+}
+
+// ImportDuplicate DTO for an imported quiz that duplicates an existing one.
+// Index is its position in ImportResult.Imported.
+type ImportDuplicate struct {
+	Index   int              `json:"index"`
+	Line    int              `json:"line,omitempty"`
+	Matches []DuplicateMatch `json:"matches"`
+}
+
+// ExportResult holds an encoded export document
+type ExportResult struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// MediaResponse DTO for quiz media metadata
+type MediaResponse struct {
+	ID          string `json:"id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+}
+
+// RevisionResponse DTO for a quiz revision
+type RevisionResponse struct {
+	ID           string    `json:"id"`
+	QuizID       string    `json:"quiz_id"`
+	Number       int       `json:"number"`
+	Question     string    `json:"question"`
+	Choice1      string    `json:"choice1"`
+	Choice2      string    `json:"choice2"`
+	Choice3      string    `json:"choice3"`
+	Choice4      string    `json:"choice4"`
+	Answer       int       `json:"answer,omitempty"`
+	Action       string    `json:"action"`
+	RevertedFrom *string   `json:"reverted_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RevisionRef DTO identifying a revision in a diff
+type RevisionRef struct {
+	ID        string    `json:"id"`
+	Number    int       `json:"number"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RevisionDiffResponse DTO for the field-level difference between two revisions
+type RevisionDiffResponse struct {
+	From    RevisionRef          `json:"from"`
+	To      RevisionRef          `json:"to"`
+	Changes []domain.FieldChange `json:"changes"`
+}
+
+// ScheduleRequest DTO for setting the publication window of a quiz. Times
+// are RFC 3339, or local times such as 2025-03-01T09:00 read in the schedule
+// time zone. An empty bound leaves that side of the window open.
+type ScheduleRequest struct {
+	PublishAt   string `json:"publish_at"`
+	UnpublishAt string `json:"unpublish_at"`
+}
+
+// TransitionRequest DTO for a workflow action. A comment is required to reject.
+type TransitionRequest struct {
+	Comment string `json:"comment"`
+}
+
+// TransitionResponse DTO for one recorded status change of a quiz
+type TransitionResponse struct {
+	ID         string        `json:"id"`
+	Action     domain.Action `json:"action"`
+	FromStatus domain.Status `json:"from_status"`
+	ToStatus   domain.Status `json:"to_status"`
+	Comment    string        `json:"comment,omitempty"`
+	ActorID    *string       `json:"actor_id,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// PutTranslationRequest DTO for creating or replacing a translation of a quiz
+type PutTranslationRequest struct {
+	Question    string `json:"question"`
+	Choice1     string `json:"choice1"`
+	Choice2     string `json:"choice2"`
+	Choice3     string `json:"choice3"`
+	Choice4     string `json:"choice4"`
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// TranslationResponse DTO for a translation of a quiz. Outdated is true when
+// the quiz has been edited since it was translated.
+type TranslationResponse struct {
+	Locale           string    `json:"locale"`
+	Question         string    `json:"question"`
+	Choice1          string    `json:"choice1"`
+	Choice2          string    `json:"choice2"`
+	Choice3          string    `json:"choice3"`
+	Choice4          string    `json:"choice4"`
+	Explanation      string    `json:"explanation,omitempty"`
+	SourceRevisionID string    `json:"source_revision_id"`
+	Outdated         bool      `json:"outdated"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// PutHintsRequest DTO for replacing the hints of a quiz, in the order they
+// are revealed
+type PutHintsRequest struct {
+	Hints []HintRequest `json:"hints"`
+}
+
+// HintRequest DTO for one hint. Penalty is the part of the question's
+// point a learner loses for revealing it.
+type HintRequest struct {
+	Body    string  `json:"body"`
+	Penalty float64 `json:"penalty"`
+}
+
+// HintResponse DTO for a hint of a quiz
+type HintResponse struct {
+	Position int     `json:"position"`
+	Body     string  `json:"body"`
+	Penalty  float64 `json:"penalty"`
+}
+
+func toHintResponses(hints []domain.Hint) []HintResponse {
+	responses := make([]HintResponse, len(hints))
+	for i, h := range hints {
+		responses[i] = HintResponse{Position: h.Position, Body: h.Body, Penalty: h.Penalty}
+	}
+	return responses
+}
+
+// Translation statuses of untranslated quizzes
+const (
+	TranslationStatusMissing  = "missing"
+	TranslationStatusOutdated = "outdated"
+)
+
+// UntranslatedQuizResponse DTO for a quiz without an up-to-date translation
+type UntranslatedQuizResponse struct {
+	QuizResponse
+	Status string `json:"status"`
+}
+
+// TrashedQuizResponse DTO for a quiz in the trash
+type TrashedQuizResponse struct {
+	QuizResponse
+	DeletedAt time.Time `json:"deleted_at"`
+	// PurgeAt is when the quiz will be removed permanently; nil when purging is disabled
+	PurgeAt *time.Time `json:"purge_at,omitempty"`
+}
+
+// RestoreQuizRequest DTO for taking a quiz out of the trash
+type RestoreQuizRequest struct {
+	// Position is "original" (default) to return to its previous slot, or "end"
+	Position string `json:"position"`
+}
+
+// BatchCreateRequest DTO for creating several quizzes at once
+type BatchCreateRequest struct {
+	Quizzes []CreateQuizRequest `json:"quizzes"`
+}
+
+// BatchDeleteRequest DTO for deleting several quizzes at once
+type BatchDeleteRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// Batch item statuses
+const (
+	BatchStatusCreated  = "created"
+	BatchStatusDeleted  = "deleted"
+	BatchStatusFailed   = "failed"
+	BatchStatusRejected = "rejected" // valid, but not applied because another item failed
+)
+
+// BatchItemResult DTO for the outcome of one item of a batch
+type BatchItemResult struct {
+	Index  int             `json:"index"`
+	ID     string          `json:"id,omitempty"`
+	Status string          `json:"status"`
+	Quiz   *QuizResponse   `json:"quiz,omitempty"`
+	Error  *BatchItemError `json:"error,omitempty"`
+	// Duplicates are the existing quizzes a created question duplicates
+	Duplicates []DuplicateMatch `json:"duplicates,omitempty"`
+}
+
+// BatchItemError DTO describing why an item of a batch failed
+type BatchItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// BatchResponse DTO for the per-item results of a batch
+type BatchResponse struct {
+	Results []BatchItemResult `json:"results"`
+}
+
+// SearchResultResponse DTO for a quiz found by search
+type SearchResultResponse struct {
+	QuizResponse
+	Rank       float64           `json:"rank"`
+	Highlights []SearchHighlight `json:"highlights"`
+}
+
+// SearchHighlight DTO for a matching field. Snippet is HTML-escaped text
+// with matches wrapped in <mark> tags.
+type SearchHighlight struct {
+	Field   string `json:"field"`
+	Snippet string `json:"snippet"`
+}
+
+// ChangeEvent DTO for a change to the quiz list sent on the event stream.
+// UserID is who made the change, when known.
+type ChangeEvent struct {
+	ID     string    `json:"id,omitempty"`
+	Type   string    `json:"type"`
+	QuizID string    `json:"quiz_id,omitempty"`
+	UserID *string   `json:"user_id,omitempty"`
+	At     time.Time `json:"at"`
 }
@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// DuplicatePolicy decides what happens when a new quiz duplicates an existing one
+type DuplicatePolicy string
+
+const (
+	// DuplicatePolicyWarn creates the quiz and reports the duplicates (the default)
+	DuplicatePolicyWarn DuplicatePolicy = "warn"
+	// DuplicatePolicyBlock refuses to create the quiz
+	DuplicatePolicyBlock DuplicatePolicy = "block"
+)
+
+// Similarity thresholds for near-duplicates. The trigram index only finds
+// candidates at least MinDuplicateThreshold similar, so lower thresholds are
+// rejected.
+const (
+	DefaultDuplicateThreshold = 0.6
+	MinDuplicateThreshold     = 0.3
+)
+
+// maxDuplicateMatches limits the duplicates reported for one question
+const maxDuplicateMatches = 5
+
+func (p DuplicatePolicy) validate() (DuplicatePolicy, error) {
+	switch p {
+	case "":
+		return DuplicatePolicyWarn, nil
+	case DuplicatePolicyWarn, DuplicatePolicyBlock:
+		return p, nil
+	default:
+		return "", domain.ErrInvalidDuplicatePolicy
+	}
+}
+
+func validThreshold(threshold float64) bool {
+	return threshold >= MinDuplicateThreshold && threshold <= 1
+}
+
+// duplicateDetector finds existing quizzes that a new question duplicates
+type duplicateDetector struct {
+	repo      domain.QuizRepository
+	threshold float64
+}
+
+func newDuplicateDetector(repo domain.QuizRepository, threshold float64) duplicateDetector {
+	if !validThreshold(threshold) {
+		threshold = DefaultDuplicateThreshold
+	}
+	return duplicateDetector{repo: repo, threshold: threshold}
+}
+
+// find returns the existing quizzes that question duplicates, exact matches first
+func (d duplicateDetector) find(ctx context.Context, question string) ([]DuplicateMatch, error) {
+	duplicates, err := d.repo.FindDuplicates(ctx, question, d.threshold, maxDuplicateMatches)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to check for duplicate quizzes", err)
+	}
+	matches := make([]DuplicateMatch, len(duplicates))
+	for i, dup := range duplicates {
+		matches[i] = DuplicateMatch{ID: dup.ID, Question: dup.Question, Similarity: dup.Similarity, Exact: dup.Exact}
+	}
+	return matches, nil
+}
+
+// DuplicateService reports duplicate questions already in the quiz bank
+type DuplicateService interface {
+	Report(ctx context.Context, threshold float64) ([]DuplicateCluster, error)
+}
+
+type duplicateService struct {
+	repo      domain.QuizRepository
+	threshold float64
+}
+
+// NewDuplicateService creates a new DuplicateService using threshold when a
+// report does not ask for one
+func NewDuplicateService(repo domain.QuizRepository, threshold float64) DuplicateService {
+	return &duplicateService{repo: repo, threshold: newDuplicateDetector(repo, threshold).threshold}
+}
+
+// Report groups quizzes into clusters of duplicates. Quizzes are in the same
+// cluster when a chain of matching pairs connects them, so two quizzes in a
+// cluster need not match each other directly. A threshold of 0 uses the
+// configured one.
+func (s *duplicateService) Report(ctx context.Context, threshold float64) ([]DuplicateCluster, error) {
+	if threshold == 0 {
+		threshold = s.threshold
+	}
+	if !validThreshold(threshold) {
+		return nil, domain.ErrInvalidThreshold
+	}
+
+	pairs, err := s.repo.FindDuplicatePairs(ctx, threshold)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to find duplicate quizzes", err)
+	}
+	if len(pairs) == 0 {
+		return []DuplicateCluster{}, nil
+	}
+	quizzes, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quizzes", err)
+	}
+
+	// Union-find over the pairs
+	parent := map[string]string{}
+	var find func(id string) string
+	find = func(id string) string {
+		if p, ok := parent[id]; ok && p != id {
+			parent[id] = find(p)
+			return parent[id]
+		}
+		parent[id] = id
+		return id
+	}
+	for _, p := range pairs {
+		parent[find(p.QuizID)] = find(p.OtherID)
+	}
+
+	clusters := map[string]*DuplicateCluster{}
+	for _, p := range pairs {
+		root := find(p.QuizID)
+		c, ok := clusters[root]
+		if !ok {
+			c = &DuplicateCluster{Exact: true, MinSimilarity: p.Similarity}
+			clusters[root] = c
+		}
+		c.Exact = c.Exact && p.Exact
+		c.MinSimilarity = min(c.MinSimilarity, p.Similarity)
+	}
+	// GetAll is in display order, so each cluster lists its quizzes in that order
+	for _, q := range quizzes {
+		if _, ok := parent[q.ID]; !ok {
+			continue
+		}
+		c := clusters[find(q.ID)]
+		c.Quizzes = append(c.Quizzes, toQuizResponse(ctx, q))
+	}
+
+	report := make([]DuplicateCluster, 0, len(clusters))
+	for _, c := range clusters {
+		if len(c.Quizzes) > 1 {
+			report = append(report, *c)
+		}
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if len(report[i].Quizzes) != len(report[j].Quizzes) {
+			return len(report[i].Quizzes) > len(report[j].Quizzes)
+		}
+		return report[i].Quizzes[0].DisplayOrder < report[j].Quizzes[0].DisplayOrder
+	})
+	return report, nil
+}
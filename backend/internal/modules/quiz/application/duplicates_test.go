@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+func newDuplicateRepo() *mockQuizRepository {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{
+		{ID: "a", Question: "What is the capital of Thailand?", DisplayOrder: 1},
+		{ID: "b", Question: "Which city is Thailand's capital?", DisplayOrder: 2},
+		{ID: "c", Question: "2 + 2 = ?", DisplayOrder: 3},
+		{ID: "d", Question: "what is the  capital of thailand", DisplayOrder: 4},
+	}
+	repo.similar = map[[2]string]float64{
+		{"which city is thailand s capital", "what is the capital of thailand"}: 0.7,
+	}
+	return repo
+}
+
+func newDuplicateQuiz(question string, policy DuplicatePolicy) CreateQuizRequest {
+	return CreateQuizRequest{Question: question, Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", OnDuplicate: policy}
+}
+
+// ============ Test Cases ============
+
+func TestCreateQuiz_WarnsAboutDuplicates(t *testing.T) {
+	repo := newDuplicateRepo()
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	resp, err := service.Create(context.Background(), newDuplicateQuiz("WHAT is the capital of Thailand!", ""))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(repo.quizzes) != 5 {
+		t.Error("expected the quiz to be created despite duplicates")
+	}
+	var ids []string
+	for _, d := range resp.Duplicates {
+		ids = append(ids, d.ID)
+	}
+	if strings.Join(ids, ",") != "a,d,b" || !resp.Duplicates[0].Exact || resp.Duplicates[2].Exact {
+		t.Errorf("expected exact duplicates a,d then near-duplicate b, got %+v", resp.Duplicates)
+	}
+}
+
+func TestCreateQuiz_NoDuplicates(t *testing.T) {
+	repo := newDuplicateRepo()
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0.8, nil)
+
+	resp, err := service.Create(context.Background(), newDuplicateQuiz("Name a Thai dessert", DuplicatePolicyBlock))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(resp.Duplicates) != 0 {
+		t.Errorf("expected no duplicates below the threshold, got %+v", resp.Duplicates)
+	}
+}
+
+func TestCreateQuiz_BlocksDuplicates(t *testing.T) {
+	repo := newDuplicateRepo()
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	_, err := service.Create(context.Background(), newDuplicateQuiz("2+2=?", DuplicatePolicyBlock))
+
+	var appErr *sharedDomain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != sharedDomain.ErrCodeConflict || appErr.Message != domain.ErrDuplicateQuiz.Message {
+		t.Fatalf("expected ErrDuplicateQuiz, got %v", err)
+	}
+	details, _ := appErr.Details.(map[string]interface{})
+	if matches, _ := details["duplicates"].([]DuplicateMatch); len(matches) != 1 || matches[0].ID != "c" {
+		t.Errorf("expected quiz c in the details, got %v", appErr.Details)
+	}
+	if len(repo.quizzes) != 4 {
+		t.Error("expected no quiz to be created")
+	}
+}
+
+func TestCreateQuiz_InvalidDuplicatePolicy(t *testing.T) {
+	service := NewQuizService(newDuplicateRepo(), newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	if _, err := service.Create(context.Background(), newDuplicateQuiz("Q", "ignore")); !errors.Is(err, domain.ErrInvalidDuplicatePolicy) {
+		t.Errorf("expected ErrInvalidDuplicatePolicy, got %v", err)
+	}
+}
+
+func TestImport_Duplicates(t *testing.T) {
+	doc := &domain.ImportDocument{Quizzes: []domain.ImportedQuiz{
+		{QuizBundle: domain.QuizBundle{Quiz: domain.Quiz{Question: "2 + 2 = ?", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D"}}, Line: 1},
+		{QuizBundle: domain.QuizBundle{Quiz: domain.Quiz{Question: "Capital of Laos?", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D"}}, Line: 7},
+		{QuizBundle: domain.QuizBundle{Quiz: domain.Quiz{Question: "capital of laos", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D"}}, Line: 13},
+	}}
+
+	t.Run("warn", func(t *testing.T) {
+		repo := newDuplicateRepo()
+		service := NewInterchangeService(repo, newMockRevisionRepo(), &mockMediaRepository{}, passthroughTxManager{}, []domain.QuizCodec{&stubCodec{doc: doc}}, 0, nil)
+
+		result, err := service.Import(context.Background(), "stub", strings.NewReader(""), DuplicatePolicyWarn)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(result.Imported) != 3 || len(result.Duplicates) != 2 {
+			t.Fatalf("expected 3 imported with 2 duplicates, got %d and %+v", len(result.Imported), result.Duplicates)
+		}
+		// The third question duplicates the second one from the same document
+		if d := result.Duplicates[1]; d.Index != 2 || d.Line != 13 || d.Matches[0].ID != result.Imported[1].ID {
+			t.Errorf("expected line 13 to duplicate the quiz from line 7, got %+v", d)
+		}
+	})
+
+	t.Run("block", func(t *testing.T) {
+		repo := newDuplicateRepo()
+		service := NewInterchangeService(repo, newMockRevisionRepo(), &mockMediaRepository{}, passthroughTxManager{}, []domain.QuizCodec{&stubCodec{doc: doc}}, 0, nil)
+
+		result, err := service.Import(context.Background(), "stub", strings.NewReader(""), DuplicatePolicyBlock)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(result.Imported) != 1 || result.Imported[0].Question != "Capital of Laos?" {
+			t.Fatalf("expected only the unique question to be imported, got %+v", result.Imported)
+		}
+		if len(result.Skipped) != 2 || result.Skipped[0].Line != 1 || result.Skipped[1].Line != 13 {
+			t.Errorf("expected lines 1 and 13 to be skipped, got %+v", result.Skipped)
+		}
+	})
+}
+
+func TestDuplicateReport_Clusters(t *testing.T) {
+	service := NewDuplicateService(newDuplicateRepo(), 0)
+
+	report, err := service.Report(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(report) != 1 || len(report[0].Quizzes) != 3 {
+		t.Fatalf("expected one cluster of a, b and d, got %+v", report)
+	}
+	if report[0].Exact || report[0].MinSimilarity != 0.7 {
+		t.Errorf("expected a near-duplicate cluster, got %+v", report[0])
+	}
+
+	// Above the near-duplicate similarity only the exact duplicates remain
+	report, _ = service.Report(context.Background(), 0.9)
+	if len(report) != 1 || len(report[0].Quizzes) != 2 || !report[0].Exact {
+		t.Errorf("expected one exact cluster of a and d, got %+v", report)
+	}
+}
+
+func TestDuplicateReport_InvalidThreshold(t *testing.T) {
+	service := NewDuplicateService(newDuplicateRepo(), 0)
+
+	for _, threshold := range []float64{0.1, 1.5, -1} {
+		if _, err := service.Report(context.Background(), threshold); !errors.Is(err, domain.ErrInvalidThreshold) {
+			t.Errorf("threshold %v: expected ErrInvalidThreshold, got %v", threshold, err)
+		}
+	}
+}
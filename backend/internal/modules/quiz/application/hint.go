@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// maxTotalPenalty is the point a question is worth; hints cannot cost more
+const maxTotalPenalty = 1
+
+// HintService defines managing the progressive hints of quizzes
+type HintService interface {
+	List(ctx context.Context, quizID string) ([]HintResponse, error)
+	Put(ctx context.Context, quizID string, req PutHintsRequest) ([]HintResponse, error)
+}
+
+type hintService struct {
+	repo      domain.QuizRepository
+	hints     domain.HintRepository
+	txManager database.TxManager
+}
+
+// NewHintService creates a new HintService
+func NewHintService(repo domain.QuizRepository, hints domain.HintRepository, txManager database.TxManager) HintService {
+	return &hintService{repo: repo, hints: hints, txManager: txManager}
+}
+
+// List returns the hints of a quiz in the order they are revealed. Hints
+// help answer the quiz, so only those who may change it can read them here;
+// learners reveal them one at a time in attempts.
+func (s *hintService) List(ctx context.Context, quizID string) ([]HintResponse, error) {
+	quiz, err := s.repo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, domain.ErrQuizNotFound
+	}
+	if err := authorizeChange(ctx, quiz); err != nil {
+		return nil, err
+	}
+
+	hints, err := s.hints.ListByQuizID(ctx, quiz.ID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch hints", err)
+	}
+	return toHintResponses(hints), nil
+}
+
+// Put replaces the hints of a quiz; an empty list removes them. Attempts
+// keep the hints and penalties they already revealed.
+func (s *hintService) Put(ctx context.Context, quizID string, req PutHintsRequest) ([]HintResponse, error) {
+	if len(req.Hints) > domain.MaxHints {
+		return nil, domain.ErrTooManyHints
+	}
+	hints := make([]domain.Hint, len(req.Hints))
+	var total float64
+	for i, h := range req.Hints {
+		body := strings.TrimSpace(h.Body)
+		if body == "" || h.Penalty < 0 || h.Penalty > maxTotalPenalty {
+			return nil, domain.ErrInvalidHint
+		}
+		total += h.Penalty
+		hints[i] = domain.Hint{Position: i + 1, Body: body, Penalty: h.Penalty}
+	}
+	// Tolerate rounding, e.g. three penalties of 0.333...
+	if total > maxTotalPenalty+1e-9 {
+		return nil, domain.ErrHintPenalties
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		quiz, err := s.repo.GetByID(ctx, quizID)
+		if err != nil {
+			return domain.ErrQuizNotFound
+		}
+		if err := authorizeChange(ctx, quiz); err != nil {
+			return err
+		}
+		for i := range hints {
+			hints[i].QuizID = quiz.ID
+		}
+		if err := s.hints.Replace(ctx, quiz.ID, hints); err != nil {
+			return sharedDomain.NewInternalError("Failed to save hints", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toHintResponses(hints), nil
+}
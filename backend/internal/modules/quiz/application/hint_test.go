@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+// mockHintRepository is an in-memory implementation of domain.HintRepository
+type mockHintRepository struct {
+	hints []domain.Hint
+}
+
+func (m *mockHintRepository) ListByQuizID(_ context.Context, quizID string) ([]domain.Hint, error) {
+	out := []domain.Hint{}
+	for _, h := range m.hints {
+		if h.QuizID == quizID {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockHintRepository) Replace(_ context.Context, quizID string, hints []domain.Hint) error {
+	kept := []domain.Hint{}
+	for _, h := range m.hints {
+		if h.QuizID != quizID {
+			kept = append(kept, h)
+		}
+	}
+	for _, h := range hints {
+		h.QuizID = quizID
+		kept = append(kept, h)
+	}
+	m.hints = kept
+	return nil
+}
+
+func TestPutHints_ReplacesInOrder(t *testing.T) {
+	repo := ownedQuizRepo()
+	hints := &mockHintRepository{hints: []domain.Hint{{QuizID: "q1", Position: 1, Body: "Old", Penalty: 0.1}}}
+	service := NewHintService(repo, hints, passthroughTxManager{})
+
+	resp, err := service.Put(signedIn("alice", "user"), "q1", PutHintsRequest{Hints: []HintRequest{
+		{Body: " Count the legs ", Penalty: 0.25},
+		{Body: "It is not 3", Penalty: 0.5},
+	}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(resp) != 2 || resp[0].Position != 1 || resp[0].Body != "Count the legs" || resp[1].Position != 2 || resp[1].Penalty != 0.5 {
+		t.Errorf("expected hints numbered in order, got %+v", resp)
+	}
+	if stored, _ := service.List(signedIn("alice", "user"), "q1"); len(stored) != 2 || stored[0].Body != "Count the legs" {
+		t.Errorf("expected the old hint replaced, got %+v", stored)
+	}
+
+	if _, err := service.Put(signedIn("alice", "user"), "q1", PutHintsRequest{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(hints.hints) != 0 {
+		t.Errorf("expected an empty list to remove the hints, got %+v", hints.hints)
+	}
+}
+
+func TestPutHints_Validation(t *testing.T) {
+	service := NewHintService(ownedQuizRepo(), &mockHintRepository{}, passthroughTxManager{})
+	alice := signedIn("alice", "user")
+
+	cases := map[string]struct {
+		hints []HintRequest
+		want  error
+	}{
+		"empty body":       {[]HintRequest{{Body: " ", Penalty: 0.1}}, domain.ErrInvalidHint},
+		"negative penalty": {[]HintRequest{{Body: "a", Penalty: -0.1}}, domain.ErrInvalidHint},
+		"penalty above 1":  {[]HintRequest{{Body: "a", Penalty: 1.5}}, domain.ErrInvalidHint},
+		"total above 1":    {[]HintRequest{{Body: "a", Penalty: 0.6}, {Body: "b", Penalty: 0.6}}, domain.ErrHintPenalties},
+		"too many":         {make([]HintRequest, domain.MaxHints+1), domain.ErrTooManyHints},
+	}
+	for name, tc := range cases {
+		if _, err := service.Put(alice, "q1", PutHintsRequest{Hints: tc.hints}); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got: %v", name, tc.want, err)
+		}
+	}
+
+	third := 1.0 / 3
+	if _, err := service.Put(alice, "q1", PutHintsRequest{Hints: []HintRequest{{Body: "a", Penalty: third}, {Body: "b", Penalty: third}, {Body: "c", Penalty: third}}}); err != nil {
+		t.Errorf("expected penalties adding up to 1 to be accepted, got: %v", err)
+	}
+}
+
+func TestHints_OnlyAuthorOrAdmin(t *testing.T) {
+	service := NewHintService(ownedQuizRepo(), &mockHintRepository{}, passthroughTxManager{})
+	req := PutHintsRequest{Hints: []HintRequest{{Body: "a", Penalty: 0.1}}}
+
+	if _, err := service.List(signedIn("bob", "user"), "q1"); !errors.Is(err, domain.ErrNotOwner) {
+		t.Errorf("expected ErrNotOwner, got: %v", err)
+	}
+	if _, err := service.Put(context.Background(), "q1", req); !errors.Is(err, domain.ErrSignInRequired) {
+		t.Errorf("expected ErrSignInRequired, got: %v", err)
+	}
+	if _, err := service.Put(signedIn("root", "admin"), "q1", req); err != nil {
+		t.Errorf("expected admins to change hints, got: %v", err)
+	}
+	if _, err := service.Put(signedIn("bob", "user"), "missing", req); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got: %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// InterchangeService defines importing and exporting quizzes in external formats
+type InterchangeService interface {
+	Formats() []string
+	Import(ctx context.Context, format string, r io.Reader, onDuplicate DuplicatePolicy) (*ImportResult, error)
+	Export(ctx context.Context, format string, ids []string) (*ExportResult, error)
+}
+
+type interchangeService struct {
+	repo      domain.QuizRepository
+	revisions domain.RevisionRepository
+	media     domain.MediaRepository
+	txManager database.TxManager
+	codecs    map[string]domain.QuizCodec
+	detector  duplicateDetector
+	events    *events.EventBus
+}
+
+// NewInterchangeService creates a new InterchangeService. Imported questions
+// at least duplicateThreshold similar to an existing one are reported as
+// duplicates. Imported quizzes are published on bus, which may be nil.
+func NewInterchangeService(repo domain.QuizRepository, revisions domain.RevisionRepository, media domain.MediaRepository, txManager database.TxManager, codecs []domain.QuizCodec, duplicateThreshold float64, bus *events.EventBus) InterchangeService {
+	byName := make(map[string]domain.QuizCodec, len(codecs))
+	for _, c := range codecs {
+		byName[c.Name()] = c
+	}
+	return &interchangeService{repo: repo, revisions: revisions, media: media, txManager: txManager, codecs: byName,
+		detector: newDuplicateDetector(repo, duplicateThreshold), events: bus}
+}
+
+// Formats returns the names of the supported formats
+func (s *interchangeService) Formats() []string {
+	names := make([]string, 0, len(s.codecs))
+	for name := range s.codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *interchangeService) codec(format string) (domain.QuizCodec, error) {
+	codec, ok := s.codecs[format]
+	if !ok {
+		return nil, domain.ErrUnknownFormat.WithDetails(map[string]interface{}{"supported": s.Formats()})
+	}
+	return codec, nil
+}
+
+// Import parses a document and creates all quizzes it contains, with their media, in a
+// single transaction. The document is rejected as a whole if it has syntax errors;
+// questions that cannot be represented are skipped and reported. Questions that
+// duplicate an existing quiz, or an earlier question in the document, are
+// reported, or skipped when onDuplicate blocks duplicates.
+func (s *interchangeService) Import(ctx context.Context, format string, r io.Reader, onDuplicate DuplicatePolicy) (*ImportResult, error) {
+	onDuplicate, err := onDuplicate.validate()
+	if err != nil {
+		return nil, err
+	}
+	codec, err := s.codec(format)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := codec.Decode(r)
+	if err != nil {
+		var parseErr *domain.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, domain.ErrInvalidDocument.WithDetails(parseErr.Issues)
+		}
+		return nil, sharedDomain.NewInternalError("Failed to read document", err)
+	}
+
+	result := &ImportResult{
+		Imported: make([]QuizResponse, 0, len(doc.Quizzes)),
+		Skipped:  doc.Skipped,
+	}
+	if result.Skipped == nil {
+		result.Skipped = []domain.ImportIssue{}
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		maxOrder, err := s.repo.GetMaxDisplayOrder(ctx)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to get max display order", err)
+		}
+
+		for _, item := range doc.Quizzes {
+			quiz := item.Quiz
+			// Earlier questions of the document are already created in this transaction
+			matches, err := s.detector.find(ctx, quiz.Question)
+			if err != nil {
+				return err
+			}
+			if len(matches) > 0 && onDuplicate == DuplicatePolicyBlock {
+				result.Skipped = append(result.Skipped, domain.ImportIssue{
+					Line:    item.Line,
+					Message: fmt.Sprintf("Duplicates existing quiz %s", matches[0].ID),
+				})
+				continue
+			}
+			if len(matches) > 0 {
+				result.Duplicates = append(result.Duplicates, ImportDuplicate{Index: len(result.Imported), Line: item.Line, Matches: matches})
+			}
+
+			quiz.ID = sharedDomain.NewID()
+			quiz.DisplayOrder = maxOrder + 1
+			quiz.Status = domain.StatusDraft
+			quiz.CreatedBy = currentUser(ctx)
+			quiz.UpdatedBy = quiz.CreatedBy
+
+			if err := saveRevision(ctx, s.revisions, &quiz, domain.RevisionActionImport, nil); err != nil {
+				return err
+			}
+			if err := s.repo.Create(ctx, &quiz); err != nil {
+				return sharedDomain.NewInternalError("Failed to create quiz", err)
+			}
+			for _, m := range item.Media {
+				m.ID = sharedDomain.NewID()
+				m.QuizID = quiz.ID
+				if err := s.media.Create(ctx, &m); err != nil {
+					return sharedDomain.NewInternalError("Failed to store quiz media", err)
+				}
+			}
+			maxOrder++
+			result.Imported = append(result.Imported, toQuizResponse(ctx, quiz))
+			publishChange(ctx, s.events, events.QuizCreatedEvent{QuizID: quiz.ID, UserID: quiz.CreatedBy})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Export encodes the given quizzes, or all quizzes the current user may
+// export when ids is empty, in display order. Every format carries the
+// answer, so admins export any quiz and other users only their own.
+func (s *interchangeService) Export(ctx context.Context, format string, ids []string) (*ExportResult, error) {
+	codec, err := s.codec(format)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := utils.GetUserID(ctx); !ok && !utils.IsAdmin(ctx) {
+		return nil, domain.ErrExportSignIn
+	}
+
+	quizzes, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quizzes", err)
+	}
+
+	if len(ids) > 0 {
+		quizzes, err = selectQuizzes(quizzes, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range quizzes {
+			if !canSeeAnswer(ctx, q) {
+				return nil, domain.ErrExportNotOwner
+			}
+		}
+	} else {
+		exportable := []domain.Quiz{}
+		for _, q := range quizzes {
+			if canSeeAnswer(ctx, q) {
+				exportable = append(exportable, q)
+			}
+		}
+		quizzes = exportable
+	}
+
+	bundles := make([]domain.QuizBundle, len(quizzes))
+	for i, q := range quizzes {
+		bundles[i].Quiz = q
+	}
+	if codec.EmbedsMedia() {
+		if err := s.attachMedia(ctx, bundles); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := codec.Encode(&buf, bundles); err != nil {
+		var missingErr *domain.MissingAnswerError
+		if errors.As(err, &missingErr) {
+			return nil, domain.ErrMissingAnswer.WithDetails(map[string]interface{}{"quiz_ids": missingErr.QuizIDs})
+		}
+		return nil, sharedDomain.NewInternalError("Failed to encode quizzes", err)
+	}
+
+	return &ExportResult{
+		Filename:    "quizzes" + codec.FileExtension(),
+		ContentType: codec.ContentType(),
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// attachMedia loads the media of every bundled quiz
+func (s *interchangeService) attachMedia(ctx context.Context, bundles []domain.QuizBundle) error {
+	ids := make([]string, len(bundles))
+	index := make(map[string]int, len(bundles))
+	for i, b := range bundles {
+		ids[i] = b.Quiz.ID
+		index[b.Quiz.ID] = i
+	}
+
+	media, err := s.media.ListByQuizIDs(ctx, ids)
+	if err != nil {
+		return sharedDomain.NewInternalError("Failed to fetch quiz media", err)
+	}
+	for _, m := range media {
+		if i, ok := index[m.QuizID]; ok {
+			bundles[i].Media = append(bundles[i].Media, m)
+		}
+	}
+	return nil
+}
+
+// selectQuizzes keeps the quizzes with the given IDs, preserving display order
+func selectQuizzes(quizzes []domain.Quiz, ids []string) ([]domain.Quiz, error) {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	selected := make([]domain.Quiz, 0, len(ids))
+	for _, q := range quizzes {
+		if wanted[q.ID] {
+			selected = append(selected, q)
+			delete(wanted, q.ID)
+		}
+	}
+
+	if len(wanted) > 0 {
+		notFound := make([]string, 0, len(wanted))
+		for id := range wanted {
+			notFound = append(notFound, id)
+		}
+		sort.Strings(notFound)
+		return nil, domain.ErrQuizNotFound.WithDetails(map[string]interface{}{"quiz_ids": notFound})
+	}
+	return selected, nil
+}
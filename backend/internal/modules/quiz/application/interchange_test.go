@@ -0,0 +1,227 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// passthroughTxManager runs fn without a real transaction
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// mockMediaRepository is an in-memory implementation of domain.MediaRepository
+type mockMediaRepository struct {
+	media []domain.Media
+}
+
+func (m *mockMediaRepository) ListByQuizIDs(_ context.Context, quizIDs []string) ([]domain.Media, error) {
+	var out []domain.Media
+	for _, media := range m.media {
+		for _, id := range quizIDs {
+			if media.QuizID == id {
+				out = append(out, media)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *mockMediaRepository) ListMetadata(ctx context.Context, quizID string) ([]domain.Media, error) {
+	return m.ListByQuizIDs(ctx, []string{quizID})
+}
+
+func (m *mockMediaRepository) GetByID(_ context.Context, quizID, id string) (*domain.Media, error) {
+	for _, media := range m.media {
+		if media.QuizID == quizID && media.ID == id {
+			return &media, nil
+		}
+	}
+	return nil, domain.ErrMediaNotFound
+}
+
+func (m *mockMediaRepository) Create(_ context.Context, media *domain.Media) error {
+	m.media = append(m.media, *media)
+	return nil
+}
+
+// stubCodec returns a fixed document and records encoded quizzes
+type stubCodec struct {
+	doc         *domain.ImportDocument
+	decodeErr   error
+	embedsMedia bool
+	encoded     []domain.QuizBundle
+}
+
+func (c *stubCodec) Name() string          { return "stub" }
+func (c *stubCodec) ContentType() string   { return "text/plain" }
+func (c *stubCodec) FileExtension() string { return ".txt" }
+func (c *stubCodec) EmbedsMedia() bool     { return c.embedsMedia }
+
+func (c *stubCodec) Decode(_ io.Reader) (*domain.ImportDocument, error) {
+	return c.doc, c.decodeErr
+}
+
+func (c *stubCodec) Encode(w io.Writer, bundles []domain.QuizBundle) error {
+	if err := domain.CheckAnswers(bundles); err != nil {
+		return err
+	}
+	c.encoded = bundles
+	_, err := io.WriteString(w, "ok")
+	return err
+}
+
+// ============ Test Cases ============
+
+func TestImport_AssignsDisplayOrderAndReportsSkipped(t *testing.T) {
+	repo := newMockRepo()
+	media := &mockMediaRepository{}
+	repo.getMaxOrderResp = 5
+	codec := &stubCodec{doc: &domain.ImportDocument{
+		Quizzes: []domain.ImportedQuiz{
+			{QuizBundle: domain.QuizBundle{
+				Quiz:  domain.Quiz{Question: "Q1", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", Answer: 1},
+				Media: []domain.Media{{Filename: "a.png", ContentType: "image/png", Data: []byte("png")}},
+			}, Line: 1},
+			{QuizBundle: domain.QuizBundle{Quiz: domain.Quiz{Question: "Q2", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", Answer: 2}}, Line: 8},
+		},
+		Skipped: []domain.ImportIssue{{Line: 15, Message: "essay questions are not supported"}},
+	}}
+	service := NewInterchangeService(repo, newMockRevisionRepo(), media, passthroughTxManager{}, []domain.QuizCodec{codec}, 0, nil)
+
+	result, err := service.Import(context.Background(), "stub", strings.NewReader(""), "")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(result.Imported) != 2 || len(repo.quizzes) != 2 {
+		t.Fatalf("expected 2 imported quizzes, got %d", len(result.Imported))
+	}
+	if result.Imported[0].DisplayOrder != 6 || result.Imported[1].DisplayOrder != 7 {
+		t.Errorf("expected display orders 6 and 7, got %d and %d", result.Imported[0].DisplayOrder, result.Imported[1].DisplayOrder)
+	}
+	if result.Imported[0].ID == "" || result.Imported[0].ID == result.Imported[1].ID {
+		t.Error("expected unique IDs to be assigned")
+	}
+	if len(media.media) != 1 || media.media[0].QuizID != result.Imported[0].ID || media.media[0].ID == "" {
+		t.Errorf("expected media stored for first quiz, got %+v", media.media)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Line != 15 {
+		t.Errorf("expected skipped issue on line 15, got %+v", result.Skipped)
+	}
+}
+
+func TestImport_ParseErrorIsValidationError(t *testing.T) {
+	repo := newMockRepo()
+	media := &mockMediaRepository{}
+	codec := &stubCodec{decodeErr: &domain.ParseError{Format: "stub", Issues: []domain.ImportIssue{{Line: 3, Message: "bad"}}}}
+	service := NewInterchangeService(repo, newMockRevisionRepo(), media, passthroughTxManager{}, []domain.QuizCodec{codec}, 0, nil)
+
+	_, err := service.Import(context.Background(), "stub", strings.NewReader(""), "")
+
+	var appErr *sharedDomain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != sharedDomain.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	issues, ok := appErr.Details.([]domain.ImportIssue)
+	if !ok || len(issues) != 1 || issues[0].Line != 3 {
+		t.Errorf("expected line-numbered details, got %+v", appErr.Details)
+	}
+	if len(repo.quizzes) != 0 {
+		t.Errorf("expected nothing imported, got %d", len(repo.quizzes))
+	}
+}
+
+func TestImport_UnknownFormat(t *testing.T) {
+	service := NewInterchangeService(newMockRepo(), newMockRevisionRepo(), &mockMediaRepository{}, passthroughTxManager{}, nil, 0, nil)
+
+	_, err := service.Import(context.Background(), "nope", strings.NewReader(""), "")
+	if err == nil {
+		t.Fatal("expected error for unknown format, got nil")
+	}
+}
+
+func TestExport_SelectedQuizzesInDisplayOrder(t *testing.T) {
+	repo := newMockRepo()
+	media := &mockMediaRepository{}
+	repo.quizzes = []domain.Quiz{
+		{ID: "a", Question: "Q1", Answer: 1, DisplayOrder: 1},
+		{ID: "b", Question: "Q2", Answer: 2, DisplayOrder: 2},
+		{ID: "c", Question: "Q3", Answer: 3, DisplayOrder: 3},
+	}
+	codec := &stubCodec{}
+	service := NewInterchangeService(repo, newMockRevisionRepo(), media, passthroughTxManager{}, []domain.QuizCodec{codec}, 0, nil)
+
+	result, err := service.Export(signedIn("admin", utils.RoleAdmin), "stub", []string{"c", "a"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if string(result.Data) != "ok" || result.Filename != "quizzes.txt" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(codec.encoded) != 2 || codec.encoded[0].Quiz.ID != "a" || codec.encoded[1].Quiz.ID != "c" {
+		t.Errorf("expected quizzes a, c in display order, got %+v", codec.encoded)
+	}
+}
+
+func TestExport_AttachesMediaForMediaFormats(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{
+		{ID: "a", Question: "Q1", DisplayOrder: 1, Answer: 1},
+		{ID: "b", Question: "Q2", DisplayOrder: 2, Answer: 1},
+	}
+	media := &mockMediaRepository{media: []domain.Media{{ID: "m", QuizID: "b", Filename: "x.png"}}}
+	codec := &stubCodec{embedsMedia: true}
+	service := NewInterchangeService(repo, newMockRevisionRepo(), media, passthroughTxManager{}, []domain.QuizCodec{codec}, 0, nil)
+
+	if _, err := service.Export(signedIn("admin", utils.RoleAdmin), "stub", nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(codec.encoded[0].Media) != 0 || len(codec.encoded[1].Media) != 1 {
+		t.Errorf("expected media attached to quiz b only, got %+v", codec.encoded)
+	}
+}
+
+func TestExport_MissingAnswer(t *testing.T) {
+	repo := newMockRepo()
+	media := &mockMediaRepository{}
+	repo.quizzes = []domain.Quiz{{ID: "a", Question: "Q1", DisplayOrder: 1}}
+	service := NewInterchangeService(repo, newMockRevisionRepo(), media, passthroughTxManager{}, []domain.QuizCodec{&stubCodec{}}, 0, nil)
+
+	_, err := service.Export(signedIn("admin", utils.RoleAdmin), "stub", nil)
+	if err == nil {
+		t.Fatal("expected error for quiz without answer, got nil")
+	}
+}
+
+func TestExport_OwnQuizzesOnly(t *testing.T) {
+	repo := ownedQuizRepo()
+	repo.quizzes[0].Answer, repo.quizzes[1].Answer = 1, 2
+	codec := &stubCodec{}
+	service := NewInterchangeService(repo, newMockRevisionRepo(), &mockMediaRepository{}, passthroughTxManager{}, []domain.QuizCodec{codec}, 0, nil)
+
+	if _, err := service.Export(context.Background(), "stub", nil); !errors.Is(err, domain.ErrExportSignIn) {
+		t.Errorf("expected ErrExportSignIn, got: %v", err)
+	}
+	if _, err := service.Export(signedIn("alice", utils.RoleReviewer), "stub", []string{"q1", "q2"}); !errors.Is(err, domain.ErrExportNotOwner) {
+		t.Errorf("expected ErrExportNotOwner for another user's quiz, got: %v", err)
+	}
+	if _, err := service.Export(signedIn("alice", "user"), "stub", nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(codec.encoded) != 1 || codec.encoded[0].Quiz.ID != "q1" {
+		t.Errorf("expected only alice's quiz, got %+v", codec.encoded)
+	}
+	if _, err := service.Export(signedIn("bob", utils.RoleAdmin), "stub", nil); err != nil || len(codec.encoded) != 2 {
+		t.Errorf("expected admins to export every quiz, got %d, %v", len(codec.encoded), err)
+	}
+}
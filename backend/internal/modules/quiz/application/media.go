@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// MediaService defines access to files attached to quizzes
+type MediaService interface {
+	List(ctx context.Context, quizID string) ([]MediaResponse, error)
+	Get(ctx context.Context, quizID, id string) (*domain.Media, error)
+}
+
+type mediaService struct {
+	repo  domain.QuizRepository
+	media domain.MediaRepository
+	now   func() time.Time
+}
+
+// NewMediaService creates a new MediaService
+func NewMediaService(repo domain.QuizRepository, media domain.MediaRepository) MediaService {
+	return &mediaService{repo: repo, media: media, now: time.Now}
+}
+
+// List returns the metadata of all media attached to a quiz
+func (s *mediaService) List(ctx context.Context, quizID string) ([]MediaResponse, error) {
+	if err := s.authorizeRead(ctx, quizID); err != nil {
+		return nil, err
+	}
+	media, err := s.media.ListMetadata(ctx, quizID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quiz media", err)
+	}
+
+	responses := make([]MediaResponse, len(media))
+	for i, m := range media {
+		responses[i] = MediaResponse{
+			ID:          m.ID,
+			Filename:    m.Filename,
+			ContentType: m.ContentType,
+			Size:        m.Size,
+		}
+	}
+	return responses, nil
+}
+
+// Get returns a media file including its data
+func (s *mediaService) Get(ctx context.Context, quizID, id string) (*domain.Media, error) {
+	if err := s.authorizeRead(ctx, quizID); err != nil {
+		return nil, err
+	}
+	media, err := s.media.GetByID(ctx, quizID, id)
+	if err != nil {
+		return nil, domain.ErrMediaNotFound
+	}
+	return media, nil
+}
+
+// authorizeRead returns nil if the current user may see the media of a
+// quiz: everyone while the quiz is in the public listing, otherwise only
+// those who may change it
+func (s *mediaService) authorizeRead(ctx context.Context, quizID string) error {
+	quiz, err := s.repo.GetByID(ctx, quizID)
+	if err != nil {
+		return domain.ErrQuizNotFound
+	}
+	if quiz.IsVisible(s.now()) {
+		return nil
+	}
+	return authorizeChange(ctx, quiz)
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// authorMe is the ?author= value for the signed-in user
+const authorMe = "me"
+
+// currentUser returns the ID of the signed-in user, or nil for anonymous requests
+func currentUser(ctx context.Context) *string {
+	if userID, ok := utils.GetUserID(ctx); ok {
+		return &userID
+	}
+	return nil
+}
+
+// authorizeChange returns nil if the current user may edit or delete quiz.
+// Admins may change every quiz and authors their own. Quizzes without an
+// author, written before ownership was recorded or without signing in, stay
+// open to everyone.
+func authorizeChange(ctx context.Context, quiz *domain.Quiz) error {
+	if quiz.CreatedBy == nil || utils.IsAdmin(ctx) {
+		return nil
+	}
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return domain.ErrSignInRequired
+	}
+	if !quiz.IsOwnedBy(userID) {
+		return domain.ErrNotOwner
+	}
+	return nil
+}
+
+// manageScope returns whose quizzes the current user may manage: nil for
+// admins and reviewers, who manage every quiz, or the user's own ID
+func manageScope(ctx context.Context) (*string, error) {
+	if utils.CanReview(ctx) {
+		return nil, nil
+	}
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, domain.ErrManageSignIn
+	}
+	return &userID, nil
+}
+
+// canSeeAnswer returns true if the current user may read the answer of quiz
+// outside the management listings: admins and the quiz's author
+func canSeeAnswer(ctx context.Context, quiz domain.Quiz) bool {
+	if utils.IsAdmin(ctx) {
+		return true
+	}
+	userID, ok := utils.GetUserID(ctx)
+	return ok && quiz.IsOwnedBy(userID)
+}
@@ -0,0 +1,354 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/listquery"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+func signedIn(userID, role string) context.Context {
+	ctx := utils.SetUserID(context.Background(), userID)
+	return utils.SetUserRole(ctx, role)
+}
+
+func ownedQuizRepo() *mockQuizRepository {
+	alice := "alice"
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{
+		{ID: "q1", Question: "Q1", Choice1: "a", Choice2: "b", Choice3: "c", Choice4: "d", DisplayOrder: 1, CreatedBy: &alice},
+		{ID: "q2", Question: "Q2", Choice1: "a", Choice2: "b", Choice3: "c", Choice4: "d", DisplayOrder: 2},
+	}
+	return repo
+}
+
+var editRequest = UpdateQuizRequest{Question: "Edited", Choice1: "a", Choice2: "b", Choice3: "c", Choice4: "d"}
+
+func TestCreateQuiz_RecordsAuthor(t *testing.T) {
+	repo := newMockRepo()
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	resp, err := service.Create(signedIn("alice", "user"), CreateQuizRequest{Question: "Q", Choice1: "a", Choice2: "b", Choice3: "c", Choice4: "d"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.CreatedBy == nil || *resp.CreatedBy != "alice" || resp.UpdatedBy == nil || *resp.UpdatedBy != "alice" {
+		t.Errorf("expected alice as author and editor, got %v and %v", resp.CreatedBy, resp.UpdatedBy)
+	}
+}
+
+func TestUpdateQuiz_Ownership(t *testing.T) {
+	cases := []struct {
+		name string
+		ctx  context.Context
+		id   string
+		want error
+	}{
+		{"owner", signedIn("alice", "user"), "q1", nil},
+		{"admin", signedIn("bob", utils.RoleAdmin), "q1", nil},
+		{"other user", signedIn("bob", "user"), "q1", domain.ErrNotOwner},
+		{"anonymous", context.Background(), "q1", domain.ErrSignInRequired},
+		{"unowned quiz", signedIn("bob", "user"), "q2", nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := ownedQuizRepo()
+			service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+			resp, err := service.Update(tc.ctx, tc.id, editRequest)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got: %v", tc.want, err)
+			}
+			if err == nil {
+				if userID, ok := utils.GetUserID(tc.ctx); ok && (resp.UpdatedBy == nil || *resp.UpdatedBy != userID) {
+					t.Errorf("expected updated_by %s, got %v", userID, resp.UpdatedBy)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteQuiz_OtherUserForbidden(t *testing.T) {
+	repo := ownedQuizRepo()
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	if err := service.Delete(signedIn("bob", "user"), "q1"); !errors.Is(err, domain.ErrNotOwner) {
+		t.Fatalf("expected ErrNotOwner, got: %v", err)
+	}
+	if len(repo.quizzes) != 2 {
+		t.Errorf("expected no quiz deleted, got %d left", len(repo.quizzes))
+	}
+}
+
+func TestBatchDelete_ForeignQuizRejected(t *testing.T) {
+	repo := ownedQuizRepo()
+	service := NewBatchService(repo, newMockRevisionRepo(), passthroughTxManager{}, 0, 0, nil)
+
+	_, err := service.Delete(signedIn("bob", "user"), BatchDeleteRequest{IDs: []string{"q2", "q1"}})
+	var appErr *sharedDomain.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected batch rejection, got: %v", err)
+	}
+	details := appErr.Details.(BatchResponse)
+	if details.Results[0].Status != BatchStatusRejected || details.Results[1].Error == nil || details.Results[1].Error.Code != string(sharedDomain.ErrCodeForbidden) {
+		t.Errorf("expected only the second item to fail as forbidden, got %+v", details.Results)
+	}
+	if len(repo.quizzes) != 2 {
+		t.Errorf("expected no quiz deleted, got %d left", len(repo.quizzes))
+	}
+}
+
+func TestListQuizzes_AuthorMe(t *testing.T) {
+	repo := ownedQuizRepo()
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	if _, err := service.List(signedIn("alice", utils.RoleReviewer), ListQuizzesRequest{Author: "me"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var args []interface{}
+	if where := repo.listQuery.Where(&args); where != " AND created_by = $1" || args[0] != "alice" {
+		t.Errorf("expected created_by filter for alice, got %q %v", where, args)
+	}
+
+	if _, err := service.List(context.Background(), ListQuizzesRequest{Visible: true, Author: "me"}); !errors.Is(err, domain.ErrSignInRequired) {
+		t.Errorf("expected ErrSignInRequired, got: %v", err)
+	}
+}
+
+func TestPublicListings_HideAnswers(t *testing.T) {
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want map[string]int
+	}{
+		{"anonymous", context.Background(), map[string]int{"q1": 0, "q2": 0}},
+		{"other user", signedIn("bob", "user"), map[string]int{"q1": 0, "q2": 0}},
+		{"author", signedIn("alice", "user"), map[string]int{"q1": 2, "q2": 0}},
+		{"admin", signedIn("bob", utils.RoleAdmin), map[string]int{"q1": 2, "q2": 3}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := ownedQuizRepo()
+			repo.quizzes[0].Answer, repo.quizzes[1].Answer = 2, 3
+			for i := range repo.quizzes {
+				repo.quizzes[i].Status = domain.StatusPublished
+			}
+			service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+			visible, err := service.GetVisible(tc.ctx)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			page, err := service.List(tc.ctx, ListQuizzesRequest{Visible: true})
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			results, err := NewSearchService(repo).Search(tc.ctx, "q", 0)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			for i := range visible {
+				for _, resp := range []QuizResponse{visible[i], page.Items[i], results[i].QuizResponse} {
+					if resp.Answer != tc.want[resp.ID] {
+						t.Errorf("expected answer %d for %s, got %d", tc.want[resp.ID], resp.ID, resp.Answer)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestManageQuizzes_Scope(t *testing.T) {
+	cases := []struct {
+		name  string
+		ctx   context.Context
+		err   error
+		where string
+		want  map[string]int
+	}{
+		{"anonymous", context.Background(), domain.ErrManageSignIn, "", nil},
+		{"user", signedIn("alice", "user"), nil, " AND created_by = $1", map[string]int{"q1": 2}},
+		{"reviewer", signedIn("bob", utils.RoleReviewer), nil, "", map[string]int{"q1": 0, "q2": 0}},
+		{"admin", signedIn("bob", utils.RoleAdmin), nil, "", map[string]int{"q1": 2, "q2": 3}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := ownedQuizRepo()
+			repo.quizzes[0].Answer, repo.quizzes[1].Answer = 2, 3
+			service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+			page, err := service.List(tc.ctx, ListQuizzesRequest{})
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got: %v", tc.err, err)
+			}
+			all, err := service.GetAll(tc.ctx)
+			if !errors.Is(err, tc.err) || tc.err != nil {
+				return
+			}
+			var args []interface{}
+			if where := repo.listQuery.Where(&args); where != tc.where {
+				t.Errorf("expected filter %q, got %q %v", tc.where, where, args)
+			}
+			if len(all) != len(tc.want) {
+				t.Errorf("expected %d quizzes, got %+v", len(tc.want), all)
+			}
+			for _, resp := range append(all, page.Items...) {
+				if resp.Answer != tc.want[resp.ID] {
+					t.Errorf("expected answer %d for %s, got %d", tc.want[resp.ID], resp.ID, resp.Answer)
+				}
+			}
+		})
+	}
+}
+
+func TestListQuizzes_PublicAnswerFilterRejected(t *testing.T) {
+	service := NewQuizService(ownedQuizRepo(), newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	byAnswer := domain.QuizListSchema.Restrict(domain.QuizListSchema.Default(), "answer", listquery.OpEq, "2")
+	if _, err := service.List(signedIn("bob", "user"), ListQuizzesRequest{Visible: true, Query: byAnswer}); !errors.Is(err, domain.ErrAnswerField) {
+		t.Errorf("expected ErrAnswerField, got: %v", err)
+	}
+	if _, err := service.List(signedIn("bob", "user"), ListQuizzesRequest{Query: byAnswer}); err != nil {
+		t.Errorf("expected users to filter their own quizzes by answer, got: %v", err)
+	}
+	if _, err := service.List(signedIn("bob", utils.RoleReviewer), ListQuizzesRequest{Query: byAnswer}); !errors.Is(err, domain.ErrAnswerField) {
+		t.Errorf("expected ErrAnswerField for reviewers managing every quiz, got: %v", err)
+	}
+	if _, err := service.List(signedIn("bob", utils.RoleAdmin), ListQuizzesRequest{Visible: true, Query: byAnswer}); err != nil {
+		t.Errorf("expected admins to filter by answer, got: %v", err)
+	}
+}
+
+func TestQuizMutators_OtherUserForbidden(t *testing.T) {
+	repo := ownedQuizRepo()
+	repo.quizzes[0].Status = domain.StatusDraft
+	translations := &mockTranslationRepository{quizzes: repo}
+	workflow := NewWorkflowService(repo, &mockTransitionRepository{}, passthroughTxManager{}, nil)
+	schedules := NewScheduleService(repo, time.UTC, nil)
+	translator := NewTranslationService(repo, translations, "th")
+	media := NewMediaService(repo, &mockMediaRepository{media: []domain.Media{{ID: "m1", QuizID: "q1"}}})
+
+	// Ordered so that the owner puts the translation before deleting it
+	calls := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"transition", func(ctx context.Context) error {
+			_, err := workflow.Transition(ctx, "q1", domain.ActionSubmit, TransitionRequest{})
+			return err
+		}},
+		{"schedule", func(ctx context.Context) error {
+			_, err := schedules.Set(ctx, "q1", ScheduleRequest{PublishAt: "2025-03-01T09:00"})
+			return err
+		}},
+		{"put translation", func(ctx context.Context) error {
+			_, err := translator.Put(ctx, "q1", "en", englishCapital())
+			return err
+		}},
+		{"delete translation", func(ctx context.Context) error {
+			return translator.Delete(ctx, "q1", "en")
+		}},
+		{"list media", func(ctx context.Context) error {
+			_, err := media.List(ctx, "q1")
+			return err
+		}},
+		{"get media", func(ctx context.Context) error {
+			_, err := media.Get(ctx, "q1", "m1")
+			return err
+		}},
+	}
+	for _, c := range calls {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.call(signedIn("bob", "user")); !errors.Is(err, domain.ErrNotOwner) {
+				t.Errorf("expected ErrNotOwner, got: %v", err)
+			}
+			if err := c.call(context.Background()); !errors.Is(err, domain.ErrSignInRequired) {
+				t.Errorf("expected ErrSignInRequired, got: %v", err)
+			}
+		})
+	}
+	if repo.quizzes[0].Status != domain.StatusDraft || repo.quizzes[0].PublishAt != nil || len(translations.translations) != 0 {
+		t.Errorf("expected nothing to change, got %+v", repo.quizzes[0])
+	}
+
+	// The owner may make every change
+	for _, c := range calls {
+		if err := c.call(signedIn("alice", "user")); err != nil {
+			t.Errorf("%s: expected no error for the owner, got: %v", c.name, err)
+		}
+	}
+}
+
+func TestMedia_VisibleQuizOpenToEveryone(t *testing.T) {
+	repo := ownedQuizRepo()
+	repo.quizzes[0].Status = domain.StatusPublished
+	service := NewMediaService(repo, &mockMediaRepository{media: []domain.Media{{ID: "m1", QuizID: "q1"}}})
+
+	if _, err := service.Get(context.Background(), "q1", "m1"); err != nil {
+		t.Errorf("expected media of a published quiz to be public, got: %v", err)
+	}
+	if _, err := service.List(context.Background(), "missing"); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got: %v", err)
+	}
+}
+
+func TestReadPaths_HideAnswers(t *testing.T) {
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want int
+	}{
+		{"other user", signedIn("bob", "user"), 0},
+		{"author", signedIn("alice", "user"), 2},
+		{"admin", signedIn("bob", utils.RoleAdmin), 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := ownedQuizRepo()
+			repo.quizzes = repo.quizzes[:1]
+			repo.quizzes[0].Answer = 2
+			repo.quizzes = append(repo.quizzes, repo.quizzes[0])
+			repo.quizzes[1].ID, repo.quizzes[1].DisplayOrder = "q3", 3
+			deletedAt := time.Now()
+			repo.trash = []domain.Quiz{repo.quizzes[0]}
+			repo.trash[0].ID, repo.trash[0].DeletedAt = "q4", &deletedAt
+			revisions := newMockRevisionRepo()
+			revisions.revisions = []domain.Revision{
+				{ID: "r1", QuizID: "q1", Number: 1, Question: "Q1", Answer: 1},
+				{ID: "r2", QuizID: "q1", Number: 2, Question: "Q1", Answer: 2},
+			}
+			repo.quizzes[0].RevisionID = "r2"
+
+			var answers []int
+			trash, _ := NewTrashService(repo, passthroughTxManager{}, 0, nil).List(tc.ctx)
+			answers = append(answers, trash[0].Answer)
+			clusters, _ := NewDuplicateService(repo, 0).Report(tc.ctx, 0)
+			answers = append(answers, clusters[0].Quizzes[0].Answer, clusters[0].Quizzes[1].Answer)
+			untranslated, _ := NewTranslationService(repo, &mockTranslationRepository{quizzes: repo}, "th").Untranslated(tc.ctx, "en")
+			answers = append(answers, untranslated[0].Answer)
+			revisionService := NewRevisionService(repo, revisions, &mockTransitionRepository{}, passthroughTxManager{}, nil)
+			list, _ := revisionService.List(tc.ctx, "q1")
+			answers = append(answers, list[0].Answer)
+			revision, _ := revisionService.Get(tc.ctx, "q1", "r2")
+			answers = append(answers, revision.Answer)
+			for i, answer := range answers {
+				if answer != tc.want {
+					t.Errorf("read %d: expected answer %d, got %d", i, tc.want, answer)
+				}
+			}
+
+			diff, err := revisionService.Diff(tc.ctx, "q1", "r1", "")
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if shown := len(diff.Changes) == 1; shown != (tc.want != 0) {
+				t.Errorf("expected the answer change shown %v, got %+v", tc.want != 0, diff.Changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package application
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+)
+
+// RevisionService defines access to the revision history of quizzes
+type RevisionService interface {
+	List(ctx context.Context, quizID string) ([]RevisionResponse, error)
+	Get(ctx context.Context, quizID, revisionID string) (*RevisionResponse, error)
+	Diff(ctx context.Context, quizID, fromID, toID string) (*RevisionDiffResponse, error)
+	Revert(ctx context.Context, quizID, revisionID string) (*QuizResponse, error)
+}
+
+type revisionService struct {
+	repo        domain.QuizRepository
+	revisions   domain.RevisionRepository
+	transitions domain.TransitionRepository
+	txManager   database.TxManager
+	events      *events.EventBus
+}
+
+// NewRevisionService creates a new RevisionService. Reverting a published
+// quiz sends it back to review, recorded in transitions. Reverts are
+// published on bus, which may be nil.
+func NewRevisionService(repo domain.QuizRepository, revisions domain.RevisionRepository, transitions domain.TransitionRepository, txManager database.TxManager, bus *events.EventBus) RevisionService {
+	return &revisionService{repo: repo, revisions: revisions, transitions: transitions, txManager: txManager, events: bus}
+}
+
+// List returns all revisions of a quiz, newest first
+func (s *revisionService) List(ctx context.Context, quizID string) ([]RevisionResponse, error) {
+	quiz, err := s.repo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, domain.ErrQuizNotFound
+	}
+
+	revisions, err := s.revisions.ListByQuizID(ctx, quizID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch revisions", err)
+	}
+
+	responses := make([]RevisionResponse, len(revisions))
+	for i, r := range revisions {
+		responses[i] = toRevisionResponse(ctx, *quiz, r)
+	}
+	return responses, nil
+}
+
+// Get returns a single revision, for example the version a learner answered.
+// Revisions outlive their quiz; those of deleted quizzes are still returned.
+func (s *revisionService) Get(ctx context.Context, quizID, revisionID string) (*RevisionResponse, error) {
+	revision, err := s.revisions.GetByID(ctx, quizID, revisionID)
+	if err != nil {
+		return nil, domain.ErrRevisionNotFound
+	}
+
+	resp := toRevisionResponse(ctx, s.quizOrDeleted(ctx, quizID), *revision)
+	return &resp, nil
+}
+
+// Diff compares two revisions of a quiz field by field. When toID is empty
+// the current revision is used.
+func (s *revisionService) Diff(ctx context.Context, quizID, fromID, toID string) (*RevisionDiffResponse, error) {
+	quiz := s.quizOrDeleted(ctx, quizID)
+	if toID == "" {
+		if quiz.RevisionID == "" {
+			return nil, domain.ErrQuizNotFound
+		}
+		toID = quiz.RevisionID
+	}
+
+	from, err := s.revisions.GetByID(ctx, quizID, fromID)
+	if err != nil {
+		return nil, domain.ErrRevisionNotFound.WithDetails(map[string]interface{}{"revision_id": fromID})
+	}
+	to, err := s.revisions.GetByID(ctx, quizID, toID)
+	if err != nil {
+		return nil, domain.ErrRevisionNotFound.WithDetails(map[string]interface{}{"revision_id": toID})
+	}
+
+	changes := domain.Diff(from, to)
+	if !canSeeAnswer(ctx, quiz) {
+		visible := []domain.FieldChange{}
+		for _, c := range changes {
+			if c.Field != "answer" {
+				visible = append(visible, c)
+			}
+		}
+		changes = visible
+	}
+	return &RevisionDiffResponse{
+		From:    toRevisionRef(*from),
+		To:      toRevisionRef(*to),
+		Changes: changes,
+	}, nil
+}
+
+// quizOrDeleted returns the quiz whose revisions are read, or a quiz without
+// an author or revision once it has been deleted, whose answers only admins see
+func (s *revisionService) quizOrDeleted(ctx context.Context, quizID string) domain.Quiz {
+	quiz, err := s.repo.GetByID(ctx, quizID)
+	if err != nil {
+		return domain.Quiz{ID: quizID}
+	}
+	return *quiz
+}
+
+// Revert restores the content of an earlier revision. History is kept:
+// the restored content is saved as a new revision.
+func (s *revisionService) Revert(ctx context.Context, quizID, revisionID string) (*QuizResponse, error) {
+	var quiz *domain.Quiz
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		quiz, err = s.repo.GetByIDForUpdate(ctx, quizID)
+		if err != nil {
+			return domain.ErrQuizNotFound
+		}
+		if err := authorizeChange(ctx, quiz); err != nil {
+			return err
+		}
+
+		target, err := s.revisions.GetByID(ctx, quizID, revisionID)
+		if err != nil {
+			return domain.ErrRevisionNotFound
+		}
+		if target.SameContent(quiz) {
+			return domain.ErrAlreadyCurrent
+		}
+
+		target.ApplyTo(quiz)
+		quiz.UpdatedBy = currentUser(ctx)
+		if err := saveRevision(ctx, s.revisions, quiz, domain.RevisionActionRevert, &target.ID); err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, quiz); err != nil {
+			return sharedDomain.NewInternalError("Failed to update quiz", err)
+		}
+		if err := reviseIfPublished(ctx, s.repo, s.transitions, quiz); err != nil {
+			return err
+		}
+		publishChange(ctx, s.events, events.QuizUpdatedEvent{QuizID: quiz.ID, UserID: quiz.UpdatedBy})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toQuizResponse(ctx, *quiz)
+	return &resp, nil
+}
+
+// saveRevision snapshots the content of quiz as its next revision and
+// points the quiz at it. The repository numbers the revision. The caller
+// saves the quiz in the same transaction.
+func saveRevision(ctx context.Context, revisions domain.RevisionRepository, quiz *domain.Quiz, action string, revertedFrom *string) error {
+	revision := domain.NewRevision(quiz, sharedDomain.NewID(), 0, action)
+	revision.RevertedFrom = revertedFrom
+	if err := revisions.Create(ctx, revision); err != nil {
+		return sharedDomain.NewInternalError("Failed to save revision", err)
+	}
+
+	quiz.RevisionID = revision.ID
+	return nil
+}
+
+// toRevisionResponse converts a revision of quiz, leaving out the answer
+// unless the current user may see the quiz's answer
+func toRevisionResponse(ctx context.Context, quiz domain.Quiz, r domain.Revision) RevisionResponse {
+	resp := RevisionResponse{
+		ID:           r.ID,
+		QuizID:       r.QuizID,
+		Number:       r.Number,
+		Question:     r.Question,
+		Choice1:      r.Choice1,
+		Choice2:      r.Choice2,
+		Choice3:      r.Choice3,
+		Choice4:      r.Choice4,
+		Answer:       r.Answer,
+		Action:       r.Action,
+		RevertedFrom: r.RevertedFrom,
+		CreatedAt:    r.CreatedAt,
+	}
+	if !canSeeAnswer(ctx, quiz) {
+		resp.Answer = 0
+	}
+	return resp
+}
+
+func toRevisionRef(r domain.Revision) RevisionRef {
+	return RevisionRef{ID: r.ID, Number: r.Number, CreatedAt: r.CreatedAt}
+}
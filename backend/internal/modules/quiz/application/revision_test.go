@@ -0,0 +1,190 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+func createTestQuiz(t *testing.T, service QuizService) *QuizResponse {
+	t.Helper()
+	resp, err := service.Create(context.Background(), CreateQuizRequest{
+		Question: "2 + 2 = ?",
+		Choice1:  "3",
+		Choice2:  "4",
+		Choice3:  "5",
+		Choice4:  "6",
+		Answer:   2,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	return &resp.QuizResponse
+}
+
+// ============ Test Cases ============
+
+func TestCreateQuiz_SavesFirstRevision(t *testing.T) {
+	revisions := newMockRevisionRepo()
+	service := NewQuizService(newMockRepo(), revisions, &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	quiz := createTestQuiz(t, service)
+
+	if len(revisions.revisions) != 1 {
+		t.Fatalf("expected 1 revision, got %d", len(revisions.revisions))
+	}
+	r := revisions.revisions[0]
+	if quiz.RevisionID != r.ID || r.Number != 1 || r.Action != domain.RevisionActionCreate {
+		t.Errorf("unexpected revision %+v for quiz %+v", r, quiz)
+	}
+}
+
+func TestUpdateQuiz_SavesRevision(t *testing.T) {
+	repo := newMockRepo()
+	revisions := newMockRevisionRepo()
+	service := NewQuizService(repo, revisions, &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+	quiz := createTestQuiz(t, service)
+
+	updated, err := service.Update(context.Background(), quiz.ID, UpdateQuizRequest{
+		Question: " 2 + 3 = ? ",
+		Choice1:  "3",
+		Choice2:  "4",
+		Choice3:  "5",
+		Choice4:  "6",
+		Answer:   3,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if updated.Question != "2 + 3 = ?" || updated.Answer != 0 || repo.quizzes[0].Answer != 3 {
+		t.Errorf("expected updated content without the answer of an unowned quiz, got %+v", updated)
+	}
+	if len(revisions.revisions) != 2 || updated.RevisionID != revisions.revisions[1].ID {
+		t.Fatalf("expected quiz to point at revision 2, got %+v", revisions.revisions)
+	}
+	if revisions.revisions[1].Number != 2 || revisions.revisions[1].Action != domain.RevisionActionUpdate {
+		t.Errorf("unexpected revision %+v", revisions.revisions[1])
+	}
+	if repo.quizzes[0].Question != "2 + 3 = ?" {
+		t.Errorf("expected repository to be updated, got %q", repo.quizzes[0].Question)
+	}
+	if len(repo.locked) != 1 || repo.locked[0] != quiz.ID {
+		t.Errorf("expected the quiz locked before it was read, got %v", repo.locked)
+	}
+}
+
+func TestUpdateQuiz_UnchangedContentKeepsRevision(t *testing.T) {
+	revisions := newMockRevisionRepo()
+	service := NewQuizService(newMockRepo(), revisions, &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+	quiz := createTestQuiz(t, service)
+
+	updated, err := service.Update(context.Background(), quiz.ID, UpdateQuizRequest{
+		Question: quiz.Question,
+		Choice1:  quiz.Choice1,
+		Choice2:  quiz.Choice2,
+		Choice3:  quiz.Choice3,
+		Choice4:  quiz.Choice4,
+		Answer:   2,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(revisions.revisions) != 1 || updated.RevisionID != quiz.RevisionID {
+		t.Errorf("expected no new revision, got %d", len(revisions.revisions))
+	}
+}
+
+func TestUpdateQuiz_NotFound(t *testing.T) {
+	service := NewQuizService(newMockRepo(), newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	_, err := service.Update(context.Background(), "missing", UpdateQuizRequest{
+		Question: "Q", Choice1: "a", Choice2: "b", Choice3: "c", Choice4: "d",
+	})
+	if !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got %v", err)
+	}
+}
+
+func TestRevert_RestoresContentAsNewRevision(t *testing.T) {
+	repo := newMockRepo()
+	revisions := newMockRevisionRepo()
+	service := NewQuizService(repo, revisions, &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+	quiz := createTestQuiz(t, service)
+	first := quiz.RevisionID
+
+	if _, err := service.Update(context.Background(), quiz.ID, UpdateQuizRequest{
+		Question: "Changed", Choice1: "a", Choice2: "b", Choice3: "c", Choice4: "d", Answer: 1,
+	}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	revisionService := NewRevisionService(repo, revisions, &mockTransitionRepository{}, passthroughTxManager{}, nil)
+	repo.locked = nil
+	reverted, err := revisionService.Revert(signedIn("admin", utils.RoleAdmin), quiz.ID, first)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(repo.locked) != 1 || repo.locked[0] != quiz.ID {
+		t.Errorf("expected the quiz locked before it was read, got %v", repo.locked)
+	}
+
+	if reverted.Question != "2 + 2 = ?" || reverted.Choice1 != "3" || reverted.Answer != 2 {
+		t.Errorf("expected original content, got %+v", reverted)
+	}
+	if len(revisions.revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revisions.revisions))
+	}
+	last := revisions.revisions[2]
+	if last.Action != domain.RevisionActionRevert || last.RevertedFrom == nil || *last.RevertedFrom != first {
+		t.Errorf("expected revert revision pointing at %s, got %+v", first, last)
+	}
+
+	// Reverting again to the same content is rejected
+	_, err = revisionService.Revert(context.Background(), quiz.ID, first)
+	if !errors.Is(err, domain.ErrAlreadyCurrent) {
+		t.Errorf("expected ErrAlreadyCurrent, got %v", err)
+	}
+}
+
+func TestDiff_AgainstCurrentRevision(t *testing.T) {
+	repo := newMockRepo()
+	revisions := newMockRevisionRepo()
+	service := NewQuizService(repo, revisions, &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+	quiz := createTestQuiz(t, service)
+
+	if _, err := service.Update(context.Background(), quiz.ID, UpdateQuizRequest{
+		Question: "2 + 2 = ?", Choice1: "3", Choice2: "four", Choice3: "5", Choice4: "6", Answer: 2,
+	}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	diff, err := NewRevisionService(repo, revisions, &mockTransitionRepository{}, passthroughTxManager{}, nil).Diff(context.Background(), quiz.ID, quiz.RevisionID, "")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if diff.From.Number != 1 || diff.To.Number != 2 {
+		t.Errorf("expected diff from 1 to 2, got %d to %d", diff.From.Number, diff.To.Number)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Field != "choice2" || diff.Changes[0].From != "4" || diff.Changes[0].To != "four" {
+		t.Errorf("unexpected changes %+v", diff.Changes)
+	}
+}
+
+func TestDiff_UnknownRevision(t *testing.T) {
+	repo := newMockRepo()
+	revisions := newMockRevisionRepo()
+	quiz := createTestQuiz(t, NewQuizService(repo, revisions, &mockTransitionRepository{}, passthroughTxManager{}, 0, nil))
+
+	_, err := NewRevisionService(repo, revisions, &mockTransitionRepository{}, passthroughTxManager{}, nil).Diff(context.Background(), quiz.ID, "missing", "")
+
+	var appErr *sharedDomain.AppError
+	if !errors.As(err, &appErr) || appErr.Message != domain.ErrRevisionNotFound.Message {
+		t.Errorf("expected ErrRevisionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+)
+
+// ScheduleService defines scheduling when published quizzes are visible
+type ScheduleService interface {
+	Set(ctx context.Context, quizID string, req ScheduleRequest) (*QuizResponse, error)
+	SyncVisibility(ctx context.Context) (int, error)
+}
+
+type scheduleService struct {
+	repo     domain.QuizRepository
+	location *time.Location
+	events   *events.EventBus
+	now      func() time.Time
+}
+
+// NewScheduleService creates a new ScheduleService. Schedule times without a
+// UTC offset are read in loc, and responses are rendered in it. Schedule and
+// visibility changes are published on bus, which may be nil.
+func NewScheduleService(repo domain.QuizRepository, loc *time.Location, bus *events.EventBus) ScheduleService {
+	return &scheduleService{repo: repo, location: loc, events: bus, now: time.Now}
+}
+
+// Set replaces the publication window of a quiz. Empty bounds are open, so
+// an empty request removes the schedule.
+func (s *scheduleService) Set(ctx context.Context, quizID string, req ScheduleRequest) (*QuizResponse, error) {
+	schedule, err := sharedDomain.NewSchedule(req.PublishAt, req.UnpublishAt, s.location)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz, err := s.repo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, domain.ErrQuizNotFound
+	}
+	if err := authorizeChange(ctx, quiz); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSchedule(ctx, quiz.ID, schedule); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to update quiz schedule", err)
+	}
+	publishChange(ctx, s.events, events.QuizUpdatedEvent{QuizID: quiz.ID, UserID: currentUser(ctx)})
+
+	quiz.Schedule = schedule
+	resp := toQuizResponse(ctx, *quiz)
+	resp.Schedule = schedule.In(s.location)
+	return &resp, nil
+}
+
+// SyncVisibility records which quizzes are visible now and publishes a
+// QuizVisibilityChangedEvent for each quiz that appeared or disappeared since
+// the last run, whether through its schedule, the review workflow or the
+// trash. It returns the number of changes. A failing subscriber does not
+// stop the other events from being published.
+func (s *scheduleService) SyncVisibility(ctx context.Context) (int, error) {
+	changes, err := s.repo.SyncVisibility(ctx)
+	if err != nil {
+		return 0, sharedDomain.NewInternalError("Failed to sync quiz visibility", err)
+	}
+	if s.events == nil {
+		return len(changes), nil
+	}
+
+	var errs []error
+	at := s.now()
+	for _, c := range changes {
+		if err := s.events.Publish(ctx, events.QuizVisibilityChangedEvent{QuizID: c.ID, Visible: c.Visible, At: at}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return len(changes), sharedDomain.NewInternalError("Failed to publish quiz visibility changes", errors.Join(errs...))
+	}
+	return len(changes), nil
+}
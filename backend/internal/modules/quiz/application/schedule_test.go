@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+)
+
+func TestSchedule_Set(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{{ID: "a", Status: domain.StatusPublished}}
+	service := NewScheduleService(repo, bangkok, nil)
+
+	resp, err := service.Set(context.Background(), "a", ScheduleRequest{PublishAt: "2025-03-01T09:00", UnpublishAt: "2025-03-01T03:00:00Z"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if want := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC); !repo.quizzes[0].PublishAt.Equal(want) {
+		t.Errorf("expected publish_at to be read in the schedule zone, got %v", repo.quizzes[0].PublishAt)
+	}
+	if resp.UnpublishAt.Location() != bangkok || resp.UnpublishAt.Hour() != 10 {
+		t.Errorf("expected unpublish_at rendered in the schedule zone, got %v", resp.UnpublishAt)
+	}
+
+	// An empty request removes the schedule
+	if _, err := service.Set(context.Background(), "a", ScheduleRequest{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if repo.quizzes[0].PublishAt != nil || repo.quizzes[0].UnpublishAt != nil {
+		t.Errorf("expected no schedule, got %+v", repo.quizzes[0].Schedule)
+	}
+}
+
+func TestSchedule_SetErrors(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{{ID: "a"}}
+	service := NewScheduleService(repo, time.UTC, nil)
+
+	if _, err := service.Set(context.Background(), "a", ScheduleRequest{PublishAt: "2025-03-01T12:00", UnpublishAt: "2025-03-01T09:00"}); !errors.Is(err, sharedDomain.ErrInvalidSchedule) {
+		t.Errorf("expected ErrInvalidSchedule, got %v", err)
+	}
+	if _, err := service.Set(context.Background(), "missing", ScheduleRequest{}); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got %v", err)
+	}
+}
+
+func TestSchedule_VisibleListRespectsWindow(t *testing.T) {
+	opens := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{
+		{ID: "a", DisplayOrder: 1, Status: domain.StatusPublished, Schedule: sharedDomain.Schedule{PublishAt: &opens}},
+		{ID: "b", DisplayOrder: 2, Status: domain.StatusPublished},
+		{ID: "c", DisplayOrder: 3, Status: domain.StatusDraft},
+	}
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	repo.now = opens.Add(-time.Second)
+	if quizzes, _ := service.GetVisible(context.Background()); len(quizzes) != 1 || quizzes[0].ID != "b" {
+		t.Errorf("expected only b before the window opens, got %+v", quizzes)
+	}
+	repo.now = opens
+	if quizzes, _ := service.GetVisible(context.Background()); len(quizzes) != 2 {
+		t.Errorf("expected a and b once the window opens, got %+v", quizzes)
+	}
+}
+
+func TestSchedule_SyncVisibilityPublishesChanges(t *testing.T) {
+	bus := events.NewEventBus()
+	var received []events.QuizVisibilityChangedEvent
+	bus.Subscribe(events.QuizVisibilityChangedEvent{}.Name(), func(_ context.Context, e events.Event) error {
+		received = append(received, e.(events.QuizVisibilityChangedEvent))
+		return nil
+	})
+
+	opens := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+	closes := opens.Add(3 * time.Hour)
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{{ID: "a", Status: domain.StatusPublished, Schedule: sharedDomain.Schedule{PublishAt: &opens, UnpublishAt: &closes}}}
+	service := NewScheduleService(repo, time.UTC, bus)
+
+	for _, now := range []time.Time{opens.Add(-time.Minute), opens, opens.Add(time.Hour), closes} {
+		repo.now = now
+		if _, err := service.SyncVisibility(context.Background()); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	if len(received) != 2 || !received[0].Visible || received[1].Visible || received[1].QuizID != "a" {
+		t.Errorf("expected a to appear then disappear, got %+v", received)
+	}
+
+	// A failing subscriber is reported once the changes are stored
+	bus.Subscribe(events.QuizVisibilityChangedEvent{}.Name(), func(context.Context, events.Event) error {
+		return errors.New("subscriber down")
+	})
+	repo.now = opens
+	if n, err := service.SyncVisibility(context.Background()); n != 1 || err == nil {
+		t.Errorf("expected the change to be counted and the error reported, got %d %v", n, err)
+	}
+	if !repo.visible["a"] {
+		t.Error("expected the visibility to be stored")
+	}
+}
@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// Search result limits
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// Snippets show at most snippetLength characters, starting up to
+// snippetContext characters before the first match
+const (
+	snippetLength  = 160
+	snippetContext = 40
+)
+
+// SearchService defines full-text search over quizzes
+type SearchService interface {
+	Search(ctx context.Context, q string, limit int) ([]SearchResultResponse, error)
+}
+
+type searchService struct {
+	repo domain.QuizRepository
+}
+
+// NewSearchService creates a new SearchService
+func NewSearchService(repo domain.QuizRepository) SearchService {
+	return &searchService{repo: repo}
+}
+
+// Search returns quizzes matching every term of q, best match first, with
+// highlighted snippets of the fields that matched. Only admins find quizzes
+// outside the public listing.
+func (s *searchService) Search(ctx context.Context, q string, limit int) ([]SearchResultResponse, error) {
+	query := domain.ParseSearchQuery(q)
+	if query.IsEmpty() {
+		return nil, domain.ErrEmptySearch
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	results, err := s.repo.Search(ctx, query, !utils.IsAdmin(ctx), limit)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to search quizzes", err)
+	}
+
+	terms := query.Terms()
+	responses := make([]SearchResultResponse, len(results))
+	for i, r := range results {
+		responses[i] = SearchResultResponse{
+			QuizResponse: toQuizResponse(ctx, r.Quiz),
+			Rank:         r.Rank,
+			Highlights:   []SearchHighlight{},
+		}
+		fields := []struct{ name, text string }{
+			{"question", r.Question},
+			{"choice1", r.Choice1},
+			{"choice2", r.Choice2},
+			{"choice3", r.Choice3},
+			{"choice4", r.Choice4},
+		}
+		for _, f := range fields {
+			if snippet, ok := highlight(f.text, terms); ok {
+				responses[i].Highlights = append(responses[i].Highlights, SearchHighlight{Field: f.name, Snippet: snippet})
+			}
+		}
+	}
+	return responses, nil
+}
+
+// highlight returns an HTML-escaped snippet of text with every
+// case-insensitive occurrence of the terms wrapped in <mark>, or false if
+// no term occurs in text
+func highlight(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var matches [][2]int
+	for _, term := range terms {
+		t := []rune(term)
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == term {
+				matches = append(matches, [2]int{i, i + len(t)})
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	// Merge overlapping matches
+	sort.Slice(matches, func(i, j int) bool { return matches[i][0] < matches[j][0] })
+	merged := [][2]int{matches[0]}
+	for _, m := range matches[1:] {
+		last := &merged[len(merged)-1]
+		if m[0] <= last[1] {
+			last[1] = max(last[1], m[1])
+			continue
+		}
+		merged = append(merged, m)
+	}
+
+	start, end := 0, len(runes)
+	if len(runes) > snippetLength {
+		start = max(0, merged[0][0]-snippetContext)
+		end = min(len(runes), start+snippetLength)
+		start = max(0, end-snippetLength)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, m := range merged {
+		from, to := max(m[0], start), min(m[1], end)
+		if from >= to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:from])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[from:to])))
+		b.WriteString("</mark>")
+		pos = to
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// ============ Test Cases ============
+
+func TestParseSearchQuery_SplitsThaiAndWords(t *testing.T) {
+	query := domain.ParseSearchQuery("  Photo-Synthesis  สังเคราะห์แสง 42 ")
+
+	if strings.Join(query.Lexemes, ",") != "photo,synthesis,42" {
+		t.Errorf("unexpected lexemes %v", query.Lexemes)
+	}
+	if len(query.Substrings) != 1 || query.Substrings[0] != "สังเคราะห์แสง" {
+		t.Errorf("unexpected substrings %v", query.Substrings)
+	}
+	if query.TSQuery() != "photo:* & synthesis:* & 42:*" {
+		t.Errorf("unexpected tsquery %q", query.TSQuery())
+	}
+}
+
+func TestParseSearchQuery_EscapesLikePatterns(t *testing.T) {
+	query := domain.ParseSearchQuery(`ร้อยละ_100%`)
+
+	if got := query.LikePatterns(); len(got) != 1 || got[0] != `%ร้อยละ\_100\%%` {
+		t.Errorf("unexpected patterns %v", got)
+	}
+	if !domain.ParseSearchQuery(" !? ").IsEmpty() {
+		t.Error("expected punctuation-only query to be empty")
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	snippet, ok := highlight("What is <b>Photosynthesis</b>? photo", []string{"photo"})
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	want := "What is &lt;b&gt;<mark>Photo</mark>synthesis&lt;/b&gt;? <mark>photo</mark>"
+	if snippet != want {
+		t.Errorf("expected %q, got %q", want, snippet)
+	}
+
+	if _, ok := highlight("nothing here", []string{"photo"}); ok {
+		t.Error("expected no match")
+	}
+}
+
+func TestHighlight_ThaiAndLongText(t *testing.T) {
+	text := strings.Repeat("ก", 200) + "การสังเคราะห์แสง" + strings.Repeat("ข", 200)
+	snippet, ok := highlight(text, []string{"สังเคราะห์"})
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") {
+		t.Errorf("expected a trimmed snippet, got %q", snippet)
+	}
+	if !strings.Contains(snippet, "การ<mark>สังเคราะห์</mark>แสง") {
+		t.Errorf("expected Thai match highlighted, got %q", snippet)
+	}
+	if n := len([]rune(strings.NewReplacer("<mark>", "", "</mark>", "", "…", "").Replace(snippet))); n != snippetLength {
+		t.Errorf("expected %d characters, got %d", snippetLength, n)
+	}
+}
+
+func TestSearch_HighlightsMatchingFields(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{
+		{ID: "a", Question: "พืชใช้แสงทำอะไร", Choice1: "สังเคราะห์แสง", Choice2: "หายใจ", Choice3: "คายน้ำ", Choice4: "ลำเลียง", Status: domain.StatusPublished},
+		{ID: "b", Question: "Which gas do plants release?", Choice1: "Oxygen", Choice2: "CO2", Choice3: "N2", Choice4: "H2", Status: domain.StatusPublished},
+	}
+	service := NewSearchService(repo)
+
+	results, err := service.Search(context.Background(), "แสง", 0)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if repo.searchLimit != DefaultSearchLimit {
+		t.Errorf("expected default limit %d, got %d", DefaultSearchLimit, repo.searchLimit)
+	}
+	if len(results) != 1 || results[0].ID != "a" {
+		t.Fatalf("expected quiz a, got %+v", results)
+	}
+	h := results[0].Highlights
+	if len(h) != 2 || h[0].Field != "question" || h[1].Field != "choice1" || h[1].Snippet != "สังเคราะห์<mark>แสง</mark>" {
+		t.Errorf("unexpected highlights %+v", h)
+	}
+}
+
+func TestSearch_PublicFindsVisibleQuizzesOnly(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{
+		{ID: "draft", Question: "Plants draft", Status: domain.StatusDraft},
+		{ID: "published", Question: "Plants published", Status: domain.StatusPublished},
+	}
+	service := NewSearchService(repo)
+
+	results, err := service.Search(signedIn("bob", "user"), "plants", 0)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "published" {
+		t.Errorf("expected only the published quiz, got %+v", results)
+	}
+
+	results, _ = service.Search(signedIn("bob", utils.RoleAdmin), "plants", 0)
+	if len(results) != 2 {
+		t.Errorf("expected admins to find every quiz, got %+v", results)
+	}
+}
+
+func TestSearch_Validation(t *testing.T) {
+	repo := newMockRepo()
+	service := NewSearchService(repo)
+
+	if _, err := service.Search(context.Background(), "  ", 10); !errors.Is(err, domain.ErrEmptySearch) {
+		t.Errorf("expected ErrEmptySearch, got %v", err)
+	}
+
+	service.Search(context.Background(), "oxygen", 1000)
+	if repo.searchLimit != MaxSearchLimit {
+		t.Errorf("expected limit clamped to %d, got %d", MaxSearchLimit, repo.searchLimit)
+	}
+}
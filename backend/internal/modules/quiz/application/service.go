@@ -4,104 +4,369 @@ import (
 	"context"
 	"strings"
 
+	"github.com/google/uuid"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
 	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
 	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/listquery"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
 )
 
 // QuizService defines the quiz business logic interface
 type QuizService interface {
 	GetAll(ctx context.Context) ([]QuizResponse, error)
-	Create(ctx context.Context, req CreateQuizRequest) (*QuizResponse, error)
+	GetVisible(ctx context.Context) ([]QuizResponse, error)
+	List(ctx context.Context, req ListQuizzesRequest) (*QuizPage, error)
+	Create(ctx context.Context, req CreateQuizRequest) (*CreateQuizResponse, error)
+	Update(ctx context.Context, id string, req UpdateQuizRequest) (*QuizResponse, error)
 	Delete(ctx context.Context, id string) error
 }
 
 type quizService struct {
-	repo domain.QuizRepository
+	repo        domain.QuizRepository
+	revisions   domain.RevisionRepository
+	transitions domain.TransitionRepository
+	txManager   database.TxManager
+	detector    duplicateDetector
+	events      *events.EventBus
 }
 
-// NewQuizService creates a new QuizService
-func NewQuizService(repo domain.QuizRepository) QuizService {
-	return &quizService{repo: repo}
+// NewQuizService creates a new QuizService. New questions at least
+// duplicateThreshold similar to an existing one are reported as duplicates.
+// Edits that send published quizzes back to review are recorded in
+// transitions. Changes are published on bus, which may be nil.
+func NewQuizService(repo domain.QuizRepository, revisions domain.RevisionRepository, transitions domain.TransitionRepository, txManager database.TxManager, duplicateThreshold float64, bus *events.EventBus) QuizService {
+	return &quizService{repo: repo, revisions: revisions, transitions: transitions, txManager: txManager, detector: newDuplicateDetector(repo, duplicateThreshold), events: bus}
 }
 
-// GetAll returns all quizzes ordered by display_order
+// GetAll returns the quizzes the current user manages in every status
+// ordered by display_order, with their number of unresolved reviewer threads
+// and their p-value
 func (s *quizService) GetAll(ctx context.Context) ([]QuizResponse, error) {
+	owner, err := manageScope(ctx)
+	if err != nil {
+		return nil, err
+	}
 	quizzes, err := s.repo.GetAll(ctx)
 	if err != nil {
 		return nil, sharedDomain.NewInternalError("Failed to fetch quizzes", err)
 	}
+	responses := []QuizResponse{}
+	for i, q := range quizzes {
+		if owner != nil && !q.IsOwnedBy(*owner) {
+			continue
+		}
+		resp := toQuizResponse(ctx, q)
+		resp.UnresolvedComments = &quizzes[i].UnresolvedComments
+		resp.PValue = quizzes[i].PValue
+		responses = append(responses, resp)
+	}
+	return responses, nil
+}
 
+// GetVisible returns the published quizzes inside their schedule ordered by display_order
+func (s *quizService) GetVisible(ctx context.Context) ([]QuizResponse, error) {
+	quizzes, err := s.repo.GetVisible(ctx)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quizzes", err)
+	}
 	responses := make([]QuizResponse, len(quizzes))
 	for i, q := range quizzes {
-		responses[i] = toQuizResponse(q)
+		responses[i] = toQuizResponse(ctx, q)
 	}
 	return responses, nil
 }
 
-// Create creates a new quiz with auto-assigned display_order
-func (s *quizService) Create(ctx context.Context, req CreateQuizRequest) (*QuizResponse, error) {
-	// Validate required fields
-	if strings.TrimSpace(req.Question) == "" ||
-		strings.TrimSpace(req.Choice1) == "" ||
-		strings.TrimSpace(req.Choice2) == "" ||
-		strings.TrimSpace(req.Choice3) == "" ||
-		strings.TrimSpace(req.Choice4) == "" {
-		return nil, domain.ErrInvalidQuiz
+// List returns one page of the quizzes matching the request's filters,
+// either by page number or by keyset cursor. Cursors stay stable while
+// quizzes are added or removed elsewhere in the list, but only work with the
+// default display_order sort. The management listing (not Visible) holds
+// every quiz for admins and reviewers and the user's own quizzes otherwise.
+func (s *quizService) List(ctx context.Context, req ListQuizzesRequest) (*QuizPage, error) {
+	pagination := sharedDomain.NewPagination(req.Page, req.PageSize)
+	query := req.Query
+	if query == nil {
+		query = domain.QuizListSchema.Default()
+	}
+	ownOnly := false
+	if req.Visible {
+		query = domain.QuizListSchema.Restrict(query, "visible", listquery.OpEq, "true")
+	} else {
+		owner, err := manageScope(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if owner != nil {
+			query = domain.QuizListSchema.Restrict(query, "created_by", listquery.OpEq, *owner)
+			ownOnly = true
+		}
+	}
+	// Sorting or filtering by answer would reveal the answers left out of
+	// other users' quizzes
+	if !utils.IsAdmin(ctx) && !ownOnly && usesField(query, "answer") {
+		return nil, domain.ErrAnswerField
+	}
+	if author := strings.TrimSpace(req.Author); author != "" {
+		if author == authorMe {
+			userID, ok := utils.GetUserID(ctx)
+			if !ok {
+				return nil, domain.ErrSignInRequired
+			}
+			author = userID
+		}
+		query = domain.QuizListSchema.Restrict(query, "created_by", listquery.OpEq, author)
 	}
 
-	// Get the next display_order
-	maxOrder, err := s.repo.GetMaxDisplayOrder(ctx)
-	if err != nil {
-		return nil, sharedDomain.NewInternalError("Failed to get max display order", err)
+	var cursor *domain.QuizCursor
+	if req.Cursor != "" {
+		cursor = &domain.QuizCursor{}
+		if err := sharedDomain.DecodeCursor(req.Cursor, cursor); err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		if _, err := uuid.Parse(cursor.ID); err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		if query.CustomSort {
+			return nil, domain.ErrCursorSort
+		}
+		pagination.Page = 0
 	}
 
-	quiz := &domain.Quiz{
-		ID:           sharedDomain.NewID(),
-		Question:     strings.TrimSpace(req.Question),
-		Choice1:      strings.TrimSpace(req.Choice1),
-		Choice2:      strings.TrimSpace(req.Choice2),
-		Choice3:      strings.TrimSpace(req.Choice3),
-		Choice4:      strings.TrimSpace(req.Choice4),
-		DisplayOrder: maxOrder + 1,
+	total, err := s.repo.Count(ctx, query)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to count quizzes", err)
 	}
+	pagination.Total = total
 
-	if err := s.repo.Create(ctx, quiz); err != nil {
-		return nil, sharedDomain.NewInternalError("Failed to create quiz", err)
+	var quizzes []domain.Quiz
+	hasNext, hasPrev := false, false
+	if cursor == nil {
+		quizzes, err = s.repo.GetPage(ctx, query, pagination.Offset(), pagination.Limit())
+		hasNext, hasPrev = pagination.HasNext(), pagination.HasPrev()
+	} else {
+		// Read one extra quiz to find out whether there is another page
+		quizzes, err = s.repo.GetPageByCursor(ctx, query, *cursor, pagination.Limit()+1)
+		more := len(quizzes) > pagination.Limit()
+		switch {
+		case more && cursor.Before:
+			quizzes = quizzes[1:]
+		case more:
+			quizzes = quizzes[:pagination.Limit()]
+		}
+		hasNext, hasPrev = more || cursor.Before, more || !cursor.Before
+	}
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quizzes", err)
 	}
 
-	resp := toQuizResponse(*quiz)
-	return &resp, nil
+	page := &QuizPage{Items: make([]QuizResponse, len(quizzes)), Pagination: pagination}
+	for i, q := range quizzes {
+		page.Items[i] = toQuizResponse(ctx, q)
+		if req.Visible {
+			continue
+		}
+		page.Items[i].UnresolvedComments = &quizzes[i].UnresolvedComments
+		page.Items[i].PValue = quizzes[i].PValue
+	}
+	if len(quizzes) > 0 && !query.CustomSort {
+		first, last := quizzes[0], quizzes[len(quizzes)-1]
+		if hasNext {
+			page.NextCursor = sharedDomain.EncodeCursor(domain.QuizCursor{DisplayOrder: last.DisplayOrder, ID: last.ID})
+		}
+		if hasPrev {
+			page.PrevCursor = sharedDomain.EncodeCursor(domain.QuizCursor{DisplayOrder: first.DisplayOrder, ID: first.ID, Before: true})
+		}
+	}
+	return page, nil
 }
 
-// Delete removes a quiz and renumbers remaining quizzes
-func (s *quizService) Delete(ctx context.Context, id string) error {
-	// Get the quiz to find its display_order
-	quiz, err := s.repo.GetByID(ctx, id)
+// Create creates a new draft quiz with auto-assigned display_order and its
+// first revision, reporting existing quizzes with the same or a similar question
+func (s *quizService) Create(ctx context.Context, req CreateQuizRequest) (*CreateQuizResponse, error) {
+	quiz, err := newQuizContent(req.Question, req.Choice1, req.Choice2, req.Choice3, req.Choice4, req.Answer)
 	if err != nil {
-		return domain.ErrQuizNotFound
+		return nil, err
 	}
+	policy, err := req.OnDuplicate.validate()
+	if err != nil {
+		return nil, err
+	}
+	quiz.ID = sharedDomain.NewID()
+	quiz.CreatedBy = currentUser(ctx)
+	quiz.UpdatedBy = quiz.CreatedBy
+
+	var duplicates []DuplicateMatch
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		duplicates, err = s.detector.find(ctx, quiz.Question)
+		if err != nil {
+			return err
+		}
+		if len(duplicates) > 0 && policy == DuplicatePolicyBlock {
+			return domain.ErrDuplicateQuiz.WithDetails(map[string]interface{}{"duplicates": duplicates})
+		}
+
+		// Get the next display_order
+		maxOrder, err := s.repo.GetMaxDisplayOrder(ctx)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to get max display order", err)
+		}
+		quiz.DisplayOrder = maxOrder + 1
 
-	// Delete the quiz
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return sharedDomain.NewInternalError("Failed to delete quiz", err)
+		if err := saveRevision(ctx, s.revisions, quiz, domain.RevisionActionCreate, nil); err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, quiz); err != nil {
+			return sharedDomain.NewInternalError("Failed to create quiz", err)
+		}
+		publishChange(ctx, s.events, events.QuizCreatedEvent{QuizID: quiz.ID, UserID: quiz.CreatedBy})
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Renumber: decrement display_order for all quizzes above the deleted one
-	if err := s.repo.DecrementDisplayOrdersAbove(ctx, quiz.DisplayOrder); err != nil {
-		return sharedDomain.NewInternalError("Failed to renumber quizzes", err)
+	return &CreateQuizResponse{QuizResponse: toQuizResponse(ctx, *quiz), Duplicates: duplicates}, nil
+}
+
+// Update replaces the content of a quiz, keeping the previous wording as a revision.
+// An update that changes nothing does not create a revision. Only the author
+// or an admin may update a quiz that has an author. Editing a published quiz
+// sends it back to review.
+func (s *quizService) Update(ctx context.Context, id string, req UpdateQuizRequest) (*QuizResponse, error) {
+	content, err := newQuizContent(req.Question, req.Choice1, req.Choice2, req.Choice3, req.Choice4, req.Answer)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	var quiz *domain.Quiz
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// Lock the quiz before reading it, so concurrent edits apply in turn
+		// and each is compared with the content the previous one saved
+		quiz, err = s.repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return domain.ErrQuizNotFound
+		}
+		if err := authorizeChange(ctx, quiz); err != nil {
+			return err
+		}
+
+		revision := domain.NewRevision(content, "", 0, "")
+		if revision.SameContent(quiz) {
+			return nil
+		}
+		revision.ApplyTo(quiz)
+		quiz.UpdatedBy = currentUser(ctx)
+
+		if err := saveRevision(ctx, s.revisions, quiz, domain.RevisionActionUpdate, nil); err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, quiz); err != nil {
+			return sharedDomain.NewInternalError("Failed to update quiz", err)
+		}
+		if err := reviseIfPublished(ctx, s.repo, s.transitions, quiz); err != nil {
+			return err
+		}
+		publishChange(ctx, s.events, events.QuizUpdatedEvent{QuizID: quiz.ID, UserID: quiz.UpdatedBy})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toQuizResponse(ctx, *quiz)
+	return &resp, nil
+}
+
+// Delete moves a quiz to the trash and renumbers remaining quizzes. Only the
+// author or an admin may delete a quiz that has an author.
+func (s *quizService) Delete(ctx context.Context, id string) error {
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// Get the quiz to find its display_order
+		quiz, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return domain.ErrQuizNotFound
+		}
+		if err := authorizeChange(ctx, quiz); err != nil {
+			return err
+		}
+
+		// Move the quiz to the trash
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return sharedDomain.NewInternalError("Failed to delete quiz", err)
+		}
+
+		// Renumber: decrement display_order for all quizzes above the deleted one
+		if err := s.repo.DecrementDisplayOrdersAbove(ctx, quiz.DisplayOrder); err != nil {
+			return sharedDomain.NewInternalError("Failed to renumber quizzes", err)
+		}
+		userID := currentUser(ctx)
+		publishChange(ctx, s.events, events.QuizDeletedEvent{QuizID: id, UserID: userID})
+		publishChange(ctx, s.events, events.QuizzesReorderedEvent{UserID: userID})
+		return nil
+	})
 }
 
-func toQuizResponse(q domain.Quiz) QuizResponse {
-	return QuizResponse{
+// toQuizResponse converts a quiz for the current user, leaving out the
+// answer unless they may see it. Every response carrying a quiz goes
+// through here.
+func toQuizResponse(ctx context.Context, q domain.Quiz) QuizResponse {
+	resp := QuizResponse{
 		ID:           q.ID,
 		Question:     q.Question,
 		Choice1:      q.Choice1,
 		Choice2:      q.Choice2,
 		Choice3:      q.Choice3,
 		Choice4:      q.Choice4,
+		Answer:       q.Answer,
 		DisplayOrder: q.DisplayOrder,
+		RevisionID:   q.RevisionID,
+		Status:       q.Status,
+		CreatedBy:    q.CreatedBy,
+		UpdatedBy:    q.UpdatedBy,
+		Schedule:     q.Schedule,
+	}
+	if !canSeeAnswer(ctx, q) {
+		resp.Answer = 0
+	}
+	return resp
+}
+
+// usesField returns true if query sorts or filters by field
+func usesField(query *listquery.Query, field string) bool {
+	for _, s := range query.Sorts {
+		if s.Field == field {
+			return true
+		}
+	}
+	for _, f := range query.Filters {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// newQuizContent validates and trims the editable fields of a quiz. New
+// quizzes start as drafts.
+func newQuizContent(question, choice1, choice2, choice3, choice4 string, answer int) (*domain.Quiz, error) {
+	quiz := &domain.Quiz{
+		Question: strings.TrimSpace(question),
+		Choice1:  strings.TrimSpace(choice1),
+		Choice2:  strings.TrimSpace(choice2),
+		Choice3:  strings.TrimSpace(choice3),
+		Choice4:  strings.TrimSpace(choice4),
+		Answer:   answer,
+		Status:   domain.StatusDraft,
+	}
+
+	// Validate required fields
+	if quiz.Question == "" || quiz.Choice1 == "" || quiz.Choice2 == "" || quiz.Choice3 == "" || quiz.Choice4 == "" {
+		return nil, domain.ErrInvalidQuiz
+	}
+	if answer < 0 || answer > domain.NumChoices {
+		return nil, domain.ErrInvalidAnswer
 	}
+	return quiz, nil
 }
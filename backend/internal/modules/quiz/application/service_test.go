@@ -2,14 +2,26 @@ package application
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 	"testing"
+	"time"
+	"unicode"
 
 	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/listquery"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
 )
 
-// mockQuizRepository is a mock implementation of domain.QuizRepository
+// mockQuizRepository is a mock implementation of domain.QuizRepository.
+// Deleted quizzes are moved from quizzes to trash.
 type mockQuizRepository struct {
 	quizzes         []domain.Quiz
+	trash           []domain.Quiz
 	getMaxOrderResp int
 	getMaxOrderErr  error
 	createErr       error
@@ -17,6 +29,20 @@ type mockQuizRepository struct {
 	decrementErr    error
 	getByIDResp     *domain.Quiz
 	getByIDErr      error
+	renumbered      int
+	searchLimit     int
+	listQuery       *listquery.Query
+	// similar holds the similarity of pairs of normalized questions,
+	// standing in for trigram similarity
+	similar map[[2]string]float64
+	// now stands in for NOW() when evaluating schedules; zero means time.Now
+	now time.Time
+	// visible is the visibility last stored by SyncVisibility
+	visible map[string]bool
+	// answered holds the IDs of quizzes answered in attempts, which are never purged
+	answered map[string]bool
+	// locked holds the IDs of quizzes read with GetByIDForUpdate
+	locked []string
 }
 
 func newMockRepo() *mockQuizRepository {
@@ -29,6 +55,62 @@ func (m *mockQuizRepository) GetAll(_ context.Context) ([]domain.Quiz, error) {
 	return m.quizzes, nil
 }
 
+func (m *mockQuizRepository) GetVisible(_ context.Context) ([]domain.Quiz, error) {
+	visible := []domain.Quiz{}
+	for _, q := range m.sorted() {
+		if q.IsVisible(m.clock()) {
+			visible = append(visible, q)
+		}
+	}
+	return visible, nil
+}
+
+func (m *mockQuizRepository) Count(_ context.Context, q *listquery.Query) (int64, error) {
+	m.listQuery = q
+	return int64(len(m.quizzes)), nil
+}
+
+// sorted returns the quizzes in list order
+func (m *mockQuizRepository) sorted() []domain.Quiz {
+	quizzes := append([]domain.Quiz{}, m.quizzes...)
+	sort.Slice(quizzes, func(i, j int) bool {
+		if quizzes[i].DisplayOrder != quizzes[j].DisplayOrder {
+			return quizzes[i].DisplayOrder < quizzes[j].DisplayOrder
+		}
+		return quizzes[i].ID < quizzes[j].ID
+	})
+	return quizzes
+}
+
+func (m *mockQuizRepository) GetPage(_ context.Context, _ *listquery.Query, offset, limit int) ([]domain.Quiz, error) {
+	quizzes := m.sorted()
+	if offset >= len(quizzes) {
+		return []domain.Quiz{}, nil
+	}
+	return quizzes[offset:min(offset+limit, len(quizzes))], nil
+}
+
+func (m *mockQuizRepository) GetPageByCursor(_ context.Context, _ *listquery.Query, cursor domain.QuizCursor, limit int) ([]domain.Quiz, error) {
+	after := func(q domain.Quiz) bool {
+		return q.DisplayOrder > cursor.DisplayOrder || (q.DisplayOrder == cursor.DisplayOrder && q.ID > cursor.ID)
+	}
+	page := []domain.Quiz{}
+	for _, q := range m.sorted() {
+		if q.ID != cursor.ID && after(q) != cursor.Before {
+			page = append(page, q)
+		}
+	}
+	if cursor.Before {
+		return page[max(0, len(page)-limit):], nil
+	}
+	return page[:min(limit, len(page))], nil
+}
+
+func (m *mockQuizRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.Quiz, error) {
+	m.locked = append(m.locked, id)
+	return m.GetByID(ctx, id)
+}
+
 func (m *mockQuizRepository) GetByID(_ context.Context, id string) (*domain.Quiz, error) {
 	if m.getByIDErr != nil {
 		return nil, m.getByIDErr
@@ -52,6 +134,66 @@ func (m *mockQuizRepository) Create(_ context.Context, quiz *domain.Quiz) error
 	return nil
 }
 
+func (m *mockQuizRepository) Update(_ context.Context, quiz *domain.Quiz) error {
+	for i := range m.quizzes {
+		if m.quizzes[i].ID == quiz.ID {
+			m.quizzes[i] = *quiz
+			return nil
+		}
+	}
+	return domain.ErrQuizNotFound
+}
+
+func (m *mockQuizRepository) clock() time.Time {
+	if m.now.IsZero() {
+		return time.Now()
+	}
+	return m.now
+}
+
+func (m *mockQuizRepository) UpdateSchedule(_ context.Context, id string, schedule sharedDomain.Schedule) error {
+	for i := range m.quizzes {
+		if m.quizzes[i].ID == id {
+			m.quizzes[i].Schedule = schedule
+			return nil
+		}
+	}
+	return domain.ErrQuizNotFound
+}
+
+func (m *mockQuizRepository) SyncVisibility(_ context.Context) ([]sharedDomain.VisibilityChange, error) {
+	if m.visible == nil {
+		m.visible = map[string]bool{}
+	}
+	changes := []sharedDomain.VisibilityChange{}
+	record := func(q domain.Quiz, visible bool) {
+		if m.visible[q.ID] != visible {
+			m.visible[q.ID] = visible
+			changes = append(changes, sharedDomain.VisibilityChange{ID: q.ID, Visible: visible})
+		}
+	}
+	for _, q := range m.quizzes {
+		record(q, q.IsVisible(m.clock()))
+	}
+	for _, q := range m.trash {
+		record(q, false)
+	}
+	return changes, nil
+}
+
+func (m *mockQuizRepository) UpdateStatus(_ context.Context, id string, from, to domain.Status) error {
+	for i := range m.quizzes {
+		if m.quizzes[i].ID == id {
+			if m.quizzes[i].Status != from {
+				return domain.ErrStatusChanged
+			}
+			m.quizzes[i].Status = to
+			return nil
+		}
+	}
+	return domain.ErrQuizNotFound
+}
+
 func (m *mockQuizRepository) Delete(_ context.Context, id string) error {
 	if m.deleteErr != nil {
 		return m.deleteErr
@@ -60,7 +202,11 @@ func (m *mockQuizRepository) Delete(_ context.Context, id string) error {
 	for _, q := range m.quizzes {
 		if q.ID != id {
 			newQuizzes = append(newQuizzes, q)
+			continue
 		}
+		deletedAt := time.Now()
+		q.DeletedAt = &deletedAt
+		m.trash = append(m.trash, q)
 	}
 	m.quizzes = newQuizzes
 	return nil
@@ -70,7 +216,11 @@ func (m *mockQuizRepository) GetMaxDisplayOrder(_ context.Context) (int, error)
 	if m.getMaxOrderErr != nil {
 		return 0, m.getMaxOrderErr
 	}
-	return m.getMaxOrderResp, nil
+	maxOrder := m.getMaxOrderResp
+	for _, q := range m.quizzes {
+		maxOrder = max(maxOrder, q.DisplayOrder)
+	}
+	return maxOrder, nil
 }
 
 func (m *mockQuizRepository) DecrementDisplayOrdersAbove(_ context.Context, order int) error {
@@ -85,12 +235,179 @@ func (m *mockQuizRepository) DecrementDisplayOrdersAbove(_ context.Context, orde
 	return nil
 }
 
+// normalize mirrors quiz_normalize: case, whitespace and punctuation are ignored
+func normalize(question string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(question), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}), " ")
+}
+
+func (m *mockQuizRepository) similarity(a, b string) float64 {
+	a, b = normalize(a), normalize(b)
+	if a == b {
+		return 1
+	}
+	return max(m.similar[[2]string{a, b}], m.similar[[2]string{b, a}])
+}
+
+func (m *mockQuizRepository) FindDuplicates(_ context.Context, question string, threshold float64, limit int) ([]domain.Duplicate, error) {
+	duplicates := []domain.Duplicate{}
+	for _, q := range m.sorted() {
+		if similarity := m.similarity(q.Question, question); similarity >= threshold {
+			duplicates = append(duplicates, domain.Duplicate{Quiz: q, Similarity: similarity, Exact: normalize(q.Question) == normalize(question)})
+		}
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool { return duplicates[i].Similarity > duplicates[j].Similarity })
+	return duplicates[:min(limit, len(duplicates))], nil
+}
+
+func (m *mockQuizRepository) FindDuplicatePairs(_ context.Context, threshold float64) ([]domain.DuplicatePair, error) {
+	pairs := []domain.DuplicatePair{}
+	quizzes := m.sorted()
+	for i, a := range quizzes {
+		for _, b := range quizzes[i+1:] {
+			if similarity := m.similarity(a.Question, b.Question); similarity >= threshold {
+				pairs = append(pairs, domain.DuplicatePair{QuizID: a.ID, OtherID: b.ID, Similarity: similarity, Exact: normalize(a.Question) == normalize(b.Question)})
+			}
+		}
+	}
+	return pairs, nil
+}
+
+// Search matches every term as a substring of the quiz text
+func (m *mockQuizRepository) Search(_ context.Context, query domain.SearchQuery, visibleOnly bool, limit int) ([]domain.SearchResult, error) {
+	m.searchLimit = limit
+	results := []domain.SearchResult{}
+	for _, q := range m.quizzes {
+		if visibleOnly && !q.IsVisible(m.clock()) {
+			continue
+		}
+		text := strings.ToLower(strings.Join(append([]string{q.Question}, q.Choice1, q.Choice2, q.Choice3, q.Choice4), " "))
+		matched := true
+		for _, term := range query.Terms() {
+			matched = matched && strings.Contains(text, term)
+		}
+		if matched {
+			results = append(results, domain.SearchResult{Quiz: q, Rank: 1})
+		}
+	}
+	return results, nil
+}
+
+func (m *mockQuizRepository) RenumberDisplayOrders(_ context.Context) error {
+	m.renumbered++
+	sort.SliceStable(m.quizzes, func(i, j int) bool { return m.quizzes[i].DisplayOrder < m.quizzes[j].DisplayOrder })
+	for i := range m.quizzes {
+		m.quizzes[i].DisplayOrder = i + 1
+	}
+	return nil
+}
+
+func (m *mockQuizRepository) IncrementDisplayOrdersFrom(_ context.Context, order int) error {
+	for i := range m.quizzes {
+		if m.quizzes[i].DisplayOrder >= order {
+			m.quizzes[i].DisplayOrder++
+		}
+	}
+	return nil
+}
+
+func (m *mockQuizRepository) GetDeleted(_ context.Context) ([]domain.Quiz, error) {
+	return m.trash, nil
+}
+
+func (m *mockQuizRepository) GetDeletedByID(_ context.Context, id string) (*domain.Quiz, error) {
+	for _, q := range m.trash {
+		if q.ID == id {
+			return &q, nil
+		}
+	}
+	return nil, domain.ErrQuizNotInTrash
+}
+
+func (m *mockQuizRepository) Restore(_ context.Context, id string, order int) error {
+	for i, q := range m.trash {
+		if q.ID == id {
+			q.DeletedAt = nil
+			q.DisplayOrder = order
+			m.quizzes = append(m.quizzes, q)
+			m.trash = append(m.trash[:i], m.trash[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrQuizNotInTrash
+}
+
+func (m *mockQuizRepository) Purge(_ context.Context, id string) error {
+	for i, q := range m.trash {
+		if q.ID == id {
+			if m.answered[id] {
+				return domain.ErrQuizAnswered
+			}
+			m.trash = append(m.trash[:i], m.trash[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrQuizNotInTrash
+}
+
+func (m *mockQuizRepository) PurgeDeletedBefore(_ context.Context, before time.Time) (int, error) {
+	kept := []domain.Quiz{}
+	for _, q := range m.trash {
+		if !q.DeletedAt.Before(before) || m.answered[q.ID] {
+			kept = append(kept, q)
+		}
+	}
+	n := len(m.trash) - len(kept)
+	m.trash = kept
+	return n, nil
+}
+
+// mockRevisionRepository is a mock implementation of domain.RevisionRepository
+type mockRevisionRepository struct {
+	revisions []domain.Revision
+}
+
+func newMockRevisionRepo() *mockRevisionRepository {
+	return &mockRevisionRepository{revisions: []domain.Revision{}}
+}
+
+func (m *mockRevisionRepository) ListByQuizID(_ context.Context, quizID string) ([]domain.Revision, error) {
+	result := []domain.Revision{}
+	for i := len(m.revisions) - 1; i >= 0; i-- {
+		if m.revisions[i].QuizID == quizID {
+			result = append(result, m.revisions[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRevisionRepository) GetByID(_ context.Context, quizID, id string) (*domain.Revision, error) {
+	for _, r := range m.revisions {
+		if r.QuizID == quizID && r.ID == id {
+			return &r, nil
+		}
+	}
+	return nil, domain.ErrRevisionNotFound
+}
+
+func (m *mockRevisionRepository) Create(_ context.Context, revision *domain.Revision) error {
+	revision.Number = 1
+	for _, r := range m.revisions {
+		if r.QuizID == revision.QuizID && r.Number >= revision.Number {
+			revision.Number = r.Number + 1
+		}
+	}
+	m.revisions = append(m.revisions, *revision)
+	return nil
+}
+
 // ============ Test Cases ============
 
 func TestCreateQuiz_Success(t *testing.T) {
 	repo := newMockRepo()
 	repo.getMaxOrderResp = 0
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
 	req := CreateQuizRequest{
 		Question: "ข้อใดต่างจากข้ออื่น",
@@ -122,7 +439,7 @@ func TestCreateQuiz_Success(t *testing.T) {
 func TestCreateQuiz_AutoIncrementDisplayOrder(t *testing.T) {
 	repo := newMockRepo()
 	repo.getMaxOrderResp = 3
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
 	req := CreateQuizRequest{
 		Question: "X + 2 = 4 จงหาค่า X",
@@ -144,7 +461,7 @@ func TestCreateQuiz_AutoIncrementDisplayOrder(t *testing.T) {
 
 func TestCreateQuiz_ValidationError_EmptyQuestion(t *testing.T) {
 	repo := newMockRepo()
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
 	req := CreateQuizRequest{
 		Question: "",
@@ -162,7 +479,7 @@ func TestCreateQuiz_ValidationError_EmptyQuestion(t *testing.T) {
 
 func TestCreateQuiz_ValidationError_EmptyChoice(t *testing.T) {
 	repo := newMockRepo()
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
 	req := CreateQuizRequest{
 		Question: "What is 1+1?",
@@ -180,7 +497,7 @@ func TestCreateQuiz_ValidationError_EmptyChoice(t *testing.T) {
 
 func TestCreateQuiz_ValidationError_WhitespaceOnly(t *testing.T) {
 	repo := newMockRepo()
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
 	req := CreateQuizRequest{
 		Question: "   ",
@@ -198,9 +515,9 @@ func TestCreateQuiz_ValidationError_WhitespaceOnly(t *testing.T) {
 
 func TestGetAll_Empty(t *testing.T) {
 	repo := newMockRepo()
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
-	resp, err := service.GetAll(context.Background())
+	resp, err := service.GetAll(signedIn("admin", utils.RoleAdmin))
 	if err != nil {
 		t.Fatalf("expected no error, got: %v", err)
 	}
@@ -216,9 +533,9 @@ func TestGetAll_WithData(t *testing.T) {
 		{ID: "1", Question: "Q1", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 1},
 		{ID: "2", Question: "Q2", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 2},
 	}
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
-	resp, err := service.GetAll(context.Background())
+	resp, err := service.GetAll(signedIn("admin", utils.RoleAdmin))
 	if err != nil {
 		t.Fatalf("expected no error, got: %v", err)
 	}
@@ -235,7 +552,7 @@ func TestDeleteQuiz_Success_WithRenumber(t *testing.T) {
 		{ID: "b", Question: "Q2", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 2},
 		{ID: "c", Question: "Q3", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 3},
 	}
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
 	// Delete quiz #2 (display_order=2)
 	err := service.Delete(context.Background(), "b")
@@ -264,7 +581,7 @@ func TestDeleteQuiz_NotFound(t *testing.T) {
 	repo.quizzes = []domain.Quiz{
 		{ID: "a", Question: "Q1", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 1},
 	}
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
 	err := service.Delete(context.Background(), "nonexistent")
 	if err == nil {
@@ -277,7 +594,7 @@ func TestDeleteQuiz_LastItem(t *testing.T) {
 	repo.quizzes = []domain.Quiz{
 		{ID: "a", Question: "Q1", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 1},
 	}
-	service := NewQuizService(repo)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
 
 	err := service.Delete(context.Background(), "a")
 	if err != nil {
@@ -288,3 +605,102 @@ func TestDeleteQuiz_LastItem(t *testing.T) {
 		t.Errorf("expected 0 quizzes, got %d", len(repo.quizzes))
 	}
 }
+
+func newPagedRepo(n int) *mockQuizRepository {
+	repo := newMockRepo()
+	for i := 1; i <= n; i++ {
+		repo.quizzes = append(repo.quizzes, domain.Quiz{ID: sharedDomain.NewID(), Question: fmt.Sprintf("Q%d", i), DisplayOrder: i})
+	}
+	return repo
+}
+
+func questions(items []QuizResponse) string {
+	var qs []string
+	for _, q := range items {
+		qs = append(qs, q.Question)
+	}
+	return strings.Join(qs, ",")
+}
+
+func TestListQuizzes_ByPage(t *testing.T) {
+	service := NewQuizService(newPagedRepo(5), newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	page, err := service.List(signedIn("admin", utils.RoleAdmin), ListQuizzesRequest{Page: 2, PageSize: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if questions(page.Items) != "Q3,Q4" {
+		t.Errorf("expected Q3,Q4, got %s", questions(page.Items))
+	}
+	if page.Pagination.Total != 5 || page.Pagination.TotalPages() != 3 {
+		t.Errorf("expected 5 quizzes on 3 pages, got %+v", page.Pagination)
+	}
+	if page.NextCursor == "" || page.PrevCursor == "" {
+		t.Error("expected cursors to continue from a numbered page")
+	}
+}
+
+func TestListQuizzes_CursorWalk(t *testing.T) {
+	service := NewQuizService(newPagedRepo(5), newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+	ctx := signedIn("admin", utils.RoleAdmin)
+
+	first, _ := service.List(ctx, ListQuizzesRequest{PageSize: 2})
+	second, err := service.List(ctx, ListQuizzesRequest{PageSize: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if questions(second.Items) != "Q3,Q4" || second.Pagination.Page != 0 {
+		t.Errorf("expected Q3,Q4 by cursor, got %s (page %d)", questions(second.Items), second.Pagination.Page)
+	}
+
+	last, _ := service.List(ctx, ListQuizzesRequest{PageSize: 2, Cursor: second.NextCursor})
+	if questions(last.Items) != "Q5" || last.NextCursor != "" || last.PrevCursor == "" {
+		t.Errorf("expected last page Q5 without next cursor, got %s %q", questions(last.Items), last.NextCursor)
+	}
+
+	back, _ := service.List(ctx, ListQuizzesRequest{PageSize: 2, Cursor: last.PrevCursor})
+	if questions(back.Items) != "Q3,Q4" || back.NextCursor == "" || back.PrevCursor == "" {
+		t.Errorf("expected Q3,Q4 going back, got %s", questions(back.Items))
+	}
+
+	start, _ := service.List(ctx, ListQuizzesRequest{PageSize: 2, Cursor: back.PrevCursor})
+	if questions(start.Items) != "Q1,Q2" || start.PrevCursor != "" {
+		t.Errorf("expected first page without prev cursor, got %s %q", questions(start.Items), start.PrevCursor)
+	}
+}
+
+func TestListQuizzes_InvalidCursor(t *testing.T) {
+	service := NewQuizService(newPagedRepo(1), newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	for _, cursor := range []string{"%%%", sharedDomain.EncodeCursor(domain.QuizCursor{ID: "not-a-uuid"})} {
+		if _, err := service.List(signedIn("admin", utils.RoleAdmin), ListQuizzesRequest{Cursor: cursor}); !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Errorf("cursor %q: expected ErrInvalidCursor, got %v", cursor, err)
+		}
+	}
+}
+
+func TestListQuizzes_CustomSort(t *testing.T) {
+	repo := newPagedRepo(3)
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+	query, err := listquery.Parse(url.Values{"sort": {"-created_at"}}, domain.QuizListSchema)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	page, err := service.List(signedIn("admin", utils.RoleAdmin), ListQuizzesRequest{PageSize: 2, Query: query})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if repo.listQuery != query {
+		t.Error("expected the query to reach the repository")
+	}
+	if page.NextCursor != "" || page.PrevCursor != "" {
+		t.Error("expected no cursors for a custom sort")
+	}
+
+	cursor := sharedDomain.EncodeCursor(domain.QuizCursor{DisplayOrder: 1, ID: sharedDomain.NewID()})
+	if _, err := service.List(signedIn("admin", utils.RoleAdmin), ListQuizzesRequest{Cursor: cursor, Query: query}); !errors.Is(err, domain.ErrCursorSort) {
+		t.Errorf("expected ErrCursorSort, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+)
+
+// Types of quiz change events
+const (
+	ChangeCreated   = "created"
+	ChangeUpdated   = "updated"
+	ChangeDeleted   = "deleted"
+	ChangeReordered = "reordered"
+	// ChangeReset tells a subscriber that changes it missed are no longer
+	// kept, so it should reload the list
+	ChangeReset = "reset"
+)
+
+// Limits of the change stream
+const (
+	// ChangeHistory is how many recent changes are kept for subscribers
+	// resuming after Last-Event-ID
+	ChangeHistory = 1000
+	// changeQueue is how many changes may wait for a slow subscriber before
+	// it is dropped; it can resume from the last change it received
+	changeQueue = 64
+)
+
+// EventStream relays quiz changes published on the event bus to subscribers
+// such as admin UIs, keeping the most recent ones so a subscriber that
+// reconnects can catch up. Changes are kept in memory and numbered per
+// process.
+type EventStream interface {
+	Subscribe(lastEventID string) *Subscription
+	Close()
+}
+
+type eventStream struct {
+	now func() time.Time
+	// epoch tells the IDs of this process apart from those of earlier ones
+	epoch string
+
+	mu          sync.Mutex
+	seq         int64
+	history     []ChangeEvent
+	subscribers map[*Subscription]bool
+	closed      bool
+}
+
+// Subscription receives quiz changes until it is cancelled
+type Subscription struct {
+	// Missed holds the changes after the Last-Event-ID the subscriber
+	// resumed from, or a single reset event when they are no longer kept
+	Missed []ChangeEvent
+	// Events receives new changes. It is closed when the stream closes or
+	// the subscriber falls too far behind.
+	Events <-chan ChangeEvent
+
+	stream *eventStream
+	events chan ChangeEvent
+}
+
+// NewEventStream creates an EventStream fed by the quiz change events
+// published on bus
+func NewEventStream(bus *events.EventBus) EventStream {
+	s := &eventStream{now: time.Now, subscribers: map[*Subscription]bool{}}
+	s.epoch = strconv.FormatInt(s.now().UnixMilli(), 36)
+	if bus != nil {
+		bus.Subscribe(events.QuizCreatedEvent{}.Name(), s.handle)
+		bus.Subscribe(events.QuizUpdatedEvent{}.Name(), s.handle)
+		bus.Subscribe(events.QuizDeletedEvent{}.Name(), s.handle)
+		bus.Subscribe(events.QuizzesReorderedEvent{}.Name(), s.handle)
+	}
+	return s
+}
+
+func (s *eventStream) handle(_ context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case events.QuizCreatedEvent:
+		s.publish(ChangeEvent{Type: ChangeCreated, QuizID: e.QuizID, UserID: e.UserID})
+	case events.QuizUpdatedEvent:
+		s.publish(ChangeEvent{Type: ChangeUpdated, QuizID: e.QuizID, UserID: e.UserID})
+	case events.QuizDeletedEvent:
+		s.publish(ChangeEvent{Type: ChangeDeleted, QuizID: e.QuizID, UserID: e.UserID})
+	case events.QuizzesReorderedEvent:
+		s.publish(ChangeEvent{Type: ChangeReordered, UserID: e.UserID})
+	}
+	return nil
+}
+
+// publish numbers a change, keeps it and sends it to every subscriber.
+// Subscribers that cannot keep up are dropped rather than blocking the
+// publisher.
+func (s *eventStream) publish(change ChangeEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+
+	s.seq++
+	change.ID = s.epoch + "-" + strconv.FormatInt(s.seq, 10)
+	change.At = s.now()
+	if len(s.history) == ChangeHistory {
+		s.history = append(s.history[:0], s.history[1:]...)
+	}
+	s.history = append(s.history, change)
+
+	for sub := range s.subscribers {
+		select {
+		case sub.events <- change:
+		default:
+			s.drop(sub)
+		}
+	}
+}
+
+// Subscribe starts receiving changes. With the ID of the last change a
+// subscriber received, the changes published since are returned first.
+func (s *eventStream) Subscribe(lastEventID string) *Subscription {
+	events := make(chan ChangeEvent, changeQueue)
+	sub := &Subscription{Events: events, stream: s, events: events}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		close(sub.events)
+		return sub
+	}
+	sub.Missed = s.missed(lastEventID)
+	s.subscribers[sub] = true
+	return sub
+}
+
+// missed returns the changes after lastEventID. s.mu must be held.
+func (s *eventStream) missed(lastEventID string) []ChangeEvent {
+	if lastEventID == "" {
+		return nil
+	}
+	// The reset carries the latest ID so the subscriber can resume from it
+	reset := []ChangeEvent{{ID: s.epoch + "-" + strconv.FormatInt(s.seq, 10), Type: ChangeReset, At: s.now()}}
+
+	epoch, seqPart, ok := strings.Cut(lastEventID, "-")
+	seq, err := strconv.ParseInt(seqPart, 10, 64)
+	if !ok || err != nil || epoch != s.epoch || seq < 0 || seq > s.seq {
+		return reset
+	}
+	oldest := s.seq - int64(len(s.history)) + 1
+	if seq < oldest-1 {
+		return reset
+	}
+	missed := make([]ChangeEvent, s.seq-seq)
+	copy(missed, s.history[seq-oldest+1:])
+	return missed
+}
+
+// drop stops sending to sub. s.mu must be held.
+func (s *eventStream) drop(sub *Subscription) {
+	if s.subscribers[sub] {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// Close ends every subscription; later subscriptions end immediately
+func (s *eventStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for sub := range s.subscribers {
+		s.drop(sub)
+	}
+}
+
+// Cancel stops the subscription
+func (sub *Subscription) Cancel() {
+	sub.stream.mu.Lock()
+	defer sub.stream.mu.Unlock()
+	sub.stream.drop(sub)
+}
+
+// publishChange publishes a quiz change on bus, which may be nil, once the
+// transaction carried by ctx commits
+func publishChange(ctx context.Context, bus *events.EventBus, event events.Event) {
+	if bus == nil {
+		return
+	}
+	database.AfterCommit(ctx, func() {
+		if err := bus.Publish(context.WithoutCancel(ctx), event); err != nil {
+			slog.Error("Failed to publish quiz change", "event", event.Name(), "error", err)
+		}
+	})
+}
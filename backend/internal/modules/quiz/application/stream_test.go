@@ -0,0 +1,134 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+)
+
+func publishTestChanges(t *testing.T, bus *events.EventBus, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := bus.Publish(context.Background(), events.QuizUpdatedEvent{QuizID: "q1"}); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+}
+
+func TestEventStream_RelaysChanges(t *testing.T) {
+	bus := events.NewEventBus()
+	stream := NewEventStream(bus)
+	sub := stream.Subscribe("")
+	defer sub.Cancel()
+
+	userID := "user-1"
+	bus.Publish(context.Background(), events.QuizCreatedEvent{QuizID: "q1", UserID: &userID})
+	bus.Publish(context.Background(), events.QuizDeletedEvent{QuizID: "q2"})
+	bus.Publish(context.Background(), events.QuizzesReorderedEvent{})
+
+	want := []ChangeEvent{{Type: ChangeCreated, QuizID: "q1"}, {Type: ChangeDeleted, QuizID: "q2"}, {Type: ChangeReordered}}
+	var ids []string
+	for _, w := range want {
+		got := <-sub.Events
+		if got.Type != w.Type || got.QuizID != w.QuizID || got.ID == "" || got.At.IsZero() {
+			t.Errorf("expected %s %s, got %+v", w.Type, w.QuizID, got)
+		}
+		ids = append(ids, got.ID)
+	}
+	if ids[0] == ids[1] || ids[1] == ids[2] {
+		t.Errorf("expected distinct IDs, got %v", ids)
+	}
+	if len(sub.Missed) != 0 {
+		t.Errorf("expected nothing missed without Last-Event-ID, got %v", sub.Missed)
+	}
+
+	// Resuming after the first change replays the other two
+	resumed := stream.Subscribe(ids[0])
+	defer resumed.Cancel()
+	if len(resumed.Missed) != 2 || resumed.Missed[0].ID != ids[1] || resumed.Missed[1].ID != ids[2] {
+		t.Errorf("expected the last two changes replayed, got %+v", resumed.Missed)
+	}
+	if upToDate := stream.Subscribe(ids[2]); len(upToDate.Missed) != 0 {
+		t.Errorf("expected nothing missed, got %+v", upToDate.Missed)
+	}
+}
+
+func TestEventStream_ResetsUnknownIDs(t *testing.T) {
+	bus := events.NewEventBus()
+	stream := NewEventStream(bus)
+	publishTestChanges(t, bus, ChangeHistory+5)
+
+	for _, id := range []string{"bogus", "0-1", stream.(*eventStream).epoch + "-3", stream.(*eventStream).epoch + "-99999"} {
+		sub := stream.Subscribe(id)
+		if len(sub.Missed) != 1 || sub.Missed[0].Type != ChangeReset {
+			t.Errorf("expected a reset for %q, got %+v", id, sub.Missed)
+			continue
+		}
+		// The reset carries the latest ID to resume from
+		if resumed := stream.Subscribe(sub.Missed[0].ID); len(resumed.Missed) != 0 {
+			t.Errorf("expected nothing missed after the reset, got %+v", resumed.Missed)
+		}
+	}
+
+	// The oldest change still kept can be resumed from
+	oldest := stream.(*eventStream).history[0].ID
+	if sub := stream.Subscribe(oldest); len(sub.Missed) != ChangeHistory-1 {
+		t.Errorf("expected %d changes replayed, got %d", ChangeHistory-1, len(sub.Missed))
+	}
+}
+
+func TestEventStream_DropsSlowSubscribersAndCloses(t *testing.T) {
+	bus := events.NewEventBus()
+	stream := NewEventStream(bus)
+	slow := stream.Subscribe("")
+	fast := stream.Subscribe("")
+
+	publishTestChanges(t, bus, changeQueue)
+	for i := 0; i < changeQueue; i++ {
+		<-fast.Events
+	}
+	publishTestChanges(t, bus, 1)
+
+	received := 0
+	for range slow.Events {
+		received++
+	}
+	if received != changeQueue {
+		t.Errorf("expected the slow subscriber dropped after %d changes, got %d", changeQueue, received)
+	}
+
+	stream.Close()
+	<-fast.Events
+	if _, ok := <-fast.Events; ok {
+		t.Error("expected the subscription closed")
+	}
+	fast.Cancel()
+	if _, ok := <-stream.Subscribe("").Events; ok {
+		t.Error("expected subscriptions after Close to end immediately")
+	}
+}
+
+func TestQuizService_PublishesChanges(t *testing.T) {
+	bus := events.NewEventBus()
+	stream := NewEventStream(bus)
+	sub := stream.Subscribe("")
+	defer sub.Cancel()
+
+	repo := newMockRepo()
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, bus)
+	quiz := createTestQuiz(t, service)
+	req := UpdateQuizRequest{Question: "Changed?", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", Answer: 1}
+	if _, err := service.Update(context.Background(), quiz.ID, req); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := service.Delete(context.Background(), quiz.ID); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{ChangeCreated, ChangeUpdated, ChangeDeleted, ChangeReordered} {
+		if got := <-sub.Events; got.Type != want {
+			t.Errorf("expected %s, got %+v", want, got)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// DefaultLocale is the locale quizzes are written in when none is configured
+const DefaultLocale = "th"
+
+// TranslationService defines managing quiz translations and localizing quizzes
+type TranslationService interface {
+	List(ctx context.Context, quizID string) ([]TranslationResponse, error)
+	Put(ctx context.Context, quizID, locale string, req PutTranslationRequest) (*TranslationResponse, error)
+	Delete(ctx context.Context, quizID, locale string) error
+	Untranslated(ctx context.Context, locale string) ([]UntranslatedQuizResponse, error)
+	Localize(ctx context.Context, quizzes []QuizResponse, locales []string) ([]QuizResponse, error)
+}
+
+type translationService struct {
+	repo          domain.QuizRepository
+	translations  domain.TranslationRepository
+	defaultLocale string
+}
+
+// NewTranslationService creates a new TranslationService for quizzes written in defaultLocale
+func NewTranslationService(repo domain.QuizRepository, translations domain.TranslationRepository, defaultLocale string) TranslationService {
+	locale, ok := utils.CanonicalLocale(defaultLocale)
+	if !ok {
+		locale = DefaultLocale
+	}
+	return &translationService{repo: repo, translations: translations, defaultLocale: locale}
+}
+
+// List returns all translations of a quiz, marking those made from an older revision
+func (s *translationService) List(ctx context.Context, quizID string) ([]TranslationResponse, error) {
+	quiz, err := s.repo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.translations.ListByQuizID(ctx, quizID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch translations", err)
+	}
+	responses := make([]TranslationResponse, len(translations))
+	for i := range translations {
+		responses[i] = toTranslationResponse(&translations[i], quiz)
+	}
+	return responses, nil
+}
+
+// Put creates or replaces the translation of a quiz into a locale, recording
+// the current revision of the quiz as its source
+func (s *translationService) Put(ctx context.Context, quizID, locale string, req PutTranslationRequest) (*TranslationResponse, error) {
+	locale, err := s.translatableLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	t := &domain.Translation{
+		QuizID:      quizID,
+		Locale:      locale,
+		Question:    strings.TrimSpace(req.Question),
+		Choice1:     strings.TrimSpace(req.Choice1),
+		Choice2:     strings.TrimSpace(req.Choice2),
+		Choice3:     strings.TrimSpace(req.Choice3),
+		Choice4:     strings.TrimSpace(req.Choice4),
+		Explanation: strings.TrimSpace(req.Explanation),
+	}
+	if t.Question == "" || t.Choice1 == "" || t.Choice2 == "" || t.Choice3 == "" || t.Choice4 == "" {
+		return nil, domain.ErrInvalidTranslation
+	}
+
+	quiz, err := s.repo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeChange(ctx, quiz); err != nil {
+		return nil, err
+	}
+	t.SourceRevisionID = quiz.RevisionID
+
+	if err := s.translations.Upsert(ctx, t); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to save translation", err)
+	}
+	resp := toTranslationResponse(t, quiz)
+	return &resp, nil
+}
+
+// Delete removes the translation of a quiz into a locale
+func (s *translationService) Delete(ctx context.Context, quizID, locale string) error {
+	locale, err := s.translatableLocale(locale)
+	if err != nil {
+		return err
+	}
+	quiz, err := s.repo.GetByID(ctx, quizID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeChange(ctx, quiz); err != nil {
+		return err
+	}
+	return s.translations.Delete(ctx, quizID, locale)
+}
+
+// Untranslated returns the quizzes without an up-to-date translation into locale
+func (s *translationService) Untranslated(ctx context.Context, locale string) ([]UntranslatedQuizResponse, error) {
+	locale, err := s.translatableLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	quizzes, err := s.translations.ListUntranslated(ctx, locale)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch untranslated quizzes", err)
+	}
+	responses := make([]UntranslatedQuizResponse, len(quizzes))
+	for i, q := range quizzes {
+		responses[i] = UntranslatedQuizResponse{QuizResponse: toQuizResponse(ctx, q.Quiz), Status: TranslationStatusMissing}
+		if q.Outdated {
+			responses[i].Status = TranslationStatusOutdated
+		}
+	}
+	return responses, nil
+}
+
+// Localize replaces the content of each quiz with its translation into the
+// first of locales that has one. Locales after the default locale are never
+// used, since every quiz is written in it. Outdated translations are still
+// used; they are marked so clients can tell.
+func (s *translationService) Localize(ctx context.Context, quizzes []QuizResponse, locales []string) ([]QuizResponse, error) {
+	var wanted []string
+	for _, l := range locales {
+		if l == s.defaultLocale {
+			break
+		}
+		wanted = append(wanted, l)
+	}
+
+	localized := make([]QuizResponse, len(quizzes))
+	copy(localized, quizzes)
+	for i := range localized {
+		localized[i].Locale = s.defaultLocale
+	}
+	if len(wanted) == 0 || len(quizzes) == 0 {
+		return localized, nil
+	}
+
+	ids := make([]string, len(quizzes))
+	for i, q := range quizzes {
+		ids[i] = q.ID
+	}
+	translations, err := s.translations.ListByQuizIDs(ctx, ids, wanted)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch translations", err)
+	}
+	byKey := make(map[[2]string]*domain.Translation, len(translations))
+	for i, t := range translations {
+		byKey[[2]string{t.QuizID, t.Locale}] = &translations[i]
+	}
+
+	for i := range localized {
+		q := &localized[i]
+		for _, locale := range wanted {
+			t, ok := byKey[[2]string{q.ID, locale}]
+			if !ok {
+				continue
+			}
+			q.Question, q.Choice1, q.Choice2, q.Choice3, q.Choice4 = t.Question, t.Choice1, t.Choice2, t.Choice3, t.Choice4
+			q.Explanation = t.Explanation
+			q.Locale = locale
+			q.TranslationOutdated = t.SourceRevisionID != q.RevisionID
+			break
+		}
+	}
+	return localized, nil
+}
+
+func (s *translationService) translatableLocale(locale string) (string, error) {
+	canonical, ok := utils.CanonicalLocale(locale)
+	if !ok {
+		return "", domain.ErrInvalidLocale
+	}
+	if canonical == s.defaultLocale {
+		return "", domain.ErrDefaultLocale
+	}
+	return canonical, nil
+}
+
+func toTranslationResponse(t *domain.Translation, quiz *domain.Quiz) TranslationResponse {
+	return TranslationResponse{
+		Locale:           t.Locale,
+		Question:         t.Question,
+		Choice1:          t.Choice1,
+		Choice2:          t.Choice2,
+		Choice3:          t.Choice3,
+		Choice4:          t.Choice4,
+		Explanation:      t.Explanation,
+		SourceRevisionID: t.SourceRevisionID,
+		Outdated:         t.IsOutdated(quiz),
+		UpdatedAt:        t.UpdatedAt,
+	}
+}
@@ -0,0 +1,200 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+// mockTranslationRepository is an in-memory implementation of domain.TranslationRepository
+type mockTranslationRepository struct {
+	quizzes      *mockQuizRepository
+	translations []domain.Translation
+}
+
+func (m *mockTranslationRepository) ListByQuizID(_ context.Context, quizID string) ([]domain.Translation, error) {
+	out := []domain.Translation{}
+	for _, t := range m.translations {
+		if t.QuizID == quizID {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Locale < out[j].Locale })
+	return out, nil
+}
+
+func (m *mockTranslationRepository) ListByQuizIDs(_ context.Context, quizIDs []string, locales []string) ([]domain.Translation, error) {
+	out := []domain.Translation{}
+	for _, t := range m.translations {
+		for _, id := range quizIDs {
+			for _, locale := range locales {
+				if t.QuizID == id && t.Locale == locale {
+					out = append(out, t)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *mockTranslationRepository) Upsert(_ context.Context, translation *domain.Translation) error {
+	for i, t := range m.translations {
+		if t.QuizID == translation.QuizID && t.Locale == translation.Locale {
+			m.translations[i] = *translation
+			return nil
+		}
+	}
+	m.translations = append(m.translations, *translation)
+	return nil
+}
+
+func (m *mockTranslationRepository) Delete(_ context.Context, quizID, locale string) error {
+	for i, t := range m.translations {
+		if t.QuizID == quizID && t.Locale == locale {
+			m.translations = append(m.translations[:i], m.translations[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrTranslationNotFound
+}
+
+func (m *mockTranslationRepository) ListUntranslated(_ context.Context, locale string) ([]domain.UntranslatedQuiz, error) {
+	out := []domain.UntranslatedQuiz{}
+	for _, q := range m.quizzes.sorted() {
+		translated, outdated := false, false
+		for _, t := range m.translations {
+			if t.QuizID == q.ID && t.Locale == locale {
+				translated, outdated = true, t.IsOutdated(&q)
+			}
+		}
+		if !translated || outdated {
+			out = append(out, domain.UntranslatedQuiz{Quiz: q, Outdated: outdated})
+		}
+	}
+	return out, nil
+}
+
+func newTranslationFixture() (*mockQuizRepository, *mockTranslationRepository, TranslationService) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{
+		{ID: "a", Question: "เมืองหลวงของไทยคือ?", Choice1: "กรุงเทพ", Choice2: "เชียงใหม่", Choice3: "ภูเก็ต", Choice4: "ขอนแก่น", DisplayOrder: 1, RevisionID: "a1"},
+		{ID: "b", Question: "1 + 1 = ?", Choice1: "1", Choice2: "2", Choice3: "3", Choice4: "4", DisplayOrder: 2, RevisionID: "b1"},
+	}
+	translations := &mockTranslationRepository{quizzes: repo}
+	return repo, translations, NewTranslationService(repo, translations, "th")
+}
+
+func englishCapital() PutTranslationRequest {
+	return PutTranslationRequest{
+		Question: "What is the capital of Thailand?", Choice1: "Bangkok", Choice2: "Chiang Mai", Choice3: "Phuket", Choice4: "Khon Kaen",
+		Explanation: "Bangkok has been the capital since 1782.",
+	}
+}
+
+// ============ Test Cases ============
+
+func TestTranslationPut_RecordsSourceRevision(t *testing.T) {
+	repo, translations, service := newTranslationFixture()
+
+	resp, err := service.Put(context.Background(), "a", "EN", englishCapital())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Locale != "en" || resp.SourceRevisionID != "a1" || resp.Outdated {
+		t.Errorf("unexpected translation %+v", resp)
+	}
+
+	// Editing the quiz makes the translation outdated
+	repo.quizzes[0].RevisionID = "a2"
+	list, _ := service.List(context.Background(), "a")
+	if len(list) != 1 || !list[0].Outdated {
+		t.Errorf("expected an outdated translation, got %+v", list)
+	}
+	if len(translations.translations) != 1 {
+		t.Errorf("expected 1 stored translation, got %d", len(translations.translations))
+	}
+}
+
+func TestTranslationPut_Errors(t *testing.T) {
+	_, _, service := newTranslationFixture()
+	ctx := context.Background()
+
+	if _, err := service.Put(ctx, "a", "th", englishCapital()); !errors.Is(err, domain.ErrDefaultLocale) {
+		t.Errorf("expected ErrDefaultLocale, got %v", err)
+	}
+	if _, err := service.Put(ctx, "a", "english", englishCapital()); !errors.Is(err, domain.ErrInvalidLocale) {
+		t.Errorf("expected ErrInvalidLocale, got %v", err)
+	}
+	if _, err := service.Put(ctx, "a", "en", PutTranslationRequest{Question: "Only a question"}); !errors.Is(err, domain.ErrInvalidTranslation) {
+		t.Errorf("expected ErrInvalidTranslation, got %v", err)
+	}
+	if _, err := service.Put(ctx, "missing", "en", englishCapital()); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got %v", err)
+	}
+}
+
+func TestTranslationLocalize_FallbackChain(t *testing.T) {
+	repo, _, service := newTranslationFixture()
+	ctx := context.Background()
+	service.Put(ctx, "a", "en", englishCapital())
+	service.Put(ctx, "b", "en-GB", PutTranslationRequest{Question: "One plus one?", Choice1: "1", Choice2: "2", Choice3: "3", Choice4: "4"})
+
+	quizzes := []QuizResponse{toQuizResponse(ctx, repo.quizzes[0]), toQuizResponse(ctx, repo.quizzes[1])}
+	localized, err := service.Localize(ctx, quizzes, []string{"en-GB", "en", "th"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if localized[0].Locale != "en" || localized[0].Choice1 != "Bangkok" || localized[0].Explanation == "" {
+		t.Errorf("expected quiz a to fall back to en, got %+v", localized[0])
+	}
+	if localized[1].Locale != "en-GB" || localized[1].Question != "One plus one?" {
+		t.Errorf("expected quiz b in en-GB, got %+v", localized[1])
+	}
+	if quizzes[0].Question != repo.quizzes[0].Question {
+		t.Error("expected the input quizzes to be left unchanged")
+	}
+
+	// Locales after the default locale are never used
+	localized, _ = service.Localize(ctx, quizzes, []string{"fr", "th", "en"})
+	if localized[0].Locale != "th" || localized[0].Question != repo.quizzes[0].Question {
+		t.Errorf("expected the original quiz, got %+v", localized[0])
+	}
+}
+
+func TestTranslationUntranslated(t *testing.T) {
+	repo, _, service := newTranslationFixture()
+	ctx := context.Background()
+	service.Put(ctx, "a", "en", englishCapital())
+	service.Put(ctx, "b", "en", PutTranslationRequest{Question: "One plus one?", Choice1: "1", Choice2: "2", Choice3: "3", Choice4: "4"})
+	repo.quizzes[1].RevisionID = "b2"
+
+	untranslated, err := service.Untranslated(ctx, "en")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(untranslated) != 1 || untranslated[0].ID != "b" || untranslated[0].Status != TranslationStatusOutdated {
+		t.Errorf("expected quiz b to be outdated, got %+v", untranslated)
+	}
+
+	untranslated, _ = service.Untranslated(ctx, "ja")
+	if len(untranslated) != 2 || untranslated[0].Status != TranslationStatusMissing {
+		t.Errorf("expected both quizzes to be missing a ja translation, got %+v", untranslated)
+	}
+}
+
+func TestTranslationDelete(t *testing.T) {
+	_, translations, service := newTranslationFixture()
+	ctx := context.Background()
+	service.Put(ctx, "a", "en", englishCapital())
+
+	if err := service.Delete(ctx, "a", "en"); err != nil || len(translations.translations) != 0 {
+		t.Fatalf("expected the translation to be deleted, got %v", err)
+	}
+	if err := service.Delete(ctx, "a", "en"); !errors.Is(err, domain.ErrTranslationNotFound) {
+		t.Errorf("expected ErrTranslationNotFound, got %v", err)
+	}
+}
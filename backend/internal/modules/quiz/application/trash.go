@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+)
+
+// Restore positions
+const (
+	RestorePositionOriginal = "original"
+	RestorePositionEnd      = "end"
+)
+
+// TrashService defines the operations on deleted quizzes
+type TrashService interface {
+	List(ctx context.Context) ([]TrashedQuizResponse, error)
+	Restore(ctx context.Context, id string, req RestoreQuizRequest) (*QuizResponse, error)
+	Purge(ctx context.Context, id string) error
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+type trashService struct {
+	repo      domain.QuizRepository
+	txManager database.TxManager
+	retention time.Duration
+	events    *events.EventBus
+	now       func() time.Time
+}
+
+// NewTrashService creates a new TrashService. Quizzes stay in the trash for
+// the retention window; a zero retention keeps them until purged by hand.
+// Restored quizzes are published on bus, which may be nil.
+func NewTrashService(repo domain.QuizRepository, txManager database.TxManager, retention time.Duration, bus *events.EventBus) TrashService {
+	return &trashService{repo: repo, txManager: txManager, retention: retention, events: bus, now: time.Now}
+}
+
+// List returns the quizzes in the trash, most recently deleted first
+func (s *trashService) List(ctx context.Context) ([]TrashedQuizResponse, error) {
+	quizzes, err := s.repo.GetDeleted(ctx)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch deleted quizzes", err)
+	}
+
+	responses := make([]TrashedQuizResponse, len(quizzes))
+	for i, q := range quizzes {
+		responses[i] = TrashedQuizResponse{QuizResponse: toQuizResponse(ctx, q), DeletedAt: *q.DeletedAt}
+		if s.retention > 0 {
+			purgeAt := q.DeletedAt.Add(s.retention)
+			responses[i].PurgeAt = &purgeAt
+		}
+	}
+	return responses, nil
+}
+
+// Restore takes a quiz out of the trash, either back into its original slot
+// (shifting later quizzes down) or at the end of the list
+func (s *trashService) Restore(ctx context.Context, id string, req RestoreQuizRequest) (*QuizResponse, error) {
+	position := req.Position
+	if position == "" {
+		position = RestorePositionOriginal
+	}
+	if position != RestorePositionOriginal && position != RestorePositionEnd {
+		return nil, domain.ErrInvalidRestorePosition
+	}
+
+	var quiz *domain.Quiz
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		quiz, err = s.repo.GetDeletedByID(ctx, id)
+		if err != nil {
+			return domain.ErrQuizNotInTrash
+		}
+		if err := authorizeChange(ctx, quiz); err != nil {
+			return err
+		}
+
+		maxOrder, err := s.repo.GetMaxDisplayOrder(ctx)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to get max display order", err)
+		}
+
+		order := maxOrder + 1
+		if position == RestorePositionOriginal && quiz.DisplayOrder < order {
+			order = max(quiz.DisplayOrder, 1)
+			if err := s.repo.IncrementDisplayOrdersFrom(ctx, order); err != nil {
+				return sharedDomain.NewInternalError("Failed to renumber quizzes", err)
+			}
+			publishChange(ctx, s.events, events.QuizzesReorderedEvent{UserID: currentUser(ctx)})
+		}
+
+		if err := s.repo.Restore(ctx, id, order); err != nil {
+			return sharedDomain.NewInternalError("Failed to restore quiz", err)
+		}
+		quiz.DisplayOrder = order
+		quiz.DeletedAt = nil
+		publishChange(ctx, s.events, events.QuizCreatedEvent{QuizID: quiz.ID, UserID: currentUser(ctx)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toQuizResponse(ctx, *quiz)
+	return &resp, nil
+}
+
+// Purge permanently removes a quiz from the trash. Quizzes answered in
+// attempts stay, so that their results keep their questions.
+func (s *trashService) Purge(ctx context.Context, id string) error {
+	quiz, err := s.repo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return domain.ErrQuizNotInTrash
+	}
+	if err := authorizeChange(ctx, quiz); err != nil {
+		return err
+	}
+	if err := s.repo.Purge(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrQuizAnswered) {
+			return err
+		}
+		return sharedDomain.NewInternalError("Failed to purge quiz", err)
+	}
+	return nil
+}
+
+// PurgeExpired permanently removes quizzes that have been in the trash longer
+// than the retention window, except answered ones, and returns how many were
+// removed
+func (s *trashService) PurgeExpired(ctx context.Context) (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	n, err := s.repo.PurgeDeletedBefore(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		return 0, sharedDomain.NewInternalError("Failed to purge deleted quizzes", err)
+	}
+	return n, nil
+}
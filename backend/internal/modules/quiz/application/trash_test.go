@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+func newTrashRepo(t *testing.T) *mockQuizRepository {
+	t.Helper()
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{
+		{ID: "a", Question: "Q1", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 1},
+		{ID: "b", Question: "Q2", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 2},
+		{ID: "c", Question: "Q3", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 3},
+	}
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+	if err := service.Delete(context.Background(), "b"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	return repo
+}
+
+func displayOrders(repo *mockQuizRepository) map[string]int {
+	orders := map[string]int{}
+	for _, q := range repo.quizzes {
+		orders[q.ID] = q.DisplayOrder
+	}
+	return orders
+}
+
+// ============ Test Cases ============
+
+func TestTrashList_ReportsPurgeTime(t *testing.T) {
+	repo := newTrashRepo(t)
+	service := NewTrashService(repo, passthroughTxManager{}, 24*time.Hour, nil)
+
+	trash, err := service.List(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(trash) != 1 || trash[0].ID != "b" {
+		t.Fatalf("expected quiz b in the trash, got %+v", trash)
+	}
+	if trash[0].PurgeAt == nil || !trash[0].PurgeAt.Equal(trash[0].DeletedAt.Add(24*time.Hour)) {
+		t.Errorf("expected purge_at one day after deleted_at, got %v", trash[0].PurgeAt)
+	}
+
+	// Without retention nothing is scheduled for purging
+	trash, _ = NewTrashService(repo, passthroughTxManager{}, 0, nil).List(context.Background())
+	if trash[0].PurgeAt != nil {
+		t.Errorf("expected no purge_at, got %v", trash[0].PurgeAt)
+	}
+}
+
+func TestTrashRestore_OriginalSlot(t *testing.T) {
+	repo := newTrashRepo(t)
+	service := NewTrashService(repo, passthroughTxManager{}, 0, nil)
+
+	quiz, err := service.Restore(context.Background(), "b", RestoreQuizRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if quiz.DisplayOrder != 2 {
+		t.Errorf("expected restored quiz at display_order 2, got %d", quiz.DisplayOrder)
+	}
+	orders := displayOrders(repo)
+	if orders["a"] != 1 || orders["b"] != 2 || orders["c"] != 3 {
+		t.Errorf("expected original order to be restored, got %v", orders)
+	}
+	if len(repo.trash) != 0 {
+		t.Errorf("expected empty trash, got %d", len(repo.trash))
+	}
+}
+
+func TestTrashRestore_End(t *testing.T) {
+	repo := newTrashRepo(t)
+	service := NewTrashService(repo, passthroughTxManager{}, 0, nil)
+
+	if _, err := service.Restore(context.Background(), "b", RestoreQuizRequest{Position: RestorePositionEnd}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	orders := displayOrders(repo)
+	if orders["a"] != 1 || orders["c"] != 2 || orders["b"] != 3 {
+		t.Errorf("expected restored quiz at the end, got %v", orders)
+	}
+}
+
+func TestTrashRestore_OriginalSlotBeyondEnd(t *testing.T) {
+	repo := newTrashRepo(t)
+	quizService := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+	if err := quizService.Delete(context.Background(), "c"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// Quiz c was at slot 2 after b was deleted; restoring b first takes slot 2
+	service := NewTrashService(repo, passthroughTxManager{}, 0, nil)
+	service.Restore(context.Background(), "b", RestoreQuizRequest{})
+	service.Restore(context.Background(), "c", RestoreQuizRequest{})
+
+	orders := displayOrders(repo)
+	if orders["a"] != 1 || orders["c"] != 2 || orders["b"] != 3 {
+		t.Errorf("expected a contiguous order, got %v", orders)
+	}
+}
+
+func TestTrashRestore_Errors(t *testing.T) {
+	service := NewTrashService(newTrashRepo(t), passthroughTxManager{}, 0, nil)
+
+	if _, err := service.Restore(context.Background(), "b", RestoreQuizRequest{Position: "top"}); !errors.Is(err, domain.ErrInvalidRestorePosition) {
+		t.Errorf("expected ErrInvalidRestorePosition, got %v", err)
+	}
+	if _, err := service.Restore(context.Background(), "a", RestoreQuizRequest{}); !errors.Is(err, domain.ErrQuizNotInTrash) {
+		t.Errorf("expected ErrQuizNotInTrash for an active quiz, got %v", err)
+	}
+}
+
+func TestTrashPurgeExpired(t *testing.T) {
+	repo := newTrashRepo(t)
+	service := NewTrashService(repo, passthroughTxManager{}, time.Hour, nil).(*trashService)
+
+	n, err := service.PurgeExpired(context.Background())
+	if err != nil || n != 0 {
+		t.Fatalf("expected nothing purged yet, got %d %v", n, err)
+	}
+
+	service.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	n, err = service.PurgeExpired(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 quiz purged, got %d %v", n, err)
+	}
+	if len(repo.trash) != 0 || len(repo.quizzes) != 2 {
+		t.Errorf("expected only the trashed quiz to be purged, got %d active %d trashed", len(repo.quizzes), len(repo.trash))
+	}
+}
+
+func TestTrashPurge_OnlyTrashedQuizzes(t *testing.T) {
+	repo := newTrashRepo(t)
+	service := NewTrashService(repo, passthroughTxManager{}, 0, nil)
+
+	if err := service.Purge(context.Background(), "a"); !errors.Is(err, domain.ErrQuizNotInTrash) {
+		t.Errorf("expected ErrQuizNotInTrash, got %v", err)
+	}
+	if err := service.Purge(context.Background(), "b"); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+func TestTrashPurge_KeepsAnsweredQuizzes(t *testing.T) {
+	repo := newTrashRepo(t)
+	repo.answered = map[string]bool{"b": true}
+	service := NewTrashService(repo, passthroughTxManager{}, time.Hour, nil).(*trashService)
+
+	if err := service.Purge(context.Background(), "b"); !errors.Is(err, domain.ErrQuizAnswered) {
+		t.Errorf("expected ErrQuizAnswered, got %v", err)
+	}
+
+	service.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	n, err := service.PurgeExpired(context.Background())
+	if err != nil || n != 0 {
+		t.Fatalf("expected nothing purged, got %d %v", n, err)
+	}
+	if len(repo.trash) != 1 {
+		t.Errorf("expected the answered quiz to stay in the trash, got %d trashed", len(repo.trash))
+	}
+}
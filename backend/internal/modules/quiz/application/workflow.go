@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// WorkflowService defines moving quizzes through draft, review and publication
+type WorkflowService interface {
+	Transition(ctx context.Context, quizID string, action domain.Action, req TransitionRequest) (*QuizResponse, error)
+	History(ctx context.Context, quizID string) ([]TransitionResponse, error)
+}
+
+type workflowService struct {
+	repo        domain.QuizRepository
+	transitions domain.TransitionRepository
+	txManager   database.TxManager
+	events      *events.EventBus
+}
+
+// NewWorkflowService creates a new WorkflowService. Status changes are
+// published on bus, which may be nil.
+func NewWorkflowService(repo domain.QuizRepository, transitions domain.TransitionRepository, txManager database.TxManager, bus *events.EventBus) WorkflowService {
+	return &workflowService{repo: repo, transitions: transitions, txManager: txManager, events: bus}
+}
+
+// Transition applies a workflow action to a quiz and records it, with the
+// comment and the user who took it when the request is authenticated
+func (s *workflowService) Transition(ctx context.Context, quizID string, action domain.Action, req TransitionRequest) (*QuizResponse, error) {
+	comment := strings.TrimSpace(req.Comment)
+	if comment == "" && action.RequiresComment() {
+		return nil, domain.ErrCommentRequired
+	}
+
+	var quiz *domain.Quiz
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		quiz, err = s.repo.GetByID(ctx, quizID)
+		if err != nil {
+			return domain.ErrQuizNotFound
+		}
+		if err := authorizeTransition(ctx, quiz, action); err != nil {
+			return err
+		}
+
+		next, err := action.Next(quiz.Status)
+		if err != nil {
+			return domain.ErrInvalidTransition.WithDetails(map[string]interface{}{"status": quiz.Status, "action": action})
+		}
+		if err := s.repo.UpdateStatus(ctx, quiz.ID, quiz.Status, next); err != nil {
+			if errors.Is(err, domain.ErrStatusChanged) {
+				return err
+			}
+			return sharedDomain.NewInternalError("Failed to update quiz status", err)
+		}
+
+		transition := &domain.Transition{
+			ID:         sharedDomain.NewID(),
+			QuizID:     quiz.ID,
+			Action:     action,
+			FromStatus: quiz.Status,
+			ToStatus:   next,
+			Comment:    comment,
+		}
+		if userID, ok := utils.GetUserID(ctx); ok {
+			transition.ActorID = &userID
+		}
+		if err := s.transitions.Create(ctx, transition); err != nil {
+			return sharedDomain.NewInternalError("Failed to record quiz transition", err)
+		}
+
+		quiz.Status = next
+		publishChange(ctx, s.events, events.QuizUpdatedEvent{QuizID: quiz.ID, UserID: transition.ActorID})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toQuizResponse(ctx, *quiz)
+	return &resp, nil
+}
+
+// reviseIfPublished sends a published quiz whose content was just edited back
+// to review, so the change stays out of the public listing until a reviewer
+// approves it. The caller runs it in the transaction that saves the edit.
+func reviseIfPublished(ctx context.Context, repo domain.QuizRepository, transitions domain.TransitionRepository, quiz *domain.Quiz) error {
+	if quiz.Status != domain.StatusPublished {
+		return nil
+	}
+	next, _ := domain.ActionRevise.Next(quiz.Status)
+	if err := repo.UpdateStatus(ctx, quiz.ID, quiz.Status, next); err != nil {
+		if errors.Is(err, domain.ErrStatusChanged) {
+			return err
+		}
+		return sharedDomain.NewInternalError("Failed to update quiz status", err)
+	}
+	transition := &domain.Transition{
+		ID:         sharedDomain.NewID(),
+		QuizID:     quiz.ID,
+		Action:     domain.ActionRevise,
+		FromStatus: quiz.Status,
+		ToStatus:   next,
+		ActorID:    currentUser(ctx),
+	}
+	if err := transitions.Create(ctx, transition); err != nil {
+		return sharedDomain.NewInternalError("Failed to record quiz transition", err)
+	}
+	quiz.Status = next
+	return nil
+}
+
+// authorizeTransition returns nil if the current user may apply action to
+// quiz. Reviewers and admins approve and reject quizzes, but never approve
+// their own; the other actions are up to whoever may change the quiz.
+func authorizeTransition(ctx context.Context, quiz *domain.Quiz, action domain.Action) error {
+	if !action.IsReview() {
+		return authorizeChange(ctx, quiz)
+	}
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return domain.ErrSignInRequired
+	}
+	if !utils.CanReview(ctx) {
+		return domain.ErrReviewerRequired
+	}
+	if action == domain.ActionApprove && quiz.IsOwnedBy(userID) {
+		return domain.ErrSelfApproval
+	}
+	return nil
+}
+
+// History returns every status change of a quiz, oldest first
+func (s *workflowService) History(ctx context.Context, quizID string) ([]TransitionResponse, error) {
+	if _, err := s.repo.GetByID(ctx, quizID); err != nil {
+		return nil, domain.ErrQuizNotFound
+	}
+
+	transitions, err := s.transitions.ListByQuizID(ctx, quizID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quiz transitions", err)
+	}
+
+	responses := make([]TransitionResponse, len(transitions))
+	for i, t := range transitions {
+		responses[i] = TransitionResponse{
+			ID:         t.ID,
+			Action:     t.Action,
+			FromStatus: t.FromStatus,
+			ToStatus:   t.ToStatus,
+			Comment:    t.Comment,
+			ActorID:    t.ActorID,
+			CreatedAt:  t.CreatedAt,
+		}
+	}
+	return responses, nil
+}
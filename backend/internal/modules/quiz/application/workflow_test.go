@@ -0,0 +1,258 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// mockTransitionRepository is an in-memory implementation of domain.TransitionRepository
+type mockTransitionRepository struct {
+	transitions []domain.Transition
+}
+
+func (m *mockTransitionRepository) ListByQuizID(_ context.Context, quizID string) ([]domain.Transition, error) {
+	out := []domain.Transition{}
+	for _, t := range m.transitions {
+		if t.QuizID == quizID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockTransitionRepository) Create(_ context.Context, transition *domain.Transition) error {
+	m.transitions = append(m.transitions, *transition)
+	return nil
+}
+
+func newWorkflowFixture(status domain.Status) (*mockQuizRepository, *mockTransitionRepository, WorkflowService) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{{ID: "a", Question: "Q1", DisplayOrder: 1, Status: status}}
+	transitions := &mockTransitionRepository{}
+	return repo, transitions, NewWorkflowService(repo, transitions, passthroughTxManager{}, nil)
+}
+
+// ============ Test Cases ============
+
+func TestWorkflow_Lifecycle(t *testing.T) {
+	repo, transitions, service := newWorkflowFixture(domain.StatusDraft)
+	ctx := signedIn("reviewer-1", utils.RoleReviewer)
+
+	steps := []struct {
+		action domain.Action
+		want   domain.Status
+	}{
+		{domain.ActionSubmit, domain.StatusInReview},
+		{domain.ActionReject, domain.StatusDraft},
+		{domain.ActionSubmit, domain.StatusInReview},
+		{domain.ActionApprove, domain.StatusPublished},
+		{domain.ActionArchive, domain.StatusArchived},
+		{domain.ActionReopen, domain.StatusDraft},
+	}
+	for _, step := range steps {
+		resp, err := service.Transition(ctx, "a", step.action, TransitionRequest{Comment: "  Needs a source  "})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", step.action, err)
+		}
+		if resp.Status != step.want || repo.quizzes[0].Status != step.want {
+			t.Fatalf("%s: expected status %s, got %s", step.action, step.want, resp.Status)
+		}
+	}
+
+	history, err := service.History(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(history) != len(steps) || len(transitions.transitions) != len(steps) {
+		t.Fatalf("expected every transition to be recorded, got %+v", history)
+	}
+	reject := history[1]
+	if reject.Action != domain.ActionReject || reject.FromStatus != domain.StatusInReview || reject.ToStatus != domain.StatusDraft {
+		t.Errorf("unexpected transition %+v", reject)
+	}
+	if reject.Comment != "Needs a source" || reject.ActorID == nil || *reject.ActorID != "reviewer-1" {
+		t.Errorf("expected the trimmed comment and the reviewer, got %+v", reject)
+	}
+}
+
+func TestWorkflow_InvalidTransition(t *testing.T) {
+	repo, transitions, service := newWorkflowFixture(domain.StatusDraft)
+
+	for _, action := range []domain.Action{domain.ActionApprove, domain.ActionArchive, domain.ActionReopen, "publish"} {
+		_, err := service.Transition(signedIn("reviewer-1", utils.RoleReviewer), "a", action, TransitionRequest{Comment: "ok"})
+
+		var appErr *sharedDomain.AppError
+		if !errors.As(err, &appErr) || appErr.Message != domain.ErrInvalidTransition.Message {
+			t.Errorf("%s: expected ErrInvalidTransition, got %v", action, err)
+		}
+	}
+	if repo.quizzes[0].Status != domain.StatusDraft || len(transitions.transitions) != 0 {
+		t.Error("expected nothing to change")
+	}
+}
+
+func TestWorkflow_RejectRequiresComment(t *testing.T) {
+	_, transitions, service := newWorkflowFixture(domain.StatusInReview)
+	ctx := signedIn("reviewer-1", utils.RoleReviewer)
+
+	if _, err := service.Transition(ctx, "a", domain.ActionReject, TransitionRequest{Comment: " "}); !errors.Is(err, domain.ErrCommentRequired) {
+		t.Errorf("expected ErrCommentRequired, got %v", err)
+	}
+	if len(transitions.transitions) != 0 {
+		t.Error("expected no transition to be recorded")
+	}
+
+	// Other actions do not need a comment
+	if _, err := service.Transition(ctx, "a", domain.ActionApprove, TransitionRequest{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestWorkflow_AnonymousSubmitHasNoActor(t *testing.T) {
+	_, transitions, service := newWorkflowFixture(domain.StatusDraft)
+
+	if _, err := service.Transition(context.Background(), "a", domain.ActionSubmit, TransitionRequest{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if transitions.transitions[0].ActorID != nil {
+		t.Errorf("expected no actor, got %v", *transitions.transitions[0].ActorID)
+	}
+}
+
+func TestWorkflow_ReviewPermissions(t *testing.T) {
+	cases := []struct {
+		name   string
+		ctx    context.Context
+		action domain.Action
+		want   error
+	}{
+		{"anonymous", context.Background(), domain.ActionApprove, domain.ErrSignInRequired},
+		{"author without role", signedIn("alice", "user"), domain.ActionApprove, domain.ErrReviewerRequired},
+		{"other user", signedIn("bob", "user"), domain.ActionReject, domain.ErrReviewerRequired},
+		{"author as reviewer", signedIn("alice", utils.RoleReviewer), domain.ActionApprove, domain.ErrSelfApproval},
+		{"author as admin", signedIn("alice", utils.RoleAdmin), domain.ActionApprove, domain.ErrSelfApproval},
+		{"author rejects", signedIn("alice", utils.RoleReviewer), domain.ActionReject, nil},
+		{"reviewer", signedIn("carol", utils.RoleReviewer), domain.ActionApprove, nil},
+		{"admin", signedIn("bob", utils.RoleAdmin), domain.ActionApprove, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, transitions, service := newWorkflowFixture(domain.StatusInReview)
+			alice := "alice"
+			repo.quizzes[0].CreatedBy = &alice
+
+			_, err := service.Transition(tc.ctx, "a", tc.action, TransitionRequest{Comment: "Checked"})
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got: %v", tc.want, err)
+			}
+			if err != nil && (repo.quizzes[0].Status != domain.StatusInReview || len(transitions.transitions) != 0) {
+				t.Error("expected nothing to change")
+			}
+		})
+	}
+}
+
+func TestWorkflow_QuizNotFound(t *testing.T) {
+	_, _, service := newWorkflowFixture(domain.StatusDraft)
+
+	if _, err := service.Transition(context.Background(), "missing", domain.ActionSubmit, TransitionRequest{}); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got %v", err)
+	}
+	if _, err := service.History(context.Background(), "missing"); !errors.Is(err, domain.ErrQuizNotFound) {
+		t.Errorf("expected ErrQuizNotFound, got %v", err)
+	}
+}
+
+func TestCreateQuiz_StartsAsDraft(t *testing.T) {
+	repo := newMockRepo()
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	resp, err := service.Create(context.Background(), CreateQuizRequest{Question: "Q", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Status != domain.StatusDraft || repo.quizzes[0].Status != domain.StatusDraft {
+		t.Errorf("expected a draft, got %s", resp.Status)
+	}
+}
+
+func TestListQuizzes_VisibleOnly(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []domain.Quiz{{ID: "a", DisplayOrder: 1, Status: domain.StatusPublished, UnresolvedComments: 2}}
+	service := NewQuizService(repo, newMockRevisionRepo(), &mockTransitionRepository{}, passthroughTxManager{}, 0, nil)
+
+	page, err := service.List(context.Background(), ListQuizzesRequest{Visible: true})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if page.Items[0].UnresolvedComments != nil {
+		t.Error("expected the public list not to report reviewer threads")
+	}
+	var args []interface{}
+	if where := repo.listQuery.Where(&args); where != " AND "+domain.VisibleCondition+" = $1" || args[0] != true {
+		t.Errorf("expected the list to be limited to visible quizzes, got %q %v", where, args)
+	}
+
+	page, _ = service.List(signedIn("admin", utils.RoleAdmin), ListQuizzesRequest{})
+	if len(repo.listQuery.Filters) != 0 {
+		t.Errorf("expected no visibility filter, got %+v", repo.listQuery.Filters)
+	}
+	if n := page.Items[0].UnresolvedComments; n == nil || *n != 2 {
+		t.Errorf("expected the management list to report 2 unresolved threads, got %v", n)
+	}
+}
+
+func TestEditPublishedQuiz_GoesBackToReview(t *testing.T) {
+	repo := newMockRepo()
+	revisions := newMockRevisionRepo()
+	transitions := &mockTransitionRepository{}
+	service := NewQuizService(repo, revisions, transitions, passthroughTxManager{}, 0, nil)
+	quiz := createTestQuiz(t, service)
+	first := quiz.RevisionID
+	repo.quizzes[0].Status = domain.StatusPublished
+
+	// An edit that changes nothing leaves the quiz published
+	unchanged := UpdateQuizRequest{Question: quiz.Question, Choice1: quiz.Choice1, Choice2: quiz.Choice2, Choice3: quiz.Choice3, Choice4: quiz.Choice4, Answer: 2}
+	if _, err := service.Update(context.Background(), quiz.ID, unchanged); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if repo.quizzes[0].Status != domain.StatusPublished || len(transitions.transitions) != 0 {
+		t.Fatalf("expected the quiz to stay published, got %s", repo.quizzes[0].Status)
+	}
+
+	resp, err := service.Update(signedIn("alice", "user"), quiz.ID, editRequest)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Status != domain.StatusInReview || repo.quizzes[0].Status != domain.StatusInReview {
+		t.Fatalf("expected the edited quiz back in review, got %s", resp.Status)
+	}
+	revise := transitions.transitions[0]
+	if revise.Action != domain.ActionRevise || revise.FromStatus != domain.StatusPublished || revise.ActorID == nil || *revise.ActorID != "alice" {
+		t.Errorf("expected a revise transition by alice, got %+v", revise)
+	}
+
+	// Reverting a published quiz needs another review too
+	repo.quizzes[0].Status = domain.StatusPublished
+	reverted, err := NewRevisionService(repo, revisions, transitions, passthroughTxManager{}, nil).Revert(context.Background(), quiz.ID, first)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if reverted.Status != domain.StatusInReview || len(transitions.transitions) != 2 {
+		t.Errorf("expected the reverted quiz back in review, got %s", reverted.Status)
+	}
+
+	// Drafts stay drafts
+	repo.quizzes[0].Status = domain.StatusDraft
+	if _, err := service.Update(context.Background(), quiz.ID, editRequest); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if repo.quizzes[0].Status != domain.StatusDraft || len(transitions.transitions) != 2 {
+		t.Errorf("expected the draft to stay a draft, got %s", repo.quizzes[0].Status)
+	}
+}
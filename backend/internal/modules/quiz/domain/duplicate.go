@@ -0,0 +1,19 @@
+package domain
+
+// Duplicate is an existing quiz whose question matches a given question.
+// Questions are compared after ignoring case, whitespace and punctuation.
+type Duplicate struct {
+	Quiz
+	// Similarity is the trigram similarity of the questions, from 0 to 1
+	Similarity float64 `db:"similarity"`
+	// Exact is true when the questions are identical after normalization
+	Exact bool `db:"exact"`
+}
+
+// DuplicatePair is two existing quizzes whose questions match
+type DuplicatePair struct {
+	QuizID     string  `db:"quiz_id"`
+	OtherID    string  `db:"other_id"`
+	Similarity float64 `db:"similarity"`
+	Exact      bool    `db:"exact"`
+}
@@ -1,16 +1,90 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
 
 // Quiz represents a quiz question entity
 type Quiz struct {
-	ID           string    `json:"id" db:"id"`
-	Question     string    `json:"question" db:"question"`
-	Choice1      string    `json:"choice1" db:"choice1"`
-	Choice2      string    `json:"choice2" db:"choice2"`
-	Choice3      string    `json:"choice3" db:"choice3"`
-	Choice4      string    `json:"choice4" db:"choice4"`
-	DisplayOrder int       `json:"display_order" db:"display_order"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           string     `json:"id" db:"id"`
+	Question     string     `json:"question" db:"question"`
+	Choice1      string     `json:"choice1" db:"choice1"`
+	Choice2      string     `json:"choice2" db:"choice2"`
+	Choice3      string     `json:"choice3" db:"choice3"`
+	Choice4      string     `json:"choice4" db:"choice4"`
+	Answer       int        `json:"answer" db:"answer"`
+	DisplayOrder int        `json:"display_order" db:"display_order"`
+	RevisionID   string     `json:"revision_id" db:"revision_id"`
+	Status       Status     `json:"status" db:"status"`
+	CreatedBy    *string    `json:"created_by,omitempty" db:"created_by"`
+	UpdatedBy    *string    `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Schedule limits when a published quiz is visible
+	sharedDomain.Schedule
+
+	// UnresolvedComments is the number of open reviewer threads; it is only
+	// read by the list queries
+	UnresolvedComments int `json:"unresolved_comments" db:"unresolved_comments"`
+	// PValue is the share of correct answers in submitted attempts, nil
+	// until someone answers; it is only read by the list queries
+	PValue *float64 `json:"p_value,omitempty" db:"p_value"`
+}
+
+// IsDeleted returns true if the quiz is in the trash
+func (q *Quiz) IsDeleted() bool {
+	return q.DeletedAt != nil
+}
+
+// IsPublished returns true if the quiz has been approved for publication
+func (q *Quiz) IsPublished() bool {
+	return q.Status == StatusPublished
+}
+
+// IsVisible returns true if the quiz is in the public listing at t: it is
+// published and t falls inside its schedule
+func (q *Quiz) IsVisible(t time.Time) bool {
+	return q.IsPublished() && q.Schedule.Contains(t)
+}
+
+// IsOwnedBy returns true if userID created the quiz
+func (q *Quiz) IsOwnedBy(userID string) bool {
+	return q.CreatedBy != nil && *q.CreatedBy == userID
+}
+
+// NumChoices is the fixed number of choices every quiz carries
+const NumChoices = 4
+
+// Choices returns the quiz choices in order
+func (q *Quiz) Choices() [NumChoices]string {
+	return [NumChoices]string{q.Choice1, q.Choice2, q.Choice3, q.Choice4}
+}
+
+// HasAnswer returns true if the correct choice has been set
+func (q *Quiz) HasAnswer() bool {
+	return q.Answer >= 1 && q.Answer <= NumChoices
+}
+
+// QuizCursor is a keyset position in the quiz list. Quizzes are listed by
+// display_order, with the ID breaking ties.
+type QuizCursor struct {
+	DisplayOrder int    `json:"o"`
+	ID           string `json:"id"`
+	// Before selects the quizzes before the position instead of after it
+	Before bool `json:"b,omitempty"`
+}
+
+// Media is a file referenced by a quiz, such as an image imported from a content package
+type Media struct {
+	ID          string    `json:"id" db:"id"`
+	QuizID      string    `json:"quiz_id" db:"quiz_id"`
+	Filename    string    `json:"filename" db:"filename"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int       `json:"size" db:"size"`
+	Data        []byte    `json:"-" db:"data"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
@@ -3,6 +3,90 @@ package domain
 import sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
 
 var (
-	ErrQuizNotFound = sharedDomain.NewNotFoundError("Quiz not found")
-	ErrInvalidQuiz  = sharedDomain.NewValidationError("Question and all 4 choices are required")
+	ErrQuizNotFound  = sharedDomain.NewNotFoundError("Quiz not found")
+	ErrInvalidQuiz   = sharedDomain.NewValidationError("Question and all 4 choices are required")
+	ErrInvalidAnswer = sharedDomain.NewValidationError("Answer must be between 1 and 4, or 0 when not set")
+	ErrMediaNotFound = sharedDomain.NewNotFoundError("Media not found")
+	ErrInvalidCursor = sharedDomain.NewValidationError("Invalid pagination cursor")
+	ErrCursorSort    = sharedDomain.NewValidationError("Cursor pagination only supports the default sort order; use page instead")
+	ErrAnswerField   = sharedDomain.NewValidationError("Only your own quizzes can be sorted or filtered by answer; use /quizzes/manage")
+)
+
+// Revision errors
+var (
+	ErrRevisionNotFound = sharedDomain.NewNotFoundError("Revision not found")
+	ErrAlreadyCurrent   = sharedDomain.NewConflictError("Revision is already the current version of the quiz")
+)
+
+// Search errors
+var (
+	ErrEmptySearch = sharedDomain.NewValidationError("Search query must contain at least one word")
+)
+
+// Duplicate errors
+var (
+	ErrDuplicateQuiz          = sharedDomain.NewConflictError("Quiz duplicates an existing question")
+	ErrInvalidDuplicatePolicy = sharedDomain.NewValidationError("on_duplicate must be 'warn' or 'block'")
+	ErrInvalidThreshold       = sharedDomain.NewValidationError("Similarity threshold must be between 0.3 and 1")
+)
+
+// Translation errors
+var (
+	ErrTranslationNotFound = sharedDomain.NewNotFoundError("Translation not found")
+	ErrInvalidLocale       = sharedDomain.NewValidationError("Locale must be a language tag such as 'en' or 'en-US'")
+	ErrDefaultLocale       = sharedDomain.NewValidationError("Quizzes are written in the default locale; edit the quiz instead")
+	ErrInvalidTranslation  = sharedDomain.NewValidationError("Translated question and all 4 choices are required")
+)
+
+// Hint errors
+var (
+	ErrInvalidHint   = sharedDomain.NewValidationError("Every hint needs a body and a penalty between 0 and 1")
+	ErrTooManyHints  = sharedDomain.NewValidationError("A quiz can have at most 5 hints")
+	ErrHintPenalties = sharedDomain.NewValidationError("Hint penalties must add up to at most 1, the point a question is worth")
+)
+
+// Workflow errors
+var (
+	ErrInvalidTransition = sharedDomain.NewConflictError("Quiz status does not allow this action")
+	ErrStatusChanged     = sharedDomain.NewConflictError("Quiz status was changed by someone else; reload and try again")
+	ErrCommentRequired   = sharedDomain.NewValidationError("A comment is required to reject a quiz")
+	ErrReviewerRequired  = sharedDomain.NewForbiddenError("Only reviewers and admins can approve or reject quizzes")
+	ErrSelfApproval      = sharedDomain.NewForbiddenError("Quizzes must be approved by someone other than their author")
+)
+
+// Trash errors
+var (
+	ErrQuizNotInTrash         = sharedDomain.NewNotFoundError("Quiz not found in trash")
+	ErrInvalidRestorePosition = sharedDomain.NewValidationError("Restore position must be 'original' or 'end'")
+	ErrQuizAnswered           = sharedDomain.NewConflictError("Quiz was answered in attempts and is kept for their results")
+)
+
+// Batch errors
+var (
+	ErrEmptyBatch    = sharedDomain.NewValidationError("Batch must contain at least one item")
+	ErrBatchTooLarge = sharedDomain.NewValidationError("Batch exceeds the maximum number of items")
+	ErrBatchRejected = sharedDomain.NewValidationError("Batch rejected: no changes were made")
+	ErrDuplicateID   = sharedDomain.NewValidationError("Quiz ID appears more than once in the batch")
+)
+
+// Interchange errors
+var (
+	ErrUnknownFormat   = sharedDomain.NewValidationError("Unknown interchange format")
+	ErrInvalidDocument = sharedDomain.NewValidationError("Document could not be parsed")
+	ErrMissingAnswer   = sharedDomain.NewValidationError("Some quizzes have no answer set and cannot be exported")
+	ErrExportSignIn    = sharedDomain.NewUnauthorizedError("Sign in to export your quizzes")
+	ErrExportNotOwner  = sharedDomain.NewForbiddenError("Only the author or an admin can export a quiz with its answer")
+)
+
+// Clone errors
+var (
+	ErrCloneSignIn   = sharedDomain.NewUnauthorizedError("Sign in to clone quizzes")
+	ErrCloneNotOwner = sharedDomain.NewForbiddenError("Only the author or an admin can clone a quiz with its answer")
+)
+
+// Ownership errors
+var (
+	ErrSignInRequired = sharedDomain.NewUnauthorizedError("Sign in to change quizzes written by other users")
+	ErrNotOwner       = sharedDomain.NewForbiddenError("Only the author or an admin can change this quiz")
+	ErrManageSignIn   = sharedDomain.NewUnauthorizedError("Sign in to manage quizzes")
 )
@@ -0,0 +1,14 @@
+package domain
+
+// MaxHints is the number of hints a quiz can carry
+const MaxHints = 5
+
+// Hint is one of the progressive hints of a quiz, revealed in position
+// order. A correct answer earns its point less the penalties of the hints
+// revealed for it.
+type Hint struct {
+	QuizID   string  `json:"quiz_id" db:"quiz_id"`
+	Position int     `json:"position" db:"position"`
+	Body     string  `json:"body" db:"body"`
+	Penalty  float64 `json:"penalty" db:"penalty"`
+}
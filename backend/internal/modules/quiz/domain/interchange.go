@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// QuizCodec converts quizzes to and from an external interchange format
+type QuizCodec interface {
+	// Name returns the format identifier used by the API and CLI (e.g. "gift")
+	Name() string
+
+	// ContentType returns the MIME type of encoded documents
+	ContentType() string
+
+	// FileExtension returns the file extension for encoded documents, including the dot
+	FileExtension() string
+
+	// EmbedsMedia returns true if encoded documents carry the media files quizzes reference
+	EmbedsMedia() bool
+
+	// Decode parses a document. Syntax errors are returned as *ParseError,
+	// questions that cannot be represented as a Quiz are reported in ImportDocument.Skipped
+	Decode(r io.Reader) (*ImportDocument, error)
+
+	// Encode writes quizzes as a document. Formats that require a correct answer
+	// return *MissingAnswerError when some quizzes have none.
+	Encode(w io.Writer, bundles []QuizBundle) error
+}
+
+// QuizBundle is a quiz together with the media files it references
+type QuizBundle struct {
+	Quiz  Quiz
+	Media []Media
+}
+
+// ImportDocument is the result of decoding an interchange document
+type ImportDocument struct {
+	Quizzes []ImportedQuiz
+	Skipped []ImportIssue
+}
+
+// ImportedQuiz is a decoded quiz along with where it was found in the source
+type ImportedQuiz struct {
+	QuizBundle
+	Line int
+}
+
+// ImportIssue describes a problem with a single question in a source document.
+// Line is set for text formats, Item names the file or identifier for package formats.
+type ImportIssue struct {
+	Line    int    `json:"line,omitempty"`
+	Item    string `json:"item,omitempty"`
+	Message string `json:"message"`
+}
+
+// ParseError is returned when a document is malformed
+type ParseError struct {
+	Format string
+	Issues []ImportIssue
+}
+
+func (e *ParseError) Error() string {
+	msgs := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		msgs[i] = issue.String()
+	}
+	return e.Format + ": " + strings.Join(msgs, "; ")
+}
+
+// String formats the issue with its location
+func (i ImportIssue) String() string {
+	switch {
+	case i.Item != "":
+		return fmt.Sprintf("%s: %s", i.Item, i.Message)
+	case i.Line > 0:
+		return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+	default:
+		return i.Message
+	}
+}
+
+// MissingAnswerError is returned by codecs that cannot encode quizzes without a correct answer
+type MissingAnswerError struct {
+	QuizIDs []string
+}
+
+func (e *MissingAnswerError) Error() string {
+	return "quizzes without answer: " + strings.Join(e.QuizIDs, ", ")
+}
+
+// CheckAnswers returns *MissingAnswerError if any bundled quiz has no answer set
+func CheckAnswers(bundles []QuizBundle) error {
+	var missing []string
+	for _, b := range bundles {
+		if !b.Quiz.HasAnswer() {
+			missing = append(missing, b.Quiz.ID)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingAnswerError{QuizIDs: missing}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "github.com/cananga-odorata/golang-template/internal/shared/listquery"
+
+// comparisons are the filter operators for ordered fields
+var comparisons = []listquery.Operator{
+	listquery.OpEq, listquery.OpNe, listquery.OpGt, listquery.OpGte, listquery.OpLt, listquery.OpLte, listquery.OpIn,
+}
+
+// VisibleCondition is the SQL condition for quizzes in the public listing:
+// published and inside their schedule
+const VisibleCondition = `(status = 'published' AND (publish_at IS NULL OR publish_at <= NOW()) AND (unpublish_at IS NULL OR unpublish_at > NOW()))`
+
+// UnresolvedCommentsColumn is the SQL expression counting the unresolved
+// reviewer threads of a quiz
+const UnresolvedCommentsColumn = `(SELECT COUNT(*) FROM quiz_comments c WHERE c.quiz_id = quizzes.id AND c.parent_id IS NULL AND c.resolved_at IS NULL)`
+
+// PValueColumn is the SQL expression for the share of answers in submitted
+// attempts that were correct; it is NULL for quizzes nobody has answered yet
+const PValueColumn = `(SELECT AVG(a.correct::int) FROM attempt_answers a JOIN attempts t ON t.id = a.attempt_id
+	WHERE a.quiz_id = quizzes.id AND a.answered_at IS NOT NULL AND t.status = 'submitted')`
+
+// QuizListSchema lists the fields the quiz list can be sorted and filtered
+// by. The list is ordered by display_order unless a request says otherwise.
+var QuizListSchema = listquery.NewSchema("display_order",
+	listquery.Field{Name: "status", Column: "status", Type: listquery.String, Ops: []listquery.Operator{listquery.OpEq, listquery.OpNe, listquery.OpIn}, Values: statusValues()},
+	listquery.Field{Name: "visible", Column: VisibleCondition, Type: listquery.Bool, Ops: []listquery.Operator{listquery.OpEq}},
+	listquery.Field{Name: "publish_at", Column: "publish_at", Type: listquery.Time, Sortable: true, Ops: comparisons},
+	listquery.Field{Name: "unpublish_at", Column: "unpublish_at", Type: listquery.Time, Sortable: true, Ops: comparisons},
+	listquery.Field{Name: "created_by", Column: "created_by", Type: listquery.String, Ops: []listquery.Operator{listquery.OpEq, listquery.OpIn}},
+	listquery.Field{Name: "updated_by", Column: "updated_by", Type: listquery.String, Ops: []listquery.Operator{listquery.OpEq, listquery.OpIn}},
+	listquery.Field{Name: "id", Column: "id", Type: listquery.String, Ops: []listquery.Operator{listquery.OpEq, listquery.OpIn}},
+	listquery.Field{Name: "question", Column: "question", Type: listquery.String, Sortable: true, Ops: []listquery.Operator{listquery.OpEq, listquery.OpContains}},
+	listquery.Field{Name: "answer", Column: "answer", Type: listquery.Int, Sortable: true, Ops: comparisons},
+	listquery.Field{Name: "display_order", Column: "display_order", Type: listquery.Int, Sortable: true, Ops: comparisons},
+	listquery.Field{Name: "unresolved_comments", Column: UnresolvedCommentsColumn, Type: listquery.Int, Sortable: true, Ops: comparisons},
+	listquery.Field{Name: "p_value", Column: PValueColumn, Type: listquery.Float, Sortable: true, NullsLast: true,
+		Ops: []listquery.Operator{listquery.OpGt, listquery.OpGte, listquery.OpLt, listquery.OpLte}},
+	listquery.Field{Name: "created_at", Column: "created_at", Type: listquery.Time, Sortable: true, Ops: comparisons},
+	listquery.Field{Name: "updated_at", Column: "updated_at", Type: listquery.Time, Sortable: true, Ops: comparisons},
+)
+
+func statusValues() []string {
+	values := make([]string, len(Statuses))
+	for i, s := range Statuses {
+		values[i] = string(s)
+	}
+	return values
+}
@@ -1,24 +1,167 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
 
-// QuizRepository defines the interface for quiz data access
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/listquery"
+)
+
+// QuizRepository defines the interface for quiz data access.
+// Unless stated otherwise, methods only see quizzes that are not in the trash.
 type QuizRepository interface {
 	// GetAll returns all quizzes ordered by display_order
 	GetAll(ctx context.Context) ([]Quiz, error)
 
+	// GetVisible returns the quizzes that are published and inside their
+	// schedule, ordered by display_order
+	GetVisible(ctx context.Context) ([]Quiz, error)
+
+	// Count returns the number of quizzes matching the query's filters
+	Count(ctx context.Context, q *listquery.Query) (int64, error)
+
+	// GetPage returns limit quizzes matching the query in its sort order, skipping offset
+	GetPage(ctx context.Context, q *listquery.Query, offset, limit int) ([]Quiz, error)
+
+	// GetPageByCursor returns up to limit quizzes matching the query's filters
+	// after (or before) the cursor, ordered by display_order
+	GetPageByCursor(ctx context.Context, q *listquery.Query, cursor QuizCursor, limit int) ([]Quiz, error)
+
+	// FindDuplicates returns up to limit quizzes whose question is identical
+	// to question after normalization or at least threshold similar to it,
+	// exact matches first, then by similarity
+	FindDuplicates(ctx context.Context, question string, threshold float64, limit int) ([]Duplicate, error)
+
+	// FindDuplicatePairs returns every pair of quizzes whose questions are
+	// identical after normalization or at least threshold similar
+	FindDuplicatePairs(ctx context.Context, threshold float64) ([]DuplicatePair, error)
+
 	// GetByID returns a quiz by its ID
 	GetByID(ctx context.Context, id string) (*Quiz, error)
 
+	// GetByIDForUpdate returns a quiz by its ID and locks it until the
+	// transaction carried by ctx ends, so changes to it are made one at a time
+	GetByIDForUpdate(ctx context.Context, id string) (*Quiz, error)
+
 	// Create inserts a new quiz
 	Create(ctx context.Context, quiz *Quiz) error
 
-	// Delete removes a quiz by its ID
+	// Delete moves a quiz to the trash, keeping its display_order as its original slot
 	Delete(ctx context.Context, id string) error
 
 	// GetMaxDisplayOrder returns the current maximum display_order
 	GetMaxDisplayOrder(ctx context.Context) (int, error)
 
+	// Update saves the content, current revision and editor of a quiz
+	Update(ctx context.Context, quiz *Quiz) error
+
+	// UpdateStatus moves a quiz from status from to status to. It returns
+	// ErrStatusChanged if the quiz is no longer in status from.
+	UpdateStatus(ctx context.Context, id string, from, to Status) error
+
+	// UpdateSchedule sets the publication window of a quiz
+	UpdateSchedule(ctx context.Context, id string, schedule sharedDomain.Schedule) error
+
+	// SyncVisibility records the current visibility of every quiz, including
+	// those in the trash, and returns the quizzes whose visibility changed
+	// since the last call
+	SyncVisibility(ctx context.Context) ([]sharedDomain.VisibilityChange, error)
+
 	// DecrementDisplayOrdersAbove decrements display_order for all quizzes with order > given value
 	DecrementDisplayOrdersAbove(ctx context.Context, order int) error
+
+	// RenumberDisplayOrders closes gaps so display_order runs 1..n in the current order
+	RenumberDisplayOrders(ctx context.Context) error
+
+	// IncrementDisplayOrdersFrom increments display_order for all quizzes with order >= given value
+	IncrementDisplayOrdersFrom(ctx context.Context, order int) error
+
+	// Search returns up to limit quizzes matching every term of the query, best
+	// match first. visibleOnly limits the results to the public listing.
+	Search(ctx context.Context, query SearchQuery, visibleOnly bool, limit int) ([]SearchResult, error)
+
+	// GetDeleted returns the quizzes in the trash, most recently deleted first
+	GetDeleted(ctx context.Context) ([]Quiz, error)
+
+	// GetDeletedByID returns a quiz in the trash by its ID
+	GetDeletedByID(ctx context.Context, id string) (*Quiz, error)
+
+	// Restore takes a quiz out of the trash at the given display_order
+	Restore(ctx context.Context, id string, order int) error
+
+	// Purge permanently removes a quiz in the trash. It returns
+	// ErrQuizAnswered if the quiz was answered in an attempt.
+	Purge(ctx context.Context, id string) error
+
+	// PurgeDeletedBefore permanently removes quizzes deleted before the given time,
+	// except those answered in attempts, and returns how many were removed
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// RevisionRepository defines the interface for quiz revision data access.
+// Revisions are never updated or deleted.
+type RevisionRepository interface {
+	// ListByQuizID returns all revisions of a quiz, newest first
+	ListByQuizID(ctx context.Context, quizID string) ([]Revision, error)
+
+	// GetByID returns a revision of a quiz
+	GetByID(ctx context.Context, quizID, id string) (*Revision, error)
+
+	// Create inserts a new revision, numbered after the latest revision of
+	// its quiz, and sets its Number
+	Create(ctx context.Context, revision *Revision) error
+}
+
+// TransitionRepository defines the interface for quiz status history.
+// Transitions are never updated or deleted.
+type TransitionRepository interface {
+	// ListByQuizID returns the transitions of a quiz, oldest first
+	ListByQuizID(ctx context.Context, quizID string) ([]Transition, error)
+
+	// Create records a transition
+	Create(ctx context.Context, transition *Transition) error
+}
+
+// TranslationRepository defines the interface for quiz translation data access
+type TranslationRepository interface {
+	// ListByQuizID returns all translations of a quiz ordered by locale
+	ListByQuizID(ctx context.Context, quizID string) ([]Translation, error)
+
+	// ListByQuizIDs returns the translations of the given quizzes into the given locales
+	ListByQuizIDs(ctx context.Context, quizIDs []string, locales []string) ([]Translation, error)
+
+	// Upsert creates or replaces the translation of a quiz into a locale
+	Upsert(ctx context.Context, translation *Translation) error
+
+	// Delete removes the translation of a quiz into a locale
+	Delete(ctx context.Context, quizID, locale string) error
+
+	// ListUntranslated returns the quizzes, in display order, that have no
+	// translation into locale or whose translation is outdated
+	ListUntranslated(ctx context.Context, locale string) ([]UntranslatedQuiz, error)
+}
+
+// HintRepository defines the interface for quiz hint data access
+type HintRepository interface {
+	// ListByQuizID returns the hints of a quiz in position order
+	ListByQuizID(ctx context.Context, quizID string) ([]Hint, error)
+
+	// Replace removes the hints of a quiz and stores the given ones
+	Replace(ctx context.Context, quizID string, hints []Hint) error
+}
+
+// MediaRepository defines the interface for quiz media data access
+type MediaRepository interface {
+	// ListByQuizIDs returns media with their data for the given quizzes
+	ListByQuizIDs(ctx context.Context, quizIDs []string) ([]Media, error)
+
+	// ListMetadata returns media of a quiz without loading the file data
+	ListMetadata(ctx context.Context, quizID string) ([]Media, error)
+
+	// GetByID returns a media file of a quiz
+	GetByID(ctx context.Context, quizID, id string) (*Media, error)
+
+	// Create inserts a new media file
+	Create(ctx context.Context, media *Media) error
 }
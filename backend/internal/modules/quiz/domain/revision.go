@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// Revision actions record why a revision was created
+const (
+	RevisionActionCreate = "create"
+	RevisionActionUpdate = "update"
+	RevisionActionImport = "import"
+	RevisionActionRevert = "revert"
+)
+
+// Revision is an immutable snapshot of the content of a quiz. Every change
+// to a quiz creates a new revision, and the quiz points at its latest one.
+type Revision struct {
+	ID           string    `json:"id" db:"id"`
+	QuizID       string    `json:"quiz_id" db:"quiz_id"`
+	Number       int       `json:"number" db:"number"`
+	Question     string    `json:"question" db:"question"`
+	Choice1      string    `json:"choice1" db:"choice1"`
+	Choice2      string    `json:"choice2" db:"choice2"`
+	Choice3      string    `json:"choice3" db:"choice3"`
+	Choice4      string    `json:"choice4" db:"choice4"`
+	Answer       int       `json:"answer" db:"answer"`
+	Action       string    `json:"action" db:"action"`
+	RevertedFrom *string   `json:"reverted_from,omitempty" db:"reverted_from"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// NewRevision snapshots the current content of a quiz
+func NewRevision(q *Quiz, id string, number int, action string) *Revision {
+	return &Revision{
+		ID:       id,
+		QuizID:   q.ID,
+		Number:   number,
+		Question: q.Question,
+		Choice1:  q.Choice1,
+		Choice2:  q.Choice2,
+		Choice3:  q.Choice3,
+		Choice4:  q.Choice4,
+		Answer:   q.Answer,
+		Action:   action,
+	}
+}
+
+// ApplyTo copies the content of the revision onto a quiz
+func (r *Revision) ApplyTo(q *Quiz) {
+	q.Question = r.Question
+	q.Choice1 = r.Choice1
+	q.Choice2 = r.Choice2
+	q.Choice3 = r.Choice3
+	q.Choice4 = r.Choice4
+	q.Answer = r.Answer
+	q.RevisionID = r.ID
+}
+
+// SameContent returns true if the quiz has the content of the revision
+func (r *Revision) SameContent(q *Quiz) bool {
+	return len(Diff(r, NewRevision(q, "", 0, ""))) == 0
+}
+
+// FieldChange is a difference in one field between two revisions
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Diff returns the fields that differ between two revisions, in field order
+func Diff(from, to *Revision) []FieldChange {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"question", from.Question, to.Question},
+		{"choice1", from.Choice1, to.Choice1},
+		{"choice2", from.Choice2, to.Choice2},
+		{"choice3", from.Choice3, to.Choice3},
+		{"choice4", from.Choice4, to.Choice4},
+		{"answer", strconv.Itoa(from.Answer), strconv.Itoa(to.Answer)},
+	}
+
+	changes := []FieldChange{}
+	for _, f := range fields {
+		if f.from != f.to {
+			changes = append(changes, FieldChange{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+	return changes
+}
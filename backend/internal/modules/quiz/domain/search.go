@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchQuery is a parsed search string. Terms written in scripts that
+// separate words with spaces become full-text lexemes; Thai terms, which
+// have no word boundaries Postgres can find, are matched as substrings.
+type SearchQuery struct {
+	Lexemes    []string
+	Substrings []string
+}
+
+// SearchResult is a quiz matching a search, with its relevance
+type SearchResult struct {
+	Quiz
+	Rank float64 `db:"rank"`
+}
+
+// ParseSearchQuery splits a search string into lexemes and substrings.
+// Every term must match for a quiz to be found.
+func ParseSearchQuery(q string) SearchQuery {
+	var query SearchQuery
+	for _, term := range strings.Fields(strings.ToLower(q)) {
+		if strings.ContainsFunc(term, isThai) {
+			query.Substrings = append(query.Substrings, term)
+			continue
+		}
+		// Punctuation separates lexemes the same way to_tsvector does
+		for _, lexeme := range strings.FieldsFunc(term, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			query.Lexemes = append(query.Lexemes, lexeme)
+		}
+	}
+	return query
+}
+
+// IsEmpty returns true if the query has nothing to search for
+func (q SearchQuery) IsEmpty() bool {
+	return len(q.Lexemes) == 0 && len(q.Substrings) == 0
+}
+
+// TSQuery returns the lexemes as a to_tsquery expression matching word
+// prefixes, or an empty string when there are none
+func (q SearchQuery) TSQuery() string {
+	parts := make([]string, len(q.Lexemes))
+	for i, lexeme := range q.Lexemes {
+		parts[i] = lexeme + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+// LikePatterns returns the substrings as escaped ILIKE patterns
+func (q SearchQuery) LikePatterns() []string {
+	escape := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	patterns := make([]string, len(q.Substrings))
+	for i, s := range q.Substrings {
+		patterns[i] = "%" + escape.Replace(s) + "%"
+	}
+	return patterns
+}
+
+// Terms returns every term of the query, for highlighting
+func (q SearchQuery) Terms() []string {
+	return append(append([]string{}, q.Lexemes...), q.Substrings...)
+}
+
+func isThai(r rune) bool {
+	return unicode.Is(unicode.Thai, r)
+}
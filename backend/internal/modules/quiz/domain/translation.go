@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Translation is the content of a quiz in another locale. The quiz itself is
+// written in the default locale; the answer is shared by all translations.
+type Translation struct {
+	QuizID      string `json:"quiz_id" db:"quiz_id"`
+	Locale      string `json:"locale" db:"locale"`
+	Question    string `json:"question" db:"question"`
+	Choice1     string `json:"choice1" db:"choice1"`
+	Choice2     string `json:"choice2" db:"choice2"`
+	Choice3     string `json:"choice3" db:"choice3"`
+	Choice4     string `json:"choice4" db:"choice4"`
+	Explanation string `json:"explanation" db:"explanation"`
+	// SourceRevisionID is the revision of the quiz that was translated
+	SourceRevisionID string    `json:"source_revision_id" db:"source_revision_id"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IsOutdated returns true if the quiz has changed since it was translated
+func (t *Translation) IsOutdated(q *Quiz) bool {
+	return t.SourceRevisionID != q.RevisionID
+}
+
+// UntranslatedQuiz is a quiz without an up-to-date translation into a locale
+type UntranslatedQuiz struct {
+	Quiz
+	// Outdated is true when a translation exists but the quiz changed since
+	Outdated bool `db:"outdated"`
+}
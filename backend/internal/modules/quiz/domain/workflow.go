@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// Status is the stage of a quiz in the review workflow
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusInReview  Status = "in_review"
+	StatusPublished Status = "published"
+	StatusArchived  Status = "archived"
+)
+
+// Statuses lists every status in workflow order
+var Statuses = []Status{StatusDraft, StatusInReview, StatusPublished, StatusArchived}
+
+// Action is a step that moves a quiz from one status to another
+type Action string
+
+const (
+	// ActionSubmit sends a draft for review
+	ActionSubmit Action = "submit"
+	// ActionApprove publishes a quiz in review
+	ActionApprove Action = "approve"
+	// ActionReject sends a quiz in review back to draft with the reviewer's comment
+	ActionReject Action = "reject"
+	// ActionArchive takes a published quiz out of the public listing
+	ActionArchive Action = "archive"
+	// ActionReopen turns an archived quiz back into a draft
+	ActionReopen Action = "reopen"
+	// ActionRevise sends a published quiz back to review when its content is
+	// edited. It is recorded by edits rather than requested.
+	ActionRevise Action = "revise"
+)
+
+// transitions lists the status each action moves a quiz from and to
+var transitions = map[Action]struct{ from, to Status }{
+	ActionSubmit:  {StatusDraft, StatusInReview},
+	ActionApprove: {StatusInReview, StatusPublished},
+	ActionReject:  {StatusInReview, StatusDraft},
+	ActionArchive: {StatusPublished, StatusArchived},
+	ActionReopen:  {StatusArchived, StatusDraft},
+	ActionRevise:  {StatusPublished, StatusInReview},
+}
+
+// Next returns the status the action moves a quiz in status from to
+func (a Action) Next(from Status) (Status, error) {
+	t, ok := transitions[a]
+	if !ok || t.from != from {
+		return "", ErrInvalidTransition
+	}
+	return t.to, nil
+}
+
+// RequiresComment returns true if the action must explain itself
+func (a Action) RequiresComment() bool {
+	return a == ActionReject
+}
+
+// IsReview returns true if the action decides on a quiz in review, which is
+// up to reviewers rather than the quiz's author
+func (a Action) IsReview() bool {
+	return a == ActionApprove || a == ActionReject
+}
+
+// Transition records one status change of a quiz. Transitions are never
+// updated or deleted.
+type Transition struct {
+	ID         string    `json:"id" db:"id"`
+	QuizID     string    `json:"quiz_id" db:"quiz_id"`
+	Action     Action    `json:"action" db:"action"`
+	FromStatus Status    `json:"from_status" db:"from_status"`
+	ToStatus   Status    `json:"to_status" db:"to_status"`
+	Comment    string    `json:"comment" db:"comment"`
+	ActorID    *string   `json:"actor_id,omitempty" db:"actor_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
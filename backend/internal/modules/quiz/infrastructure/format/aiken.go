@@ -0,0 +1,158 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+// aikenCodec implements the Moodle Aiken format.
+// Questions with a number of choices other than 4 are reported as skipped.
+type aikenCodec struct{}
+
+// NewAikenCodec creates a codec for the Moodle Aiken format
+func NewAikenCodec() domain.QuizCodec {
+	return aikenCodec{}
+}
+
+func (aikenCodec) Name() string          { return "aiken" }
+func (aikenCodec) ContentType() string   { return "text/plain; charset=utf-8" }
+func (aikenCodec) FileExtension() string { return ".aiken.txt" }
+func (aikenCodec) EmbedsMedia() bool     { return false }
+
+var (
+	aikenChoice = regexp.MustCompile(`^([A-Z])[.)]\s+(.*)$`)
+	aikenAnswer = regexp.MustCompile(`(?i)^ANSWER\s*:\s*(.*)$`)
+)
+
+type aikenQuestion struct {
+	line    int
+	text    []string
+	letters []string
+	choices []string
+}
+
+// Decode parses an Aiken document
+func (c aikenCodec) Decode(r io.Reader) (*domain.ImportDocument, error) {
+	doc := &domain.ImportDocument{}
+	var syntaxIssues []domain.ImportIssue
+	var current *aikenQuestion
+
+	fail := func(line int, format string, args ...interface{}) {
+		syntaxIssues = append(syntaxIssues, domain.ImportIssue{Line: line, Message: fmt.Sprintf(format, args...)})
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if line == "" {
+			continue
+		}
+
+		if m := aikenAnswer.FindStringSubmatch(line); m != nil {
+			if current == nil || len(current.choices) == 0 {
+				fail(lineNo, "ANSWER line without choices")
+				continue
+			}
+			letter := strings.ToUpper(strings.TrimSpace(m[1]))
+			answer := 0
+			for i, l := range current.letters {
+				if l == letter {
+					answer = i + 1
+				}
+			}
+			if answer == 0 {
+				fail(lineNo, "ANSWER %q does not match any choice", m[1])
+				continue
+			}
+			if q := current.toQuiz(answer); q != nil {
+				doc.Quizzes = append(doc.Quizzes, domain.ImportedQuiz{QuizBundle: domain.QuizBundle{Quiz: *q}, Line: current.line})
+			} else {
+				doc.Skipped = append(doc.Skipped, domain.ImportIssue{
+					Line:    current.line,
+					Message: fmt.Sprintf("expected %d choices, found %d", domain.NumChoices, len(current.choices)),
+				})
+			}
+			current = nil
+			continue
+		}
+
+		if m := aikenChoice.FindStringSubmatch(line); m != nil {
+			if current == nil {
+				fail(lineNo, "choice %s appears before any question text", m[1])
+				continue
+			}
+			current.letters = append(current.letters, m[1])
+			current.choices = append(current.choices, strings.TrimSpace(m[2]))
+			continue
+		}
+
+		if current != nil && len(current.choices) > 0 {
+			fail(current.line, "missing ANSWER line for question")
+		}
+		if current == nil {
+			current = &aikenQuestion{line: lineNo}
+		}
+		current.text = append(current.text, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		fail(current.line, "missing ANSWER line for question")
+	}
+
+	if len(syntaxIssues) > 0 {
+		return nil, &domain.ParseError{Format: c.Name(), Issues: syntaxIssues}
+	}
+	return doc, nil
+}
+
+// toQuiz returns nil when the question cannot be represented as a Quiz
+func (q *aikenQuestion) toQuiz(answer int) *domain.Quiz {
+	if len(q.choices) != domain.NumChoices {
+		return nil
+	}
+	return &domain.Quiz{
+		Question: strings.Join(q.text, "\n"),
+		Choice1:  q.choices[0],
+		Choice2:  q.choices[1],
+		Choice3:  q.choices[2],
+		Choice4:  q.choices[3],
+		Answer:   answer,
+	}
+}
+
+// Encode writes quizzes as Aiken questions. Aiken is line based,
+// so line breaks inside questions and choices are flattened to spaces.
+func (c aikenCodec) Encode(w io.Writer, bundles []domain.QuizBundle) error {
+	if err := domain.CheckAnswers(bundles); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, b := range bundles {
+		q := b.Quiz
+		fmt.Fprintln(bw, singleLine(q.Question))
+		for i, choice := range q.Choices() {
+			fmt.Fprintf(bw, "%c. %s\n", 'A'+i, singleLine(choice))
+		}
+		fmt.Fprintf(bw, "ANSWER: %c\n\n", 'A'+q.Answer-1)
+	}
+	return bw.Flush()
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,110 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+func TestAikenDecode_Success(t *testing.T) {
+	src := `What is the correct answer to this question?
+A. Is it this one?
+B. Maybe this answer?
+C) Possibly this one?
+D. Must be this one!
+ANSWER: D
+
+X + 2 = 4 จงหาค่า X
+A. 1
+B. 2
+C. 3
+D. 4
+ANSWER: B
+`
+	doc, err := NewAikenCodec().Decode(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(doc.Quizzes) != 2 {
+		t.Fatalf("expected 2 quizzes, got %d", len(doc.Quizzes))
+	}
+	if q := doc.Quizzes[0]; q.Line != 1 || q.Quiz.Answer != 4 || q.Quiz.Choice3 != "Possibly this one?" {
+		t.Errorf("unexpected first quiz: %+v", q)
+	}
+	if q := doc.Quizzes[1]; q.Line != 8 || q.Quiz.Answer != 2 {
+		t.Errorf("unexpected second quiz: %+v", q)
+	}
+}
+
+func TestAikenDecode_SkipsWrongChoiceCount(t *testing.T) {
+	src := `Three choices only
+A. one
+B. two
+C. three
+ANSWER: A
+`
+	doc, err := NewAikenCodec().Decode(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(doc.Quizzes) != 0 || len(doc.Skipped) != 1 || doc.Skipped[0].Line != 1 {
+		t.Errorf("expected one skipped question on line 1, got %+v", doc)
+	}
+}
+
+func TestAikenDecode_SyntaxErrors(t *testing.T) {
+	src := `A. orphan choice
+
+Question one
+A. a
+B. b
+C. c
+D. d
+ANSWER: E
+
+Question two
+A. a
+B. b
+`
+	_, err := NewAikenCodec().Decode(strings.NewReader(src))
+
+	var parseErr *domain.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+
+	wantLines := []int{1, 8, 10}
+	if len(parseErr.Issues) != len(wantLines) {
+		t.Fatalf("expected %d issues, got %+v", len(wantLines), parseErr.Issues)
+	}
+	for i, line := range wantLines {
+		if parseErr.Issues[i].Line != line {
+			t.Errorf("issue[%d]: expected line %d, got %d", i, line, parseErr.Issues[i].Line)
+		}
+	}
+}
+
+func TestAikenEncode_RoundTrip(t *testing.T) {
+	bundles := []domain.QuizBundle{
+		{Quiz: domain.Quiz{ID: "a", Question: "Multi\nline", Choice1: "w", Choice2: "x", Choice3: "y", Choice4: "z", Answer: 3}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewAikenCodec().Encode(&buf, bundles); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Multi line\nA. w\n") || !strings.Contains(buf.String(), "ANSWER: C") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	doc, err := NewAikenCodec().Decode(&buf)
+	if err != nil {
+		t.Fatalf("expected no error decoding export, got: %v", err)
+	}
+	if got := doc.Quizzes[0].Quiz; got.Question != "Multi line" || got.Answer != 3 {
+		t.Errorf("round trip mismatch: %+v", got)
+	}
+}
@@ -0,0 +1,13 @@
+// Package format implements quiz interchange formats used to import and export quizzes.
+package format
+
+import "github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+
+// Codecs returns all supported interchange formats
+func Codecs() []domain.QuizCodec {
+	return []domain.QuizCodec{
+		NewGIFTCodec(),
+		NewAikenCodec(),
+		NewQTICodec(),
+	}
+}
@@ -0,0 +1,351 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+// giftCodec implements the Moodle GIFT format.
+// Only single-answer multiple choice questions with exactly 4 choices map to a Quiz;
+// other GIFT question types are reported as skipped.
+type giftCodec struct{}
+
+// NewGIFTCodec creates a codec for the Moodle GIFT format
+func NewGIFTCodec() domain.QuizCodec {
+	return giftCodec{}
+}
+
+func (giftCodec) Name() string          { return "gift" }
+func (giftCodec) ContentType() string   { return "text/plain; charset=utf-8" }
+func (giftCodec) FileExtension() string { return ".gift.txt" }
+func (giftCodec) EmbedsMedia() bool     { return false }
+
+var (
+	giftTrueFalse    = regexp.MustCompile(`(?i)^(T|F|TRUE|FALSE)\s*(#.*)?$`)
+	giftFormatPrefix = regexp.MustCompile(`^\[(html|moodle|plain|markdown)\]`)
+	giftWeight       = regexp.MustCompile(`^%(-?[0-9.]+)%`)
+)
+
+// giftBlock is one question as written in the source, with comment lines blanked out
+// so that offsets can still be mapped back to line numbers
+type giftBlock struct {
+	text      string
+	startLine int
+}
+
+func (b giftBlock) lineAt(offset int) int {
+	return b.startLine + strings.Count(b.text[:offset], "\n")
+}
+
+// Decode parses a GIFT document
+func (c giftCodec) Decode(r io.Reader) (*domain.ImportDocument, error) {
+	blocks, err := splitGIFTBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &domain.ImportDocument{}
+	var syntaxIssues []domain.ImportIssue
+
+	for _, block := range blocks {
+		if strings.HasPrefix(strings.TrimSpace(block.text), "$CATEGORY:") {
+			continue
+		}
+
+		quiz, skip, syntax := parseGIFTQuestion(block)
+		switch {
+		case syntax != nil:
+			syntaxIssues = append(syntaxIssues, *syntax)
+		case skip != nil:
+			doc.Skipped = append(doc.Skipped, *skip)
+		default:
+			doc.Quizzes = append(doc.Quizzes, domain.ImportedQuiz{QuizBundle: domain.QuizBundle{Quiz: *quiz}, Line: block.startLine})
+		}
+	}
+
+	if len(syntaxIssues) > 0 {
+		return nil, &domain.ParseError{Format: c.Name(), Issues: syntaxIssues}
+	}
+	return doc, nil
+}
+
+// splitGIFTBlocks splits the document on blank lines
+func splitGIFTBlocks(r io.Reader) ([]giftBlock, error) {
+	var blocks []giftBlock
+	var lines []string
+	start := 0
+
+	flush := func() {
+		if len(lines) > 0 && strings.TrimSpace(strings.Join(lines, "")) != "" {
+			blocks = append(blocks, giftBlock{text: strings.Join(lines, "\n"), startLine: start})
+		}
+		lines = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			flush()
+			continue
+		}
+		if len(lines) == 0 {
+			start = lineNo
+		}
+		if strings.HasPrefix(trimmed, "//") {
+			line = ""
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return blocks, nil
+}
+
+func parseGIFTQuestion(block giftBlock) (*domain.Quiz, *domain.ImportIssue, *domain.ImportIssue) {
+	text := block.text
+	issueAt := func(offset int, format string, args ...interface{}) *domain.ImportIssue {
+		return &domain.ImportIssue{Line: block.lineAt(offset), Message: fmt.Sprintf(format, args...)}
+	}
+
+	// Optional ::title::
+	body := 0
+	if trimmed := strings.TrimLeft(text, " \t\n"); strings.HasPrefix(trimmed, "::") {
+		titleStart := len(text) - len(trimmed) + 2
+		end := indexUnescaped(text[titleStart:], "::")
+		if end < 0 {
+			return nil, nil, issueAt(titleStart, "unterminated question title")
+		}
+		body = titleStart + end + 2
+	}
+
+	open := indexUnescaped(text[body:], "{")
+	if open < 0 {
+		return nil, issueAt(body, "description items without answers are not supported"), nil
+	}
+	open += body
+
+	closing := indexUnescaped(text[open+1:], "}")
+	if closing < 0 {
+		return nil, nil, issueAt(open, "answer block is not closed with '}'")
+	}
+	closing += open + 1
+
+	before := giftText(giftFormatPrefix.ReplaceAllString(strings.TrimSpace(text[body:open]), ""))
+	after := giftText(text[closing+1:])
+	question := before
+	if after != "" {
+		question = strings.TrimSpace(before + " _____ " + after)
+	}
+	if question == "" {
+		return nil, issueAt(open, "question text is empty"), nil
+	}
+
+	answers := strings.TrimSpace(text[open+1 : closing])
+	switch {
+	case answers == "":
+		return nil, issueAt(open, "essay questions are not supported"), nil
+	case giftTrueFalse.MatchString(answers):
+		return nil, issueAt(open, "true/false questions are not supported"), nil
+	case strings.HasPrefix(answers, "#"):
+		return nil, issueAt(open, "numerical questions are not supported"), nil
+	}
+
+	choices, syntax := splitGIFTAnswers(answers)
+	if syntax != "" {
+		return nil, nil, issueAt(open, "%s", syntax)
+	}
+
+	hasWrong := false
+	correct := 0
+	for i, choice := range choices {
+		if choice.matching {
+			return nil, issueAt(open, "matching questions are not supported"), nil
+		}
+		if !choice.correct {
+			hasWrong = true
+		} else if correct == 0 {
+			correct = i + 1
+		} else {
+			return nil, issueAt(open, "questions with more than one correct answer are not supported"), nil
+		}
+	}
+	if !hasWrong {
+		return nil, issueAt(open, "short answer questions are not supported"), nil
+	}
+	if correct == 0 {
+		return nil, issueAt(open, "multiple choice question has no correct answer"), nil
+	}
+	if len(choices) != domain.NumChoices {
+		return nil, issueAt(open, "expected %d choices, found %d", domain.NumChoices, len(choices)), nil
+	}
+	for _, choice := range choices {
+		if choice.text == "" {
+			return nil, issueAt(open, "choice text is empty"), nil
+		}
+	}
+
+	return &domain.Quiz{
+		Question: question,
+		Choice1:  choices[0].text,
+		Choice2:  choices[1].text,
+		Choice3:  choices[2].text,
+		Choice4:  choices[3].text,
+		Answer:   correct,
+	}, nil, nil
+}
+
+type giftChoice struct {
+	text     string
+	correct  bool
+	matching bool
+}
+
+// splitGIFTAnswers splits the inside of an answer block on unescaped '=' and '~'
+func splitGIFTAnswers(answers string) ([]giftChoice, string) {
+	var choices []giftChoice
+	var marker byte
+	startOf := -1
+
+	emit := func(end int) {
+		raw := answers[startOf:end]
+		choice := giftChoice{correct: marker == '='}
+		if m := giftWeight.FindStringSubmatch(raw); m != nil {
+			raw = raw[len(m[0]):]
+			choice.correct = m[1] == "100"
+		}
+		if feedback := indexUnescaped(raw, "#"); feedback >= 0 {
+			raw = raw[:feedback]
+		}
+		if marker == '=' && indexUnescaped(raw, "->") >= 0 {
+			choice.matching = true
+		}
+		choice.text = giftText(raw)
+		choices = append(choices, choice)
+	}
+
+	escaped := false
+	for i := 0; i < len(answers); i++ {
+		ch := answers[i]
+		if escaped {
+			escaped = false
+			continue
+		}
+		switch ch {
+		case '\\':
+			escaped = true
+		case '=', '~':
+			if startOf < 0 {
+				if strings.TrimSpace(answers[:i]) != "" {
+					return nil, "unexpected text before first answer"
+				}
+			} else {
+				emit(i)
+			}
+			marker = ch
+			startOf = i + 1
+		}
+	}
+	if startOf < 0 {
+		return nil, "answer block has no '=' or '~' answers"
+	}
+	emit(len(answers))
+
+	return choices, ""
+}
+
+// indexUnescaped returns the byte index of the first occurrence of sep not preceded by a backslash
+func indexUnescaped(s, sep string) int {
+	escaped := false
+	for i := 0; i < len(s); i++ {
+		if escaped {
+			escaped = false
+			continue
+		}
+		if s[i] == '\\' {
+			escaped = true
+			continue
+		}
+		if strings.HasPrefix(s[i:], sep) {
+			return i
+		}
+	}
+	return -1
+}
+
+// giftText turns raw GIFT text into plain text: source line breaks become spaces
+// and escape sequences are resolved
+func giftText(raw string) string {
+	raw = strings.Join(strings.Fields(raw), " ")
+
+	var b strings.Builder
+	escaped := false
+	for _, r := range raw {
+		if escaped {
+			if r == 'n' {
+				b.WriteRune('\n')
+			} else {
+				b.WriteRune(r)
+			}
+			escaped = false
+			continue
+		}
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+var giftEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`~`, `\~`,
+	`=`, `\=`,
+	`#`, `\#`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`:`, `\:`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// Encode writes quizzes as GIFT multiple choice questions
+func (c giftCodec) Encode(w io.Writer, bundles []domain.QuizBundle) error {
+	if err := domain.CheckAnswers(bundles); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, b := range bundles {
+		q := b.Quiz
+		fmt.Fprintf(bw, "// id: %s\n", q.ID)
+		fmt.Fprintf(bw, "::Q%d:: %s {\n", q.DisplayOrder, giftEscaper.Replace(q.Question))
+		for i, choice := range q.Choices() {
+			marker := "~"
+			if i+1 == q.Answer {
+				marker = "="
+			}
+			fmt.Fprintf(bw, "\t%s%s\n", marker, giftEscaper.Replace(choice))
+		}
+		bw.WriteString("}\n\n")
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,137 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+func TestGIFTDecode_MultipleChoice(t *testing.T) {
+	src := `// a comment
+$CATEGORY: $course$/Math
+
+::Q1:: What is 2+2? {
+	~3
+	=4#correct!
+	~5
+	~6
+}
+
+[html]Which one is <b>odd</b>? {~2 ~4 =%100%9 ~8}
+`
+	doc, err := NewGIFTCodec().Decode(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(doc.Quizzes) != 2 {
+		t.Fatalf("expected 2 quizzes, got %d", len(doc.Quizzes))
+	}
+
+	q := doc.Quizzes[0]
+	if q.Line != 4 {
+		t.Errorf("expected line 4, got %d", q.Line)
+	}
+	if q.Quiz.Question != "What is 2+2?" {
+		t.Errorf("unexpected question %q", q.Quiz.Question)
+	}
+	if q.Quiz.Choice2 != "4" || q.Quiz.Answer != 2 {
+		t.Errorf("expected answer 2 with text '4', got %d %q", q.Quiz.Answer, q.Quiz.Choice2)
+	}
+
+	if doc.Quizzes[1].Quiz.Question != "Which one is <b>odd</b>?" || doc.Quizzes[1].Quiz.Answer != 3 {
+		t.Errorf("unexpected second quiz %+v", doc.Quizzes[1].Quiz)
+	}
+}
+
+func TestGIFTDecode_Escapes(t *testing.T) {
+	src := `Solve 1 \= x\: {=1 ~2 ~3 ~a \{b\}}`
+	doc, err := NewGIFTCodec().Decode(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := doc.Quizzes[0].Quiz; got.Question != "Solve 1 = x:" || got.Choice4 != "a {b}" {
+		t.Errorf("escapes not resolved: %+v", got)
+	}
+}
+
+func TestGIFTDecode_SkipsUnsupportedTypes(t *testing.T) {
+	src := `The sky is blue. {T}
+
+Write an essay. {}
+
+Pi to two decimals? {#3.14:0.01}
+
+Capital of Thailand? {=Bangkok =Krung Thep}
+
+Match. {=a -> 1 =b -> 2 =c -> 3}
+
+Pick two. {~%50%a ~%50%b ~c ~d}
+
+Pick one. {=a ~b ~c}
+`
+	doc, err := NewGIFTCodec().Decode(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(doc.Quizzes) != 0 {
+		t.Errorf("expected no quizzes, got %d", len(doc.Quizzes))
+	}
+
+	wantLines := []int{1, 3, 5, 7, 9, 11, 13}
+	if len(doc.Skipped) != len(wantLines) {
+		t.Fatalf("expected %d skipped, got %d: %+v", len(wantLines), len(doc.Skipped), doc.Skipped)
+	}
+	for i, line := range wantLines {
+		if doc.Skipped[i].Line != line {
+			t.Errorf("skipped[%d]: expected line %d, got %d", i, line, doc.Skipped[i].Line)
+		}
+	}
+}
+
+func TestGIFTDecode_SyntaxErrorHasLineNumber(t *testing.T) {
+	src := `First? {=a ~b ~c ~d}
+
+Second? {
+	=a
+	~b
+`
+	_, err := NewGIFTCodec().Decode(strings.NewReader(src))
+
+	var parseErr *domain.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+	if len(parseErr.Issues) != 1 || parseErr.Issues[0].Line != 3 {
+		t.Errorf("expected one issue on line 3, got %+v", parseErr.Issues)
+	}
+}
+
+func TestGIFTEncode_RoundTrip(t *testing.T) {
+	bundles := []domain.QuizBundle{
+		{Quiz: domain.Quiz{ID: "a", Question: "ข้อใดต่างจากข้ออื่น {x=1}", Choice1: "3", Choice2: "5", Choice3: "9", Choice4: "1~1", Answer: 4, DisplayOrder: 1}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewGIFTCodec().Encode(&buf, bundles); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	doc, err := NewGIFTCodec().Decode(&buf)
+	if err != nil {
+		t.Fatalf("expected no error decoding export, got: %v", err)
+	}
+	got := doc.Quizzes[0].Quiz
+	if got.Question != bundles[0].Quiz.Question || got.Choice4 != "1~1" || got.Answer != 4 {
+		t.Errorf("round trip mismatch: %+v", got)
+	}
+}
+
+func TestGIFTEncode_RequiresAnswer(t *testing.T) {
+	err := NewGIFTCodec().Encode(&bytes.Buffer{}, []domain.QuizBundle{{Quiz: domain.Quiz{ID: "a", Question: "Q"}}})
+	if err == nil {
+		t.Fatal("expected error for quiz without answer, got nil")
+	}
+}
@@ -0,0 +1,584 @@
+package format
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+// qtiCodec implements IMS QTI 2.1 content packages (zip with imsmanifest.xml).
+// Items with a single choiceInteraction of 4 choices and one selectable answer map to a Quiz;
+// any other interaction is reported as skipped. Images and objects referenced by an item
+// are imported as quiz media.
+type qtiCodec struct{}
+
+// NewQTICodec creates a codec for IMS QTI 2.1 content packages
+func NewQTICodec() domain.QuizCodec {
+	return qtiCodec{}
+}
+
+func (qtiCodec) Name() string          { return "qti" }
+func (qtiCodec) ContentType() string   { return "application/zip" }
+func (qtiCodec) FileExtension() string { return ".qti.zip" }
+func (qtiCodec) EmbedsMedia() bool     { return true }
+
+const (
+	qtiManifestFile = "imsmanifest.xml"
+	qtiItemType     = "imsqti_item_xmlv2p1"
+	qtiNamespace    = "http://www.imsglobal.org/xsd/imsqti_v2p1"
+	cpNamespace     = "http://www.imsglobal.org/xsd/imscp_v1p1"
+)
+
+// Decompressed size limits, so that a small package cannot expand into more
+// memory than the server has
+const (
+	maxQTIEntrySize   = 32 << 20
+	maxQTIPackageSize = 128 << 20
+)
+
+// errQTIEntryTooLarge is returned by reads past maxQTIEntrySize
+var errQTIEntryTooLarge = fmt.Errorf("file is larger than %d MB uncompressed", maxQTIEntrySize>>20)
+
+// ============ Decoding ============
+
+type qtiManifest struct {
+	Resources []qtiResource `xml:"resources>resource"`
+}
+
+type qtiResource struct {
+	Identifier string `xml:"identifier,attr"`
+	Type       string `xml:"type,attr"`
+	Href       string `xml:"href,attr"`
+	Base       string `xml:"http://www.w3.org/XML/1998/namespace base,attr"`
+}
+
+// Decode reads a content package and converts its assessment items
+func (c qtiCodec) Decode(r io.Reader) (*domain.ImportDocument, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, c.parseError("", "not a valid zip archive")
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	var total uint64
+	for _, f := range zr.File {
+		if f.UncompressedSize64 > maxQTIEntrySize {
+			return nil, c.parseError(f.Name, errQTIEntryTooLarge.Error())
+		}
+		total += f.UncompressedSize64
+		files[path.Clean(f.Name)] = f
+	}
+	if total > maxQTIPackageSize {
+		return nil, c.parseError("", fmt.Sprintf("package is larger than %d MB uncompressed", maxQTIPackageSize>>20))
+	}
+
+	manifestFile, ok := files[qtiManifestFile]
+	if !ok {
+		return nil, c.parseError("", qtiManifestFile+" not found at the package root")
+	}
+	var manifest qtiManifest
+	if err := decodeZipXML(manifestFile, &manifest); err != nil {
+		return nil, c.parseError(qtiManifestFile, err.Error())
+	}
+
+	doc := &domain.ImportDocument{}
+	var syntaxIssues []domain.ImportIssue
+
+	for _, res := range manifest.Resources {
+		if !strings.HasPrefix(res.Type, "imsqti_item_xml") {
+			continue
+		}
+		href := path.Clean(path.Join(res.Base, res.Href))
+
+		if res.Type != qtiItemType {
+			doc.Skipped = append(doc.Skipped, domain.ImportIssue{Item: href, Message: "unsupported item type " + res.Type})
+			continue
+		}
+
+		itemFile, ok := files[href]
+		if !ok {
+			syntaxIssues = append(syntaxIssues, domain.ImportIssue{Item: href, Message: "file listed in manifest not found in package"})
+			continue
+		}
+
+		root, err := parseZipXMLTree(itemFile)
+		if err != nil || root.Name.Local != "assessmentItem" {
+			msg := "not an assessmentItem document"
+			if err != nil {
+				msg = err.Error()
+			}
+			syntaxIssues = append(syntaxIssues, domain.ImportIssue{Item: href, Message: msg})
+			continue
+		}
+
+		bundle, reason := qtiItemToBundle(root, path.Dir(href), files)
+		if reason != "" {
+			doc.Skipped = append(doc.Skipped, domain.ImportIssue{Item: href, Message: reason})
+			continue
+		}
+		doc.Quizzes = append(doc.Quizzes, domain.ImportedQuiz{QuizBundle: *bundle})
+	}
+
+	if len(syntaxIssues) > 0 {
+		return nil, &domain.ParseError{Format: c.Name(), Issues: syntaxIssues}
+	}
+	return doc, nil
+}
+
+func (c qtiCodec) parseError(item, message string) error {
+	return &domain.ParseError{Format: c.Name(), Issues: []domain.ImportIssue{{Item: item, Message: message}}}
+}
+
+// qtiItemToBundle converts an assessmentItem, returning a reason when it is not supported
+func qtiItemToBundle(item *xmlNode, baseDir string, files map[string]*zip.File) (*domain.QuizBundle, string) {
+	body := item.child("itemBody")
+	if body == nil {
+		return nil, "item has no itemBody"
+	}
+
+	interactions := body.findAll(func(n *xmlNode) bool {
+		return strings.HasSuffix(n.Name.Local, "Interaction")
+	})
+	if len(interactions) == 0 {
+		return nil, "item has no interaction"
+	}
+	if len(interactions) > 1 {
+		return nil, fmt.Sprintf("items with %d interactions are not supported", len(interactions))
+	}
+
+	interaction := interactions[0]
+	if interaction.Name.Local != "choiceInteraction" {
+		return nil, "unsupported interaction " + interaction.Name.Local
+	}
+	if max := interaction.attr("maxChoices"); max != "" && max != "1" {
+		return nil, "choiceInteraction with maxChoices=" + max + " is not supported"
+	}
+
+	choices := interaction.children("simpleChoice")
+	if len(choices) != domain.NumChoices {
+		return nil, fmt.Sprintf("expected %d choices, found %d", domain.NumChoices, len(choices))
+	}
+
+	// Question text is the item body around the interaction followed by its prompt
+	var question []string
+	if text := body.text(interaction); text != "" {
+		question = append(question, text)
+	}
+	if prompt := interaction.child("prompt"); prompt != nil {
+		if text := prompt.text(nil); text != "" {
+			question = append(question, text)
+		}
+	}
+	if len(question) == 0 {
+		return nil, "question text is empty"
+	}
+
+	quiz := domain.Quiz{Question: strings.Join(question, "\n")}
+	texts := [domain.NumChoices]*string{&quiz.Choice1, &quiz.Choice2, &quiz.Choice3, &quiz.Choice4}
+	correct := qtiCorrectResponse(item, interaction.attr("responseIdentifier"))
+	for i, choice := range choices {
+		*texts[i] = choice.text(nil)
+		if *texts[i] == "" {
+			return nil, "choice text is empty"
+		}
+		if choice.attr("identifier") == correct {
+			quiz.Answer = i + 1
+		}
+	}
+
+	media, reason := qtiMedia(item, baseDir, files)
+	if reason != "" {
+		return nil, reason
+	}
+
+	return &domain.QuizBundle{Quiz: quiz, Media: media}, ""
+}
+
+func qtiCorrectResponse(item *xmlNode, responseID string) string {
+	for _, decl := range item.children("responseDeclaration") {
+		if decl.attr("identifier") != responseID {
+			continue
+		}
+		if correct := decl.child("correctResponse"); correct != nil {
+			if value := correct.child("value"); value != nil {
+				return value.text(nil)
+			}
+		}
+	}
+	return ""
+}
+
+// qtiMedia loads images and objects referenced by the item body from the package
+func qtiMedia(item *xmlNode, baseDir string, files map[string]*zip.File) ([]domain.Media, string) {
+	refs := item.findAll(func(n *xmlNode) bool {
+		return (n.Name.Local == "img" && n.attr("src") != "") || (n.Name.Local == "object" && n.attr("data") != "")
+	})
+
+	var media []domain.Media
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		src, contentType := ref.attr("src"), ""
+		if ref.Name.Local == "object" {
+			src, contentType = ref.attr("data"), ref.attr("type")
+		}
+		if strings.Contains(src, "://") {
+			continue
+		}
+
+		name := path.Clean(path.Join(baseDir, src))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		f, ok := files[name]
+		if !ok {
+			return nil, "referenced media not found in package: " + name
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, "referenced media could not be read: " + name
+		}
+
+		if contentType == "" {
+			contentType = mime.TypeByExtension(path.Ext(name))
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		media = append(media, domain.Media{Filename: path.Base(name), ContentType: contentType, Data: data})
+	}
+	return media, ""
+}
+
+// ============ Encoding ============
+
+type qtiManifestOut struct {
+	XMLName    xml.Name         `xml:"manifest"`
+	Xmlns      string           `xml:"xmlns,attr"`
+	Identifier string           `xml:"identifier,attr"`
+	Metadata   qtiMetadataOut   `xml:"metadata"`
+	Orgs       struct{}         `xml:"organizations"`
+	Resources  []qtiResourceOut `xml:"resources>resource"`
+}
+
+type qtiMetadataOut struct {
+	Schema        string `xml:"schema"`
+	SchemaVersion string `xml:"schemaversion"`
+}
+
+type qtiResourceOut struct {
+	Identifier string       `xml:"identifier,attr"`
+	Type       string       `xml:"type,attr"`
+	Href       string       `xml:"href,attr"`
+	Files      []qtiFileOut `xml:"file"`
+}
+
+type qtiFileOut struct {
+	Href string `xml:"href,attr"`
+}
+
+// Encode writes quizzes as a QTI 2.1 content package
+func (c qtiCodec) Encode(w io.Writer, bundles []domain.QuizBundle) error {
+	zw := zip.NewWriter(w)
+
+	manifest := qtiManifestOut{
+		Xmlns:      cpNamespace,
+		Identifier: "quiz-export",
+		Metadata:   qtiMetadataOut{Schema: "QTIv2.1 Package", SchemaVersion: "1.0.0"},
+	}
+
+	for _, b := range bundles {
+		itemID := "quiz-" + b.Quiz.ID
+		itemHref := "items/" + itemID + ".xml"
+		res := qtiResourceOut{Identifier: itemID, Type: qtiItemType, Href: itemHref, Files: []qtiFileOut{{Href: itemHref}}}
+
+		mediaHrefs := make([]string, len(b.Media))
+		for i, m := range b.Media {
+			mediaHrefs[i] = "media/" + b.Quiz.ID + "/" + m.Filename
+			if err := writeZipFile(zw, mediaHrefs[i], m.Data); err != nil {
+				return err
+			}
+			res.Files = append(res.Files, qtiFileOut{Href: mediaHrefs[i]})
+		}
+
+		var item bytes.Buffer
+		writeQTIItem(&item, itemID, b, mediaHrefs)
+		if err := writeZipFile(zw, itemHref, item.Bytes()); err != nil {
+			return err
+		}
+
+		manifest.Resources = append(manifest.Resources, res)
+	}
+
+	out, err := xml.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, qtiManifestFile, append([]byte(xml.Header), out...)); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeQTIItem(buf *bytes.Buffer, itemID string, b domain.QuizBundle, mediaHrefs []string) {
+	q := b.Quiz
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(buf, `<assessmentItem xmlns="%s" identifier="%s" title="Q%d" adaptive="false" timeDependent="false">`+"\n",
+		qtiNamespace, escapeXML(itemID), q.DisplayOrder)
+
+	buf.WriteString(`  <responseDeclaration identifier="RESPONSE" cardinality="single" baseType="identifier">` + "\n")
+	if q.HasAnswer() {
+		fmt.Fprintf(buf, "    <correctResponse><value>CHOICE_%d</value></correctResponse>\n", q.Answer)
+	}
+	buf.WriteString("  </responseDeclaration>\n")
+	buf.WriteString(`  <outcomeDeclaration identifier="SCORE" cardinality="single" baseType="float"/>` + "\n")
+
+	buf.WriteString("  <itemBody>\n")
+	for i, m := range b.Media {
+		rel := "../" + escapeXML(mediaHrefs[i])
+		if strings.HasPrefix(m.ContentType, "image/") {
+			fmt.Fprintf(buf, `    <p><img src="%s" alt="%s"/></p>`+"\n", rel, escapeXML(m.Filename))
+		} else {
+			fmt.Fprintf(buf, `    <p><object data="%s" type="%s"/></p>`+"\n", rel, escapeXML(m.ContentType))
+		}
+	}
+	buf.WriteString(`    <choiceInteraction responseIdentifier="RESPONSE" shuffle="false" maxChoices="1">` + "\n")
+	fmt.Fprintf(buf, "      <prompt>%s</prompt>\n", escapeXMLText(q.Question))
+	for i, choice := range q.Choices() {
+		fmt.Fprintf(buf, `      <simpleChoice identifier="CHOICE_%d">%s</simpleChoice>`+"\n", i+1, escapeXMLText(choice))
+	}
+	buf.WriteString("    </choiceInteraction>\n")
+	buf.WriteString("  </itemBody>\n")
+
+	buf.WriteString(`  <responseProcessing template="http://www.imsglobal.org/question/qti_v2p1/rptemplates/match_correct"/>` + "\n")
+	buf.WriteString("</assessmentItem>\n")
+}
+
+// ============ XML helpers ============
+
+// xmlNode is a minimal DOM used to walk mixed-content QTI item bodies
+type xmlNode struct {
+	Name     xml.Name
+	Attr     []xml.Attr
+	Children []*xmlNode
+	Text     string // set for character data nodes only
+}
+
+func parseZipXMLTree(f *zip.File) (*xmlNode, error) {
+	rc, err := openZipFile(f)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	dec := xml.NewDecoder(rc)
+	var stack []*xmlNode
+	var root *xmlNode
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{Name: t.Name, Attr: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, &xmlNode{Text: string(t)})
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("empty document")
+	}
+	return root, nil
+}
+
+func (n *xmlNode) attr(name string) string {
+	for _, a := range n.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (n *xmlNode) child(name string) *xmlNode {
+	for _, c := range n.Children {
+		if c.Name.Local == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *xmlNode) children(name string) []*xmlNode {
+	var out []*xmlNode
+	for _, c := range n.Children {
+		if c.Name.Local == name {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (n *xmlNode) findAll(match func(*xmlNode) bool) []*xmlNode {
+	var out []*xmlNode
+	for _, c := range n.Children {
+		if c.Name.Local == "" {
+			continue
+		}
+		if match(c) {
+			out = append(out, c)
+		}
+		out = append(out, c.findAll(match)...)
+	}
+	return out
+}
+
+// qtiBlockElements start a new line when rendered as plain text
+var qtiBlockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "blockquote": true, "pre": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "tr": true,
+}
+
+// text renders the node as plain text, leaving out exclude, feedback and prompts of nested interactions
+func (n *xmlNode) text(exclude *xmlNode) string {
+	var lines []string
+	var line strings.Builder
+
+	var walk func(*xmlNode)
+	walk = func(c *xmlNode) {
+		if c == exclude {
+			return
+		}
+		switch c.Name.Local {
+		case "":
+			line.WriteString(c.Text)
+			return
+		case "feedbackInline", "feedbackBlock", "modalFeedback", "rubricBlock":
+			return
+		}
+
+		block := qtiBlockElements[c.Name.Local]
+		flush := func() {
+			if s := strings.Join(strings.Fields(line.String()), " "); s != "" {
+				lines = append(lines, s)
+			}
+			line.Reset()
+		}
+		if block {
+			flush()
+		}
+		for _, cc := range c.Children {
+			walk(cc)
+		}
+		if block {
+			flush()
+		}
+	}
+
+	for _, c := range n.Children {
+		walk(c)
+	}
+	if s := strings.Join(strings.Fields(line.String()), " "); s != "" {
+		lines = append(lines, s)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func decodeZipXML(f *zip.File, v interface{}) error {
+	rc, err := openZipFile(f)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := openZipFile(f)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// openZipFile opens an entry of a package. Its size in the zip header is
+// checked by Decode, but the header may lie: reads fail with
+// errQTIEntryTooLarge once more than maxQTIEntrySize bytes come out.
+func openZipFile(f *zip.File) (io.ReadCloser, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	return &limitedZipFile{r: io.LimitReader(rc, maxQTIEntrySize+1), Closer: rc}, nil
+}
+
+type limitedZipFile struct {
+	r    io.Reader
+	read int64
+	io.Closer
+}
+
+func (l *limitedZipFile) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > maxQTIEntrySize {
+		return 0, errQTIEntryTooLarge
+	}
+	return n, err
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// escapeXMLText escapes text and keeps line breaks as <br/>
+func escapeXMLText(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = escapeXML(line)
+	}
+	return strings.Join(lines, "<br/>")
+}
@@ -0,0 +1,187 @@
+package format
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+func buildPackage(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+const testManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest xmlns="http://www.imsglobal.org/xsd/imscp_v1p1" identifier="pkg">
+  <resources>
+    <resource identifier="i1" type="imsqti_item_xmlv2p1" href="items/choice.xml"/>
+    <resource identifier="i2" type="imsqti_item_xmlv2p1" href="items/text.xml"/>
+    <resource identifier="i3" type="imsqti_item_xmlv2p1" href="items/multi.xml"/>
+    <resource identifier="img" type="webcontent" href="images/planet.png"/>
+  </resources>
+</manifest>`
+
+const testChoiceItem = `<?xml version="1.0" encoding="UTF-8"?>
+<assessmentItem xmlns="http://www.imsglobal.org/xsd/imsqti_v2p1" identifier="choice" title="Planets">
+  <responseDeclaration identifier="RESPONSE" cardinality="single" baseType="identifier">
+    <correctResponse><value>C</value></correctResponse>
+  </responseDeclaration>
+  <itemBody>
+    <p>Look at the <b>picture</b>.</p>
+    <p><img src="../images/planet.png" alt="planet"/></p>
+    <choiceInteraction responseIdentifier="RESPONSE" shuffle="true" maxChoices="1">
+      <prompt>Which planet is shown?</prompt>
+      <simpleChoice identifier="A">Mars</simpleChoice>
+      <simpleChoice identifier="B">Venus<feedbackInline outcomeIdentifier="FB" identifier="B">No</feedbackInline></simpleChoice>
+      <simpleChoice identifier="C">Saturn</simpleChoice>
+      <simpleChoice identifier="D">Earth</simpleChoice>
+    </choiceInteraction>
+  </itemBody>
+</assessmentItem>`
+
+const testTextItem = `<assessmentItem xmlns="http://www.imsglobal.org/xsd/imsqti_v2p1" identifier="text">
+  <itemBody><p>Capital of Thailand? <textEntryInteraction responseIdentifier="RESPONSE"/></p></itemBody>
+</assessmentItem>`
+
+const testMultiItem = `<assessmentItem xmlns="http://www.imsglobal.org/xsd/imsqti_v2p1" identifier="multi">
+  <itemBody>
+    <choiceInteraction responseIdentifier="RESPONSE" maxChoices="0">
+      <prompt>Pick all even numbers</prompt>
+      <simpleChoice identifier="A">1</simpleChoice>
+      <simpleChoice identifier="B">2</simpleChoice>
+      <simpleChoice identifier="C">3</simpleChoice>
+      <simpleChoice identifier="D">4</simpleChoice>
+    </choiceInteraction>
+  </itemBody>
+</assessmentItem>`
+
+func TestQTIDecode_Package(t *testing.T) {
+	pkg := buildPackage(t, map[string]string{
+		"imsmanifest.xml":   testManifest,
+		"items/choice.xml":  testChoiceItem,
+		"items/text.xml":    testTextItem,
+		"items/multi.xml":   testMultiItem,
+		"images/planet.png": "\x89PNG",
+	})
+
+	doc, err := NewQTICodec().Decode(bytes.NewReader(pkg))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(doc.Quizzes) != 1 {
+		t.Fatalf("expected 1 quiz, got %d", len(doc.Quizzes))
+	}
+	q := doc.Quizzes[0]
+	if q.Quiz.Question != "Look at the picture.\nWhich planet is shown?" {
+		t.Errorf("unexpected question %q", q.Quiz.Question)
+	}
+	if q.Quiz.Choice2 != "Venus" || q.Quiz.Answer != 3 {
+		t.Errorf("unexpected choices/answer: %+v", q.Quiz)
+	}
+	if len(q.Media) != 1 || q.Media[0].Filename != "planet.png" || q.Media[0].ContentType != "image/png" || string(q.Media[0].Data) != "\x89PNG" {
+		t.Errorf("unexpected media: %+v", q.Media)
+	}
+
+	if len(doc.Skipped) != 2 {
+		t.Fatalf("expected 2 skipped items, got %+v", doc.Skipped)
+	}
+	if doc.Skipped[0].Item != "items/text.xml" || doc.Skipped[0].Message != "unsupported interaction textEntryInteraction" {
+		t.Errorf("unexpected skipped[0]: %+v", doc.Skipped[0])
+	}
+	if doc.Skipped[1].Item != "items/multi.xml" {
+		t.Errorf("unexpected skipped[1]: %+v", doc.Skipped[1])
+	}
+}
+
+func TestQTIDecode_MissingFiles(t *testing.T) {
+	_, err := NewQTICodec().Decode(bytes.NewReader(buildPackage(t, map[string]string{"readme.txt": "hi"})))
+	var parseErr *domain.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError for missing manifest, got %v", err)
+	}
+
+	pkg := buildPackage(t, map[string]string{"imsmanifest.xml": testManifest})
+	_, err = NewQTICodec().Decode(bytes.NewReader(pkg))
+	if !errors.As(err, &parseErr) || len(parseErr.Issues) != 3 {
+		t.Fatalf("expected 3 issues for missing item files, got %v", err)
+	}
+}
+
+func TestQTIEncode_RoundTrip(t *testing.T) {
+	bundles := []domain.QuizBundle{
+		{
+			Quiz:  domain.Quiz{ID: "a", Question: "1 < 2 & 3?\nSecond line", Choice1: "yes", Choice2: "no", Choice3: "maybe", Choice4: "n/a", Answer: 1, DisplayOrder: 1},
+			Media: []domain.Media{{Filename: "chart.png", ContentType: "image/png", Data: []byte("img")}},
+		},
+		{
+			Quiz: domain.Quiz{ID: "b", Question: "No answer yet", Choice1: "1", Choice2: "2", Choice3: "3", Choice4: "4", DisplayOrder: 2},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewQTICodec().Encode(&buf, bundles); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	doc, err := NewQTICodec().Decode(&buf)
+	if err != nil {
+		t.Fatalf("expected no error decoding export, got: %v", err)
+	}
+	if len(doc.Quizzes) != 2 || len(doc.Skipped) != 0 {
+		t.Fatalf("expected 2 quizzes, got %d (skipped %+v)", len(doc.Quizzes), doc.Skipped)
+	}
+
+	got := doc.Quizzes[0]
+	if got.Quiz.Question != bundles[0].Quiz.Question || got.Quiz.Answer != 1 || got.Quiz.Choice4 != "n/a" {
+		t.Errorf("round trip mismatch: %+v", got.Quiz)
+	}
+	if len(got.Media) != 1 || got.Media[0].Filename != "chart.png" || string(got.Media[0].Data) != "img" {
+		t.Errorf("media round trip mismatch: %+v", got.Media)
+	}
+	if doc.Quizzes[1].Quiz.Answer != 0 {
+		t.Errorf("expected no answer for second quiz, got %d", doc.Quizzes[1].Quiz.Answer)
+	}
+}
+
+func TestQTIDecode_SizeLimits(t *testing.T) {
+	// Zeros compress to almost nothing, like a zip bomb
+	pkg := buildPackage(t, map[string]string{
+		"imsmanifest.xml": testManifest,
+		"images/big.png":  string(make([]byte, maxQTIEntrySize+1)),
+	})
+	if len(pkg) > 1<<20 {
+		t.Fatalf("expected a small package, got %d bytes", len(pkg))
+	}
+	_, err := NewQTICodec().Decode(bytes.NewReader(pkg))
+	var parseErr *domain.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Issues[0].Item != "images/big.png" {
+		t.Fatalf("expected the oversized entry to be rejected, got %v", err)
+	}
+
+	// Entries within the limit can still add up to too much
+	files := map[string]string{"imsmanifest.xml": testManifest}
+	for i := 0; i*maxQTIEntrySize <= maxQTIPackageSize; i++ {
+		files[fmt.Sprintf("images/%d.png", i)] = string(make([]byte, maxQTIEntrySize))
+	}
+	_, err = NewQTICodec().Decode(bytes.NewReader(buildPackage(t, files)))
+	if !errors.As(err, &parseErr) || parseErr.Issues[0].Item != "" {
+		t.Fatalf("expected the oversized package to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresHintRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresHintRepository creates a new PostgreSQL quiz hint repository
+func NewPostgresHintRepository(db *sqlx.DB) domain.HintRepository {
+	return &postgresHintRepository{db: db}
+}
+
+func (r *postgresHintRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// ListByQuizID returns the hints of a quiz in position order
+func (r *postgresHintRepository) ListByQuizID(ctx context.Context, quizID string) ([]domain.Hint, error) {
+	hints := []domain.Hint{}
+	query := `SELECT quiz_id, position, body, penalty FROM quiz_hints WHERE quiz_id = $1 ORDER BY position ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &hints, query, quizID); err != nil {
+		return nil, err
+	}
+	return hints, nil
+}
+
+// Replace removes the hints of a quiz and stores the given ones; it should
+// run inside a transaction
+func (r *postgresHintRepository) Replace(ctx context.Context, quizID string, hints []domain.Hint) error {
+	q := r.getQueryable(ctx)
+	if _, err := q.ExecContext(ctx, `DELETE FROM quiz_hints WHERE quiz_id = $1`, quizID); err != nil {
+		return err
+	}
+	query := `INSERT INTO quiz_hints (quiz_id, position, body, penalty) VALUES ($1, $2, $3, $4)`
+	for _, h := range hints {
+		if _, err := q.ExecContext(ctx, query, quizID, h.Position, h.Body, h.Penalty); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type postgresMediaRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresMediaRepository creates a new PostgreSQL quiz media repository
+func NewPostgresMediaRepository(db *sqlx.DB) domain.MediaRepository {
+	return &postgresMediaRepository{db: db}
+}
+
+func (r *postgresMediaRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// ListByQuizIDs returns media with their data for the given quizzes
+func (r *postgresMediaRepository) ListByQuizIDs(ctx context.Context, quizIDs []string) ([]domain.Media, error) {
+	var media []domain.Media
+	query := `SELECT id, quiz_id, filename, content_type, octet_length(data) AS size, data, created_at
+	           FROM quiz_media WHERE quiz_id = ANY($1) ORDER BY created_at ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &media, query, pq.Array(quizIDs)); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// ListMetadata returns media of a quiz without loading the file data
+func (r *postgresMediaRepository) ListMetadata(ctx context.Context, quizID string) ([]domain.Media, error) {
+	media := []domain.Media{}
+	query := `SELECT id, quiz_id, filename, content_type, octet_length(data) AS size, created_at
+	           FROM quiz_media WHERE quiz_id = $1 ORDER BY created_at ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &media, query, quizID); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// GetByID returns a media file of a quiz
+func (r *postgresMediaRepository) GetByID(ctx context.Context, quizID, id string) (*domain.Media, error) {
+	var media domain.Media
+	query := `SELECT id, quiz_id, filename, content_type, octet_length(data) AS size, data, created_at
+	           FROM quiz_media WHERE quiz_id = $1 AND id = $2`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &media, query, quizID, id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrMediaNotFound
+	}
+	return &media, err
+}
+
+// Create inserts a new media file
+func (r *postgresMediaRepository) Create(ctx context.Context, media *domain.Media) error {
+	query := `INSERT INTO quiz_media (id, quiz_id, filename, content_type, data, created_at)
+	           VALUES ($1, $2, $3, $4, $5, NOW())`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query, media.ID, media.QuizID, media.Filename, media.ContentType, media.Data)
+	return err
+}
@@ -3,12 +3,20 @@ package infrastructure
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/cananga-odorata/golang-template/internal/infra/database"
 	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/listquery"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// listColumns are the computed columns read by the list queries
+const listColumns = domain.UnresolvedCommentsColumn + ` AS unresolved_comments, ` + domain.PValueColumn + ` AS p_value`
+
 type postgresQuizRepository struct {
 	db *sqlx.DB
 }
@@ -22,11 +30,11 @@ func (r *postgresQuizRepository) getQueryable(ctx context.Context) database.Quer
 	return database.GetQueryable(ctx, r.db)
 }
 
-// GetAll returns all quizzes ordered by display_order
+// GetAll returns all quizzes not in the trash ordered by display_order
 func (r *postgresQuizRepository) GetAll(ctx context.Context) ([]domain.Quiz, error) {
 	var quizzes []domain.Quiz
-	query := `SELECT id, question, choice1, choice2, choice3, choice4, display_order, created_at, updated_at
-	           FROM quizzes ORDER BY display_order ASC`
+	query := `SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at, ` + listColumns + `
+	           FROM quizzes WHERE deleted_at IS NULL ORDER BY display_order ASC`
 	q := r.getQueryable(ctx)
 	err := q.SelectContext(ctx, &quizzes, query)
 	if err != nil {
@@ -38,11 +46,83 @@ func (r *postgresQuizRepository) GetAll(ctx context.Context) ([]domain.Quiz, err
 	return quizzes, nil
 }
 
-// GetByID returns a quiz by its ID
+// GetVisible returns the quizzes not in the trash that are published and
+// inside their schedule, ordered by display_order
+func (r *postgresQuizRepository) GetVisible(ctx context.Context) ([]domain.Quiz, error) {
+	quizzes := []domain.Quiz{}
+	query := `SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at, ` + listColumns + `
+	           FROM quizzes WHERE deleted_at IS NULL AND ` + domain.VisibleCondition + ` ORDER BY display_order ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &quizzes, query); err != nil {
+		return nil, err
+	}
+	return quizzes, nil
+}
+
+// Count returns the number of quizzes not in the trash matching the query's filters
+func (r *postgresQuizRepository) Count(ctx context.Context, lq *listquery.Query) (int64, error) {
+	var count int64
+	args := []interface{}{}
+	query := `SELECT COUNT(*) FROM quizzes WHERE deleted_at IS NULL` + lq.Where(&args)
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &count, query, args...)
+	return count, err
+}
+
+// GetPage returns limit quizzes matching the query in its sort order, skipping offset
+func (r *postgresQuizRepository) GetPage(ctx context.Context, lq *listquery.Query, offset, limit int) ([]domain.Quiz, error) {
+	quizzes := []domain.Quiz{}
+	args := []interface{}{}
+	where := lq.Where(&args)
+	args = append(args, offset, limit)
+	query := fmt.Sprintf(`SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at, `+listColumns+`
+	           FROM quizzes WHERE deleted_at IS NULL%s %s OFFSET $%d LIMIT $%d`, where, lq.OrderBy("id"), len(args)-1, len(args))
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &quizzes, query, args...); err != nil {
+		return nil, err
+	}
+	return quizzes, nil
+}
+
+// GetPageByCursor returns up to limit quizzes matching the query's filters
+// after (or before) the cursor, ordered by display_order
+func (r *postgresQuizRepository) GetPageByCursor(ctx context.Context, lq *listquery.Query, cursor domain.QuizCursor, limit int) ([]domain.Quiz, error) {
+	quizzes := []domain.Quiz{}
+	args := []interface{}{cursor.DisplayOrder, cursor.ID, limit}
+	where := lq.Where(&args)
+	query := `SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at, ` + listColumns + `
+	           FROM quizzes WHERE deleted_at IS NULL AND (display_order, id) > ($1, $2)` + where + `
+	           ORDER BY display_order ASC, id ASC LIMIT $3`
+	if cursor.Before {
+		// Read backwards from the cursor, then restore the list order
+		query = `SELECT * FROM (
+	               SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at, ` + listColumns + `
+	               FROM quizzes WHERE deleted_at IS NULL AND (display_order, id) < ($1, $2)` + where + `
+	               ORDER BY display_order DESC, id DESC LIMIT $3
+	           ) page ORDER BY display_order ASC, id ASC`
+	}
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &quizzes, query, args...); err != nil {
+		return nil, err
+	}
+	return quizzes, nil
+}
+
+// GetByID returns a quiz not in the trash by its ID
 func (r *postgresQuizRepository) GetByID(ctx context.Context, id string) (*domain.Quiz, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate returns a quiz by its ID and locks it until the
+// transaction carried by ctx ends
+func (r *postgresQuizRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.Quiz, error) {
+	return r.getByID(ctx, id, " FOR UPDATE")
+}
+
+func (r *postgresQuizRepository) getByID(ctx context.Context, id, lock string) (*domain.Quiz, error) {
 	var quiz domain.Quiz
-	query := `SELECT id, question, choice1, choice2, choice3, choice4, display_order, created_at, updated_at
-	           FROM quizzes WHERE id = $1`
+	query := `SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at
+	           FROM quizzes WHERE id = $1 AND deleted_at IS NULL` + lock
 	q := r.getQueryable(ctx)
 	err := q.GetContext(ctx, &quiz, query, id)
 	if err == sql.ErrNoRows {
@@ -53,16 +133,80 @@ func (r *postgresQuizRepository) GetByID(ctx context.Context, id string) (*domai
 
 // Create inserts a new quiz
 func (r *postgresQuizRepository) Create(ctx context.Context, quiz *domain.Quiz) error {
-	query := `INSERT INTO quizzes (id, question, choice1, choice2, choice3, choice4, display_order, created_at, updated_at)
-	           VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())`
+	query := `INSERT INTO quizzes (id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at)
+	           VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW())`
 	q := r.getQueryable(ctx)
-	_, err := q.ExecContext(ctx, query, quiz.ID, quiz.Question, quiz.Choice1, quiz.Choice2, quiz.Choice3, quiz.Choice4, quiz.DisplayOrder)
+	_, err := q.ExecContext(ctx, query, quiz.ID, quiz.Question, quiz.Choice1, quiz.Choice2, quiz.Choice3, quiz.Choice4, quiz.Answer, quiz.DisplayOrder, quiz.RevisionID, quiz.Status,
+		quiz.PublishAt, quiz.UnpublishAt, quiz.CreatedBy, quiz.UpdatedBy)
 	return err
 }
 
-// Delete removes a quiz by its ID
+// Update saves the content, current revision and editor of a quiz
+func (r *postgresQuizRepository) Update(ctx context.Context, quiz *domain.Quiz) error {
+	query := `UPDATE quizzes SET question = $2, choice1 = $3, choice2 = $4, choice3 = $5, choice4 = $6, answer = $7,
+	           revision_id = $8, updated_by = $9, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, quiz.ID, quiz.Question, quiz.Choice1, quiz.Choice2, quiz.Choice3, quiz.Choice4, quiz.Answer, quiz.RevisionID, quiz.UpdatedBy)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrQuizNotFound
+	}
+	return nil
+}
+
+// UpdateStatus moves a quiz from status from to status to. The status in the
+// WHERE clause guards against a concurrent transition.
+func (r *postgresQuizRepository) UpdateStatus(ctx context.Context, id string, from, to domain.Status) error {
+	query := `UPDATE quizzes SET status = $3, updated_at = NOW() WHERE id = $1 AND status = $2 AND deleted_at IS NULL`
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, id, from, to)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrStatusChanged
+	}
+	return nil
+}
+
+// UpdateSchedule sets the publication window of a quiz
+func (r *postgresQuizRepository) UpdateSchedule(ctx context.Context, id string, schedule sharedDomain.Schedule) error {
+	query := `UPDATE quizzes SET publish_at = $2, unpublish_at = $3, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, id, schedule.PublishAt, schedule.UnpublishAt)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrQuizNotFound
+	}
+	return nil
+}
+
+// SyncVisibility stores the current visibility of every quiz whose
+// visibility differs from the one last stored, and returns those quizzes.
+// Quizzes moved to the trash count as hidden.
+func (r *postgresQuizRepository) SyncVisibility(ctx context.Context) ([]sharedDomain.VisibilityChange, error) {
+	changes := []sharedDomain.VisibilityChange{}
+	query := `UPDATE quizzes SET visible = (deleted_at IS NULL AND ` + domain.VisibleCondition + `)
+	           WHERE visible IS DISTINCT FROM (deleted_at IS NULL AND ` + domain.VisibleCondition + `)
+	           RETURNING id, visible`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &changes, query); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Delete moves a quiz to the trash. Its display_order is kept so it can be
+// restored to its original slot.
 func (r *postgresQuizRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM quizzes WHERE id = $1`
+	query := `UPDATE quizzes SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 	q := r.getQueryable(ctx)
 	result, err := q.ExecContext(ctx, query, id)
 	if err != nil {
@@ -78,7 +222,7 @@ func (r *postgresQuizRepository) Delete(ctx context.Context, id string) error {
 // GetMaxDisplayOrder returns the current maximum display_order (0 if no quizzes)
 func (r *postgresQuizRepository) GetMaxDisplayOrder(ctx context.Context) (int, error) {
 	var maxOrder sql.NullInt64
-	query := `SELECT MAX(display_order) FROM quizzes`
+	query := `SELECT MAX(display_order) FROM quizzes WHERE deleted_at IS NULL`
 	q := r.getQueryable(ctx)
 	err := q.GetContext(ctx, &maxOrder, query)
 	if err != nil {
@@ -92,8 +236,176 @@ func (r *postgresQuizRepository) GetMaxDisplayOrder(ctx context.Context) (int, e
 
 // DecrementDisplayOrdersAbove decrements display_order for all quizzes with order > given value
 func (r *postgresQuizRepository) DecrementDisplayOrdersAbove(ctx context.Context, order int) error {
-	query := `UPDATE quizzes SET display_order = display_order - 1, updated_at = NOW() WHERE display_order > $1`
+	query := `UPDATE quizzes SET display_order = display_order - 1, updated_at = NOW() WHERE display_order > $1 AND deleted_at IS NULL`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query, order)
+	return err
+}
+
+// RenumberDisplayOrders closes gaps so display_order runs 1..n in the current order
+func (r *postgresQuizRepository) RenumberDisplayOrders(ctx context.Context) error {
+	query := `UPDATE quizzes q SET display_order = o.position, updated_at = NOW()
+	           FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY display_order, created_at) AS position
+	                 FROM quizzes WHERE deleted_at IS NULL) o
+	           WHERE q.id = o.id AND q.display_order <> o.position`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query)
+	return err
+}
+
+// IncrementDisplayOrdersFrom increments display_order for all quizzes with order >= given value
+func (r *postgresQuizRepository) IncrementDisplayOrdersFrom(ctx context.Context, order int) error {
+	query := `UPDATE quizzes SET display_order = display_order + 1, updated_at = NOW() WHERE display_order >= $1 AND deleted_at IS NULL`
 	q := r.getQueryable(ctx)
 	_, err := q.ExecContext(ctx, query, order)
 	return err
 }
+
+// Search returns up to limit quizzes matching every term of the query, best match first.
+// Lexemes use the full-text index; Thai substrings use the trigram index on search_text.
+// A quiz whose question contains all substrings ranks above one matching only in its choices.
+func (r *postgresQuizRepository) Search(ctx context.Context, query domain.SearchQuery, visibleOnly bool, limit int) ([]domain.SearchResult, error) {
+	results := []domain.SearchResult{}
+	visible := ""
+	if visibleOnly {
+		visible = " AND " + domain.VisibleCondition
+	}
+	sqlQuery := `SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at,
+	               (CASE WHEN $1 = '' THEN 0 ELSE ts_rank_cd(search_vector, to_tsquery('simple', $1)) END) +
+	               (CASE WHEN cardinality($2::text[]) > 0 AND question ILIKE ALL ($2::text[]) THEN 1 ELSE 0 END) AS rank
+	           FROM quizzes
+	           WHERE deleted_at IS NULL
+	             AND ($1 = '' OR search_vector @@ to_tsquery('simple', $1))
+	             AND search_text ILIKE ALL ($2::text[])` + visible + `
+	           ORDER BY rank DESC, display_order ASC
+	           LIMIT $3`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &results, sqlQuery, query.TSQuery(), pq.Array(query.LikePatterns()), limit); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FindDuplicates returns up to limit quizzes whose normalized question equals
+// or is at least threshold similar to the normalized question. The % operator
+// lets the trigram index narrow the candidates (similarity >= 0.3) before the
+// threshold is applied.
+func (r *postgresQuizRepository) FindDuplicates(ctx context.Context, question string, threshold float64, limit int) ([]domain.Duplicate, error) {
+	duplicates := []domain.Duplicate{}
+	query := `SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at,
+	               similarity(normalized_question, quiz_normalize($1)) AS similarity,
+	               normalized_question = quiz_normalize($1) AS exact
+	           FROM quizzes
+	           WHERE deleted_at IS NULL
+	             AND (normalized_question = quiz_normalize($1)
+	                  OR (normalized_question % quiz_normalize($1) AND similarity(normalized_question, quiz_normalize($1)) >= $2))
+	           ORDER BY exact DESC, similarity DESC, display_order ASC
+	           LIMIT $3`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &duplicates, query, question, threshold, limit); err != nil {
+		return nil, err
+	}
+	return duplicates, nil
+}
+
+// FindDuplicatePairs returns every pair of quizzes not in the trash whose
+// normalized questions are equal or at least threshold similar
+func (r *postgresQuizRepository) FindDuplicatePairs(ctx context.Context, threshold float64) ([]domain.DuplicatePair, error) {
+	pairs := []domain.DuplicatePair{}
+	query := `SELECT a.id AS quiz_id, b.id AS other_id,
+	               similarity(a.normalized_question, b.normalized_question) AS similarity,
+	               a.normalized_question = b.normalized_question AS exact
+	           FROM quizzes a
+	           JOIN quizzes b ON a.id < b.id
+	             AND (a.normalized_question = b.normalized_question OR a.normalized_question % b.normalized_question)
+	           WHERE a.deleted_at IS NULL AND b.deleted_at IS NULL
+	             AND (a.normalized_question = b.normalized_question
+	                  OR similarity(a.normalized_question, b.normalized_question) >= $1)
+	           ORDER BY a.display_order ASC, b.display_order ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &pairs, query, threshold); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// GetDeleted returns the quizzes in the trash, most recently deleted first
+func (r *postgresQuizRepository) GetDeleted(ctx context.Context) ([]domain.Quiz, error) {
+	quizzes := []domain.Quiz{}
+	query := `SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at, deleted_at
+	           FROM quizzes WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &quizzes, query); err != nil {
+		return nil, err
+	}
+	return quizzes, nil
+}
+
+// GetDeletedByID returns a quiz in the trash by its ID
+func (r *postgresQuizRepository) GetDeletedByID(ctx context.Context, id string) (*domain.Quiz, error) {
+	var quiz domain.Quiz
+	query := `SELECT id, question, choice1, choice2, choice3, choice4, answer, display_order, revision_id, status, publish_at, unpublish_at, created_by, updated_by, created_at, updated_at, deleted_at
+	           FROM quizzes WHERE id = $1 AND deleted_at IS NOT NULL`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &quiz, query, id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrQuizNotInTrash
+	}
+	return &quiz, err
+}
+
+// Restore takes a quiz out of the trash at the given display_order
+func (r *postgresQuizRepository) Restore(ctx context.Context, id string, order int) error {
+	query := `UPDATE quizzes SET deleted_at = NULL, display_order = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, id, order)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrQuizNotInTrash
+	}
+	return nil
+}
+
+// notAnswered is the SQL condition for quizzes nobody answered in an attempt.
+// Answered quizzes are never purged: attempt results refer to them.
+const notAnswered = `NOT EXISTS (SELECT 1 FROM attempt_answers a WHERE a.quiz_id = quizzes.id)`
+
+// Purge permanently removes a quiz in the trash. Its media is removed with it;
+// its revisions are kept.
+func (r *postgresQuizRepository) Purge(ctx context.Context, id string) error {
+	query := `DELETE FROM quizzes WHERE id = $1 AND deleted_at IS NOT NULL AND ` + notAnswered
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows > 0 {
+		return nil
+	}
+
+	var answered bool
+	if err := q.GetContext(ctx, &answered, `SELECT EXISTS (SELECT 1 FROM attempt_answers WHERE quiz_id = $1)`, id); err != nil {
+		return err
+	}
+	if answered {
+		return domain.ErrQuizAnswered
+	}
+	return domain.ErrQuizNotInTrash
+}
+
+// PurgeDeletedBefore permanently removes quizzes deleted before the given
+// time that nobody answered
+func (r *postgresQuizRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM quizzes WHERE deleted_at IS NOT NULL AND deleted_at < $1 AND ` + notAnswered
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
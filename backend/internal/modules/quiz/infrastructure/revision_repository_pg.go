@@ -0,0 +1,63 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresRevisionRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRevisionRepository creates a new PostgreSQL quiz revision repository
+func NewPostgresRevisionRepository(db *sqlx.DB) domain.RevisionRepository {
+	return &postgresRevisionRepository{db: db}
+}
+
+func (r *postgresRevisionRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// ListByQuizID returns all revisions of a quiz, newest first
+func (r *postgresRevisionRepository) ListByQuizID(ctx context.Context, quizID string) ([]domain.Revision, error) {
+	revisions := []domain.Revision{}
+	query := `SELECT id, quiz_id, number, question, choice1, choice2, choice3, choice4, answer, action, reverted_from, created_at
+	           FROM quiz_revisions WHERE quiz_id = $1 ORDER BY number DESC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &revisions, query, quizID); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetByID returns a revision of a quiz
+func (r *postgresRevisionRepository) GetByID(ctx context.Context, quizID, id string) (*domain.Revision, error) {
+	var revision domain.Revision
+	query := `SELECT id, quiz_id, number, question, choice1, choice2, choice3, choice4, answer, action, reverted_from, created_at
+	           FROM quiz_revisions WHERE quiz_id = $1 AND id = $2`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &revision, query, quizID, id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrRevisionNotFound
+	}
+	return &revision, err
+}
+
+// Create inserts a new revision, numbered after the latest revision of its
+// quiz. Callers revising an existing quiz lock it first with
+// QuizRepository.GetByIDForUpdate, so concurrent saves do not take the same
+// number.
+func (r *postgresRevisionRepository) Create(ctx context.Context, revision *domain.Revision) error {
+	q := r.getQueryable(ctx)
+	query := `INSERT INTO quiz_revisions (id, quiz_id, number, question, choice1, choice2, choice3, choice4, answer, action, reverted_from, created_at)
+	           SELECT $1, $2, COALESCE(MAX(number), 0) + 1, $3, $4, $5, $6, $7, $8, $9, $10, NOW()
+	           FROM quiz_revisions WHERE quiz_id = $2
+	           RETURNING number, created_at`
+	return q.GetContext(ctx, revision, query, revision.ID, revision.QuizID,
+		revision.Question, revision.Choice1, revision.Choice2, revision.Choice3, revision.Choice4,
+		revision.Answer, revision.Action, revision.RevertedFrom)
+}
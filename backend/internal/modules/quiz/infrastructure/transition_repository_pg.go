@@ -0,0 +1,44 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresTransitionRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresTransitionRepository creates a new PostgreSQL quiz status history repository
+func NewPostgresTransitionRepository(db *sqlx.DB) domain.TransitionRepository {
+	return &postgresTransitionRepository{db: db}
+}
+
+func (r *postgresTransitionRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// ListByQuizID returns the transitions of a quiz, oldest first
+func (r *postgresTransitionRepository) ListByQuizID(ctx context.Context, quizID string) ([]domain.Transition, error) {
+	transitions := []domain.Transition{}
+	query := `SELECT id, quiz_id, action, from_status, to_status, comment, actor_id, created_at
+	           FROM quiz_transitions WHERE quiz_id = $1 ORDER BY created_at ASC, id ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &transitions, query, quizID); err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// Create records a transition
+func (r *postgresTransitionRepository) Create(ctx context.Context, transition *domain.Transition) error {
+	query := `INSERT INTO quiz_transitions (id, quiz_id, action, from_status, to_status, comment, actor_id, created_at)
+	           VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+	           RETURNING created_at`
+	q := r.getQueryable(ctx)
+	return q.QueryRowxContext(ctx, query, transition.ID, transition.QuizID, transition.Action,
+		transition.FromStatus, transition.ToStatus, transition.Comment, transition.ActorID).Scan(&transition.CreatedAt)
+}
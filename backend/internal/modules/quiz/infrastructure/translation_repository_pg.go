@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type postgresTranslationRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresTranslationRepository creates a new PostgreSQL quiz translation repository
+func NewPostgresTranslationRepository(db *sqlx.DB) domain.TranslationRepository {
+	return &postgresTranslationRepository{db: db}
+}
+
+func (r *postgresTranslationRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// ListByQuizID returns all translations of a quiz ordered by locale
+func (r *postgresTranslationRepository) ListByQuizID(ctx context.Context, quizID string) ([]domain.Translation, error) {
+	translations := []domain.Translation{}
+	query := `SELECT quiz_id, locale, question, choice1, choice2, choice3, choice4, explanation, source_revision_id, created_at, updated_at
+	           FROM quiz_translations WHERE quiz_id = $1 ORDER BY locale ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &translations, query, quizID); err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// ListByQuizIDs returns the translations of the given quizzes into the given locales
+func (r *postgresTranslationRepository) ListByQuizIDs(ctx context.Context, quizIDs []string, locales []string) ([]domain.Translation, error) {
+	translations := []domain.Translation{}
+	query := `SELECT quiz_id, locale, question, choice1, choice2, choice3, choice4, explanation, source_revision_id, created_at, updated_at
+	           FROM quiz_translations WHERE quiz_id = ANY($1) AND locale = ANY($2)`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &translations, query, pq.Array(quizIDs), pq.Array(locales)); err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// Upsert creates or replaces the translation of a quiz into a locale
+func (r *postgresTranslationRepository) Upsert(ctx context.Context, t *domain.Translation) error {
+	query := `INSERT INTO quiz_translations (quiz_id, locale, question, choice1, choice2, choice3, choice4, explanation, source_revision_id, created_at, updated_at)
+	           VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+	           ON CONFLICT (quiz_id, locale) DO UPDATE SET
+	               question = EXCLUDED.question, choice1 = EXCLUDED.choice1, choice2 = EXCLUDED.choice2,
+	               choice3 = EXCLUDED.choice3, choice4 = EXCLUDED.choice4, explanation = EXCLUDED.explanation,
+	               source_revision_id = EXCLUDED.source_revision_id, updated_at = NOW()
+	           RETURNING created_at, updated_at`
+	q := r.getQueryable(ctx)
+	return q.QueryRowxContext(ctx, query, t.QuizID, t.Locale, t.Question, t.Choice1, t.Choice2, t.Choice3, t.Choice4, t.Explanation, t.SourceRevisionID).
+		Scan(&t.CreatedAt, &t.UpdatedAt)
+}
+
+// Delete removes the translation of a quiz into a locale
+func (r *postgresTranslationRepository) Delete(ctx context.Context, quizID, locale string) error {
+	query := `DELETE FROM quiz_translations WHERE quiz_id = $1 AND locale = $2`
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, quizID, locale)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrTranslationNotFound
+	}
+	return nil
+}
+
+// ListUntranslated returns the quizzes not in the trash, in display order,
+// that have no translation into locale or whose translation is outdated
+func (r *postgresTranslationRepository) ListUntranslated(ctx context.Context, locale string) ([]domain.UntranslatedQuiz, error) {
+	quizzes := []domain.UntranslatedQuiz{}
+	query := `SELECT q.id, q.question, q.choice1, q.choice2, q.choice3, q.choice4, q.answer, q.display_order, q.revision_id, q.status, q.publish_at, q.unpublish_at, q.created_at, q.updated_at,
+	               t.quiz_id IS NOT NULL AS outdated
+	           FROM quizzes q
+	           LEFT JOIN quiz_translations t ON t.quiz_id = q.id AND t.locale = $1
+	           WHERE q.deleted_at IS NULL AND (t.quiz_id IS NULL OR t.source_revision_id <> q.revision_id)
+	           ORDER BY q.display_order ASC, q.id ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &quizzes, query, locale); err != nil {
+		return nil, err
+	}
+	return quizzes, nil
+}
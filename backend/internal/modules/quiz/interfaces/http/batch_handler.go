@@ -0,0 +1,55 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+)
+
+// BatchHandler handles HTTP requests for bulk quiz operations
+type BatchHandler struct {
+	service application.BatchService
+}
+
+// NewBatchHandler creates a new BatchHandler
+func NewBatchHandler(service application.BatchService) *BatchHandler {
+	return &BatchHandler{service: service}
+}
+
+// Create handles POST /quizzes/batch
+func (h *BatchHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req application.BatchCreateRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	result, err := h.service.Create(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, result)
+}
+
+// Delete handles POST /quizzes/batch-delete
+func (h *BatchHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var req application.BatchDeleteRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	result, err := h.service.Delete(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, result)
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// CloneHandler handles HTTP requests for copying quizzes
+type CloneHandler struct {
+	service application.CloneService
+}
+
+// NewCloneHandler creates a new CloneHandler
+func NewCloneHandler(service application.CloneService) *CloneHandler {
+	return &CloneHandler{service: service}
+}
+
+// Clone handles POST /quizzes/{id}/clone
+func (h *CloneHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	clone, err := h.service.Clone(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, clone)
+}
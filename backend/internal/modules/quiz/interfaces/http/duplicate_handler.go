@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+)
+
+// DuplicateHandler handles HTTP requests for the duplicate question report
+type DuplicateHandler struct {
+	service application.DuplicateService
+}
+
+// NewDuplicateHandler creates a new DuplicateHandler
+func NewDuplicateHandler(service application.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{service: service}
+}
+
+// Report handles GET /quizzes/duplicates?threshold=
+func (h *DuplicateHandler) Report(w http.ResponseWriter, r *http.Request) {
+	threshold := 0.0
+	if raw := r.URL.Query().Get("threshold"); raw != "" {
+		t, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			dto.ErrorFromAppError(w, domain.ErrInvalidThreshold)
+			return
+		}
+		threshold = t
+	}
+
+	clusters, err := h.service.Report(r.Context(), threshold)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, clusters)
+}
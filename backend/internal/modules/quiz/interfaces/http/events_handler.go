@@ -0,0 +1,96 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+)
+
+// Event stream timing
+const (
+	// HeartbeatInterval is how often an idle stream sends a comment so
+	// proxies do not close the connection
+	HeartbeatInterval = 15 * time.Second
+	// eventWriteWait is how long a write to a stream may take
+	eventWriteWait = 10 * time.Second
+	// retryAfter is how long clients wait before reconnecting, in milliseconds
+	retryAfter = 3000
+)
+
+// EventsHandler handles the Server-Sent Events stream of quiz changes
+type EventsHandler struct {
+	stream    application.EventStream
+	heartbeat time.Duration
+}
+
+// NewEventsHandler creates a new EventsHandler
+func NewEventsHandler(stream application.EventStream) *EventsHandler {
+	return &EventsHandler{stream: stream, heartbeat: HeartbeatInterval}
+}
+
+// Stream handles GET /quizzes/events. A client resumes with the Last-Event-ID
+// header, or the last_event_id query parameter where it cannot set headers.
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	sub := h.stream.Subscribe(lastEventID)
+	defer sub.Cancel()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// The server's write timeout would end the stream; each write gets its own
+	rc.SetWriteDeadline(time.Now().Add(eventWriteWait))
+	fmt.Fprintf(w, "retry: %d\n\n", retryAfter)
+	for _, change := range sub.Missed {
+		if err := writeChange(w, change); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case change, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			rc.SetWriteDeadline(time.Now().Add(eventWriteWait))
+			if err := writeChange(w, change); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			rc.SetWriteDeadline(time.Now().Add(eventWriteWait))
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeChange writes a change as an event named after its type
+func writeChange(w http.ResponseWriter, change application.ChangeEvent) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", change.ID, change.Type, data)
+	return err
+}
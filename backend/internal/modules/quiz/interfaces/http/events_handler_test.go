@@ -0,0 +1,98 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/go-chi/chi/v5"
+)
+
+// openEventStream connects to the stream and returns a reader of its lines
+func openEventStream(t *testing.T, srv *httptest.Server, lastEventID string) (*http.Response, *bufio.Scanner) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/quizzes/events", nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp, bufio.NewScanner(resp.Body)
+}
+
+// readEvent returns the fields of the next event or comment
+func readEvent(t *testing.T, lines *bufio.Scanner) map[string]string {
+	t.Helper()
+	fields := map[string]string{}
+	for lines.Scan() {
+		line := lines.Text()
+		if line == "" {
+			if len(fields) > 0 {
+				return fields
+			}
+			continue
+		}
+		name, value, _ := strings.Cut(line, ":")
+		fields[name] = strings.TrimPrefix(value, " ")
+	}
+	t.Fatalf("expected an event, got: %v", lines.Err())
+	return nil
+}
+
+func TestEventsHandler_Stream(t *testing.T) {
+	bus := events.NewEventBus()
+	stream := application.NewEventStream(bus)
+	handler := NewEventsHandler(stream)
+	handler.heartbeat = 20 * time.Millisecond
+	r := chi.NewRouter()
+	r.Get("/quizzes/events", handler.Stream)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	resp, lines := openEventStream(t, srv, "")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if ev := readEvent(t, lines); ev["retry"] != "3000" {
+		t.Errorf("expected the retry hint first, got %v", ev)
+	}
+
+	bus.Publish(context.Background(), events.QuizCreatedEvent{QuizID: "q1"})
+	created := readEvent(t, lines)
+	if created["event"] != "created" || created["id"] == "" || !strings.Contains(created["data"], `"quiz_id":"q1"`) {
+		t.Errorf("unexpected event %v", created)
+	}
+	if ev := readEvent(t, lines); ev[""] != "heartbeat" {
+		t.Errorf("expected a heartbeat, got %v", ev)
+	}
+
+	// A reconnecting client receives what it missed
+	bus.Publish(context.Background(), events.QuizDeletedEvent{QuizID: "q1"})
+	_, resumed := openEventStream(t, srv, created["id"])
+	readEvent(t, resumed)
+	if ev := readEvent(t, resumed); ev["event"] != "deleted" {
+		t.Errorf("expected the missed deletion, got %v", ev)
+	}
+	_, reset := openEventStream(t, srv, "stale-1")
+	readEvent(t, reset)
+	if ev := readEvent(t, reset); ev["event"] != "reset" {
+		t.Errorf("expected a reset for an unknown ID, got %v", ev)
+	}
+
+	// Closing the stream ends the response
+	stream.Close()
+	for lines.Scan() {
+	}
+	if err := lines.Err(); err != nil {
+		t.Errorf("expected the stream to end cleanly, got: %v", err)
+	}
+}
@@ -3,31 +3,121 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
 	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/cananga-odorata/golang-template/internal/shared/listquery"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
 	"github.com/go-chi/chi/v5"
 )
 
 // QuizHandler handles HTTP requests for quiz operations
 type QuizHandler struct {
-	service application.QuizService
+	service      application.QuizService
+	translations application.TranslationService
+	// legacyList makes GET /quizzes without pagination parameters return
+	// every quiz as a plain array, as it did before pagination existed
+	legacyList bool
 }
 
-// NewQuizHandler creates a new QuizHandler
-func NewQuizHandler(service application.QuizService) *QuizHandler {
-	return &QuizHandler{service: service}
+// NewQuizHandler creates a new QuizHandler. Listed quizzes are localized
+// into the locales preferred by the request.
+func NewQuizHandler(service application.QuizService, translations application.TranslationService, legacyList bool) *QuizHandler {
+	return &QuizHandler{service: service, translations: translations, legacyList: legacyList}
 }
 
-// List handles GET /quizzes
+// List handles GET /quizzes?page=&page_size= and GET /quizzes?cursor=&page_size=,
+// optionally with sort=, filter[field][op]= and author= (a user ID or "me")
+// parameters. This is the public listing: only published quizzes inside
+// their schedule are returned.
 func (h *QuizHandler) List(w http.ResponseWriter, r *http.Request) {
-	quizzes, err := h.service.GetAll(r.Context())
+	h.list(w, r, true)
+}
+
+// Manage handles GET /quizzes/manage, which takes the same parameters as
+// List but returns quizzes in every status
+func (h *QuizHandler) Manage(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, false)
+}
+
+func (h *QuizHandler) list(w http.ResponseWriter, r *http.Request, visible bool) {
+	query := r.URL.Query()
+	if h.legacyList && !query.Has("page") && !query.Has("page_size") && !query.Has("cursor") && !query.Has("author") && !listquery.HasParams(query) {
+		getAll := h.service.GetAll
+		if visible {
+			getAll = h.service.GetVisible
+		}
+		quizzes, err := getAll(r.Context())
+		if err == nil {
+			quizzes, err = h.localize(r, quizzes)
+		}
+		if err != nil {
+			dto.ErrorFromAppError(w, err)
+			return
+		}
+
+		dto.OK(w, quizzes)
+		return
+	}
+
+	listQuery, err := listquery.Parse(query, domain.QuizListSchema)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	req := application.ListQuizzesRequest{Cursor: query.Get("cursor"), Query: listQuery, Visible: visible, Author: query.Get("author")}
+	for param, target := range map[string]*int{"page": &req.Page, "page_size": &req.PageSize} {
+		if raw := query.Get(param); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 {
+				dto.Error(w, http.StatusBadRequest, "INVALID_PAGINATION", "Query parameter '"+param+"' must be a positive number")
+				return
+			}
+			*target = n
+		}
+	}
+
+	page, err := h.service.List(r.Context(), req)
+	if err == nil {
+		page.Items, err = h.localize(r, page.Items)
+	}
 	if err != nil {
 		dto.ErrorFromAppError(w, err)
 		return
 	}
 
-	dto.OK(w, quizzes)
+	resp := dto.NewPaginatedResponse(page.Items, page.Pagination)
+	resp.NextCursor, resp.PrevCursor = page.NextCursor, page.PrevCursor
+	if req.Cursor == "" {
+		if page.Pagination.HasNext() {
+			resp.Links.Next = dto.PageURL(r, map[string]string{"page": strconv.Itoa(page.Pagination.Page + 1)})
+		}
+		if page.Pagination.HasPrev() {
+			resp.Links.Prev = dto.PageURL(r, map[string]string{"page": strconv.Itoa(page.Pagination.Page - 1)})
+		}
+	} else {
+		if page.NextCursor != "" {
+			resp.Links.Next = dto.PageURL(r, map[string]string{"cursor": page.NextCursor})
+		}
+		if page.PrevCursor != "" {
+			resp.Links.Prev = dto.PageURL(r, map[string]string{"cursor": page.PrevCursor})
+		}
+	}
+
+	dto.SetLinkHeader(w, resp.Links)
+	dto.OK(w, resp)
+}
+
+// localize translates quizzes into the locales the locale middleware resolved
+func (h *QuizHandler) localize(r *http.Request, quizzes []application.QuizResponse) ([]application.QuizResponse, error) {
+	locales, ok := utils.GetLocales(r.Context())
+	if !ok {
+		return quizzes, nil
+	}
+	return h.translations.Localize(r.Context(), quizzes, locales)
 }
 
 // Create handles POST /quizzes
@@ -48,6 +138,25 @@ func (h *QuizHandler) Create(w http.ResponseWriter, r *http.Request) {
 	dto.Created(w, quiz)
 }
 
+// Update handles PUT /quizzes/{id}
+func (h *QuizHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var req application.UpdateQuizRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	quiz, err := h.service.Update(r.Context(), id, req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, quiz)
+}
+
 // Delete handles DELETE /quizzes/{id}
 func (h *QuizHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
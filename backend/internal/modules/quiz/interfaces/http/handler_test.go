@@ -16,17 +16,46 @@ import (
 
 // mockQuizService is a mock implementation of application.QuizService
 type mockQuizService struct {
-	quizzes   []application.QuizResponse
-	createErr error
-	deleteErr error
-	created   *application.QuizResponse
+	quizzes    []application.QuizResponse
+	createErr  error
+	deleteErr  error
+	created    *application.QuizResponse
+	listed     *application.ListQuizzesRequest
+	nextCursor string
 }
 
 func (m *mockQuizService) GetAll(_ context.Context) ([]application.QuizResponse, error) {
 	return m.quizzes, nil
 }
 
-func (m *mockQuizService) Create(_ context.Context, _ application.CreateQuizRequest) (*application.QuizResponse, error) {
+func (m *mockQuizService) GetVisible(_ context.Context) ([]application.QuizResponse, error) {
+	visible := []application.QuizResponse{}
+	for _, q := range m.quizzes {
+		if q.Status == domain.StatusPublished {
+			visible = append(visible, q)
+		}
+	}
+	return visible, nil
+}
+
+func (m *mockQuizService) List(_ context.Context, req application.ListQuizzesRequest) (*application.QuizPage, error) {
+	m.listed = &req
+	pagination := sharedDomain.NewPagination(req.Page, req.PageSize)
+	pagination.Total = int64(len(m.quizzes))
+	if req.Cursor != "" {
+		pagination.Page = 0
+	}
+	return &application.QuizPage{Items: m.quizzes, Pagination: pagination, NextCursor: m.nextCursor}, nil
+}
+
+func (m *mockQuizService) Create(_ context.Context, _ application.CreateQuizRequest) (*application.CreateQuizResponse, error) {
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	return &application.CreateQuizResponse{QuizResponse: *m.created}, nil
+}
+
+func (m *mockQuizService) Update(_ context.Context, _ string, _ application.UpdateQuizRequest) (*application.QuizResponse, error) {
 	if m.createErr != nil {
 		return nil, m.createErr
 	}
@@ -41,7 +70,7 @@ func (m *mockQuizService) Delete(_ context.Context, _ string) error {
 
 func TestListHandler_Empty(t *testing.T) {
 	svc := &mockQuizService{quizzes: []application.QuizResponse{}}
-	handler := NewQuizHandler(svc)
+	handler := NewQuizHandler(svc, nil, true)
 
 	req := httptest.NewRequest(http.MethodGet, "/quizzes", nil)
 	rec := httptest.NewRecorder()
@@ -66,7 +95,7 @@ func TestListHandler_WithData(t *testing.T) {
 			{ID: "2", Question: "Q2", Choice1: "A", Choice2: "B", Choice3: "C", Choice4: "D", DisplayOrder: 2},
 		},
 	}
-	handler := NewQuizHandler(svc)
+	handler := NewQuizHandler(svc, nil, true)
 
 	req := httptest.NewRequest(http.MethodGet, "/quizzes", nil)
 	rec := httptest.NewRecorder()
@@ -90,7 +119,7 @@ func TestCreateHandler_Success(t *testing.T) {
 			DisplayOrder: 1,
 		},
 	}
-	handler := NewQuizHandler(svc)
+	handler := NewQuizHandler(svc, nil, true)
 
 	body, _ := json.Marshal(application.CreateQuizRequest{
 		Question: "Test Q",
@@ -113,7 +142,7 @@ func TestCreateHandler_Success(t *testing.T) {
 
 func TestCreateHandler_InvalidJSON(t *testing.T) {
 	svc := &mockQuizService{}
-	handler := NewQuizHandler(svc)
+	handler := NewQuizHandler(svc, nil, true)
 
 	req := httptest.NewRequest(http.MethodPost, "/quizzes", bytes.NewReader([]byte("invalid json")))
 	req.Header.Set("Content-Type", "application/json")
@@ -130,7 +159,7 @@ func TestCreateHandler_ValidationError(t *testing.T) {
 	svc := &mockQuizService{
 		createErr: domain.ErrInvalidQuiz,
 	}
-	handler := NewQuizHandler(svc)
+	handler := NewQuizHandler(svc, nil, true)
 
 	body, _ := json.Marshal(application.CreateQuizRequest{
 		Question: "",
@@ -153,7 +182,7 @@ func TestCreateHandler_ValidationError(t *testing.T) {
 
 func TestDeleteHandler_Success(t *testing.T) {
 	svc := &mockQuizService{deleteErr: nil}
-	handler := NewQuizHandler(svc)
+	handler := NewQuizHandler(svc, nil, true)
 
 	// Use chi router to inject URL params
 	r := chi.NewRouter()
@@ -173,7 +202,7 @@ func TestDeleteHandler_NotFound(t *testing.T) {
 	svc := &mockQuizService{
 		deleteErr: sharedDomain.NewNotFoundError("Quiz not found"),
 	}
-	handler := NewQuizHandler(svc)
+	handler := NewQuizHandler(svc, nil, true)
 
 	r := chi.NewRouter()
 	r.Delete("/quizzes/{id}", handler.Delete)
@@ -187,3 +216,130 @@ func TestDeleteHandler_NotFound(t *testing.T) {
 		t.Errorf("expected status 404, got %d", rec.Code)
 	}
 }
+
+func TestListHandler_LegacyFlag(t *testing.T) {
+	quizzes := []application.QuizResponse{{ID: "a", Status: domain.StatusPublished}}
+
+	// With the flag, a request without pagination parameters returns a plain array
+	rec := httptest.NewRecorder()
+	NewQuizHandler(&mockQuizService{quizzes: quizzes}, nil, true).List(rec, httptest.NewRequest(http.MethodGet, "/quizzes", nil))
+	var legacy struct{ Data []application.QuizResponse }
+	if err := json.NewDecoder(rec.Body).Decode(&legacy); err != nil || len(legacy.Data) != 1 {
+		t.Errorf("expected a plain array, got %v", err)
+	}
+
+	// Without it, the first page is returned
+	svc := &mockQuizService{quizzes: quizzes}
+	rec = httptest.NewRecorder()
+	NewQuizHandler(svc, nil, false).List(rec, httptest.NewRequest(http.MethodGet, "/quizzes", nil))
+	if svc.listed == nil {
+		t.Error("expected a paginated list")
+	}
+}
+
+func TestListHandler_PageLinks(t *testing.T) {
+	svc := &mockQuizService{quizzes: make([]application.QuizResponse, 5), nextCursor: "abc"}
+	rec := httptest.NewRecorder()
+	NewQuizHandler(svc, nil, true).List(rec, httptest.NewRequest(http.MethodGet, "/api/v1/quizzes?page=2&page_size=2", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var resp struct {
+		Data struct {
+			Page       int    `json:"page"`
+			Total      int64  `json:"total"`
+			TotalPages int    `json:"total_pages"`
+			NextCursor string `json:"next_cursor"`
+			Links      struct {
+				Next string `json:"next"`
+				Prev string `json:"prev"`
+			} `json:"links"`
+		}
+	}
+	json.NewDecoder(rec.Body).Decode(&resp)
+
+	if resp.Data.Page != 2 || resp.Data.Total != 5 || resp.Data.TotalPages != 3 || resp.Data.NextCursor != "abc" {
+		t.Errorf("unexpected page %+v", resp.Data)
+	}
+	if resp.Data.Links.Next != "/api/v1/quizzes?page=3&page_size=2" || resp.Data.Links.Prev != "/api/v1/quizzes?page=1&page_size=2" {
+		t.Errorf("unexpected links %+v", resp.Data.Links)
+	}
+	if link := rec.Header().Get("Link"); link != `</api/v1/quizzes?page=3&page_size=2>; rel="next", </api/v1/quizzes?page=1&page_size=2>; rel="prev"` {
+		t.Errorf("unexpected Link header %q", link)
+	}
+}
+
+func TestListHandler_CursorLinks(t *testing.T) {
+	svc := &mockQuizService{quizzes: make([]application.QuizResponse, 2), nextCursor: "next"}
+	rec := httptest.NewRecorder()
+	NewQuizHandler(svc, nil, true).List(rec, httptest.NewRequest(http.MethodGet, "/quizzes?cursor=abc&page_size=2", nil))
+
+	if svc.listed.Cursor != "abc" {
+		t.Errorf("expected cursor to be passed on, got %+v", svc.listed)
+	}
+	if link := rec.Header().Get("Link"); link != `</quizzes?cursor=next&page_size=2>; rel="next"` {
+		t.Errorf("unexpected Link header %q", link)
+	}
+}
+
+func TestListHandler_InvalidPage(t *testing.T) {
+	for _, target := range []string{"/quizzes?page=0", "/quizzes?page_size=abc"} {
+		rec := httptest.NewRecorder()
+		NewQuizHandler(&mockQuizService{}, nil, true).List(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", target, rec.Code)
+		}
+	}
+}
+
+func TestListHandler_SortAndFilter(t *testing.T) {
+	// Sort and filter parameters select the paginated list even with the legacy flag
+	svc := &mockQuizService{quizzes: []application.QuizResponse{{ID: "a"}}}
+	rec := httptest.NewRecorder()
+	NewQuizHandler(svc, nil, true).List(rec, httptest.NewRequest(http.MethodGet, "/quizzes?sort=-created_at&filter[answer][in]=1,2", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if svc.listed == nil || svc.listed.Query == nil || !svc.listed.Query.CustomSort || len(svc.listed.Query.Filters) != 1 {
+		t.Fatalf("expected the parsed query to reach the service, got %+v", svc.listed)
+	}
+
+	for _, target := range []string{"/quizzes?sort=choice1", "/quizzes?filter[question][gt]=a", "/quizzes?filter[answer]=one"} {
+		rec = httptest.NewRecorder()
+		NewQuizHandler(svc, nil, true).List(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", target, rec.Code)
+		}
+	}
+}
+
+func TestListHandler_PublishedOnly(t *testing.T) {
+	svc := &mockQuizService{quizzes: []application.QuizResponse{{ID: "a", Status: domain.StatusPublished}, {ID: "b", Status: domain.StatusDraft}}}
+	handler := NewQuizHandler(svc, nil, true)
+
+	for target, want := range map[string]int{"/quizzes": 1, "/quizzes/manage": 2} {
+		rec := httptest.NewRecorder()
+		if target == "/quizzes" {
+			handler.List(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		} else {
+			handler.Manage(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		}
+		var resp struct{ Data []application.QuizResponse }
+		json.NewDecoder(rec.Body).Decode(&resp)
+		if len(resp.Data) != want {
+			t.Errorf("%s: expected %d quizzes, got %+v", target, want, resp.Data)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.List(rec, httptest.NewRequest(http.MethodGet, "/quizzes?page=1", nil))
+	if !svc.listed.Visible {
+		t.Error("expected the public page to be limited to published quizzes")
+	}
+	handler.Manage(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/quizzes/manage?page=1", nil))
+	if svc.listed.Visible {
+		t.Error("expected the manage page to list every status")
+	}
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// HintHandler handles HTTP requests for quiz hints
+type HintHandler struct {
+	service application.HintService
+}
+
+// NewHintHandler creates a new HintHandler
+func NewHintHandler(service application.HintService) *HintHandler {
+	return &HintHandler{service: service}
+}
+
+// List handles GET /quizzes/{id}/hints
+func (h *HintHandler) List(w http.ResponseWriter, r *http.Request) {
+	hints, err := h.service.List(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, hints)
+}
+
+// Put handles PUT /quizzes/{id}/hints
+func (h *HintHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var req application.PutHintsRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	hints, err := h.service.Put(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, hints)
+}
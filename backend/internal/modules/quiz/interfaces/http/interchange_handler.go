@@ -0,0 +1,86 @@
+package http
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+)
+
+// maxImportSize limits the size of uploaded documents, including content packages with media
+const maxImportSize = 50 << 20
+
+// InterchangeHandler handles HTTP requests for quiz import and export
+type InterchangeHandler struct {
+	service application.InterchangeService
+}
+
+// NewInterchangeHandler creates a new InterchangeHandler
+func NewInterchangeHandler(service application.InterchangeService) *InterchangeHandler {
+	return &InterchangeHandler{service: service}
+}
+
+// Import handles POST /quizzes/import?format={format}&on_duplicate={warn|block}
+// The document is sent either as the raw request body or as the "file" field of a multipart form.
+func (h *InterchangeHandler) Import(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		dto.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'format' is required")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	var body io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			dto.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Multipart field 'file' is required")
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	result, err := h.service.Import(r.Context(), format, body, application.DuplicatePolicy(r.URL.Query().Get("on_duplicate")))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, result)
+}
+
+// Export handles GET /quizzes/export?format={format}&ids={id,id}
+func (h *InterchangeHandler) Export(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		dto.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'format' is required")
+		return
+	}
+
+	var ids []string
+	if raw := r.URL.Query().Get("ids"); raw != "" {
+		for _, id := range strings.Split(raw, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	result, err := h.service.Export(r.Context(), format, ids)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", result.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": result.Filename}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(result.Data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(result.Data)
+}
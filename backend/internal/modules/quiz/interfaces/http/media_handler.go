@@ -0,0 +1,72 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// MediaHandler handles HTTP requests for quiz media
+type MediaHandler struct {
+	service application.MediaService
+}
+
+// NewMediaHandler creates a new MediaHandler
+func NewMediaHandler(service application.MediaService) *MediaHandler {
+	return &MediaHandler{service: service}
+}
+
+// List handles GET /quizzes/{id}/media
+func (h *MediaHandler) List(w http.ResponseWriter, r *http.Request) {
+	media, err := h.service.List(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, media)
+}
+
+// inlineImageTypes are the image types shown in the page. Other images, such
+// as SVG, can carry scripts.
+var inlineImageTypes = map[string]bool{
+	"image/png": true, "image/jpeg": true, "image/gif": true, "image/webp": true, "image/avif": true, "image/bmp": true,
+}
+
+// servedInline returns true if media of contentType is safe to show in the
+// page: raster images, audio and video
+func servedInline(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return inlineImageTypes[mediaType] || strings.HasPrefix(mediaType, "audio/") || strings.HasPrefix(mediaType, "video/")
+}
+
+// Get handles GET /quizzes/{id}/media/{mediaID}. Media is uploaded in
+// imported packages, so anything but raster images, audio and video is sent
+// as a sandboxed download rather than rendered on the API's origin.
+func (h *MediaHandler) Get(w http.ResponseWriter, r *http.Request) {
+	media, err := h.service.Get(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "mediaID"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	disposition := "inline"
+	if !servedInline(media.ContentType) {
+		disposition = "attachment"
+		w.Header().Set("Content-Security-Policy", "sandbox")
+	}
+	w.Header().Set("Content-Type", media.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": media.Filename}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(media.Data)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(media.Data)
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+// stubMediaService serves one media file of any content type
+type stubMediaService struct {
+	media domain.Media
+}
+
+func (s *stubMediaService) List(_ context.Context, _ string) ([]application.MediaResponse, error) {
+	return []application.MediaResponse{}, nil
+}
+
+func (s *stubMediaService) Get(_ context.Context, _, _ string) (*domain.Media, error) {
+	return &s.media, nil
+}
+
+func TestMediaHandler_Get_Disposition(t *testing.T) {
+	cases := []struct {
+		contentType string
+		inline      bool
+	}{
+		{"image/png", true},
+		{"image/jpeg", true},
+		{"audio/mpeg", true},
+		{"video/mp4", true},
+		{"image/svg+xml", false},
+		{"text/html; charset=utf-8", false},
+		{"application/pdf", false},
+		{"application/octet-stream", false},
+		{"not a type", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.contentType, func(t *testing.T) {
+			handler := NewMediaHandler(&stubMediaService{media: domain.Media{Filename: "file", ContentType: tc.contentType, Data: []byte("data")}})
+			rec := httptest.NewRecorder()
+			handler.Get(rec, httptest.NewRequest(http.MethodGet, "/quizzes/q1/media/m1", nil))
+
+			disposition, csp := rec.Header().Get("Content-Disposition"), rec.Header().Get("Content-Security-Policy")
+			if tc.inline && (!strings.HasPrefix(disposition, "inline") || csp != "") {
+				t.Errorf("expected inline without CSP, got %q %q", disposition, csp)
+			}
+			if !tc.inline && (!strings.HasPrefix(disposition, "attachment") || csp != "sandbox") {
+				t.Errorf("expected a sandboxed attachment, got %q %q", disposition, csp)
+			}
+			if rec.Header().Get("X-Content-Type-Options") != "nosniff" {
+				t.Error("expected nosniff")
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// RevisionHandler handles HTTP requests for quiz revision history
+type RevisionHandler struct {
+	service application.RevisionService
+}
+
+// NewRevisionHandler creates a new RevisionHandler
+func NewRevisionHandler(service application.RevisionService) *RevisionHandler {
+	return &RevisionHandler{service: service}
+}
+
+// List handles GET /quizzes/{id}/revisions
+func (h *RevisionHandler) List(w http.ResponseWriter, r *http.Request) {
+	revisions, err := h.service.List(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, revisions)
+}
+
+// Get handles GET /quizzes/{id}/revisions/{revisionID}
+func (h *RevisionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	revision, err := h.service.Get(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "revisionID"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, revision)
+}
+
+// Diff handles GET /quizzes/{id}/revisions/diff?from=&to=
+// When "to" is omitted the current revision is compared.
+func (h *RevisionHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	from := r.URL.Query().Get("from")
+	if from == "" {
+		dto.Error(w, http.StatusBadRequest, "MISSING_FROM", "Query parameter 'from' is required")
+		return
+	}
+
+	diff, err := h.service.Diff(r.Context(), chi.URLParam(r, "id"), from, r.URL.Query().Get("to"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, diff)
+}
+
+// Revert handles POST /quizzes/{id}/revisions/{revisionID}/revert
+func (h *RevisionHandler) Revert(w http.ResponseWriter, r *http.Request) {
+	quiz, err := h.service.Revert(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "revisionID"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, quiz)
+}
@@ -2,16 +2,81 @@ package http
 
 import (
 	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
 	"github.com/go-chi/chi/v5"
 )
 
-// RegisterRoutes registers all quiz module routes
-func RegisterRoutes(r chi.Router, service application.QuizService) {
-	handler := NewQuizHandler(service)
+// Services holds the application services behind the quiz routes
+type Services struct {
+	Quiz         application.QuizService
+	Interchange  application.InterchangeService
+	Media        application.MediaService
+	Revisions    application.RevisionService
+	Trash        application.TrashService
+	Batch        application.BatchService
+	Search       application.SearchService
+	Duplicates   application.DuplicateService
+	Translations application.TranslationService
+	Hints        application.HintService
+	Workflow     application.WorkflowService
+	Schedules    application.ScheduleService
+	Clones       application.CloneService
+	Events       application.EventStream
+}
+
+// RegisterRoutes registers all quiz module routes. With legacyList, GET /quizzes
+// without pagination parameters returns every quiz as a plain array.
+func RegisterRoutes(r chi.Router, services Services, legacyList bool) {
+	handler := NewQuizHandler(services.Quiz, services.Translations, legacyList)
+	interchangeHandler := NewInterchangeHandler(services.Interchange)
+	mediaHandler := NewMediaHandler(services.Media)
+	revisionHandler := NewRevisionHandler(services.Revisions)
+	trashHandler := NewTrashHandler(services.Trash)
+	batchHandler := NewBatchHandler(services.Batch)
+	searchHandler := NewSearchHandler(services.Search)
+	duplicateHandler := NewDuplicateHandler(services.Duplicates)
+	translationHandler := NewTranslationHandler(services.Translations)
+	hintHandler := NewHintHandler(services.Hints)
+	workflowHandler := NewWorkflowHandler(services.Workflow)
+	scheduleHandler := NewScheduleHandler(services.Schedules)
+	cloneHandler := NewCloneHandler(services.Clones)
+	eventsHandler := NewEventsHandler(services.Events)
 
 	r.Route("/quizzes", func(r chi.Router) {
 		r.Get("/", handler.List)
 		r.Post("/", handler.Create)
+		r.Get("/manage", handler.Manage)
+		r.Get("/search", searchHandler.Search)
+		r.Get("/duplicates", duplicateHandler.Report)
+		r.Get("/events", eventsHandler.Stream)
+		r.Get("/untranslated/{locale}", translationHandler.Untranslated)
+		r.Post("/batch", batchHandler.Create)
+		r.Post("/batch-delete", batchHandler.Delete)
+		r.Post("/import", interchangeHandler.Import)
+		r.Get("/export", interchangeHandler.Export)
+		r.Get("/trash", trashHandler.List)
+		r.Post("/trash/{id}/restore", trashHandler.Restore)
+		r.Delete("/trash/{id}", trashHandler.Purge)
+		r.Put("/{id}", handler.Update)
 		r.Delete("/{id}", handler.Delete)
+		r.Post("/{id}/clone", cloneHandler.Clone)
+		r.Get("/{id}/revisions", revisionHandler.List)
+		r.Get("/{id}/revisions/diff", revisionHandler.Diff)
+		r.Get("/{id}/revisions/{revisionID}", revisionHandler.Get)
+		r.Post("/{id}/revisions/{revisionID}/revert", revisionHandler.Revert)
+		r.Post("/{id}/submit", workflowHandler.Transition(domain.ActionSubmit))
+		r.Post("/{id}/approve", workflowHandler.Transition(domain.ActionApprove))
+		r.Post("/{id}/reject", workflowHandler.Transition(domain.ActionReject))
+		r.Post("/{id}/archive", workflowHandler.Transition(domain.ActionArchive))
+		r.Post("/{id}/reopen", workflowHandler.Transition(domain.ActionReopen))
+		r.Get("/{id}/transitions", workflowHandler.History)
+		r.Put("/{id}/schedule", scheduleHandler.Put)
+		r.Get("/{id}/translations", translationHandler.List)
+		r.Put("/{id}/translations/{locale}", translationHandler.Put)
+		r.Delete("/{id}/translations/{locale}", translationHandler.Delete)
+		r.Get("/{id}/hints", hintHandler.List)
+		r.Put("/{id}/hints", hintHandler.Put)
+		r.Get("/{id}/media", mediaHandler.List)
+		r.Get("/{id}/media/{mediaID}", mediaHandler.Get)
 	})
 }
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// ScheduleHandler handles HTTP requests for quiz publication schedules
+type ScheduleHandler struct {
+	service application.ScheduleService
+}
+
+// NewScheduleHandler creates a new ScheduleHandler
+func NewScheduleHandler(service application.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{service: service}
+}
+
+// Put handles PUT /quizzes/{id}/schedule
+func (h *ScheduleHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var req application.ScheduleRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	quiz, err := h.service.Set(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, quiz)
+}
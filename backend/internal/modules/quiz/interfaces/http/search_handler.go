@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+)
+
+// SearchHandler handles HTTP requests for quiz search
+type SearchHandler struct {
+	service application.SearchService
+}
+
+// NewSearchHandler creates a new SearchHandler
+func NewSearchHandler(service application.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search handles GET /quizzes/search?q=&limit=
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			dto.Error(w, http.StatusBadRequest, "INVALID_LIMIT", "Query parameter 'limit' must be a positive number")
+			return
+		}
+		limit = n
+	}
+
+	results, err := h.service.Search(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, results)
+}
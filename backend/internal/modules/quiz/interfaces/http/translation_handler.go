@@ -0,0 +1,70 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// TranslationHandler handles HTTP requests for quiz translations
+type TranslationHandler struct {
+	service application.TranslationService
+}
+
+// NewTranslationHandler creates a new TranslationHandler
+func NewTranslationHandler(service application.TranslationService) *TranslationHandler {
+	return &TranslationHandler{service: service}
+}
+
+// List handles GET /quizzes/{id}/translations
+func (h *TranslationHandler) List(w http.ResponseWriter, r *http.Request) {
+	translations, err := h.service.List(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, translations)
+}
+
+// Put handles PUT /quizzes/{id}/translations/{locale}
+func (h *TranslationHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var req application.PutTranslationRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	translation, err := h.service.Put(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "locale"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, translation)
+}
+
+// Delete handles DELETE /quizzes/{id}/translations/{locale}
+func (h *TranslationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "locale")); err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.NoContent(w)
+}
+
+// Untranslated handles GET /quizzes/untranslated/{locale}
+func (h *TranslationHandler) Untranslated(w http.ResponseWriter, r *http.Request) {
+	quizzes, err := h.service.Untranslated(r.Context(), chi.URLParam(r, "locale"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, quizzes)
+}
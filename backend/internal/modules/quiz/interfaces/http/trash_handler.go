@@ -0,0 +1,61 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// TrashHandler handles HTTP requests for deleted quizzes
+type TrashHandler struct {
+	service application.TrashService
+}
+
+// NewTrashHandler creates a new TrashHandler
+func NewTrashHandler(service application.TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// List handles GET /quizzes/trash
+func (h *TrashHandler) List(w http.ResponseWriter, r *http.Request) {
+	quizzes, err := h.service.List(r.Context())
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, quizzes)
+}
+
+// Restore handles POST /quizzes/trash/{id}/restore. The body is optional.
+func (h *TrashHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	var req application.RestoreQuizRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	quiz, err := h.service.Restore(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, quiz)
+}
+
+// Purge handles DELETE /quizzes/trash/{id}
+func (h *TrashHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Purge(r.Context(), chi.URLParam(r, "id")); err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.NoContent(w)
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// WorkflowHandler handles HTTP requests for the quiz review workflow
+type WorkflowHandler struct {
+	service application.WorkflowService
+}
+
+// NewWorkflowHandler creates a new WorkflowHandler
+func NewWorkflowHandler(service application.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{service: service}
+}
+
+// Transition returns the handler for POST /quizzes/{id}/{action}. The body,
+// {"comment": "..."}, is optional except to reject.
+func (h *WorkflowHandler) Transition(action domain.Action) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req application.TransitionRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+			return
+		}
+
+		quiz, err := h.service.Transition(r.Context(), chi.URLParam(r, "id"), action, req)
+		if err != nil {
+			dto.ErrorFromAppError(w, err)
+			return
+		}
+
+		dto.OK(w, quiz)
+	}
+}
+
+// History handles GET /quizzes/{id}/transitions
+func (h *WorkflowHandler) History(w http.ResponseWriter, r *http.Request) {
+	transitions, err := h.service.History(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, transitions)
+}
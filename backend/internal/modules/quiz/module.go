@@ -1,29 +1,157 @@
 package quiz
 
 import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
 	"github.com/cananga-odorata/golang-template/internal/modules/quiz/application"
 	"github.com/cananga-odorata/golang-template/internal/modules/quiz/infrastructure"
+	"github.com/cananga-odorata/golang-template/internal/modules/quiz/infrastructure/format"
 	httpinterface "github.com/cananga-odorata/golang-template/internal/modules/quiz/interfaces/http"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
 	"github.com/go-chi/chi/v5"
 	"github.com/jmoiron/sqlx"
 )
 
 // Module represents the quiz module with all its dependencies
 type Module struct {
-	Service application.QuizService
+	Service      application.QuizService
+	Interchange  application.InterchangeService
+	Media        application.MediaService
+	Revisions    application.RevisionService
+	Trash        application.TrashService
+	Batch        application.BatchService
+	Search       application.SearchService
+	Duplicates   application.DuplicateService
+	Translations application.TranslationService
+	Hints        application.HintService
+	Workflow     application.WorkflowService
+	Schedules    application.ScheduleService
+	Clones       application.CloneService
+	Events       application.EventStream
+
+	legacyList bool
+}
+
+// Options configures the quiz module
+type Options struct {
+	// TrashRetention is how long deleted quizzes are kept; zero keeps them until purged by hand
+	TrashRetention time.Duration
+	// MaxBatchSize limits the number of items in a bulk request
+	MaxBatchSize int
+	// LegacyList keeps GET /quizzes without pagination parameters returning every quiz
+	LegacyList bool
+	// DuplicateThreshold is the trigram similarity from which questions count as near-duplicates
+	DuplicateThreshold float64
+	// DefaultLocale is the locale quizzes are written in; other locales are translations
+	DefaultLocale string
+	// Location is the time zone schedule times without a UTC offset are read in; nil means UTC
+	Location *time.Location
+	// Events receives quiz changes and visibility changes and feeds the
+	// event stream; nil drops them
+	Events *events.EventBus
 }
 
 // NewModule initializes the quiz module with all dependencies
-func NewModule(db *sqlx.DB) *Module {
+func NewModule(db *sqlx.DB, opts Options) *Module {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
 	repo := infrastructure.NewPostgresQuizRepository(db)
-	service := application.NewQuizService(repo)
+	revisionRepo := infrastructure.NewPostgresRevisionRepository(db)
+	mediaRepo := infrastructure.NewPostgresMediaRepository(db)
+	translationRepo := infrastructure.NewPostgresTranslationRepository(db)
+	transitionRepo := infrastructure.NewPostgresTransitionRepository(db)
+	hintRepo := infrastructure.NewPostgresHintRepository(db)
+	txManager := database.NewTxManager(db)
+	service := application.NewQuizService(repo, revisionRepo, transitionRepo, txManager, opts.DuplicateThreshold, opts.Events)
+	interchange := application.NewInterchangeService(repo, revisionRepo, mediaRepo, txManager, format.Codecs(), opts.DuplicateThreshold, opts.Events)
 
 	return &Module{
-		Service: service,
+		Service:      service,
+		Interchange:  interchange,
+		Media:        application.NewMediaService(repo, mediaRepo),
+		Revisions:    application.NewRevisionService(repo, revisionRepo, transitionRepo, txManager, opts.Events),
+		Trash:        application.NewTrashService(repo, txManager, opts.TrashRetention, opts.Events),
+		Batch:        application.NewBatchService(repo, revisionRepo, txManager, opts.MaxBatchSize, opts.DuplicateThreshold, opts.Events),
+		Search:       application.NewSearchService(repo),
+		Duplicates:   application.NewDuplicateService(repo, opts.DuplicateThreshold),
+		Translations: application.NewTranslationService(repo, translationRepo, opts.DefaultLocale),
+		Hints:        application.NewHintService(repo, hintRepo, txManager),
+		Workflow:     application.NewWorkflowService(repo, transitionRepo, txManager, opts.Events),
+		Schedules:    application.NewScheduleService(repo, opts.Location, opts.Events),
+		Clones:       application.NewCloneService(repo, revisionRepo, mediaRepo, translationRepo, hintRepo, txManager, opts.Events),
+		Events:       application.NewEventStream(opts.Events),
+		legacyList:   opts.LegacyList,
 	}
 }
 
 // RegisterRoutes registers the module's HTTP routes
 func (m *Module) RegisterRoutes(r chi.Router) {
-	httpinterface.RegisterRoutes(r, m.Service)
+	httpinterface.RegisterRoutes(r, httpinterface.Services{
+		Quiz:         m.Service,
+		Interchange:  m.Interchange,
+		Media:        m.Media,
+		Revisions:    m.Revisions,
+		Trash:        m.Trash,
+		Batch:        m.Batch,
+		Search:       m.Search,
+		Duplicates:   m.Duplicates,
+		Translations: m.Translations,
+		Hints:        m.Hints,
+		Workflow:     m.Workflow,
+		Schedules:    m.Schedules,
+		Clones:       m.Clones,
+		Events:       m.Events,
+	}, m.legacyList)
+}
+
+// CloseEvents ends the open event streams so the server can shut down
+func (m *Module) CloseEvents() {
+	m.Events.Close()
+}
+
+// RunTrashPurge purges expired quizzes from the trash every interval until ctx is done
+func (m *Module) RunTrashPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := m.Trash.PurgeExpired(ctx)
+		if err != nil {
+			slog.Error("Failed to purge quiz trash", "error", err)
+		} else if n > 0 {
+			slog.Info("Purged deleted quizzes", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunVisibilitySync records quiz visibility every interval until ctx is
+// done, publishing an event for each quiz that appeared or disappeared
+func (m *Module) RunVisibilitySync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := m.Schedules.SyncVisibility(ctx)
+		if err != nil {
+			slog.Error("Failed to sync quiz visibility", "error", err)
+		} else if n > 0 {
+			slog.Info("Quiz visibility changed", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
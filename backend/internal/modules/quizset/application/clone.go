@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// CloneService defines deep copies of quiz sets
+type CloneService interface {
+	Clone(ctx context.Context, id string, req CloneQuizSetRequest) (*CloneQuizSetResponse, error)
+}
+
+type cloneService struct {
+	repo      domain.QuizSetRepository
+	quizzes   domain.QuizCloner
+	txManager database.TxManager
+	location  *time.Location
+}
+
+// NewCloneService creates a new CloneService copying quizzes through quizzes
+func NewCloneService(repo domain.QuizSetRepository, quizzes domain.QuizCloner, txManager database.TxManager, loc *time.Location) CloneService {
+	return &cloneService{repo: repo, quizzes: quizzes, txManager: txManager, location: loc}
+}
+
+// Clone copies a quiz set together with its quizzes, which become new drafts
+// in the same order. The copy has no schedule and is titled "<title> (copy)"
+// unless req names it.
+func (s *cloneService) Clone(ctx context.Context, id string, req CloneQuizSetRequest) (*CloneQuizSetResponse, error) {
+	source, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrQuizSetNotFound
+	}
+
+	set := &domain.QuizSet{ID: sharedDomain.NewID(), Title: strings.TrimSpace(req.Title), Description: source.Description, PassMark: source.PassMark}
+	if set.Title == "" {
+		set.Title = source.Title + " (copy)"
+	}
+
+	var ids map[string]string
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		ids, err = s.quizzes.CloneQuizzes(ctx, source.QuizIDs)
+		if err != nil {
+			return err
+		}
+		set.QuizIDs = make([]string, len(source.QuizIDs))
+		for i, quizID := range source.QuizIDs {
+			set.QuizIDs[i] = ids[quizID]
+		}
+
+		if err := s.repo.Create(ctx, set); err != nil {
+			return sharedDomain.NewInternalError("Failed to create quiz set", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids[source.ID] = set.ID
+	return &CloneQuizSetResponse{QuizSet: toQuizSetResponse(*set, s.location), IDMap: ids}, nil
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// stubQuizCloner maps every quiz to "<id>-copy"
+type stubQuizCloner struct {
+	cloned []string
+}
+
+func (c *stubQuizCloner) CloneQuizzes(_ context.Context, ids []string) (map[string]string, error) {
+	c.cloned = append(c.cloned, ids...)
+	out := make(map[string]string, len(ids))
+	for _, id := range ids {
+		out[id] = id + "-copy"
+	}
+	return out, nil
+}
+
+func TestCloneQuizSet(t *testing.T) {
+	publish := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo := newMockRepo()
+	repo.sets = []domain.QuizSet{{ID: "s1", Title: "Midterm", Description: "Term 1", QuizIDs: []string{"q2", "q1"},
+		Schedule: sharedDomain.Schedule{PublishAt: &publish}}}
+	quizzes := &stubQuizCloner{}
+	service := NewCloneService(repo, quizzes, passthroughTxManager{}, time.UTC)
+
+	resp, err := service.Clone(context.Background(), "s1", CloneQuizSetRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	set := resp.QuizSet
+	if set.ID == "s1" || resp.IDMap["s1"] != set.ID {
+		t.Fatalf("expected id_map to point s1 at the copy, got %v for %s", resp.IDMap, set.ID)
+	}
+	if set.Title != "Midterm (copy)" || set.Description != "Term 1" || set.PublishAt != nil {
+		t.Errorf("expected an unscheduled copy titled 'Midterm (copy)', got %+v", set)
+	}
+	if len(set.QuizIDs) != 2 || set.QuizIDs[0] != "q2-copy" || set.QuizIDs[1] != "q1-copy" {
+		t.Errorf("expected cloned quizzes in set order, got %v", set.QuizIDs)
+	}
+	if resp.IDMap["q1"] != "q1-copy" || len(repo.sets) != 2 {
+		t.Errorf("expected quiz mapping and a stored copy, got %v and %d sets", resp.IDMap, len(repo.sets))
+	}
+
+	resp, err = service.Clone(context.Background(), "s1", CloneQuizSetRequest{Title: " Final "})
+	if err != nil || resp.QuizSet.Title != "Final" {
+		t.Errorf("expected the requested title, got %v, %v", resp, err)
+	}
+}
+
+func TestCloneQuizSet_NotFound(t *testing.T) {
+	service := NewCloneService(newMockRepo(), &stubQuizCloner{}, passthroughTxManager{}, time.UTC)
+
+	if _, err := service.Clone(context.Background(), "missing", CloneQuizSetRequest{}); !errors.Is(err, domain.ErrQuizSetNotFound) {
+		t.Errorf("expected ErrQuizSetNotFound, got: %v", err)
+	}
+}
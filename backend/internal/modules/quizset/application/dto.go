@@ -0,0 +1,71 @@
+package application
+
+import (
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// QuizSetRequest DTO for creating or replacing a quiz set. PublishAt and
+// UnpublishAt bound when the set is visible; see quiz ScheduleRequest for
+// the accepted formats. PassMark is the percentage a fixed attempt needs to
+// pass and earn a certificate; without it the set awards none.
+type QuizSetRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	QuizIDs     []string `json:"quiz_ids"`
+	PassMark    *float64 `json:"pass_mark"`
+	PublishAt   string   `json:"publish_at"`
+	UnpublishAt string   `json:"unpublish_at"`
+}
+
+// QuizSetResponse DTO for quiz set responses
+type QuizSetResponse struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	QuizIDs     []string  `json:"quiz_ids"`
+	PassMark    *float64  `json:"pass_mark,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	sharedDomain.Schedule
+}
+
+// CloneQuizSetRequest DTO for copying a quiz set; an empty title keeps the
+// original title with " (copy)" appended
+type CloneQuizSetRequest struct {
+	Title string `json:"title"`
+}
+
+// CloneQuizSetResponse DTO for a copied quiz set. IDMap holds the new ID of
+// the set and of each of its quizzes and media files, keyed by the old one.
+type CloneQuizSetResponse struct {
+	QuizSet QuizSetResponse   `json:"quiz_set"`
+	IDMap   map[string]string `json:"id_map"`
+}
+
+// PrintResult holds a rendered document
+type PrintResult struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+	Seed        int64
+}
+
+func toQuizSetResponse(s domain.QuizSet, loc *time.Location) QuizSetResponse {
+	quizIDs := s.QuizIDs
+	if quizIDs == nil {
+		quizIDs = []string{}
+	}
+	return QuizSetResponse{
+		ID:          s.ID,
+		Title:       s.Title,
+		Description: s.Description,
+		QuizIDs:     quizIDs,
+		PassMark:    s.PassMark,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+		Schedule:    s.Schedule.In(loc),
+	}
+}
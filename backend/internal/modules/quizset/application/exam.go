@@ -0,0 +1,216 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// maxRandomSeed keeps generated seeds short enough to write on an exam paper
+const maxRandomSeed = 1_000_000
+
+// ExamService defines rendering quiz sets as printable exams
+type ExamService interface {
+	// RenderExam renders one form. A random seed is chosen when seed is nil.
+	RenderExam(ctx context.Context, setID, form string, seed *int64) (*PrintResult, error)
+
+	// RenderAnswerKey renders the answer key of a form printed with the given seed
+	RenderAnswerKey(ctx context.Context, setID, form string, seed int64) (*PrintResult, error)
+
+	// RenderPackage renders every form and its answer key into one zip archive
+	RenderPackage(ctx context.Context, setID string, forms []string, seed *int64) (*PrintResult, error)
+}
+
+type examService struct {
+	repo     domain.QuizSetRepository
+	renderer domain.ExamRenderer
+}
+
+// NewExamService creates a new ExamService
+func NewExamService(repo domain.QuizSetRepository, renderer domain.ExamRenderer) ExamService {
+	return &examService{repo: repo, renderer: renderer}
+}
+
+// RenderExam renders the question paper of one form
+func (s *examService) RenderExam(ctx context.Context, setID, label string, seed *int64) (*PrintResult, error) {
+	if err := authorizePrint(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateForms([]string{label}); err != nil {
+		return nil, err
+	}
+	actualSeed := seedOrRandom(seed)
+
+	forms, err := s.buildForms(ctx, setID, []string{label}, actualSeed)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := s.renderer.RenderExam(&buf, forms[0]); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to render exam", err)
+	}
+	return &PrintResult{
+		Filename:    "exam-" + label + s.renderer.FileExtension(),
+		ContentType: s.renderer.ContentType(),
+		Data:        buf.Bytes(),
+		Seed:        actualSeed,
+	}, nil
+}
+
+// RenderAnswerKey renders the answer key of one form
+func (s *examService) RenderAnswerKey(ctx context.Context, setID, label string, seed int64) (*PrintResult, error) {
+	if err := authorizePrint(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateForms([]string{label}); err != nil {
+		return nil, err
+	}
+
+	forms, err := s.buildForms(ctx, setID, []string{label}, seed)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAnswers(forms[0]); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := s.renderer.RenderAnswerKey(&buf, forms[0]); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to render answer key", err)
+	}
+	return &PrintResult{
+		Filename:    "answer-key-" + label + s.renderer.FileExtension(),
+		ContentType: s.renderer.ContentType(),
+		Data:        buf.Bytes(),
+		Seed:        seed,
+	}, nil
+}
+
+// RenderPackage renders exam-X and answer-key-X documents for every form into a zip archive
+func (s *examService) RenderPackage(ctx context.Context, setID string, labels []string, seed *int64) (*PrintResult, error) {
+	if err := authorizePrint(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateForms(labels); err != nil {
+		return nil, err
+	}
+	actualSeed := seedOrRandom(seed)
+
+	forms, err := s.buildForms(ctx, setID, labels, actualSeed)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAnswers(forms[0]); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, form := range forms {
+		if err := s.addToZip(zw, "exam-"+form.Label, form, s.renderer.RenderExam); err != nil {
+			return nil, sharedDomain.NewInternalError("Failed to render exam package", err)
+		}
+		if err := s.addToZip(zw, "answer-key-"+form.Label, form, s.renderer.RenderAnswerKey); err != nil {
+			return nil, sharedDomain.NewInternalError("Failed to render exam package", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to write exam package", err)
+	}
+
+	return &PrintResult{
+		Filename:    fmt.Sprintf("exam-%s-seed-%d.zip", strings.Join(labels, ""), actualSeed),
+		ContentType: "application/zip",
+		Data:        buf.Bytes(),
+		Seed:        actualSeed,
+	}, nil
+}
+
+func (s *examService) addToZip(zw *zip.Writer, name string, form *domain.ExamForm, render func(io.Writer, *domain.ExamForm) error) error {
+	fw, err := zw.Create(name + s.renderer.FileExtension())
+	if err != nil {
+		return err
+	}
+	return render(fw, form)
+}
+
+// buildForms loads the quiz set and shuffles one form per label
+func (s *examService) buildForms(ctx context.Context, setID string, labels []string, seed int64) ([]*domain.ExamForm, error) {
+	set, err := s.repo.GetByID(ctx, setID)
+	if err != nil {
+		return nil, domain.ErrQuizSetNotFound
+	}
+
+	quizzes, err := s.repo.GetQuizzes(ctx, setID)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quizzes", err)
+	}
+	if len(quizzes) == 0 {
+		return nil, domain.ErrEmptyQuizSet
+	}
+
+	forms := make([]*domain.ExamForm, len(labels))
+	for i, label := range labels {
+		forms[i] = domain.BuildExamForm(set, quizzes, label, seed)
+	}
+	return forms, nil
+}
+
+// authorizePrint returns nil if the current user may print quiz sets. Quiz
+// sets have no author, and printing reads quizzes in every status, so only
+// the reviewers and admins who manage them may print exams or answer keys.
+func authorizePrint(ctx context.Context) error {
+	if _, ok := utils.GetUserID(ctx); !ok {
+		return domain.ErrPrintSignIn
+	}
+	if !utils.CanReview(ctx) {
+		return domain.ErrPrintReviewer
+	}
+	return nil
+}
+
+// validateForms requires at least one form and distinct labels
+func validateForms(labels []string) error {
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if !domain.ValidFormLabel(label) || seen[label] {
+			return domain.ErrInvalidForms.WithDetails(map[string]interface{}{"forms": labels})
+		}
+		seen[label] = true
+	}
+	if len(labels) == 0 {
+		return domain.ErrInvalidForms
+	}
+	return nil
+}
+
+// checkAnswers fails when a question of the form has no correct answer.
+// All forms of a set share the same quizzes, so checking one is enough.
+func checkAnswers(form *domain.ExamForm) error {
+	var missing []string
+	for _, q := range form.Questions {
+		if q.Answer == 0 {
+			missing = append(missing, q.QuizID)
+		}
+	}
+	if len(missing) > 0 {
+		return domain.ErrMissingAnswer.WithDetails(map[string]interface{}{"quiz_ids": missing})
+	}
+	return nil
+}
+
+func seedOrRandom(seed *int64) int64 {
+	if seed != nil {
+		return *seed
+	}
+	return rand.Int64N(maxRandomSeed)
+}
@@ -0,0 +1,186 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	quizDomain "github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// reviewer may print quiz sets
+var reviewer = utils.SetUserRole(utils.SetUserID(context.Background(), "rita"), utils.RoleReviewer)
+
+// stubRenderer writes a short description of each form it renders
+type stubRenderer struct {
+	forms []*domain.ExamForm
+}
+
+func (r *stubRenderer) ContentType() string   { return "text/plain" }
+func (r *stubRenderer) FileExtension() string { return ".txt" }
+
+func (r *stubRenderer) RenderExam(w io.Writer, form *domain.ExamForm) error {
+	r.forms = append(r.forms, form)
+	_, err := fmt.Fprintf(w, "exam %s", form.Label)
+	return err
+}
+
+func (r *stubRenderer) RenderAnswerKey(w io.Writer, form *domain.ExamForm) error {
+	_, err := fmt.Fprintf(w, "key %s", form.Label)
+	return err
+}
+
+func newExamRepo(withAnswers bool) *mockQuizSetRepository {
+	repo := newMockRepo()
+	set := domain.QuizSet{ID: "s1", Title: "Final"}
+	for i := 1; i <= 10; i++ {
+		q := quizDomain.Quiz{
+			ID:       fmt.Sprintf("q%d", i),
+			Question: fmt.Sprintf("Question %d", i),
+			Choice1:  fmt.Sprintf("%d-1", i),
+			Choice2:  fmt.Sprintf("%d-2", i),
+			Choice3:  fmt.Sprintf("%d-3", i),
+			Choice4:  fmt.Sprintf("%d-4", i),
+		}
+		if withAnswers {
+			q.Answer = i%4 + 1
+		}
+		repo.quizzes = append(repo.quizzes, q)
+		set.QuizIDs = append(set.QuizIDs, q.ID)
+	}
+	repo.sets = []domain.QuizSet{set}
+	return repo
+}
+
+// ============ Test Cases ============
+
+func TestBuildExamForm_DeterministicShuffle(t *testing.T) {
+	repo := newExamRepo(true)
+	set := &repo.sets[0]
+
+	a1 := domain.BuildExamForm(set, repo.quizzes, "A", 42)
+	a2 := domain.BuildExamForm(set, repo.quizzes, "A", 42)
+	b := domain.BuildExamForm(set, repo.quizzes, "B", 42)
+
+	sameOrder := func(x, y *domain.ExamForm) bool {
+		for i := range x.Questions {
+			if x.Questions[i].QuizID != y.Questions[i].QuizID || x.Questions[i].Choices != y.Questions[i].Choices {
+				return false
+			}
+		}
+		return true
+	}
+	if !sameOrder(a1, a2) {
+		t.Error("expected the same label and seed to produce the same form")
+	}
+	if sameOrder(a1, b) {
+		t.Error("expected forms A and B to be shuffled differently")
+	}
+
+	for _, q := range b.Questions {
+		quiz := repo.quizzes[q.Source-1]
+		if quiz.ID != q.QuizID {
+			t.Fatalf("question %d: source %d does not point at quiz %s", q.Number, q.Source, q.QuizID)
+		}
+		if q.Choices[q.Answer-1] != quiz.Choices()[quiz.Answer-1] {
+			t.Errorf("question %d: answer %d does not point at the correct choice", q.Number, q.Answer)
+		}
+	}
+}
+
+func TestRenderExam_RandomSeedIsReported(t *testing.T) {
+	renderer := &stubRenderer{}
+	service := NewExamService(newExamRepo(false), renderer)
+
+	result, err := service.RenderExam(reviewer, "s1", "B", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if result.Filename != "exam-B.txt" || string(result.Data) != "exam B" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if renderer.forms[0].Seed != result.Seed {
+		t.Errorf("expected rendered seed %d to be reported, got %d", renderer.forms[0].Seed, result.Seed)
+	}
+}
+
+func TestRenderAnswerKey_RequiresAnswers(t *testing.T) {
+	service := NewExamService(newExamRepo(false), &stubRenderer{})
+
+	_, err := service.RenderAnswerKey(reviewer, "s1", "A", 1)
+
+	var appErr *sharedDomain.AppError
+	if !errors.As(err, &appErr) || appErr.Message != domain.ErrMissingAnswer.Message {
+		t.Fatalf("expected missing answer error, got %v", err)
+	}
+	if ids := appErr.Details.(map[string]interface{})["quiz_ids"].([]string); len(ids) != 10 {
+		t.Errorf("expected all 10 quizzes reported, got %v", ids)
+	}
+}
+
+func TestRenderPackage_ContainsEveryForm(t *testing.T) {
+	service := NewExamService(newExamRepo(true), &stubRenderer{})
+	seed := int64(7)
+
+	result, err := service.RenderPackage(reviewer, "s1", []string{"A", "B", "C"}, &seed)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(result.Data), int64(len(result.Data)))
+	if err != nil {
+		t.Fatalf("expected a zip archive, got: %v", err)
+	}
+	want := []string{"exam-A.txt", "answer-key-A.txt", "exam-B.txt", "answer-key-B.txt", "exam-C.txt", "answer-key-C.txt"}
+	if len(zr.File) != len(want) {
+		t.Fatalf("expected %d files, got %d", len(want), len(zr.File))
+	}
+	for i, f := range zr.File {
+		if f.Name != want[i] {
+			t.Errorf("file %d: expected %s, got %s", i, want[i], f.Name)
+		}
+	}
+}
+
+func TestRenderPackage_InvalidForms(t *testing.T) {
+	service := NewExamService(newExamRepo(true), &stubRenderer{})
+
+	for _, forms := range [][]string{{}, {"A", "A"}, {"AB"}, {"1"}} {
+		if _, err := service.RenderPackage(reviewer, "s1", forms, nil); err == nil {
+			t.Errorf("expected error for forms %v, got nil", forms)
+		}
+	}
+}
+
+func TestRender_ReviewersOnly(t *testing.T) {
+	service := NewExamService(newExamRepo(true), &stubRenderer{})
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"anonymous", context.Background(), domain.ErrPrintSignIn},
+		{"user", utils.SetUserID(context.Background(), "bob"), domain.ErrPrintReviewer},
+		{"admin", utils.SetUserRole(utils.SetUserID(context.Background(), "ada"), utils.RoleAdmin), nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := service.RenderExam(tc.ctx, "s1", "A", nil); !errors.Is(err, tc.want) {
+				t.Errorf("exam: expected %v, got %v", tc.want, err)
+			}
+			if _, err := service.RenderAnswerKey(tc.ctx, "s1", "A", 1); !errors.Is(err, tc.want) {
+				t.Errorf("answer key: expected %v, got %v", tc.want, err)
+			}
+			if _, err := service.RenderPackage(tc.ctx, "s1", []string{"A"}, nil); !errors.Is(err, tc.want) {
+				t.Errorf("package: expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+)
+
+// QuizSetService defines the quiz set business logic interface
+type QuizSetService interface {
+	GetAll(ctx context.Context) ([]QuizSetResponse, error)
+	GetVisible(ctx context.Context) ([]QuizSetResponse, error)
+	GetByID(ctx context.Context, id string) (*QuizSetResponse, error)
+	GetVisibleByID(ctx context.Context, id string) (*QuizSetResponse, error)
+	Create(ctx context.Context, req QuizSetRequest) (*QuizSetResponse, error)
+	Update(ctx context.Context, id string, req QuizSetRequest) (*QuizSetResponse, error)
+	Delete(ctx context.Context, id string) error
+	SyncVisibility(ctx context.Context) (int, error)
+}
+
+type quizSetService struct {
+	repo      domain.QuizSetRepository
+	txManager database.TxManager
+	location  *time.Location
+	events    *events.EventBus
+	now       func() time.Time
+}
+
+// NewQuizSetService creates a new QuizSetService. Schedule times without a
+// UTC offset are read in loc, and responses are rendered in it. Visibility
+// changes are published on bus, which may be nil.
+func NewQuizSetService(repo domain.QuizSetRepository, txManager database.TxManager, loc *time.Location, bus *events.EventBus) QuizSetService {
+	return &quizSetService{repo: repo, txManager: txManager, location: loc, events: bus, now: time.Now}
+}
+
+// GetAll returns all quiz sets, whatever their schedule
+func (s *quizSetService) GetAll(ctx context.Context) ([]QuizSetResponse, error) {
+	return s.list(ctx, false)
+}
+
+// GetVisible returns the quiz sets inside their schedule
+func (s *quizSetService) GetVisible(ctx context.Context) ([]QuizSetResponse, error) {
+	return s.list(ctx, true)
+}
+
+func (s *quizSetService) list(ctx context.Context, visibleOnly bool) ([]QuizSetResponse, error) {
+	sets, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, sharedDomain.NewInternalError("Failed to fetch quiz sets", err)
+	}
+
+	now := s.now()
+	responses := make([]QuizSetResponse, 0, len(sets))
+	for _, set := range sets {
+		if visibleOnly && !set.IsVisible(now) {
+			continue
+		}
+		responses = append(responses, toQuizSetResponse(set, s.location))
+	}
+	return responses, nil
+}
+
+// GetByID returns a quiz set with its quiz IDs in order, whatever its schedule
+func (s *quizSetService) GetByID(ctx context.Context, id string) (*QuizSetResponse, error) {
+	set, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrQuizSetNotFound
+	}
+
+	resp := toQuizSetResponse(*set, s.location)
+	return &resp, nil
+}
+
+// GetVisibleByID returns a quiz set inside its schedule. Sets outside their
+// schedule are reported as not found.
+func (s *quizSetService) GetVisibleByID(ctx context.Context, id string) (*QuizSetResponse, error) {
+	set, err := s.repo.GetByID(ctx, id)
+	if err != nil || !set.IsVisible(s.now()) {
+		return nil, domain.ErrQuizSetNotFound
+	}
+
+	resp := toQuizSetResponse(*set, s.location)
+	return &resp, nil
+}
+
+// Create creates a quiz set from existing quizzes
+func (s *quizSetService) Create(ctx context.Context, req QuizSetRequest) (*QuizSetResponse, error) {
+	set := &domain.QuizSet{ID: sharedDomain.NewID()}
+
+	err := s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.apply(ctx, set, req); err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, set); err != nil {
+			return sharedDomain.NewInternalError("Failed to create quiz set", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toQuizSetResponse(*set, s.location)
+	return &resp, nil
+}
+
+// Update replaces the title, description, pass mark, schedule and quizzes of a quiz set
+func (s *quizSetService) Update(ctx context.Context, id string, req QuizSetRequest) (*QuizSetResponse, error) {
+	set, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrQuizSetNotFound
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.apply(ctx, set, req); err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, set); err != nil {
+			return sharedDomain.NewInternalError("Failed to update quiz set", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toQuizSetResponse(*set, s.location)
+	return &resp, nil
+}
+
+// Delete removes a quiz set; its quizzes are kept
+func (s *quizSetService) Delete(ctx context.Context, id string) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return domain.ErrQuizSetNotFound
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return sharedDomain.NewInternalError("Failed to delete quiz set", err)
+	}
+	return nil
+}
+
+// SyncVisibility records which quiz sets are visible now and publishes a
+// QuizSetVisibilityChangedEvent for each set that appeared or disappeared
+// since the last run. It returns the number of changes. A failing
+// subscriber does not stop the other events from being published.
+func (s *quizSetService) SyncVisibility(ctx context.Context) (int, error) {
+	changes, err := s.repo.SyncVisibility(ctx)
+	if err != nil {
+		return 0, sharedDomain.NewInternalError("Failed to sync quiz set visibility", err)
+	}
+	if s.events == nil {
+		return len(changes), nil
+	}
+
+	var errs []error
+	at := s.now()
+	for _, c := range changes {
+		if err := s.events.Publish(ctx, events.QuizSetVisibilityChangedEvent{QuizSetID: c.ID, Visible: c.Visible, At: at}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return len(changes), sharedDomain.NewInternalError("Failed to publish quiz set visibility changes", errors.Join(errs...))
+	}
+	return len(changes), nil
+}
+
+// apply validates req and copies it onto set
+func (s *quizSetService) apply(ctx context.Context, set *domain.QuizSet, req QuizSetRequest) error {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return domain.ErrInvalidQuizSet
+	}
+	if req.PassMark != nil && (*req.PassMark <= 0 || *req.PassMark > 100) {
+		return domain.ErrInvalidPassMark
+	}
+	schedule, err := sharedDomain.NewSchedule(req.PublishAt, req.UnpublishAt, s.location)
+	if err != nil {
+		return err
+	}
+
+	quizIDs := make([]string, 0, len(req.QuizIDs))
+	seen := make(map[string]bool, len(req.QuizIDs))
+	for _, id := range req.QuizIDs {
+		id = strings.TrimSpace(id)
+		if seen[id] {
+			return domain.ErrDuplicateQuiz.WithDetails(map[string]interface{}{"quiz_id": id})
+		}
+		seen[id] = true
+		quizIDs = append(quizIDs, id)
+	}
+
+	if len(quizIDs) > 0 {
+		missing, err := s.repo.MissingQuizIDs(ctx, quizIDs)
+		if err != nil {
+			return sharedDomain.NewInternalError("Failed to check quizzes", err)
+		}
+		if len(missing) > 0 {
+			return domain.ErrUnknownQuiz.WithDetails(map[string]interface{}{"quiz_ids": missing})
+		}
+	}
+
+	set.Title = title
+	set.Description = strings.TrimSpace(req.Description)
+	set.QuizIDs = quizIDs
+	set.PassMark = req.PassMark
+	set.Schedule = schedule
+	return nil
+}
@@ -0,0 +1,262 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	quizDomain "github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+)
+
+// passthroughTxManager runs fn without a real transaction
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// mockQuizSetRepository is an in-memory implementation of domain.QuizSetRepository
+type mockQuizSetRepository struct {
+	sets    []domain.QuizSet
+	quizzes []quizDomain.Quiz
+	// now stands in for NOW() when evaluating schedules
+	now time.Time
+	// visible is the visibility last stored by SyncVisibility
+	visible map[string]bool
+}
+
+func newMockRepo() *mockQuizSetRepository {
+	return &mockQuizSetRepository{}
+}
+
+func (m *mockQuizSetRepository) GetAll(_ context.Context) ([]domain.QuizSet, error) {
+	return m.sets, nil
+}
+
+func (m *mockQuizSetRepository) GetByID(_ context.Context, id string) (*domain.QuizSet, error) {
+	for _, s := range m.sets {
+		if s.ID == id {
+			return &s, nil
+		}
+	}
+	return nil, domain.ErrQuizSetNotFound
+}
+
+func (m *mockQuizSetRepository) Create(_ context.Context, set *domain.QuizSet) error {
+	m.sets = append(m.sets, *set)
+	return nil
+}
+
+func (m *mockQuizSetRepository) Update(_ context.Context, set *domain.QuizSet) error {
+	for i := range m.sets {
+		if m.sets[i].ID == set.ID {
+			m.sets[i] = *set
+			return nil
+		}
+	}
+	return domain.ErrQuizSetNotFound
+}
+
+func (m *mockQuizSetRepository) Delete(_ context.Context, id string) error {
+	for i := range m.sets {
+		if m.sets[i].ID == id {
+			m.sets = append(m.sets[:i], m.sets[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrQuizSetNotFound
+}
+
+func (m *mockQuizSetRepository) MissingQuizIDs(_ context.Context, quizIDs []string) ([]string, error) {
+	missing := []string{}
+	for _, id := range quizIDs {
+		if m.quiz(id) == nil {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+func (m *mockQuizSetRepository) SyncVisibility(_ context.Context) ([]sharedDomain.VisibilityChange, error) {
+	if m.visible == nil {
+		m.visible = map[string]bool{}
+	}
+	changes := []sharedDomain.VisibilityChange{}
+	for _, s := range m.sets {
+		if visible := s.IsVisible(m.now); m.visible[s.ID] != visible {
+			m.visible[s.ID] = visible
+			changes = append(changes, sharedDomain.VisibilityChange{ID: s.ID, Visible: visible})
+		}
+	}
+	return changes, nil
+}
+
+func (m *mockQuizSetRepository) GetQuizzes(ctx context.Context, setID string) ([]quizDomain.Quiz, error) {
+	set, err := m.GetByID(ctx, setID)
+	if err != nil {
+		return nil, err
+	}
+	quizzes := []quizDomain.Quiz{}
+	for _, id := range set.QuizIDs {
+		quizzes = append(quizzes, *m.quiz(id))
+	}
+	return quizzes, nil
+}
+
+func (m *mockQuizSetRepository) quiz(id string) *quizDomain.Quiz {
+	for i := range m.quizzes {
+		if m.quizzes[i].ID == id {
+			return &m.quizzes[i]
+		}
+	}
+	return nil
+}
+
+// ============ Test Cases ============
+
+func TestCreateQuizSet_Success(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []quizDomain.Quiz{{ID: "a"}, {ID: "b"}}
+	service := NewQuizSetService(repo, passthroughTxManager{}, time.UTC, nil)
+
+	resp, err := service.Create(context.Background(), QuizSetRequest{Title: "  Midterm ", QuizIDs: []string{"b", "a"}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.ID == "" || resp.Title != "Midterm" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if len(resp.QuizIDs) != 2 || resp.QuizIDs[0] != "b" {
+		t.Errorf("expected quiz order to be kept, got %v", resp.QuizIDs)
+	}
+	if len(repo.sets) != 1 {
+		t.Errorf("expected 1 stored set, got %d", len(repo.sets))
+	}
+}
+
+func TestCreateQuizSet_ValidationErrors(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []quizDomain.Quiz{{ID: "a"}}
+	service := NewQuizSetService(repo, passthroughTxManager{}, time.UTC, nil)
+
+	cases := map[string]QuizSetRequest{
+		"empty title":  {Title: " ", QuizIDs: []string{"a"}},
+		"duplicate":    {Title: "T", QuizIDs: []string{"a", "a"}},
+		"unknown quiz": {Title: "T", QuizIDs: []string{"a", "zzz"}},
+		"zero pass":    {Title: "T", QuizIDs: []string{"a"}, PassMark: new(float64)},
+	}
+	for name, req := range cases {
+		_, err := service.Create(context.Background(), req)
+
+		var appErr *sharedDomain.AppError
+		if !errors.As(err, &appErr) || appErr.Code != sharedDomain.ErrCodeValidation {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+	if len(repo.sets) != 0 {
+		t.Errorf("expected nothing stored, got %d sets", len(repo.sets))
+	}
+}
+
+func TestUpdateQuizSet_ReplacesQuizzes(t *testing.T) {
+	repo := newMockRepo()
+	repo.quizzes = []quizDomain.Quiz{{ID: "a"}, {ID: "b"}}
+	repo.sets = []domain.QuizSet{{ID: "s1", Title: "Old", QuizIDs: []string{"a"}}}
+	service := NewQuizSetService(repo, passthroughTxManager{}, time.UTC, nil)
+
+	resp, err := service.Update(context.Background(), "s1", QuizSetRequest{Title: "New", QuizIDs: []string{"b"}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if resp.Title != "New" || len(repo.sets[0].QuizIDs) != 1 || repo.sets[0].QuizIDs[0] != "b" {
+		t.Errorf("unexpected stored set %+v", repo.sets[0])
+	}
+}
+
+func TestDeleteQuizSet_NotFound(t *testing.T) {
+	service := NewQuizSetService(newMockRepo(), passthroughTxManager{}, time.UTC, nil)
+
+	err := service.Delete(context.Background(), "missing")
+	if !errors.Is(err, domain.ErrQuizSetNotFound) {
+		t.Errorf("expected ErrQuizSetNotFound, got %v", err)
+	}
+}
+
+func TestQuizSetSchedule_Visibility(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	repo := newMockRepo()
+	service := NewQuizSetService(repo, passthroughTxManager{}, bangkok, nil).(*quizSetService)
+
+	// Exam day runs from 09:00 to 12:00 local time
+	resp, err := service.Create(context.Background(), QuizSetRequest{Title: "Final", PublishAt: "2025-03-01T09:00", UnpublishAt: "2025-03-01 12:00"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if want := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC); !resp.PublishAt.Equal(want) || resp.PublishAt.Location() != bangkok {
+		t.Errorf("expected publish_at 09:00 in the schedule zone, got %v", resp.PublishAt)
+	}
+
+	for at, want := range map[string]bool{"08:59": false, "09:00": true, "11:59": true, "12:00": false} {
+		now, _ := time.ParseInLocation("2006-01-02 15:04", "2025-03-01 "+at, bangkok)
+		service.now = func() time.Time { return now }
+
+		sets, _ := service.GetVisible(context.Background())
+		_, err := service.GetVisibleByID(context.Background(), resp.ID)
+		if (len(sets) == 1) != want || (err == nil) != want {
+			t.Errorf("%s: expected visible=%v, got %d sets and %v", at, want, len(sets), err)
+		}
+		if !want && !errors.Is(err, domain.ErrQuizSetNotFound) {
+			t.Errorf("%s: expected ErrQuizSetNotFound, got %v", at, err)
+		}
+	}
+
+	all, _ := service.GetAll(context.Background())
+	if _, err := service.GetByID(context.Background(), resp.ID); len(all) != 1 || err != nil {
+		t.Errorf("expected managers to see the set outside its schedule, got %d sets and %v", len(all), err)
+	}
+}
+
+func TestQuizSetSchedule_Invalid(t *testing.T) {
+	service := NewQuizSetService(newMockRepo(), passthroughTxManager{}, time.UTC, nil)
+
+	_, err := service.Create(context.Background(), QuizSetRequest{Title: "T", PublishAt: "2025-03-01T12:00", UnpublishAt: "2025-03-01T09:00"})
+	if !errors.Is(err, sharedDomain.ErrInvalidSchedule) {
+		t.Errorf("expected ErrInvalidSchedule, got %v", err)
+	}
+}
+
+func TestQuizSetSyncVisibility_PublishesChanges(t *testing.T) {
+	bus := events.NewEventBus()
+	var received []events.QuizSetVisibilityChangedEvent
+	bus.Subscribe(events.QuizSetVisibilityChangedEvent{}.Name(), func(_ context.Context, e events.Event) error {
+		received = append(received, e.(events.QuizSetVisibilityChangedEvent))
+		return nil
+	})
+
+	opens := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	repo := newMockRepo()
+	repo.sets = []domain.QuizSet{{ID: "s1", Schedule: sharedDomain.Schedule{PublishAt: &opens}}}
+	service := NewQuizSetService(repo, passthroughTxManager{}, time.UTC, bus)
+
+	for _, step := range []struct {
+		now  time.Time
+		want int
+	}{
+		{opens.Add(-time.Minute), 0},
+		{opens, 1},
+		{opens.Add(time.Minute), 0},
+	} {
+		repo.now = step.now
+		n, err := service.SyncVisibility(context.Background())
+		if err != nil || n != step.want {
+			t.Fatalf("at %v: expected %d changes, got %d (%v)", step.now, step.want, n, err)
+		}
+	}
+	if len(received) != 1 || received[0].QuizSetID != "s1" || !received[0].Visible {
+		t.Errorf("expected one event for s1 becoming visible, got %+v", received)
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// QuizSet is an ordered collection of quizzes that is taken or printed as one exam
+type QuizSet struct {
+	ID          string   `json:"id" db:"id"`
+	Title       string   `json:"title" db:"title"`
+	Description string   `json:"description" db:"description"`
+	QuizIDs     []string `json:"quiz_ids" db:"-"`
+	// PassMark is the percentage of the maximum score a fixed attempt needs
+	// to pass and earn a certificate; nil means the set awards none
+	PassMark  *float64  `json:"pass_mark,omitempty" db:"pass_mark"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// Schedule limits when the set is visible to takers
+	sharedDomain.Schedule
+}
+
+// IsVisible returns true if the set is visible to takers at t
+func (s *QuizSet) IsVisible(t time.Time) bool {
+	return s.Schedule.Contains(t)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+
+var (
+	ErrQuizSetNotFound = sharedDomain.NewNotFoundError("Quiz set not found")
+	ErrInvalidQuizSet  = sharedDomain.NewValidationError("Title is required")
+	ErrDuplicateQuiz   = sharedDomain.NewValidationError("A quiz can only appear once in a quiz set")
+	ErrUnknownQuiz     = sharedDomain.NewValidationError("Some quizzes do not exist")
+	ErrInvalidPassMark = sharedDomain.NewValidationError("pass_mark must be greater than 0 and at most 100")
+)
+
+// Printing errors
+var (
+	ErrEmptyQuizSet  = sharedDomain.NewValidationError("Quiz set has no quizzes to print")
+	ErrInvalidForms  = sharedDomain.NewValidationError("Forms must be distinct single letters A-Z")
+	ErrInvalidSeed   = sharedDomain.NewValidationError("Seed must be an integer")
+	ErrMissingAnswer = sharedDomain.NewValidationError("Some quizzes have no answer set, so no answer key can be printed")
+	ErrPrintSignIn   = sharedDomain.NewUnauthorizedError("Sign in to print quiz sets")
+	ErrPrintReviewer = sharedDomain.NewForbiddenError("Only reviewers and admins can print quiz sets and their answer keys")
+)
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"hash/fnv"
+	"io"
+	"math/rand/v2"
+
+	quizDomain "github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+)
+
+// ExamForm is one printed version of a quiz set. Every form of the same
+// set and seed contains the same questions in its own shuffled order.
+type ExamForm struct {
+	SetID     string
+	Title     string
+	Label     string
+	Seed      int64
+	Questions []ExamQuestion
+}
+
+// ExamQuestion is a question as printed on a form
+type ExamQuestion struct {
+	Number   int
+	QuizID   string
+	Source   int // 1-based position of the quiz in the quiz set
+	Question string
+	Choices  [quizDomain.NumChoices]string
+	Answer   int // 1-based position of the correct choice on this form, 0 if not set
+}
+
+// ExamRenderer renders exam forms to a printable document
+type ExamRenderer interface {
+	ContentType() string
+	FileExtension() string
+	RenderExam(w io.Writer, form *ExamForm) error
+	RenderAnswerKey(w io.Writer, form *ExamForm) error
+}
+
+// ValidFormLabel reports whether label can name a form
+func ValidFormLabel(label string) bool {
+	return len(label) == 1 && label[0] >= 'A' && label[0] <= 'Z'
+}
+
+// BuildExamForm shuffles the quizzes of a set into the form with the given
+// label. The same set, label and seed always produce the same form.
+func BuildExamForm(set *QuizSet, quizzes []quizDomain.Quiz, label string, seed int64) *ExamForm {
+	h := fnv.New64a()
+	h.Write([]byte(label))
+	rng := rand.New(rand.NewPCG(uint64(seed), h.Sum64()))
+
+	form := &ExamForm{
+		SetID:     set.ID,
+		Title:     set.Title,
+		Label:     label,
+		Seed:      seed,
+		Questions: make([]ExamQuestion, len(quizzes)),
+	}
+
+	for i, src := range rng.Perm(len(quizzes)) {
+		quiz := quizzes[src]
+		choices := quiz.Choices()
+		q := ExamQuestion{
+			Number:   i + 1,
+			QuizID:   quiz.ID,
+			Source:   src + 1,
+			Question: quiz.Question,
+		}
+		for pos, orig := range rng.Perm(quizDomain.NumChoices) {
+			q.Choices[pos] = choices[orig]
+			if quiz.HasAnswer() && orig == quiz.Answer-1 {
+				q.Answer = pos + 1
+			}
+		}
+		form.Questions[i] = q
+	}
+	return form
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+
+	quizDomain "github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// QuizSetRepository defines the interface for quiz set data access
+type QuizSetRepository interface {
+	// GetAll returns all quiz sets ordered by title, with their quiz IDs
+	GetAll(ctx context.Context) ([]QuizSet, error)
+
+	// GetByID returns a quiz set by its ID, with its quiz IDs
+	GetByID(ctx context.Context, id string) (*QuizSet, error)
+
+	// Create inserts a new quiz set and its items
+	Create(ctx context.Context, set *QuizSet) error
+
+	// Update changes the title, description, schedule and items of a quiz set
+	Update(ctx context.Context, set *QuizSet) error
+
+	// Delete removes a quiz set by its ID
+	Delete(ctx context.Context, id string) error
+
+	// MissingQuizIDs returns the given quiz IDs that do not exist
+	MissingQuizIDs(ctx context.Context, quizIDs []string) ([]string, error)
+
+	// SyncVisibility records the current visibility of every quiz set and
+	// returns the sets whose visibility changed since the last call
+	SyncVisibility(ctx context.Context) ([]sharedDomain.VisibilityChange, error)
+
+	// GetQuizzes returns the quizzes of a set in set order
+	GetQuizzes(ctx context.Context, setID string) ([]quizDomain.Quiz, error)
+}
+
+// QuizCloner copies quizzes into new drafts, returning the new ID of every
+// copied quiz and media file keyed by the old one
+type QuizCloner interface {
+	CloneQuizzes(ctx context.Context, ids []string) (map[string]string, error)
+}
@@ -0,0 +1,179 @@
+// Package printing renders exam forms as PDF documents
+package printing
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	"github.com/cananga-odorata/golang-template/pkg/pdf"
+)
+
+// Page layout in points
+const (
+	margin       = 56.0
+	bodySize     = 11.0
+	lineHeight   = 15.0
+	numberIndent = 24.0
+	choiceIndent = 20.0
+	blockSpacing = 10.0
+	footerSize   = 8.0
+)
+
+var choiceLetters = [...]string{"A", "B", "C", "D"}
+
+type pdfRenderer struct {
+	font pdf.Font
+}
+
+// NewPDFRenderer creates an ExamRenderer producing A4 PDF documents.
+// A nil font falls back to Helvetica, which cannot show Thai text.
+func NewPDFRenderer(font pdf.Font) domain.ExamRenderer {
+	return &pdfRenderer{font: font}
+}
+
+func (r *pdfRenderer) ContentType() string   { return "application/pdf" }
+func (r *pdfRenderer) FileExtension() string { return ".pdf" }
+
+// RenderExam writes the question paper of a form
+func (r *pdfRenderer) RenderExam(w io.Writer, form *domain.ExamForm) error {
+	l := r.newLayout(form, fmt.Sprintf("%s - Form %s", form.Title, form.Label))
+	l.titleBlock(form.Title, "Form "+form.Label)
+	l.text(margin, 10, "Name ______________________________    Student ID ________________    Score ______")
+	l.y += lineHeight
+	l.rule()
+
+	textWidth := l.contentWidth() - numberIndent
+	for _, q := range form.Questions {
+		question := pdf.WrapText(l.font, bodySize, textWidth, q.Question)
+		choices := make([][]string, len(q.Choices))
+		height := float64(len(question))*lineHeight + blockSpacing
+		for i, choice := range q.Choices {
+			choices[i] = pdf.WrapText(l.font, bodySize, textWidth-choiceIndent-numberIndent, choice)
+			height += float64(len(choices[i])) * lineHeight
+		}
+		l.reserve(height)
+
+		l.text(margin, bodySize, fmt.Sprintf("%d.", q.Number))
+		for _, line := range question {
+			l.text(margin+numberIndent, bodySize, line)
+			l.y += lineHeight
+		}
+		for i, lines := range choices {
+			x := margin + numberIndent + choiceIndent
+			l.text(x, bodySize, choiceLetters[i]+".")
+			for _, line := range lines {
+				l.text(x+numberIndent, bodySize, line)
+				l.y += lineHeight
+			}
+		}
+		l.y += blockSpacing
+	}
+
+	l.footers(fmt.Sprintf("Form %s  |  Seed %d", form.Label, form.Seed))
+	_, err := l.doc.WriteTo(w)
+	return err
+}
+
+// RenderAnswerKey writes the correct choice of every question on a form
+func (r *pdfRenderer) RenderAnswerKey(w io.Writer, form *domain.ExamForm) error {
+	l := r.newLayout(form, fmt.Sprintf("%s - Answer key, Form %s", form.Title, form.Label))
+	l.titleBlock(form.Title, "Answer key - Form "+form.Label)
+
+	columns := [...]float64{margin, margin + 80, margin + 160}
+	tableHeader := func() {
+		for i, heading := range [...]string{"Question", "Answer", "Set position"} {
+			l.text(columns[i], 10, heading)
+		}
+		l.y += lineHeight
+		l.rule()
+	}
+	tableHeader()
+
+	for _, q := range form.Questions {
+		if l.reserve(lineHeight) {
+			tableHeader()
+		}
+		l.text(columns[0], bodySize, fmt.Sprintf("%d", q.Number))
+		answer := "-"
+		if q.Answer > 0 {
+			answer = choiceLetters[q.Answer-1]
+		}
+		l.text(columns[1], bodySize, answer)
+		l.text(columns[2], bodySize, fmt.Sprintf("%d", q.Source))
+		l.y += lineHeight
+	}
+
+	l.footers(fmt.Sprintf("Answer key  |  Form %s  |  Seed %d", form.Label, form.Seed))
+	_, err := l.doc.WriteTo(w)
+	return err
+}
+
+// layout places content top to bottom, starting new pages as needed
+type layout struct {
+	doc     *pdf.Document
+	font    pdf.Font
+	page    *pdf.Page
+	heading string
+	y       float64
+}
+
+func (r *pdfRenderer) newLayout(form *domain.ExamForm, title string) *layout {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height, r.font)
+	doc.SetTitle(title)
+	l := &layout{doc: doc, font: doc.Font(), heading: fmt.Sprintf("%s - Form %s", form.Title, form.Label)}
+	l.page = doc.AddPage()
+	l.y = margin
+	return l
+}
+
+func (l *layout) contentWidth() float64 {
+	return pdf.A4Width - 2*margin
+}
+
+// text draws s with its baseline one font size below the current position
+func (l *layout) text(x, size float64, s string) {
+	l.page.Text(x, l.y+size, size, s)
+}
+
+func (l *layout) rule() {
+	l.page.Line(margin, l.y, pdf.A4Width-margin, l.y, 0.5)
+	l.y += blockSpacing
+}
+
+// titleBlock draws the document title with a right-aligned subtitle
+func (l *layout) titleBlock(title, subtitle string) {
+	subtitleWidth := l.font.Width(subtitle, 14)
+	lines := pdf.WrapText(l.font, 16, l.contentWidth()-subtitleWidth-blockSpacing, title)
+	l.text(pdf.A4Width-margin-subtitleWidth, 14, subtitle)
+	for _, line := range lines {
+		l.text(margin, 16, line)
+		l.y += 22
+	}
+	l.y += blockSpacing
+}
+
+// reserve starts a new page unless height points fit on the current one.
+// It reports whether a new page was started.
+func (l *layout) reserve(height float64) bool {
+	bottom := pdf.A4Height - margin
+	if l.y+height <= bottom {
+		return false
+	}
+	l.page = l.doc.AddPage()
+	l.y = margin
+	l.text(margin, 9, l.heading)
+	l.y += lineHeight
+	l.rule()
+	return true
+}
+
+// footers writes the page numbers once the page count is known
+func (l *layout) footers(label string) {
+	pages := l.doc.Pages()
+	for i, page := range pages {
+		footer := fmt.Sprintf("%s  |  Page %d of %d", label, i+1, len(pages))
+		x := (pdf.A4Width - l.font.Width(footer, footerSize)) / 2
+		page.Text(x, pdf.A4Height-margin/2, footerSize, footer)
+	}
+}
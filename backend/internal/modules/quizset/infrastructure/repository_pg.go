@@ -0,0 +1,184 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	quizDomain "github.com/cananga-odorata/golang-template/internal/modules/quiz/domain"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	sharedDomain "github.com/cananga-odorata/golang-template/internal/shared/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// visibleCondition is the SQL condition for quiz sets inside their schedule
+const visibleCondition = `((publish_at IS NULL OR publish_at <= NOW()) AND (unpublish_at IS NULL OR unpublish_at > NOW()))`
+
+type postgresQuizSetRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresQuizSetRepository creates a new PostgreSQL quiz set repository
+func NewPostgresQuizSetRepository(db *sqlx.DB) domain.QuizSetRepository {
+	return &postgresQuizSetRepository{db: db}
+}
+
+func (r *postgresQuizSetRepository) getQueryable(ctx context.Context) database.Queryable {
+	return database.GetQueryable(ctx, r.db)
+}
+
+// GetAll returns all quiz sets ordered by title, with their quiz IDs
+func (r *postgresQuizSetRepository) GetAll(ctx context.Context) ([]domain.QuizSet, error) {
+	sets := []domain.QuizSet{}
+	query := `SELECT id, title, description, pass_mark, publish_at, unpublish_at, created_at, updated_at FROM quiz_sets ORDER BY title ASC, created_at ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &sets, query); err != nil {
+		return nil, err
+	}
+
+	var items []struct {
+		QuizSetID string `db:"quiz_set_id"`
+		QuizID    string `db:"quiz_id"`
+	}
+	// Quizzes in the trash are hidden from their sets until restored
+	query = `SELECT i.quiz_set_id, i.quiz_id FROM quiz_set_items i JOIN quizzes q ON q.id = i.quiz_id
+	          WHERE q.deleted_at IS NULL ORDER BY i.quiz_set_id, i.position ASC`
+	if err := q.SelectContext(ctx, &items, query); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string][]string, len(sets))
+	for _, item := range items {
+		byID[item.QuizSetID] = append(byID[item.QuizSetID], item.QuizID)
+	}
+	for i := range sets {
+		sets[i].QuizIDs = byID[sets[i].ID]
+		if sets[i].QuizIDs == nil {
+			sets[i].QuizIDs = []string{}
+		}
+	}
+	return sets, nil
+}
+
+// GetByID returns a quiz set by its ID, with its quiz IDs
+func (r *postgresQuizSetRepository) GetByID(ctx context.Context, id string) (*domain.QuizSet, error) {
+	var set domain.QuizSet
+	query := `SELECT id, title, description, pass_mark, publish_at, unpublish_at, created_at, updated_at FROM quiz_sets WHERE id = $1`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, &set, query, id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrQuizSetNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	set.QuizIDs = []string{}
+	query = `SELECT i.quiz_id FROM quiz_set_items i JOIN quizzes q ON q.id = i.quiz_id
+	          WHERE i.quiz_set_id = $1 AND q.deleted_at IS NULL ORDER BY i.position ASC`
+	if err := q.SelectContext(ctx, &set.QuizIDs, query, id); err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// Create inserts a new quiz set and its items
+func (r *postgresQuizSetRepository) Create(ctx context.Context, set *domain.QuizSet) error {
+	query := `INSERT INTO quiz_sets (id, title, description, pass_mark, publish_at, unpublish_at, created_at, updated_at)
+	           VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+	           RETURNING created_at, updated_at`
+	q := r.getQueryable(ctx)
+	if err := q.GetContext(ctx, set, query, set.ID, set.Title, set.Description, set.PassMark, set.PublishAt, set.UnpublishAt); err != nil {
+		return err
+	}
+	return r.insertItems(ctx, set.ID, set.QuizIDs)
+}
+
+// Update changes the title, description, schedule and items of a quiz set
+func (r *postgresQuizSetRepository) Update(ctx context.Context, set *domain.QuizSet) error {
+	query := `UPDATE quiz_sets SET title = $2, description = $3, pass_mark = $4, publish_at = $5, unpublish_at = $6, updated_at = NOW()
+	           WHERE id = $1 RETURNING created_at, updated_at`
+	q := r.getQueryable(ctx)
+	err := q.GetContext(ctx, set, query, set.ID, set.Title, set.Description, set.PassMark, set.PublishAt, set.UnpublishAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrQuizSetNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	// Items of trashed quizzes are kept so they reappear when the quiz is restored
+	query = `DELETE FROM quiz_set_items i USING quizzes q
+	          WHERE i.quiz_set_id = $1 AND q.id = i.quiz_id AND q.deleted_at IS NULL`
+	if _, err := q.ExecContext(ctx, query, set.ID); err != nil {
+		return err
+	}
+	return r.insertItems(ctx, set.ID, set.QuizIDs)
+}
+
+// insertItems stores quiz IDs with their position in the set
+func (r *postgresQuizSetRepository) insertItems(ctx context.Context, setID string, quizIDs []string) error {
+	if len(quizIDs) == 0 {
+		return nil
+	}
+	query := `INSERT INTO quiz_set_items (quiz_set_id, quiz_id, position)
+	           SELECT $1, t.quiz_id, t.position FROM unnest($2::uuid[]) WITH ORDINALITY AS t(quiz_id, position)`
+	q := r.getQueryable(ctx)
+	_, err := q.ExecContext(ctx, query, setID, pq.Array(quizIDs))
+	return err
+}
+
+// Delete removes a quiz set by its ID
+func (r *postgresQuizSetRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM quiz_sets WHERE id = $1`
+	q := r.getQueryable(ctx)
+	result, err := q.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrQuizSetNotFound
+	}
+	return nil
+}
+
+// MissingQuizIDs returns the given quiz IDs that do not exist or are in the trash
+func (r *postgresQuizSetRepository) MissingQuizIDs(ctx context.Context, quizIDs []string) ([]string, error) {
+	missing := []string{}
+	query := `SELECT t.id FROM unnest($1::text[]) AS t(id)
+	           WHERE NOT EXISTS (SELECT 1 FROM quizzes q WHERE q.id::text = t.id AND q.deleted_at IS NULL)`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &missing, query, pq.Array(quizIDs)); err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+// SyncVisibility stores the current visibility of every quiz set whose
+// visibility differs from the one last stored, and returns those sets
+func (r *postgresQuizSetRepository) SyncVisibility(ctx context.Context) ([]sharedDomain.VisibilityChange, error) {
+	changes := []sharedDomain.VisibilityChange{}
+	query := `UPDATE quiz_sets SET visible = ` + visibleCondition + `
+	           WHERE visible IS DISTINCT FROM ` + visibleCondition + `
+	           RETURNING id, visible`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &changes, query); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// GetQuizzes returns the quizzes of a set in set order
+func (r *postgresQuizSetRepository) GetQuizzes(ctx context.Context, setID string) ([]quizDomain.Quiz, error) {
+	quizzes := []quizDomain.Quiz{}
+	query := `SELECT q.id, q.question, q.choice1, q.choice2, q.choice3, q.choice4, q.answer, q.display_order, q.revision_id, q.status, q.publish_at, q.unpublish_at, q.created_by, q.updated_by, q.created_at, q.updated_at
+	           FROM quiz_set_items i JOIN quizzes q ON q.id = i.quiz_id
+	           WHERE i.quiz_set_id = $1 AND q.deleted_at IS NULL ORDER BY i.position ASC`
+	q := r.getQueryable(ctx)
+	if err := q.SelectContext(ctx, &quizzes, query, setID); err != nil {
+		return nil, err
+	}
+	return quizzes, nil
+}
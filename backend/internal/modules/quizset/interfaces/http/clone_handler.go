@@ -0,0 +1,40 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// CloneHandler handles HTTP requests for copying quiz sets
+type CloneHandler struct {
+	service application.CloneService
+}
+
+// NewCloneHandler creates a new CloneHandler
+func NewCloneHandler(service application.CloneService) *CloneHandler {
+	return &CloneHandler{service: service}
+}
+
+// Clone handles POST /quiz-sets/{id}/clone; the body is optional
+func (h *CloneHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	var req application.CloneQuizSetRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	clone, err := h.service.Clone(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, clone)
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// ExamHandler handles HTTP requests for printable exams
+type ExamHandler struct {
+	service application.ExamService
+}
+
+// NewExamHandler creates a new ExamHandler
+func NewExamHandler(service application.ExamService) *ExamHandler {
+	return &ExamHandler{service: service}
+}
+
+// Exam handles GET /quiz-sets/{id}/exam.pdf?form=A&seed=42
+func (h *ExamHandler) Exam(w http.ResponseWriter, r *http.Request) {
+	seed, ok := parseSeed(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.RenderExam(r.Context(), chi.URLParam(r, "id"), formParam(r), seed)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	writeDocument(w, result)
+}
+
+// AnswerKey handles GET /quiz-sets/{id}/answer-key.pdf?form=A&seed=42
+func (h *ExamHandler) AnswerKey(w http.ResponseWriter, r *http.Request) {
+	seed, ok := parseSeed(w, r)
+	if !ok {
+		return
+	}
+	if seed == nil {
+		dto.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Query parameter 'seed' is required to match the printed exam")
+		return
+	}
+
+	result, err := h.service.RenderAnswerKey(r.Context(), chi.URLParam(r, "id"), formParam(r), *seed)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	writeDocument(w, result)
+}
+
+// Package handles GET /quiz-sets/{id}/exam-package?forms=A,B,C&seed=42
+func (h *ExamHandler) Package(w http.ResponseWriter, r *http.Request) {
+	seed, ok := parseSeed(w, r)
+	if !ok {
+		return
+	}
+
+	forms := []string{"A"}
+	if raw := r.URL.Query().Get("forms"); raw != "" {
+		forms = forms[:0]
+		for _, form := range strings.Split(raw, ",") {
+			forms = append(forms, strings.ToUpper(strings.TrimSpace(form)))
+		}
+	}
+
+	result, err := h.service.RenderPackage(r.Context(), chi.URLParam(r, "id"), forms, seed)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	writeDocument(w, result)
+}
+
+// formParam returns the requested form label, A by default
+func formParam(r *http.Request) string {
+	if form := r.URL.Query().Get("form"); form != "" {
+		return strings.ToUpper(strings.TrimSpace(form))
+	}
+	return "A"
+}
+
+// parseSeed reads the optional seed parameter, writing an error response when it is invalid
+func parseSeed(w http.ResponseWriter, r *http.Request) (*int64, bool) {
+	raw := r.URL.Query().Get("seed")
+	if raw == "" {
+		return nil, true
+	}
+	seed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		dto.ErrorFromAppError(w, domain.ErrInvalidSeed)
+		return nil, false
+	}
+	return &seed, true
+}
+
+// writeDocument sends a rendered document as a download. The seed is
+// returned so that answer keys can be generated for the same forms later.
+func writeDocument(w http.ResponseWriter, result *application.PrintResult) {
+	w.Header().Set("Content-Type", result.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": result.Filename}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(result.Data)))
+	w.Header().Set("X-Exam-Seed", strconv.FormatInt(result.Seed, 10))
+	w.WriteHeader(http.StatusOK)
+	w.Write(result.Data)
+}
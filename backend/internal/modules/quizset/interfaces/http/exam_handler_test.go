@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/application"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockExamService is a mock implementation of application.ExamService
+type mockExamService struct {
+	form  string
+	forms []string
+	seed  *int64
+}
+
+func (m *mockExamService) RenderExam(_ context.Context, _, form string, seed *int64) (*application.PrintResult, error) {
+	m.form, m.seed = form, seed
+	return &application.PrintResult{Filename: "exam-" + form + ".pdf", ContentType: "application/pdf", Data: []byte("%PDF"), Seed: 99}, nil
+}
+
+func (m *mockExamService) RenderAnswerKey(_ context.Context, _, form string, seed int64) (*application.PrintResult, error) {
+	m.form, m.seed = form, &seed
+	return &application.PrintResult{Filename: "answer-key-" + form + ".pdf", ContentType: "application/pdf", Data: []byte("%PDF"), Seed: seed}, nil
+}
+
+func (m *mockExamService) RenderPackage(_ context.Context, _ string, forms []string, seed *int64) (*application.PrintResult, error) {
+	m.forms, m.seed = forms, seed
+	return &application.PrintResult{Filename: "exam.zip", ContentType: "application/zip", Data: []byte("PK"), Seed: 5}, nil
+}
+
+func serveExam(svc application.ExamService, target string) *httptest.ResponseRecorder {
+	r := chi.NewRouter()
+	RegisterRoutes(r, nil, svc, nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+// ============ Test Cases ============
+
+func TestExamHandler_DefaultsAndSeedHeader(t *testing.T) {
+	svc := &mockExamService{}
+	rec := serveExam(svc, "/quiz-sets/s1/exam.pdf?form=b")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if svc.form != "B" || svc.seed != nil {
+		t.Errorf("expected form B without seed, got %q %v", svc.form, svc.seed)
+	}
+	if rec.Header().Get("X-Exam-Seed") != "99" || rec.Header().Get("Content-Type") != "application/pdf" {
+		t.Errorf("unexpected headers %v", rec.Header())
+	}
+	if rec.Header().Get("Content-Disposition") != `attachment; filename=exam-B.pdf` {
+		t.Errorf("unexpected Content-Disposition %q", rec.Header().Get("Content-Disposition"))
+	}
+}
+
+func TestAnswerKeyHandler_RequiresSeed(t *testing.T) {
+	rec := serveExam(&mockExamService{}, "/quiz-sets/s1/answer-key.pdf?form=A")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+
+	rec = serveExam(&mockExamService{}, "/quiz-sets/s1/answer-key.pdf?form=A&seed=abc")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid seed, got %d", rec.Code)
+	}
+}
+
+func TestPackageHandler_ParsesForms(t *testing.T) {
+	svc := &mockExamService{}
+	rec := serveExam(svc, "/quiz-sets/s1/exam-package?forms=a,%20b,C&seed=12")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if len(svc.forms) != 3 || svc.forms[1] != "B" || svc.seed == nil || *svc.seed != 12 {
+		t.Errorf("unexpected forms %v seed %v", svc.forms, svc.seed)
+	}
+}
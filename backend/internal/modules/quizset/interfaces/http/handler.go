@@ -0,0 +1,108 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/application"
+	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+// QuizSetHandler handles HTTP requests for quiz sets
+type QuizSetHandler struct {
+	service application.QuizSetService
+}
+
+// NewQuizSetHandler creates a new QuizSetHandler
+func NewQuizSetHandler(service application.QuizSetService) *QuizSetHandler {
+	return &QuizSetHandler{service: service}
+}
+
+// List handles GET /quiz-sets, which returns the sets inside their schedule
+func (h *QuizSetHandler) List(w http.ResponseWriter, r *http.Request) {
+	sets, err := h.service.GetVisible(r.Context())
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, sets)
+}
+
+// Manage handles GET /quiz-sets/manage, which returns every set whatever its schedule
+func (h *QuizSetHandler) Manage(w http.ResponseWriter, r *http.Request) {
+	sets, err := h.service.GetAll(r.Context())
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, sets)
+}
+
+// GetByID handles GET /quiz-sets/{id}. Sets outside their schedule are not found.
+func (h *QuizSetHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	set, err := h.service.GetVisibleByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, set)
+}
+
+// ManageByID handles GET /quiz-sets/manage/{id}, whatever the set's schedule
+func (h *QuizSetHandler) ManageByID(w http.ResponseWriter, r *http.Request) {
+	set, err := h.service.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, set)
+}
+
+// Create handles POST /quiz-sets
+func (h *QuizSetHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req application.QuizSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	set, err := h.service.Create(r.Context(), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.Created(w, set)
+}
+
+// Update handles PUT /quiz-sets/{id}
+func (h *QuizSetHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req application.QuizSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dto.Error(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request payload")
+		return
+	}
+
+	set, err := h.service.Update(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.OK(w, set)
+}
+
+// Delete handles DELETE /quiz-sets/{id}
+func (h *QuizSetHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
+	dto.NoContent(w)
+}
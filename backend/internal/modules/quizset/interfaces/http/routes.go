@@ -0,0 +1,27 @@
+package http
+
+import (
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/application"
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterRoutes registers all quiz set module routes
+func RegisterRoutes(r chi.Router, service application.QuizSetService, exam application.ExamService, clone application.CloneService) {
+	handler := NewQuizSetHandler(service)
+	examHandler := NewExamHandler(exam)
+	cloneHandler := NewCloneHandler(clone)
+
+	r.Route("/quiz-sets", func(r chi.Router) {
+		r.Get("/", handler.List)
+		r.Post("/", handler.Create)
+		r.Get("/manage", handler.Manage)
+		r.Get("/manage/{id}", handler.ManageByID)
+		r.Get("/{id}", handler.GetByID)
+		r.Put("/{id}", handler.Update)
+		r.Delete("/{id}", handler.Delete)
+		r.Post("/{id}/clone", cloneHandler.Clone)
+		r.Get("/{id}/exam.pdf", examHandler.Exam)
+		r.Get("/{id}/answer-key.pdf", examHandler.AnswerKey)
+		r.Get("/{id}/exam-package", examHandler.Package)
+	})
+}
@@ -0,0 +1,80 @@
+package quizset
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/infra/database"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/domain"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/infrastructure"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset/infrastructure/printing"
+	httpinterface "github.com/cananga-odorata/golang-template/internal/modules/quizset/interfaces/http"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
+	"github.com/cananga-odorata/golang-template/pkg/pdf"
+	"github.com/go-chi/chi/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// Module represents the quiz set module with all its dependencies
+type Module struct {
+	Service application.QuizSetService
+	Exam    application.ExamService
+	Clones  application.CloneService
+}
+
+// Options configures the quiz set module
+type Options struct {
+	// FontPath optionally points to a TrueType font used for printed exams
+	FontPath string
+	// Location is the time zone schedule times without a UTC offset are read in; nil means UTC
+	Location *time.Location
+	// Events receives visibility changes; nil drops them
+	Events *events.EventBus
+	// Quizzes copies the quizzes of cloned sets
+	Quizzes domain.QuizCloner
+}
+
+// NewModule initializes the quiz set module with all dependencies
+func NewModule(db *sqlx.DB, opts Options) *Module {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	repo := infrastructure.NewPostgresQuizSetRepository(db)
+	txManager := database.NewTxManager(db)
+	renderer := printing.NewPDFRenderer(pdf.LoadFont(opts.FontPath))
+
+	return &Module{
+		Service: application.NewQuizSetService(repo, txManager, opts.Location, opts.Events),
+		Exam:    application.NewExamService(repo, renderer),
+		Clones:  application.NewCloneService(repo, opts.Quizzes, txManager, opts.Location),
+	}
+}
+
+// RegisterRoutes registers the module's HTTP routes
+func (m *Module) RegisterRoutes(r chi.Router) {
+	httpinterface.RegisterRoutes(r, m.Service, m.Exam, m.Clones)
+}
+
+// RunVisibilitySync records quiz set visibility every interval until ctx is
+// done, publishing an event for each set that appeared or disappeared
+func (m *Module) RunVisibilitySync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := m.Service.SyncVisibility(ctx)
+		if err != nil {
+			slog.Error("Failed to sync quiz set visibility", "error", err)
+		} else if n > 0 {
+			slog.Info("Quiz set visibility changed", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
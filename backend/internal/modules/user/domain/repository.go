@@ -1,6 +1,10 @@
 package domain
 
-import "context"
+import (
+	"context"
+
+	"github.com/cananga-odorata/golang-template/internal/shared/listquery"
+)
 
 // UserRepository defines the contract for user persistence
 type UserRepository interface {
@@ -12,12 +16,27 @@ type UserRepository interface {
 	List(ctx context.Context, filter UserFilter) ([]*User, int64, error)
 }
 
-// UserFilter holds filters for listing users
+// UserFilter holds filters for listing users. Query holds the sort and
+// filter parameters of the request; nil lists the newest users first.
 type UserFilter struct {
 	TenantID string
-	Role     *Role
-	Status   *Status
 	Search   string
+	Query    *listquery.Query
 	Limit    int
 	Offset   int
 }
+
+// UserListSchema lists the fields the user list can be sorted and filtered by
+var UserListSchema = listquery.NewSchema("-created_at",
+	listquery.Field{Name: "email", Column: "email", Type: listquery.String, Sortable: true, Ops: []listquery.Operator{listquery.OpEq, listquery.OpContains}},
+	listquery.Field{Name: "first_name", Column: "first_name", Type: listquery.String, Sortable: true, Ops: []listquery.Operator{listquery.OpEq, listquery.OpContains}},
+	listquery.Field{Name: "last_name", Column: "last_name", Type: listquery.String, Sortable: true, Ops: []listquery.Operator{listquery.OpEq, listquery.OpContains}},
+	listquery.Field{Name: "role", Column: "role", Type: listquery.String, Sortable: true, Ops: []listquery.Operator{listquery.OpEq, listquery.OpNe, listquery.OpIn},
+		Values: []string{string(RoleAdmin), string(RoleUser)}},
+	listquery.Field{Name: "status", Column: "status", Type: listquery.String, Sortable: true, Ops: []listquery.Operator{listquery.OpEq, listquery.OpNe, listquery.OpIn},
+		Values: []string{string(StatusActive), string(StatusInactive), string(StatusPending)}},
+	listquery.Field{Name: "created_at", Column: "created_at", Type: listquery.Time, Sortable: true,
+		Ops: []listquery.Operator{listquery.OpGt, listquery.OpGte, listquery.OpLt, listquery.OpLte}},
+	listquery.Field{Name: "updated_at", Column: "updated_at", Type: listquery.Time, Sortable: true,
+		Ops: []listquery.Operator{listquery.OpGt, listquery.OpGte, listquery.OpLt, listquery.OpLte}},
+)
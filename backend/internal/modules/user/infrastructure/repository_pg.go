@@ -93,24 +93,19 @@ func (r *postgresUserRepository) List(ctx context.Context, filter domain.UserFil
 		argIdx++
 	}
 
-	if filter.Role != nil {
-		whereClause += fmt.Sprintf(" AND role = $%d", argIdx)
-		args = append(args, *filter.Role)
-		argIdx++
-	}
-
-	if filter.Status != nil {
-		whereClause += fmt.Sprintf(" AND status = $%d", argIdx)
-		args = append(args, *filter.Status)
-		argIdx++
-	}
-
 	if filter.Search != "" {
 		whereClause += fmt.Sprintf(" AND (first_name ILIKE $%d OR last_name ILIKE $%d OR email ILIKE $%d)", argIdx, argIdx, argIdx)
 		args = append(args, "%"+filter.Search+"%")
 		argIdx++
 	}
 
+	listQuery := filter.Query
+	if listQuery == nil {
+		listQuery = domain.UserListSchema.Default()
+	}
+	whereClause += listQuery.Where(&args)
+	argIdx = len(args) + 1
+
 	// Count total
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM users %s", whereClause)
 	q := r.getQueryable(ctx)
@@ -119,7 +114,7 @@ func (r *postgresUserRepository) List(ctx context.Context, filter domain.UserFil
 	}
 
 	// Get paginated results
-	query := fmt.Sprintf("SELECT * FROM users %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d", whereClause, argIdx, argIdx+1)
+	query := fmt.Sprintf("SELECT * FROM users %s %s LIMIT $%d OFFSET $%d", whereClause, listQuery.OrderBy("id"), argIdx, argIdx+1)
 	args = append(args, filter.Limit, filter.Offset)
 
 	if err := q.SelectContext(ctx, &users, query, args...); err != nil {
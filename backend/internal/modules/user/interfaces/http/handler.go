@@ -8,6 +8,7 @@ import (
 	"github.com/cananga-odorata/golang-template/internal/modules/user/application"
 	"github.com/cananga-odorata/golang-template/internal/modules/user/domain"
 	"github.com/cananga-odorata/golang-template/internal/shared/dto"
+	"github.com/cananga-odorata/golang-template/internal/shared/listquery"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -101,22 +102,25 @@ func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 		pageSize = 20
 	}
 
+	// Sort and filter, e.g. ?sort=last_name&filter[role]=admin&filter[status][in]=active,pending.
+	// The older ?role= and ?status= parameters filter the same way.
+	query := r.URL.Query()
+	for _, legacy := range []string{"role", "status"} {
+		if value := query.Get(legacy); value != "" {
+			query.Add("filter["+legacy+"]", value)
+		}
+	}
+	listQuery, err := listquery.Parse(query, domain.UserListSchema)
+	if err != nil {
+		dto.ErrorFromAppError(w, err)
+		return
+	}
+
 	filter := domain.UserFilter{
 		Limit:  pageSize,
 		Offset: (page - 1) * pageSize,
 		Search: r.URL.Query().Get("search"),
-	}
-
-	// Optional role filter
-	if role := r.URL.Query().Get("role"); role != "" {
-		r := domain.Role(role)
-		filter.Role = &r
-	}
-
-	// Optional status filter
-	if status := r.URL.Query().Get("status"); status != "" {
-		s := domain.Status(status)
-		filter.Status = &s
+		Query:  listQuery,
 	}
 
 	users, total, err := h.service.List(r.Context(), filter)
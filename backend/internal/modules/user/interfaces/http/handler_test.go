@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/modules/user/application"
+	"github.com/cananga-odorata/golang-template/internal/modules/user/domain"
+)
+
+// mockUserService records the filter of the last list request
+type mockUserService struct {
+	application.UserService
+	listed *domain.UserFilter
+}
+
+func (m *mockUserService) List(_ context.Context, filter domain.UserFilter) ([]*application.UserResponse, int64, error) {
+	m.listed = &filter
+	return []*application.UserResponse{}, 0, nil
+}
+
+func listUsers(t *testing.T, target string) (*httptest.ResponseRecorder, *mockUserService) {
+	t.Helper()
+	svc := &mockUserService{}
+	rec := httptest.NewRecorder()
+	NewUserHandler(svc).List(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec, svc
+}
+
+// ============ Test Cases ============
+
+func TestListHandler_LegacyRoleParam(t *testing.T) {
+	rec, svc := listUsers(t, "/users?role=admin")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var args []interface{}
+	if where := svc.listed.Query.Where(&args); where != " AND role = $1" || args[0] != "admin" {
+		t.Errorf("expected a role filter, got %q %v", where, args)
+	}
+
+	if rec, _ := listUsers(t, "/users?role=owner"); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown role, got %d", rec.Code)
+	}
+}
+
+func TestListHandler_LegacyStatusParam(t *testing.T) {
+	rec, svc := listUsers(t, "/users?status=pending")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var args []interface{}
+	if where := svc.listed.Query.Where(&args); where != " AND status = $1" || args[0] != "pending" {
+		t.Errorf("expected a status filter, got %q %v", where, args)
+	}
+
+	if rec, _ := listUsers(t, "/users?status=deleted"); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown status, got %d", rec.Code)
+	}
+}
@@ -1,11 +1,22 @@
 package server
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/cananga-odorata/golang-template/internal/config"
+	"github.com/cananga-odorata/golang-template/internal/modules/assignment"
+	"github.com/cananga-odorata/golang-template/internal/modules/attempt"
+	"github.com/cananga-odorata/golang-template/internal/modules/certificate"
+	"github.com/cananga-odorata/golang-template/internal/modules/comment"
+	"github.com/cananga-odorata/golang-template/internal/modules/leaderboard"
+	"github.com/cananga-odorata/golang-template/internal/modules/live"
+	"github.com/cananga-odorata/golang-template/internal/modules/practice"
 	"github.com/cananga-odorata/golang-template/internal/modules/quiz"
+	"github.com/cananga-odorata/golang-template/internal/modules/quizset"
+	"github.com/cananga-odorata/golang-template/internal/shared/events"
 	"github.com/cananga-odorata/golang-template/internal/shared/middleware"
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
@@ -18,6 +29,10 @@ type Server struct {
 	Router *chi.Mux
 	Config *config.Config
 	DB     *sqlx.DB
+
+	quiz    *quiz.Module
+	quizSet *quizset.Module
+	live    *live.Module
 }
 
 // New creates a new server with all modules wired
@@ -31,13 +46,15 @@ func New(cfg *config.Config, db *sqlx.DB) *Server {
 	r.Use(chimiddleware.RequestID)
 	// Rate limiting
 	r.Use(middleware.RateLimitMiddleware(cfg.RateLimit, cfg.RateLimitBurst))
+	// Preferred locales from ?lang= or Accept-Language
+	r.Use(middleware.Locale(cfg.DefaultLocale))
 
 	// CORS
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   cfg.CORSOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Request-ID"},
-		ExposedHeaders:   []string{"Link", "X-Request-ID"},
+		ExposedHeaders:   []string{"Link", "X-Request-ID", "Content-Disposition", "X-Exam-Seed"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -45,27 +62,100 @@ func New(cfg *config.Config, db *sqlx.DB) *Server {
 	// Health check
 	r.Get("/health", healthHandler)
 
+	// Domain events
+	bus := events.NewEventBus()
+	bus.Subscribe(events.QuizCreatedEvent{}.Name(), logEvent)
+	bus.Subscribe(events.QuizUpdatedEvent{}.Name(), logEvent)
+	bus.Subscribe(events.QuizDeletedEvent{}.Name(), logEvent)
+	bus.Subscribe(events.QuizzesReorderedEvent{}.Name(), logEvent)
+	bus.Subscribe(events.QuizVisibilityChangedEvent{}.Name(), logEvent)
+	bus.Subscribe(events.QuizSetVisibilityChangedEvent{}.Name(), logEvent)
+	bus.Subscribe(events.CommentMentionedEvent{}.Name(), logEvent)
+	bus.Subscribe(events.AttemptSubmittedEvent{}.Name(), logEvent)
+
 	// Initialize modules
-	quizModule := quiz.NewModule(db)
+	quizModule := quiz.NewModule(db, quiz.Options{
+		TrashRetention:     cfg.TrashRetention(),
+		MaxBatchSize:       cfg.MaxBatchSize,
+		LegacyList:         cfg.LegacyQuizList,
+		DuplicateThreshold: cfg.DuplicateThreshold,
+		DefaultLocale:      cfg.DefaultLocale,
+		Location:           cfg.ScheduleLocation,
+		Events:             bus,
+	})
+	quizSetModule := quizset.NewModule(db, quizset.Options{
+		FontPath: cfg.PDFFontPath,
+		Location: cfg.ScheduleLocation,
+		Events:   bus,
+		Quizzes:  quizModule.Clones,
+	})
+	commentModule := comment.NewModule(db, bus)
+	assignmentModule := assignment.NewModule(db, cfg.ScheduleLocation)
+	attemptModule := attempt.NewModule(db, bus, assignmentModule.Service)
+	leaderboardModule := leaderboard.NewModule(db, bus, cfg.ScheduleLocation)
+	certificateModule := certificate.NewModule(db, certificate.Options{
+		FontPath: cfg.PDFFontPath,
+		Location: cfg.ScheduleLocation,
+		Events:   bus,
+	})
+	practiceModule := practice.NewModule(db)
+	liveModule := live.NewModule(db, cfg.CORSOrigins)
 
 	// API v1 routes
 	r.Route("/api/v1", func(api chi.Router) {
+		// Signed-in users are recorded as authors; anonymous requests stay allowed
+		api.Use(middleware.OptionalJWTAuth(cfg.JWTSecret))
+		api.Use(middleware.UserRoles(cfg.AdminUserIDs, cfg.ReviewerUserIDs))
+
 		// Quiz routes (public - no auth required for this assignment)
 		quizModule.RegisterRoutes(api)
+		quizSetModule.RegisterRoutes(api)
+		commentModule.RegisterRoutes(api)
+		attemptModule.RegisterRoutes(api)
+		assignmentModule.RegisterRoutes(api)
+		leaderboardModule.RegisterRoutes(api)
+		certificateModule.RegisterRoutes(api)
+		practiceModule.RegisterRoutes(api)
+		liveModule.RegisterRoutes(api)
 	})
 
 	slog.Info("Server initialized",
-		"modules", []string{"quiz"},
+		"modules", []string{"quiz", "quizset", "comment", "attempt", "assignment", "leaderboard", "certificate", "practice", "live"},
 		"environment", cfg.Environment,
 	)
 
 	return &Server{
-		Router: r,
-		Config: cfg,
-		DB:     db,
+		Router:  r,
+		Config:  cfg,
+		DB:      db,
+		quiz:    quizModule,
+		quizSet: quizSetModule,
+		live:    liveModule,
 	}
 }
 
+// StartJobs runs the background jobs of all modules until ctx is done
+func (s *Server) StartJobs(ctx context.Context) {
+	go s.quiz.RunTrashPurge(ctx, time.Hour)
+	go s.quiz.RunVisibilitySync(ctx, time.Minute)
+	go s.quizSet.RunVisibilitySync(ctx, time.Minute)
+	go s.live.RunExpiry(ctx, time.Minute)
+}
+
+// Shutdown ends the quiz event streams, which http.Server.Shutdown would
+// wait for, and the live sessions, whose WebSocket connections it does not
+// wait for
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.quiz.CloseEvents()
+	return s.live.Shutdown(ctx)
+}
+
+// logEvent records domain events in the server log
+func logEvent(_ context.Context, event events.Event) error {
+	slog.Info("Event", "name", event.Name(), "event", event)
+	return nil
+}
+
 func healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeCursor encodes a keyset position as an opaque, URL-safe cursor
+func EncodeCursor(position interface{}) string {
+	data, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes a cursor made by EncodeCursor into position
+func DecodeCursor(cursor string, position interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, position)
+}
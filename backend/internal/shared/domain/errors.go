@@ -16,9 +16,10 @@ const (
 
 // AppError represents a structured application error
 type AppError struct {
-	Code    ErrorCode `json:"code"`
-	Message string    `json:"message"`
-	Err     error     `json:"-"`
+	Code    ErrorCode   `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+	Err     error       `json:"-"`
 }
 
 func (e *AppError) Error() string {
@@ -32,6 +33,13 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// WithDetails returns a copy of the error carrying structured details for the client
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
 // HTTPStatus returns the corresponding HTTP status code
 func (e *AppError) HTTPStatus() int {
 	return ErrorCodeToHTTPStatus(e.Code)
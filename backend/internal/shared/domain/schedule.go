@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Schedule errors
+var (
+	ErrInvalidScheduleTime = NewValidationError("publish_at and unpublish_at must be RFC 3339 timestamps or local times such as 2025-03-01T09:00")
+	ErrInvalidSchedule     = NewValidationError("unpublish_at must be after publish_at")
+)
+
+// localTimeLayouts are the accepted layouts without a UTC offset, read in the
+// schedule's time zone
+var localTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// Schedule is the window in which content is visible. A nil bound leaves
+// that side of the window open.
+type Schedule struct {
+	PublishAt   *time.Time `json:"publish_at,omitempty" db:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty" db:"unpublish_at"`
+}
+
+// NewSchedule parses the bounds of a window. Empty bounds are open; times
+// without a UTC offset are read in loc.
+func NewSchedule(publishAt, unpublishAt string, loc *time.Location) (Schedule, error) {
+	var s Schedule
+	var err error
+	if s.PublishAt, err = ParseTime(publishAt, loc); err != nil {
+		return Schedule{}, ErrInvalidScheduleTime.WithDetails(map[string]interface{}{"field": "publish_at"})
+	}
+	if s.UnpublishAt, err = ParseTime(unpublishAt, loc); err != nil {
+		return Schedule{}, ErrInvalidScheduleTime.WithDetails(map[string]interface{}{"field": "unpublish_at"})
+	}
+	if s.PublishAt != nil && s.UnpublishAt != nil && !s.UnpublishAt.After(*s.PublishAt) {
+		return Schedule{}, ErrInvalidSchedule
+	}
+	return s, nil
+}
+
+// ParseTime parses an RFC 3339 timestamp, or a local time such as
+// 2025-03-01T09:00 read in loc. An empty string is nil.
+func ParseTime(raw string, loc *time.Location) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	var err error
+	for _, layout := range localTimeLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, raw, loc); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, err
+}
+
+// Contains returns true if t falls inside the window
+func (s Schedule) Contains(t time.Time) bool {
+	if s.PublishAt != nil && t.Before(*s.PublishAt) {
+		return false
+	}
+	return s.UnpublishAt == nil || t.Before(*s.UnpublishAt)
+}
+
+// In returns the schedule with its bounds expressed in loc
+func (s Schedule) In(loc *time.Location) Schedule {
+	in := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		local := t.In(loc)
+		return &local
+	}
+	return Schedule{PublishAt: in(s.PublishAt), UnpublishAt: in(s.UnpublishAt)}
+}
+
+// VisibilityChange reports that scheduled content became visible or hidden
+type VisibilityChange struct {
+	ID      string `db:"id"`
+	Visible bool   `db:"visible"`
+}
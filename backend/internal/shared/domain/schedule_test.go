@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSchedule_LocalTimesUseLocation(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+
+	s, err := NewSchedule("2025-03-01T09:00", "2025-03-01T12:00:00Z", bangkok)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !s.PublishAt.Equal(time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 09:00 in Bangkok to be 02:00 UTC, got %v", s.PublishAt.UTC())
+	}
+	if !s.UnpublishAt.Equal(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected an explicit offset to be kept, got %v", s.UnpublishAt.UTC())
+	}
+
+	for at, want := range map[time.Time]bool{
+		time.Date(2025, 3, 1, 1, 59, 0, 0, time.UTC): false,
+		time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC):  true,
+		time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC): false,
+	} {
+		if got := s.Contains(at); got != want {
+			t.Errorf("Contains(%v) = %v, want %v", at, got, want)
+		}
+	}
+}
+
+func TestNewSchedule_OpenBounds(t *testing.T) {
+	s, err := NewSchedule("", " ", time.UTC)
+	if err != nil || s.PublishAt != nil || s.UnpublishAt != nil {
+		t.Fatalf("expected an open schedule, got %+v, %v", s, err)
+	}
+	if !s.Contains(time.Now()) {
+		t.Error("expected an open schedule to contain every time")
+	}
+}
+
+func TestNewSchedule_Errors(t *testing.T) {
+	_, err := NewSchedule("tomorrow", "", time.UTC)
+
+	var appErr *AppError
+	if !errors.As(err, &appErr) || appErr.Message != ErrInvalidScheduleTime.Message {
+		t.Errorf("expected ErrInvalidScheduleTime, got %v", err)
+	}
+	if _, err := NewSchedule("2025-03-01T09:00", "2025-03-01T09:00", time.UTC); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("expected ErrInvalidSchedule, got %v", err)
+	}
+}
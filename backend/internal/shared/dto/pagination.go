@@ -1,6 +1,12 @@
 package dto
 
-import "github.com/cananga-odorata/golang-template/internal/shared/domain"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
 
 // PaginationRequest represents pagination query parameters
 type PaginationRequest struct {
@@ -13,22 +19,63 @@ func (r PaginationRequest) ToPagination() domain.Pagination {
 	return domain.NewPagination(r.Page, r.PageSize)
 }
 
-// PaginatedResponse wraps paginated data
+// PaginatedResponse wraps paginated data. Page is omitted when the list is
+// read with a cursor.
 type PaginatedResponse[T any] struct {
-	Data       []T   `json:"data"`
-	Page       int   `json:"page"`
-	PageSize   int   `json:"page_size"`
-	Total      int64 `json:"total"`
-	TotalPages int   `json:"total_pages"`
+	Items      []T       `json:"items"`
+	Page       int       `json:"page,omitempty"`
+	PageSize   int       `json:"page_size"`
+	Total      int64     `json:"total"`
+	TotalPages int       `json:"total_pages"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+	PrevCursor string    `json:"prev_cursor,omitempty"`
+	Links      PageLinks `json:"links"`
+}
+
+// PageLinks holds the URLs of the neighbouring pages, if any
+type PageLinks struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
 }
 
 // NewPaginatedResponse creates a paginated response
 func NewPaginatedResponse[T any](data []T, pagination domain.Pagination) PaginatedResponse[T] {
 	return PaginatedResponse[T]{
-		Data:       data,
+		Items:      data,
 		Page:       pagination.Page,
 		PageSize:   pagination.PageSize,
 		Total:      pagination.Total,
 		TotalPages: pagination.TotalPages(),
 	}
 }
+
+// PageURL returns the request URL with the given query parameters replaced.
+// Parameters set to an empty string are removed.
+func PageURL(r *http.Request, params map[string]string) string {
+	query := r.URL.Query()
+	for key, value := range params {
+		if value == "" {
+			query.Del(key)
+			continue
+		}
+		query.Set(key, value)
+	}
+
+	u := *r.URL
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
+
+// SetLinkHeader sets an RFC 8288 Link header for the neighbouring pages
+func SetLinkHeader(w http.ResponseWriter, links PageLinks) {
+	var parts []string
+	if links.Next != "" {
+		parts = append(parts, fmt.Sprintf(`<%s>; rel="next"`, links.Next))
+	}
+	if links.Prev != "" {
+		parts = append(parts, fmt.Sprintf(`<%s>; rel="prev"`, links.Prev))
+	}
+	if len(parts) > 0 {
+		w.Header().Set("Link", strings.Join(parts, ", "))
+	}
+}
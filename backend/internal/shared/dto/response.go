@@ -17,8 +17,9 @@ type Response struct {
 
 // ErrorBody represents the error details in a response
 type ErrorBody struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 // JSON sends a successful JSON response
@@ -44,11 +45,25 @@ func Error(w http.ResponseWriter, status int, code, message string) {
 	})
 }
 
+// ErrorWithDetails sends an error JSON response with structured details
+func ErrorWithDetails(w http.ResponseWriter, status int, code, message string, details interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Response{
+		Success: false,
+		Error: &ErrorBody{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
 // ErrorFromAppError sends an error response from AppError
 func ErrorFromAppError(w http.ResponseWriter, err error) {
 	var appErr *domain.AppError
 	if errors.As(err, &appErr) {
-		Error(w, appErr.HTTPStatus(), string(appErr.Code), appErr.Message)
+		ErrorWithDetails(w, appErr.HTTPStatus(), string(appErr.Code), appErr.Message, appErr.Details)
 		return
 	}
 	Error(w, http.StatusInternalServerError, string(domain.ErrCodeInternal), "Internal server error")
@@ -0,0 +1,83 @@
+package events
+
+import "time"
+
+// QuizVisibilityChangedEvent is published when a quiz enters or leaves the
+// public listing, because it was published, archived or reached a bound of
+// its schedule
+type QuizVisibilityChangedEvent struct {
+	QuizID  string
+	Visible bool
+	At      time.Time
+}
+
+func (e QuizVisibilityChangedEvent) Name() string { return "quiz.visibility_changed" }
+
+// QuizCreatedEvent is published when a quiz is added to the list, because it
+// was created, imported, cloned or restored from the trash
+type QuizCreatedEvent struct {
+	QuizID string
+	UserID *string
+}
+
+func (e QuizCreatedEvent) Name() string { return "quiz.created" }
+
+// QuizUpdatedEvent is published when a quiz's content, status or schedule changes
+type QuizUpdatedEvent struct {
+	QuizID string
+	UserID *string
+}
+
+func (e QuizUpdatedEvent) Name() string { return "quiz.updated" }
+
+// QuizDeletedEvent is published when a quiz is moved to the trash
+type QuizDeletedEvent struct {
+	QuizID string
+	UserID *string
+}
+
+func (e QuizDeletedEvent) Name() string { return "quiz.deleted" }
+
+// QuizzesReorderedEvent is published when the display order of quizzes
+// other than the one created or deleted changes
+type QuizzesReorderedEvent struct {
+	UserID *string
+}
+
+func (e QuizzesReorderedEvent) Name() string { return "quiz.reordered" }
+
+// QuizSetVisibilityChangedEvent is published when a quiz set reaches a bound
+// of its schedule
+type QuizSetVisibilityChangedEvent struct {
+	QuizSetID string
+	Visible   bool
+	At        time.Time
+}
+
+func (e QuizSetVisibilityChangedEvent) Name() string { return "quiz_set.visibility_changed" }
+
+// CommentMentionedEvent is published for every user mentioned in a new
+// reviewer comment
+type CommentMentionedEvent struct {
+	CommentID string
+	QuizID    string
+	UserID    string
+	AuthorID  *string
+}
+
+func (e CommentMentionedEvent) Name() string { return "comment.mentioned" }
+
+// AttemptSubmittedEvent is published when an attempt ends, because its last
+// question was answered, a stopping rule was met or the learner submitted it
+type AttemptSubmittedEvent struct {
+	AttemptID   string
+	QuizSetID   *string
+	UserID      *string
+	Mode        string
+	Score       float64
+	MaxScore    float64
+	StartedAt   time.Time
+	SubmittedAt time.Time
+}
+
+func (e AttemptSubmittedEvent) Name() string { return "attempt.submitted" }
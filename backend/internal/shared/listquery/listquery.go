@@ -0,0 +1,362 @@
+// Package listquery parses the sort and filter parameters of list endpoints
+// and turns them into parameterized SQL.
+//
+//	GET /things?sort=-created_at,name&filter[status]=active&filter[score][gte]=10
+//
+// Only the fields and operators a resource allows in its Schema are
+// accepted. Column names in the generated SQL come from the Schema, never
+// from the request; values are always passed as bind parameters.
+package listquery
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+// Errors returned by Parse. Details name the offending parameter.
+var (
+	ErrInvalidSort   = domain.NewValidationError("Invalid sort parameter")
+	ErrInvalidFilter = domain.NewValidationError("Invalid filter parameter")
+)
+
+// Operator is a filter comparison
+type Operator string
+
+const (
+	OpEq       Operator = "eq"
+	OpNe       Operator = "ne"
+	OpGt       Operator = "gt"
+	OpGte      Operator = "gte"
+	OpLt       Operator = "lt"
+	OpLte      Operator = "lte"
+	OpIn       Operator = "in"       // comma-separated values
+	OpContains Operator = "contains" // case-insensitive substring, text fields only
+)
+
+var operatorSQL = map[Operator]string{
+	OpEq:  "=",
+	OpNe:  "<>",
+	OpGt:  ">",
+	OpGte: ">=",
+	OpLt:  "<",
+	OpLte: "<=",
+}
+
+// Type is the type of a field's values
+type Type int
+
+const (
+	String Type = iota
+	Int
+	Time // RFC 3339 timestamp or YYYY-MM-DD date
+	Bool
+	Float
+)
+
+// Field is a field clients may sort or filter by
+type Field struct {
+	// Name is the field name used in requests
+	Name string
+	// Column is the SQL expression the field maps to
+	Column string
+	Type   Type
+	// Sortable allows the field in sort
+	Sortable bool
+	// Ops lists the allowed filter operators; none means the field cannot be filtered
+	Ops []Operator
+	// Values restricts filter values to a fixed set, e.g. for enums
+	Values []string
+	// NullsLast sorts rows without a value last in both directions
+	NullsLast bool
+}
+
+// Schema is the allowlist of fields for one resource
+type Schema struct {
+	fields      map[string]Field
+	defaultSort []Sort
+}
+
+// NewSchema creates a schema. defaultSort is used when a request has no sort,
+// e.g. "-created_at".
+func NewSchema(defaultSort string, fields ...Field) Schema {
+	s := Schema{fields: make(map[string]Field, len(fields))}
+	for _, f := range fields {
+		s.fields[f.Name] = f
+	}
+	sorts, err := s.parseSort(defaultSort)
+	if err != nil {
+		panic(fmt.Sprintf("listquery: invalid default sort %q: %v", defaultSort, err))
+	}
+	s.defaultSort = sorts
+	return s
+}
+
+// Default returns a query with the schema's default sort and no filters
+func (s Schema) Default() *Query {
+	return &Query{Sorts: s.defaultSort}
+}
+
+// Sort orders a list by one field
+type Sort struct {
+	Field string
+	Desc  bool
+
+	column    string
+	nullsLast bool
+}
+
+// Filter restricts a list by one field
+type Filter struct {
+	Field string
+	Op    Operator
+	Value interface{} // []interface{} for OpIn
+
+	column string
+}
+
+// Query is a parsed set of sort and filter parameters
+type Query struct {
+	Sorts   []Sort
+	Filters []Filter
+	// CustomSort is true when the request chose the order
+	CustomSort bool
+}
+
+var filterKey = regexp.MustCompile(`^filter\[([a-z0-9_]+)\](?:\[([a-z_]+)\])?$`)
+
+// HasParams returns true if the query string contains sort or filter parameters
+func HasParams(values url.Values) bool {
+	for key := range values {
+		if key == "sort" || strings.HasPrefix(key, "filter[") {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse reads sort and filter[field][op] parameters against the schema.
+// Other parameters are ignored.
+func Parse(values url.Values, schema Schema) (*Query, error) {
+	q := schema.Default()
+
+	if raw := values.Get("sort"); raw != "" {
+		sorts, err := schema.parseSort(raw)
+		if err != nil {
+			return nil, ErrInvalidSort.WithDetails(map[string]interface{}{"param": "sort", "reason": err.Error()})
+		}
+		q.Sorts, q.CustomSort = sorts, true
+	}
+
+	// Sorted so the generated SQL does not depend on map order
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, raw := range values[key] {
+			filter, err := schema.parseFilter(key, raw)
+			if err != nil {
+				return nil, ErrInvalidFilter.WithDetails(map[string]interface{}{"param": key, "reason": err.Error()})
+			}
+			q.Filters = append(q.Filters, filter)
+		}
+	}
+	return q, nil
+}
+
+// Restrict returns a copy of q that also applies filter[field][op]=raw, for
+// conditions the server imposes rather than the client. It panics if the
+// schema does not allow the filter.
+func (s Schema) Restrict(q *Query, field string, op Operator, raw string) *Query {
+	filter, err := s.parseFilter("filter["+field+"]["+string(op)+"]", raw)
+	if err != nil {
+		panic(fmt.Sprintf("listquery: invalid restriction on %q: %v", field, err))
+	}
+	restricted := *q
+	restricted.Filters = append(append([]Filter{}, q.Filters...), filter)
+	return &restricted
+}
+
+func (s Schema) parseSort(raw string) ([]Sort, error) {
+	var sorts []Sort
+	seen := map[string]bool{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+
+		field, ok := s.fields[name]
+		if !ok || !field.Sortable {
+			return nil, fmt.Errorf("cannot sort by %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%q appears more than once", name)
+		}
+		seen[name] = true
+		sorts = append(sorts, Sort{Field: name, Desc: desc, column: field.Column, nullsLast: field.NullsLast})
+	}
+	return sorts, nil
+}
+
+func (s Schema) parseFilter(key, raw string) (Filter, error) {
+	m := filterKey.FindStringSubmatch(key)
+	if m == nil {
+		return Filter{}, fmt.Errorf("expected filter[field] or filter[field][op]")
+	}
+	field, ok := s.fields[m[1]]
+	if !ok || len(field.Ops) == 0 {
+		return Filter{}, fmt.Errorf("cannot filter by %q", m[1])
+	}
+	op := OpEq
+	if m[2] != "" {
+		op = Operator(m[2])
+	}
+	if !allows(field.Ops, op) {
+		return Filter{}, fmt.Errorf("operator %q is not supported for %q", op, field.Name)
+	}
+
+	filter := Filter{Field: field.Name, Op: op, column: field.Column}
+	if op == OpIn {
+		var list []interface{}
+		for _, part := range strings.Split(raw, ",") {
+			v, err := field.parseValue(strings.TrimSpace(part))
+			if err != nil {
+				return Filter{}, err
+			}
+			list = append(list, v)
+		}
+		filter.Value = list
+		return filter, nil
+	}
+
+	v, err := field.parseValue(raw)
+	if err != nil {
+		return Filter{}, err
+	}
+	filter.Value = v
+	return filter, nil
+}
+
+func (f Field) parseValue(raw string) (interface{}, error) {
+	if len(f.Values) > 0 && !contains(f.Values, raw) {
+		return nil, fmt.Errorf("%q must be one of %s", f.Name, strings.Join(f.Values, ", "))
+	}
+	switch f.Type {
+	case Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q must be a whole number", f.Name)
+		}
+		return n, nil
+	case Float:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("%q must be a number", f.Name)
+		}
+		return n, nil
+	case Time:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q must be an RFC 3339 timestamp or a YYYY-MM-DD date", f.Name)
+		}
+		return t, nil
+	case Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q must be true or false", f.Name)
+		}
+		return b, nil
+	default:
+		return raw, nil
+	}
+}
+
+// Where returns the filters as SQL conditions joined by AND, each prefixed
+// with " AND ", and appends their values to args. Placeholders are numbered
+// after the arguments already in args.
+func (q *Query) Where(args *[]interface{}) string {
+	var b strings.Builder
+	placeholder := func(v interface{}) string {
+		*args = append(*args, v)
+		return fmt.Sprintf("$%d", len(*args))
+	}
+
+	for _, f := range q.Filters {
+		b.WriteString(" AND ")
+		switch f.Op {
+		case OpIn:
+			values := f.Value.([]interface{})
+			placeholders := make([]string, len(values))
+			for i, v := range values {
+				placeholders[i] = placeholder(v)
+			}
+			fmt.Fprintf(&b, "%s IN (%s)", f.column, strings.Join(placeholders, ", "))
+		case OpContains:
+			escape := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+			fmt.Fprintf(&b, "%s ILIKE %s", f.column, placeholder("%"+escape.Replace(f.Value.(string))+"%"))
+		default:
+			fmt.Fprintf(&b, "%s %s %s", f.column, operatorSQL[f.Op], placeholder(f.Value))
+		}
+	}
+	return b.String()
+}
+
+// OrderBy returns an ORDER BY clause for the sorts. tiebreak columns are
+// appended in ascending order so the order is total, e.g. "id".
+func (q *Query) OrderBy(tiebreak ...string) string {
+	parts := make([]string, 0, len(q.Sorts)+len(tiebreak))
+	sorted := map[string]bool{}
+	for _, s := range q.Sorts {
+		dir := "ASC"
+		if s.Desc {
+			dir = "DESC"
+		}
+		if s.nullsLast {
+			dir += " NULLS LAST"
+		}
+		parts = append(parts, s.column+" "+dir)
+		sorted[s.column] = true
+	}
+	for _, column := range tiebreak {
+		if !sorted[column] {
+			parts = append(parts, column+" ASC")
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "ORDER BY " + strings.Join(parts, ", ")
+}
+
+func allows(ops []Operator, op Operator) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
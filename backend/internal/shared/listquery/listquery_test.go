@@ -0,0 +1,153 @@
+package listquery
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cananga-odorata/golang-template/internal/shared/domain"
+)
+
+var testSchema = NewSchema("-created_at",
+	Field{Name: "name", Column: "name", Type: String, Sortable: true, Ops: []Operator{OpEq, OpContains}},
+	Field{Name: "score", Column: "score", Type: Int, Sortable: true, Ops: []Operator{OpEq, OpGte, OpLt, OpIn}},
+	Field{Name: "status", Column: "status", Type: String, Ops: []Operator{OpEq, OpIn}, Values: []string{"active", "inactive"}},
+	Field{Name: "created_at", Column: "created_at", Type: Time, Sortable: true, Ops: []Operator{OpGte}},
+	Field{Name: "rating", Column: "rating", Type: Float, Sortable: true, Ops: []Operator{OpLt}, NullsLast: true},
+	Field{Name: "secret", Column: "secret", Type: String},
+)
+
+func parse(t *testing.T, raw string) *Query {
+	t.Helper()
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		t.Fatalf("invalid test query %q: %v", raw, err)
+	}
+	q, err := Parse(values, testSchema)
+	if err != nil {
+		t.Fatalf("expected no error for %q, got: %v", raw, err)
+	}
+	return q
+}
+
+// ============ Test Cases ============
+
+func TestParse_DefaultSort(t *testing.T) {
+	q := parse(t, "page=2")
+
+	if q.CustomSort || len(q.Filters) != 0 {
+		t.Errorf("expected default query, got %+v", q)
+	}
+	if got := q.OrderBy("id"); got != "ORDER BY created_at DESC, id ASC" {
+		t.Errorf("unexpected order: %s", got)
+	}
+}
+
+func TestParse_Sort(t *testing.T) {
+	q := parse(t, "sort=-score,name")
+
+	if !q.CustomSort {
+		t.Error("expected a custom sort")
+	}
+	if got := q.OrderBy("id"); got != "ORDER BY score DESC, name ASC, id ASC" {
+		t.Errorf("unexpected order: %s", got)
+	}
+}
+
+func TestParse_NullsLast(t *testing.T) {
+	q := parse(t, "sort=-rating&filter[rating][lt]=0.25")
+
+	if got := q.OrderBy("id"); got != "ORDER BY rating DESC NULLS LAST, id ASC" {
+		t.Errorf("unexpected order: %s", got)
+	}
+	var args []interface{}
+	if where := q.Where(&args); where != " AND rating < $1" || !reflect.DeepEqual(args, []interface{}{0.25}) {
+		t.Errorf("unexpected where: %q %v", where, args)
+	}
+}
+
+func TestParse_Filters(t *testing.T) {
+	q := parse(t, "filter[score][gte]=10&filter[status][in]=active,inactive&filter[name][contains]=50%25_off&filter[created_at][gte]=2024-01-02")
+
+	args := []interface{}{"tenant"}
+	where := q.Where(&args)
+
+	want := " AND created_at >= $2 AND name ILIKE $3 AND score >= $4 AND status IN ($5, $6)"
+	if where != want {
+		t.Errorf("expected %q, got %q", want, where)
+	}
+	wantArgs := []interface{}{"tenant", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), `%50\%\_off%`, 10, "active", "inactive"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("expected args %v, got %v", wantArgs, args)
+	}
+}
+
+func TestParse_DefaultOperatorIsEq(t *testing.T) {
+	q := parse(t, "filter[name]=Ada")
+
+	var args []interface{}
+	if where := q.Where(&args); where != " AND name = $1" {
+		t.Errorf("unexpected where: %q", where)
+	}
+}
+
+func TestRestrict(t *testing.T) {
+	q := parse(t, "filter[name]=Ada")
+	restricted := testSchema.Restrict(q, "status", OpEq, "active")
+
+	var args []interface{}
+	if where := restricted.Where(&args); where != " AND name = $1 AND status = $2" {
+		t.Errorf("unexpected where: %q", where)
+	}
+	if len(q.Filters) != 1 {
+		t.Errorf("expected the original query to be left unchanged, got %+v", q.Filters)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a value the schema does not allow")
+		}
+	}()
+	testSchema.Restrict(q, "status", OpEq, "deleted")
+}
+
+func TestParse_Rejects(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want error
+	}{
+		{"sort=secret", ErrInvalidSort},
+		{"sort=status", ErrInvalidSort},
+		{"sort=name,-name", ErrInvalidSort},
+		{"sort=name%20DESC", ErrInvalidSort},
+		{"filter[secret]=x", ErrInvalidFilter},
+		{"filter[unknown]=x", ErrInvalidFilter},
+		{"filter[name][gt]=x", ErrInvalidFilter},
+		{"filter[score][gte]=ten", ErrInvalidFilter},
+		{"filter[rating][lt]=NaN", ErrInvalidFilter},
+		{"filter[status]=deleted", ErrInvalidFilter},
+		{"filter[created_at][gte]=yesterday", ErrInvalidFilter},
+		{"filter[name)]=x", ErrInvalidFilter},
+	}
+
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.raw)
+		_, err := Parse(values, testSchema)
+
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || appErr.Message != tt.want.(*domain.AppError).Message {
+			t.Errorf("%s: expected %v, got %v", tt.raw, tt.want, err)
+		}
+	}
+}
+
+func TestHasParams(t *testing.T) {
+	if HasParams(url.Values{"page": {"1"}}) {
+		t.Error("expected no list query params")
+	}
+	if !HasParams(url.Values{"filter[name]": {"x"}}) || !HasParams(url.Values{"sort": {"name"}}) {
+		t.Error("expected list query params")
+	}
+}
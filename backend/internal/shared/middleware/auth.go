@@ -76,3 +76,34 @@ func OptionalJWTAuth(jwtSecret string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// UserRoles gives the admin role to the signed-in users listed in adminIDs,
+// the reviewer role to those listed in reviewerIDs and the user role to
+// everyone else who is signed in. It runs after the JWT middleware.
+func UserRoles(adminIDs, reviewerIDs []string) func(http.Handler) http.Handler {
+	roles := make(map[string]string, len(adminIDs)+len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			roles[id] = utils.RoleReviewer
+		}
+	}
+	for _, id := range adminIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			roles[id] = utils.RoleAdmin
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if userID, ok := utils.GetUserID(r.Context()); ok {
+				role, ok := roles[userID]
+				if !ok {
+					role = "user"
+				}
+				r = r.WithContext(utils.SetUserRole(r.Context(), role))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+func TestUserRoles(t *testing.T) {
+	var role string
+	var hasRole bool
+	handler := OptionalJWTAuth("secret")(UserRoles([]string{" alice ", ""}, []string{"carol", "alice"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, hasRole = utils.GetUserRole(r.Context())
+	})))
+
+	cases := []struct {
+		token    string
+		wantRole string
+		wantSet  bool
+	}{
+		{"Bearer jwt_alice_2025-01-01T00:00:00Z", utils.RoleAdmin, true},
+		{"Bearer jwt_bob_2025-01-01T00:00:00Z", "user", true},
+		{"Bearer jwt_carol_2025-01-01T00:00:00Z", utils.RoleReviewer, true},
+		{"", "", false},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tc.token != "" {
+			req.Header.Set("Authorization", tc.token)
+		}
+		role, hasRole = "", false
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		if role != tc.wantRole || hasRole != tc.wantSet {
+			t.Errorf("%q: expected role %q (set %v), got %q (set %v)", tc.token, tc.wantRole, tc.wantSet, role, hasRole)
+		}
+	}
+}
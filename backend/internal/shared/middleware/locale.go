@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+// Locale resolves the locales a client prefers and stores them in the request
+// context as a fallback chain ending with defaultLocale. The ?lang= parameter
+// (comma-separated, most preferred first) takes precedence over the
+// Accept-Language header.
+func Locale(defaultLocale string) func(http.Handler) http.Handler {
+	fallback, ok := utils.CanonicalLocale(defaultLocale)
+	if !ok {
+		panic("middleware: invalid default locale " + defaultLocale)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var preferred []string
+			if lang := r.URL.Query().Get("lang"); lang != "" {
+				for _, tag := range strings.Split(lang, ",") {
+					if canonical, ok := utils.CanonicalLocale(tag); ok {
+						preferred = append(preferred, canonical)
+					}
+				}
+			} else {
+				preferred = utils.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+			}
+
+			w.Header().Add("Vary", "Accept-Language")
+			ctx := utils.SetLocales(r.Context(), utils.LocaleChain(preferred, fallback))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cananga-odorata/golang-template/internal/shared/utils"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	tests := []struct {
+		name, target, acceptLanguage, want string
+	}{
+		{"default only", "/", "", "th"},
+		{"accept-language by weight", "/", "fr;q=0.5, en-us, *;q=0.1", "en-US,en,fr,th"},
+		{"lang overrides header", "/?lang=zh-hant-tw,en", "fr", "zh-Hant-TW,zh-Hant,zh,en,th"},
+		{"invalid tags ignored", "/?lang=../etc,th-TH", "", "th-TH,th"},
+		{"q=0 excluded", "/", "en;q=0, ja", "ja,th"},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		handler := Locale("th")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ = utils.GetLocales(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+		if tt.acceptLanguage != "" {
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, strings.Join(got, ","))
+		}
+		if rec.Header().Get("Vary") != "Accept-Language" {
+			t.Errorf("%s: expected Vary: Accept-Language", tt.name)
+		}
+	}
+}
@@ -8,6 +8,7 @@ const (
 	userIDKey   contextKey = "user_id"
 	tenantIDKey contextKey = "tenant_id"
 	userRoleKey contextKey = "user_role"
+	localesKey  contextKey = "locales"
 )
 
 // SetUserID sets the user ID in context
@@ -50,6 +51,12 @@ func MustGetTenantID(ctx context.Context) string {
 	return id
 }
 
+// RoleAdmin is the role of users who may manage everyone's content
+const RoleAdmin = "admin"
+
+// RoleReviewer is the role of users who approve or reject quizzes in review
+const RoleReviewer = "reviewer"
+
 // SetUserRole sets the user role in context
 func SetUserRole(ctx context.Context, role string) context.Context {
 	return context.WithValue(ctx, userRoleKey, role)
@@ -60,3 +67,26 @@ func GetUserRole(ctx context.Context) (string, bool) {
 	role, ok := ctx.Value(userRoleKey).(string)
 	return role, ok
 }
+
+// IsAdmin returns true if the user in context has the admin role
+func IsAdmin(ctx context.Context) bool {
+	role, _ := GetUserRole(ctx)
+	return role == RoleAdmin
+}
+
+// CanReview returns true if the user in context has the reviewer or admin role
+func CanReview(ctx context.Context) bool {
+	role, _ := GetUserRole(ctx)
+	return role == RoleReviewer || role == RoleAdmin
+}
+
+// SetLocales sets the preferred locales, most preferred first, in context
+func SetLocales(ctx context.Context, locales []string) context.Context {
+	return context.WithValue(ctx, localesKey, locales)
+}
+
+// GetLocales retrieves the preferred locales from context
+func GetLocales(ctx context.Context) ([]string, bool) {
+	locales, ok := ctx.Value(localesKey).([]string)
+	return locales, ok
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// CanonicalLocale returns a BCP 47 language tag in canonical case, e.g.
+// "en-us" becomes "en-US" and "ZH-hant-TW" becomes "zh-Hant-TW", or false if
+// tag is not a language tag
+func CanonicalLocale(tag string) (string, bool) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if !localePattern.MatchString(tag) {
+		return "", false
+	}
+	parts := strings.Split(tag, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "-"), true
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language header
+// in order of preference. Wildcards, invalid tags and tags with q=0 are left out.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if canonical, ok := CanonicalLocale(tag); ok && q > 0 {
+			tags = append(tags, weighted{canonical, q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+// LocaleChain returns the locales to try, in order, for the preferred tags:
+// each tag is followed by its less specific forms ("en-US" then "en"), and
+// fallback comes last. Duplicates are removed.
+func LocaleChain(preferred []string, fallback string) []string {
+	var chain []string
+	seen := map[string]bool{}
+	add := func(tag string) {
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			chain = append(chain, tag)
+		}
+	}
+	for _, tag := range preferred {
+		for t := tag; t != ""; {
+			add(t)
+			i := strings.LastIndex(t, "-")
+			if i < 0 {
+				break
+			}
+			t = t[:i]
+		}
+	}
+	add(fallback)
+	return chain
+}
@@ -10,4 +10,4 @@ CREATE TABLE IF NOT EXISTS quizzes (
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
-CREATE INDEX idx_quizzes_display_order ON quizzes (display_order);
+CREATE INDEX IF NOT EXISTS idx_quizzes_display_order ON quizzes (display_order);
@@ -0,0 +1,3 @@
+ALTER TABLE quizzes
+    ADD COLUMN IF NOT EXISTS answer SMALLINT NOT NULL DEFAULT 0
+        CHECK (answer BETWEEN 0 AND 4);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS quiz_set_items;
+DROP TABLE IF EXISTS quiz_sets;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS quiz_sets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS quiz_set_items (
+    quiz_set_id UUID NOT NULL REFERENCES quiz_sets (id) ON DELETE CASCADE,
+    quiz_id UUID NOT NULL REFERENCES quizzes (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (quiz_set_id, quiz_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_set_items_quiz_id ON quiz_set_items (quiz_id);
@@ -0,0 +1,2 @@
+ALTER TABLE quizzes DROP COLUMN IF EXISTS revision_id;
+DROP TABLE IF EXISTS quiz_revisions;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS quiz_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- No foreign key: revisions are immutable and outlive their quiz
+    quiz_id UUID NOT NULL,
+    number INT NOT NULL,
+    question TEXT NOT NULL,
+    choice1 TEXT NOT NULL,
+    choice2 TEXT NOT NULL,
+    choice3 TEXT NOT NULL,
+    choice4 TEXT NOT NULL,
+    answer SMALLINT NOT NULL DEFAULT 0,
+    action TEXT NOT NULL,
+    reverted_from UUID REFERENCES quiz_revisions (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (quiz_id, number)
+);
+
+-- Existing quizzes start with their current content as revision 1
+INSERT INTO quiz_revisions (quiz_id, number, question, choice1, choice2, choice3, choice4, answer, action, created_at)
+SELECT id, 1, question, choice1, choice2, choice3, choice4, answer, 'create', created_at FROM quizzes;
+
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS revision_id UUID REFERENCES quiz_revisions (id);
+UPDATE quizzes q SET revision_id = r.id FROM quiz_revisions r WHERE r.quiz_id = q.id AND r.number = 1;
+ALTER TABLE quizzes ALTER COLUMN revision_id SET NOT NULL;
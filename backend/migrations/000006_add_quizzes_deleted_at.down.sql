@@ -0,0 +1,3 @@
+DELETE FROM quizzes WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_quizzes_deleted_at;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,5 @@
+-- Deleted quizzes stay in the trash until they are restored or purged.
+-- A trashed quiz keeps its display_order as the slot it is restored to.
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_quizzes_deleted_at ON quizzes (deleted_at) WHERE deleted_at IS NOT NULL;
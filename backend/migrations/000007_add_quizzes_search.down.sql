@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_quizzes_search_text;
+DROP INDEX IF EXISTS idx_quizzes_search_vector;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS search_text;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS search_vector;
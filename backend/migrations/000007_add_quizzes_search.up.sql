@@ -0,0 +1,17 @@
+-- Full-text search over questions and choices.
+-- The 'simple' configuration does not stem, so Thai and English are treated alike.
+-- Thai is written without spaces between words and no Postgres parser segments it,
+-- so Thai search terms are matched as substrings of search_text with a trigram index.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', question), 'A') ||
+    setweight(to_tsvector('simple', choice1 || ' ' || choice2 || ' ' || choice3 || ' ' || choice4), 'B')
+) STORED;
+
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (
+    question || ' ' || choice1 || ' ' || choice2 || ' ' || choice3 || ' ' || choice4
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_quizzes_search_vector ON quizzes USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_quizzes_search_text ON quizzes USING GIN (search_text gin_trgm_ops);
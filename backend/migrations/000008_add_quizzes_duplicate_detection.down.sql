@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_quizzes_normalized_question_trgm;
+DROP INDEX IF EXISTS idx_quizzes_normalized_question;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS normalized_question;
+DROP FUNCTION IF EXISTS quiz_normalize(text);
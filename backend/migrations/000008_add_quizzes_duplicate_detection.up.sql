@@ -0,0 +1,13 @@
+-- Duplicate detection compares questions after normalization: case, whitespace
+-- and punctuation are ignored. Exact duplicates share a normalized question,
+-- near-duplicates are found by trigram similarity (pg_trgm, added in 000007).
+CREATE OR REPLACE FUNCTION quiz_normalize(text) RETURNS text
+    LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+    AS $$ SELECT btrim(regexp_replace(lower($1), '[[:space:][:punct:]]+', ' ', 'g')) $$;
+
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS normalized_question TEXT GENERATED ALWAYS AS (
+    quiz_normalize(question)
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_quizzes_normalized_question ON quizzes (normalized_question) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_quizzes_normalized_question_trgm ON quizzes USING GIN (normalized_question gin_trgm_ops);
@@ -0,0 +1,19 @@
+-- Translations of a quiz into other locales. The quiz itself is written in the
+-- default locale. source_revision_id is the quiz revision the translation was
+-- made from, so translations of edited quizzes can be found.
+CREATE TABLE IF NOT EXISTS quiz_translations (
+    quiz_id UUID NOT NULL REFERENCES quizzes (id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    question TEXT NOT NULL,
+    choice1 TEXT NOT NULL,
+    choice2 TEXT NOT NULL,
+    choice3 TEXT NOT NULL,
+    choice4 TEXT NOT NULL,
+    explanation TEXT NOT NULL DEFAULT '',
+    source_revision_id UUID NOT NULL REFERENCES quiz_revisions (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (quiz_id, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_translations_locale ON quiz_translations (locale);
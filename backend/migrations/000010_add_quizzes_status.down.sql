@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS quiz_transitions;
+DROP INDEX IF EXISTS idx_quizzes_status;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS status;
@@ -0,0 +1,23 @@
+-- Quizzes move through draft -> in_review -> published -> archived. Quizzes
+-- that existed before the workflow were already live, so they start published;
+-- new quizzes start as drafts.
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published'
+    CHECK (status IN ('draft', 'in_review', 'published', 'archived'));
+ALTER TABLE quizzes ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX IF NOT EXISTS idx_quizzes_status ON quizzes (status, display_order) WHERE deleted_at IS NULL;
+
+-- Every status change, with the reviewer's comment
+CREATE TABLE IF NOT EXISTS quiz_transitions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- No foreign key: like revisions, the history outlives its quiz
+    quiz_id UUID NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    from_status VARCHAR(16) NOT NULL,
+    to_status VARCHAR(16) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    actor_id TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_transitions_quiz_id ON quiz_transitions (quiz_id, created_at);
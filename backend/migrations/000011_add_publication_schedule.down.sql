@@ -0,0 +1,9 @@
+ALTER TABLE quiz_sets DROP CONSTRAINT IF EXISTS quiz_sets_schedule_check;
+ALTER TABLE quiz_sets DROP COLUMN IF EXISTS visible;
+ALTER TABLE quiz_sets DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE quiz_sets DROP COLUMN IF EXISTS publish_at;
+
+ALTER TABLE quizzes DROP CONSTRAINT IF EXISTS quizzes_schedule_check;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS visible;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS publish_at;
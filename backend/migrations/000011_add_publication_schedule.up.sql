@@ -0,0 +1,14 @@
+-- Quizzes and quiz sets are only visible between publish_at and unpublish_at.
+-- Either bound may be NULL to leave that side open. visible is the visibility
+-- last seen by the background job, so it can report changes; reads evaluate
+-- the window themselves.
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMPTZ;
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS visible BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE quizzes ADD CONSTRAINT quizzes_schedule_check CHECK (unpublish_at > publish_at);
+UPDATE quizzes SET visible = (status = 'published');
+
+ALTER TABLE quiz_sets ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE quiz_sets ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMPTZ;
+ALTER TABLE quiz_sets ADD COLUMN IF NOT EXISTS visible BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE quiz_sets ADD CONSTRAINT quiz_sets_schedule_check CHECK (unpublish_at > publish_at);
@@ -0,0 +1,21 @@
+-- Reviewer comments on quizzes. A comment without a parent starts a thread,
+-- optionally anchored to one choice; replies point at the thread's first
+-- comment. Threads, not replies, are resolved. author_id, resolved_by and
+-- mentions hold user IDs as given by the auth layer.
+CREATE TABLE IF NOT EXISTS quiz_comments (
+    id UUID PRIMARY KEY,
+    quiz_id UUID NOT NULL REFERENCES quizzes (id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES quiz_comments (id) ON DELETE CASCADE,
+    choice SMALLINT CHECK (choice BETWEEN 1 AND 4),
+    body TEXT NOT NULL,
+    author_id TEXT,
+    mentions TEXT[] NOT NULL DEFAULT '{}',
+    resolved_at TIMESTAMPTZ,
+    resolved_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_comments_quiz_id ON quiz_comments (quiz_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_quiz_comments_unresolved ON quiz_comments (quiz_id) WHERE parent_id IS NULL AND resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_quiz_comments_mentions ON quiz_comments USING GIN (mentions);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_quizzes_created_by;
+
+ALTER TABLE quizzes DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS created_by;
@@ -0,0 +1,6 @@
+-- The users who created and last edited each quiz, as given by the auth
+-- layer. Quizzes created before this, or without signing in, have no author.
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS created_by TEXT;
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS updated_by TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_quizzes_created_by ON quizzes (created_by) WHERE deleted_at IS NULL;
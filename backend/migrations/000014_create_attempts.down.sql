@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS attempt_answers;
+DROP TABLE IF EXISTS attempts;
+DROP TABLE IF EXISTS quiz_item_parameters;
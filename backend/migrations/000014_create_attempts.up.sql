@@ -0,0 +1,49 @@
+-- Item response theory parameters of quizzes, used to pick questions in
+-- adaptive attempts. Quizzes without a row have discrimination 1 and
+-- difficulty 0.
+CREATE TABLE IF NOT EXISTS quiz_item_parameters (
+    quiz_id UUID PRIMARY KEY REFERENCES quizzes (id) ON DELETE CASCADE,
+    discrimination DOUBLE PRECISION NOT NULL DEFAULT 1 CHECK (discrimination > 0),
+    difficulty DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Attempts take a quiz set in order (fixed) or pick each question from the
+-- learner's running ability estimate (adaptive). user_id holds the user ID
+-- as given by the auth layer.
+CREATE TABLE IF NOT EXISTS attempts (
+    id UUID PRIMARY KEY,
+    quiz_set_id UUID REFERENCES quiz_sets (id) ON DELETE SET NULL,
+    user_id TEXT,
+    mode TEXT NOT NULL CHECK (mode IN ('fixed', 'adaptive')),
+    model TEXT CHECK (model IN ('1pl', '2pl')),
+    max_items INT NOT NULL CHECK (max_items > 0),
+    target_se DOUBLE PRECISION CHECK (target_se > 0),
+    status TEXT NOT NULL DEFAULT 'in_progress' CHECK (status IN ('in_progress', 'submitted')),
+    ability DOUBLE PRECISION,
+    standard_error DOUBLE PRECISION,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL,
+    submitted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_attempts_user_id ON attempts (user_id, started_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_attempts_quiz_set_id ON attempts (quiz_set_id) WHERE status = 'submitted';
+
+-- The questions presented in an attempt, in order. revision_id is the quiz
+-- revision the learner saw.
+CREATE TABLE IF NOT EXISTS attempt_answers (
+    attempt_id UUID NOT NULL REFERENCES attempts (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    quiz_id UUID NOT NULL REFERENCES quizzes (id) ON DELETE CASCADE,
+    revision_id UUID NOT NULL REFERENCES quiz_revisions (id),
+    choice SMALLINT CHECK (choice BETWEEN 1 AND 4),
+    correct BOOLEAN,
+    presented_at TIMESTAMPTZ NOT NULL,
+    answered_at TIMESTAMPTZ,
+    PRIMARY KEY (attempt_id, position),
+    UNIQUE (attempt_id, quiz_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attempt_answers_quiz_id ON attempt_answers (quiz_id) WHERE answered_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS leaderboard_preferences;
+DROP TABLE IF EXISTS leaderboard_entries;
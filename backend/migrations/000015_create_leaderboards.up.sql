@@ -0,0 +1,24 @@
+-- The best fixed attempt of every user on a quiz set, per period: "all" for
+-- the all-time board and the ISO week (e.g. 2026-W42) for weekly boards.
+-- Rows are replaced only when a later attempt ranks higher.
+CREATE TABLE IF NOT EXISTS leaderboard_entries (
+    quiz_set_id UUID NOT NULL REFERENCES quiz_sets (id) ON DELETE CASCADE,
+    period TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    attempt_id UUID NOT NULL REFERENCES attempts (id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    max_score DOUBLE PRECISION NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    submitted_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (quiz_set_id, period, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_entries_rank
+    ON leaderboard_entries (quiz_set_id, period, score DESC, duration_ms ASC, submitted_at ASC);
+
+-- Users who asked not to be named on public leaderboards
+CREATE TABLE IF NOT EXISTS leaderboard_preferences (
+    user_id TEXT PRIMARY KEY,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_attempts_assignment_user;
+ALTER TABLE attempts DROP COLUMN IF EXISTS late;
+ALTER TABLE attempts DROP COLUMN IF EXISTS assignment_id;
+DROP TABLE IF EXISTS assignment_groups;
+DROP TABLE IF EXISTS assignment_users;
+DROP TABLE IF EXISTS assignments;
+DROP TABLE IF EXISTS user_group_members;
+DROP TABLE IF EXISTS user_groups;
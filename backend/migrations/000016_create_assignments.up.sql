@@ -0,0 +1,60 @@
+-- Named groups of users, such as a class, that quiz sets can be assigned to
+CREATE TABLE IF NOT EXISTS user_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    created_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_group_members (
+    group_id UUID NOT NULL REFERENCES user_groups (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_group_members_user_id ON user_group_members (user_id);
+
+-- A quiz set learners must attempt between opens_at and due_at. Late
+-- attempts are refused, accepted, or accepted with late_penalty percent
+-- taken off their score; max_attempts NULL allows any number.
+CREATE TABLE IF NOT EXISTS assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    quiz_set_id UUID NOT NULL REFERENCES quiz_sets (id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    opens_at TIMESTAMPTZ,
+    due_at TIMESTAMPTZ NOT NULL,
+    late_policy TEXT NOT NULL DEFAULT 'reject' CHECK (late_policy IN ('reject', 'accept', 'penalty')),
+    late_penalty DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (late_penalty >= 0 AND late_penalty <= 100),
+    max_attempts INT CHECK (max_attempts > 0),
+    created_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (opens_at IS NULL OR opens_at < due_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignments_quiz_set_id ON assignments (quiz_set_id);
+
+-- Who an assignment is for: users directly, or every member of a group
+CREATE TABLE IF NOT EXISTS assignment_users (
+    assignment_id UUID NOT NULL REFERENCES assignments (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    PRIMARY KEY (assignment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_users_user_id ON assignment_users (user_id);
+
+CREATE TABLE IF NOT EXISTS assignment_groups (
+    assignment_id UUID NOT NULL REFERENCES assignments (id) ON DELETE CASCADE,
+    group_id UUID NOT NULL REFERENCES user_groups (id) ON DELETE CASCADE,
+    PRIMARY KEY (assignment_id, group_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_groups_group_id ON assignment_groups (group_id);
+
+-- Attempts started for an assignment count against its limit; late is set
+-- when they were started after the due date
+ALTER TABLE attempts ADD COLUMN IF NOT EXISTS assignment_id UUID REFERENCES assignments (id) ON DELETE SET NULL;
+ALTER TABLE attempts ADD COLUMN IF NOT EXISTS late BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_attempts_assignment_user ON attempts (assignment_id, user_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS certificates;
+ALTER TABLE quiz_sets DROP COLUMN IF EXISTS pass_mark;
@@ -0,0 +1,27 @@
+-- The percentage of the maximum score a fixed attempt needs to pass a quiz
+-- set; sets without one award no certificates
+ALTER TABLE quiz_sets ADD COLUMN IF NOT EXISTS pass_mark DOUBLE PRECISION CHECK (pass_mark > 0 AND pass_mark <= 100);
+
+-- Proof that a learner passed a quiz set. The title, scores and pass mark are
+-- copied so certificates stay verifiable after the set changes or is deleted.
+CREATE TABLE IF NOT EXISTS certificates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code TEXT NOT NULL UNIQUE,
+    attempt_id UUID UNIQUE REFERENCES attempts (id) ON DELETE SET NULL,
+    quiz_set_id UUID REFERENCES quiz_sets (id) ON DELETE SET NULL,
+    quiz_set_title TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    max_score DOUBLE PRECISION NOT NULL,
+    pass_mark DOUBLE PRECISION NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ,
+    revoked_by TEXT,
+    revoke_reason TEXT NOT NULL DEFAULT ''
+);
+
+-- A learner holds at most one valid certificate per quiz set
+CREATE UNIQUE INDEX IF NOT EXISTS idx_certificates_valid
+    ON certificates (quiz_set_id, user_id) WHERE revoked_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_certificates_user_id ON certificates (user_id, issued_at DESC);
@@ -0,0 +1,16 @@
+-- The spaced-repetition schedule of a quiz for one learner. ease and
+-- interval_days follow SM-2; a card is due for review from due_at.
+CREATE TABLE IF NOT EXISTS practice_cards (
+    user_id TEXT NOT NULL,
+    quiz_id UUID NOT NULL REFERENCES quizzes (id) ON DELETE CASCADE,
+    ease DOUBLE PRECISION NOT NULL DEFAULT 2.5 CHECK (ease >= 1.3),
+    interval_days INTEGER NOT NULL DEFAULT 0 CHECK (interval_days >= 0),
+    repetitions INTEGER NOT NULL DEFAULT 0,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    reviews INTEGER NOT NULL DEFAULT 0,
+    due_at TIMESTAMPTZ NOT NULL,
+    last_reviewed_at TIMESTAMPTZ,
+    PRIMARY KEY (user_id, quiz_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_practice_cards_due ON practice_cards (user_id, due_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attempt_hints;
+DROP TABLE IF EXISTS quiz_hints;
@@ -0,0 +1,22 @@
+-- Progressive hints of a quiz, revealed in order. Using a hint takes its
+-- penalty off the point a correct answer earns.
+CREATE TABLE IF NOT EXISTS quiz_hints (
+    quiz_id UUID NOT NULL REFERENCES quizzes (id) ON DELETE CASCADE,
+    position INT NOT NULL CHECK (position > 0),
+    body TEXT NOT NULL,
+    penalty DOUBLE PRECISION NOT NULL CHECK (penalty >= 0 AND penalty <= 1),
+    PRIMARY KEY (quiz_id, position)
+);
+
+-- The hints revealed for a question of an attempt. The body and penalty are
+-- copied so results do not change when the author edits the hints.
+CREATE TABLE IF NOT EXISTS attempt_hints (
+    attempt_id UUID NOT NULL,
+    position INT NOT NULL,
+    hint INT NOT NULL CHECK (hint > 0),
+    body TEXT NOT NULL,
+    penalty DOUBLE PRECISION NOT NULL,
+    revealed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (attempt_id, position, hint),
+    FOREIGN KEY (attempt_id, position) REFERENCES attempt_answers (attempt_id, position) ON DELETE CASCADE
+);
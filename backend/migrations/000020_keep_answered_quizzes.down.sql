@@ -0,0 +1,3 @@
+ALTER TABLE attempt_answers DROP CONSTRAINT IF EXISTS attempt_answers_quiz_id_fkey;
+ALTER TABLE attempt_answers ADD CONSTRAINT attempt_answers_quiz_id_fkey
+    FOREIGN KEY (quiz_id) REFERENCES quizzes (id) ON DELETE CASCADE;
@@ -0,0 +1,6 @@
+-- Answers keep their quiz: purging a quiz from the trash must not delete the
+-- graded answers that attempt results, statistics, leaderboards and
+-- certificates are built on, so answered quizzes can no longer be deleted
+ALTER TABLE attempt_answers DROP CONSTRAINT IF EXISTS attempt_answers_quiz_id_fkey;
+ALTER TABLE attempt_answers ADD CONSTRAINT attempt_answers_quiz_id_fkey
+    FOREIGN KEY (quiz_id) REFERENCES quizzes (id) ON DELETE RESTRICT;
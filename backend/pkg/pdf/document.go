@@ -0,0 +1,233 @@
+// Package pdf is a small PDF writer for generating printable documents
+// without external tools. It supports text in a single font per document,
+// lines and rectangles, which is enough for exam papers and reports.
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// A4 page size in points
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Document is a PDF document under construction
+type Document struct {
+	width  float64
+	height float64
+	font   Font
+	title  string
+	pages  []*Page
+	runes  map[rune]struct{}
+}
+
+// New creates a document with pages of the given size in points.
+// When font is nil the standard Helvetica font is used.
+func New(width, height float64, font Font) *Document {
+	if font == nil {
+		font = Helvetica()
+	}
+	return &Document{
+		width:  width,
+		height: height,
+		font:   font,
+		runes:  make(map[rune]struct{}),
+	}
+}
+
+// SetTitle sets the document title shown by PDF viewers
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// Font returns the document font
+func (d *Document) Font() Font {
+	return d.font
+}
+
+// PageSize returns the page width and height in points
+func (d *Document) PageSize() (float64, float64) {
+	return d.width, d.height
+}
+
+// AddPage appends a new empty page
+func (d *Document) AddPage() *Page {
+	p := &Page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Pages returns the pages added so far
+func (d *Document) Pages() []*Page {
+	return d.pages
+}
+
+// Page is a single page. Coordinates are in points measured from the
+// top-left corner; y is the text baseline for Text.
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+}
+
+// Text draws s at (x, y) with the given font size
+func (p *Page) Text(x, y, size float64, s string) {
+	if s == "" {
+		return
+	}
+	for _, r := range s {
+		p.doc.runes[r] = struct{}{}
+	}
+	fmt.Fprintf(&p.content, "BT /F1 %s Tf %s %s Td <%X> Tj ET\n",
+		num(size), num(x), num(p.doc.height-y), p.doc.font.encode(s))
+}
+
+// Line draws a straight line between two points
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(p.doc.height-y1), num(x2), num(p.doc.height-y2))
+}
+
+// Rect draws the outline of a rectangle whose top-left corner is (x, y)
+func (p *Page) Rect(x, y, w, h, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n",
+		num(width), num(x), num(p.doc.height-y-h), num(w), num(h))
+}
+
+// WriteTo writes the complete PDF file to w
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pw := &writer{w: bufio.NewWriter(w), offsets: make(map[int]int64)}
+
+	catalogRef := pw.alloc()
+	pagesRef := pw.alloc()
+	infoRef := pw.alloc()
+	fontRef := pw.alloc()
+
+	pageRefs := make([]int, len(d.pages))
+	for i := range d.pages {
+		pageRefs[i] = pw.alloc()
+	}
+
+	pw.header()
+	pw.object(catalogRef, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesRef))
+
+	kids := new(bytes.Buffer)
+	for _, ref := range pageRefs {
+		fmt.Fprintf(kids, "%d 0 R ", ref)
+	}
+	pw.object(pagesRef, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(pageRefs)))
+	pw.object(infoRef, fmt.Sprintf("<< /Title %s /Producer (golang-template pdf) >>", textString(d.title)))
+
+	for i, page := range d.pages {
+		contentRef := pw.alloc()
+		pw.object(pageRefs[i], fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+			pagesRef, num(d.width), num(d.height), fontRef, contentRef))
+		pw.stream(contentRef, "", page.content.Bytes())
+	}
+
+	d.font.writeObjects(pw, fontRef, d.usedRunes())
+
+	pw.trailer(catalogRef, infoRef)
+	if pw.err != nil {
+		return pw.n, pw.err
+	}
+	return pw.n, pw.w.Flush()
+}
+
+func (d *Document) usedRunes() []rune {
+	runes := make([]rune, 0, len(d.runes))
+	for r := range d.runes {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes
+}
+
+// writer tracks object offsets while serializing a document
+type writer struct {
+	w       *bufio.Writer
+	n       int64
+	err     error
+	next    int
+	offsets map[int]int64
+}
+
+func (pw *writer) alloc() int {
+	pw.next++
+	return pw.next
+}
+
+func (pw *writer) write(b []byte) {
+	if pw.err != nil {
+		return
+	}
+	n, err := pw.w.Write(b)
+	pw.n += int64(n)
+	pw.err = err
+}
+
+func (pw *writer) printf(format string, args ...interface{}) {
+	pw.write([]byte(fmt.Sprintf(format, args...)))
+}
+
+func (pw *writer) header() {
+	// The binary comment marks the file as binary for transfer tools
+	pw.write([]byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n"))
+}
+
+func (pw *writer) object(ref int, body string) {
+	pw.offsets[ref] = pw.n
+	pw.printf("%d 0 obj\n%s\nendobj\n", ref, body)
+}
+
+// stream writes a Flate-compressed stream object. extra is added to the stream dictionary.
+func (pw *writer) stream(ref int, extra string, data []byte) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+
+	pw.offsets[ref] = pw.n
+	pw.printf("%d 0 obj\n<< /Length %d /Filter /FlateDecode%s >>\nstream\n", ref, buf.Len(), extra)
+	pw.write(buf.Bytes())
+	pw.printf("\nendstream\nendobj\n")
+}
+
+func (pw *writer) trailer(rootRef, infoRef int) {
+	xref := pw.n
+	pw.printf("xref\n0 %d\n0000000000 65535 f \n", pw.next+1)
+	for ref := 1; ref <= pw.next; ref++ {
+		pw.printf("%010d 00000 n \n", pw.offsets[ref])
+	}
+	pw.printf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", pw.next+1, rootRef, infoRef, xref)
+}
+
+// num formats a coordinate compactly with at most two decimals
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// textString encodes s as a UTF-16BE PDF text string
+func textString(s string) string {
+	var b bytes.Buffer
+	b.WriteString("<FEFF")
+	for _, r := range s {
+		if r > 0xFFFF {
+			r -= 0x10000
+			fmt.Fprintf(&b, "%04X%04X", 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+			continue
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	b.WriteString(">")
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package pdf
+
+import "fmt"
+
+// Font is a font usable by a Document. Fonts are immutable and may be
+// shared between documents rendered concurrently.
+type Font interface {
+	// Name returns the font name
+	Name() string
+
+	// Width returns the advance width of s in points at the given size
+	Width(s string, size float64) float64
+
+	// HasGlyph reports whether the font can render r
+	HasGlyph(r rune) bool
+
+	// encode returns the bytes of s in the font encoding
+	encode(s string) []byte
+
+	// writeObjects writes the font dictionary as object ref, plus any
+	// objects it depends on. runes lists every character used in the document.
+	writeObjects(pw *writer, ref int, runes []rune)
+}
+
+// standardFont is one of the base 14 PDF fonts using WinAnsiEncoding.
+// Characters outside Latin-1 cannot be shown and are replaced by '?'.
+type standardFont struct {
+	name   string
+	widths [95]int // ASCII 32..126, in 1/1000 em
+}
+
+// Helvetica returns the standard Helvetica font. It needs no embedding but
+// only covers Latin-1; use a TrueType font for other scripts such as Thai.
+func Helvetica() Font {
+	return helvetica
+}
+
+var helvetica = &standardFont{
+	name: "Helvetica",
+	widths: [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0-9
+		278, 278, 584, 584, 584, 556, 1015, // : to @
+		667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A-M
+		722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N-Z
+		278, 278, 278, 469, 556, 333, // [ to `
+		556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a-m
+		556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n-z
+		334, 260, 334, 584, // { to ~
+	},
+}
+
+func (f *standardFont) Name() string {
+	return f.name
+}
+
+func (f *standardFont) HasGlyph(r rune) bool {
+	return r >= 32 && r <= 126 || r >= 160 && r <= 255
+}
+
+func (f *standardFont) Width(s string, size float64) float64 {
+	total := 0
+	for _, b := range f.encode(s) {
+		total += f.charWidth(b)
+	}
+	return float64(total) * size / 1000
+}
+
+func (f *standardFont) charWidth(b byte) int {
+	if b >= 32 && b <= 126 {
+		return f.widths[b-32]
+	}
+	// Latin-1 supplement: an average width is close enough for layout
+	return 556
+}
+
+func (f *standardFont) encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		if f.HasGlyph(r) {
+			out = append(out, byte(r))
+		} else {
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+func (f *standardFont) writeObjects(pw *writer, ref int, _ []rune) {
+	pw.object(ref, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.name))
+}
@@ -0,0 +1,111 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument_WritesValidCrossReferences(t *testing.T) {
+	doc := New(A4Width, A4Height, nil)
+	doc.SetTitle("Quiz")
+	doc.AddPage().Text(50, 50, 12, "Hello (world)")
+	page := doc.AddPage()
+	page.Line(0, 0, 100, 100, 1)
+	page.Rect(10, 10, 20, 20, 0.5)
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	out := buf.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.7")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+	if !bytes.Contains(out, []byte("/Count 2")) {
+		t.Error("expected two pages")
+	}
+
+	// startxref must point at the xref table, and every entry at its object
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	for i, e := range entries {
+		offset, _ := strconv.Atoi(string(e[1]))
+		want := fmt.Sprintf("%d 0 obj", i+1)
+		if !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, out[offset:offset+10])
+		}
+	}
+}
+
+func TestDocument_ContentStreamHasText(t *testing.T) {
+	doc := New(A4Width, A4Height, nil)
+	doc.AddPage().Text(50, 50, 12, "Hi ก")
+
+	var buf bytes.Buffer
+	doc.WriteTo(&buf)
+
+	start := bytes.Index(buf.Bytes(), []byte("stream\n")) + len("stream\n")
+	end := bytes.Index(buf.Bytes(), []byte("\nendstream"))
+	zr, err := zlib.NewReader(bytes.NewReader(buf.Bytes()[start:end]))
+	if err != nil {
+		t.Fatalf("expected a Flate stream, got: %v", err)
+	}
+	content, _ := io.ReadAll(zr)
+
+	// "Hi ?" in hex: Thai is not covered by Helvetica and is replaced
+	if !strings.Contains(string(content), "<4869203F> Tj") {
+		t.Errorf("unexpected content stream %q", content)
+	}
+	if !strings.Contains(string(content), "50 791.89 Td") {
+		t.Errorf("expected y to be measured from the top, got %q", content)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	font := Helvetica()
+	size := 10.0
+
+	lines := WrapText(font, size, font.Width("aaa bbb", size), "aaa bbb ccc\nddd")
+	want := []string{"aaa bbb", "ccc", "ddd"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Errorf("expected %v, got %v", want, lines)
+	}
+
+	// Words without spaces are broken between characters
+	long := strings.Repeat("x", 50)
+	lines = WrapText(font, size, font.Width("xxxxxxxxxx", size), long)
+	if len(lines) != 5 || strings.Join(lines, "") != long {
+		t.Errorf("expected 5 lines of 10 characters, got %v", lines)
+	}
+}
+
+func TestSplitAtWidth_KeepsCombiningMarks(t *testing.T) {
+	// Thai "กิ" is a consonant followed by a combining vowel mark
+	head, tail := splitAtWidth(Helvetica(), 10, 1, "กิข")
+	if head != "กิ" || tail != "ข" {
+		t.Errorf("expected mark to stay with its base, got %q %q", head, tail)
+	}
+}
+
+func TestParseTrueType_RejectsOtherFiles(t *testing.T) {
+	for _, data := range [][]byte{nil, []byte("OTTO\x00\x00\x00\x00\x00\x00\x00\x00"), bytes.Repeat([]byte{0}, 64)} {
+		if _, err := ParseTrueType(data); !errors.Is(err, ErrUnsupportedFont) {
+			t.Errorf("expected ErrUnsupportedFont, got %v", err)
+		}
+	}
+}
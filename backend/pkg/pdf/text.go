@@ -0,0 +1,73 @@
+package pdf
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WrapText splits text into lines no wider than maxWidth points. Lines are
+// broken at spaces where possible; text without spaces, such as Thai, is
+// broken between characters, never before a combining mark.
+func WrapText(font Font, size, maxWidth float64, text string) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		lines = append(lines, wrapParagraph(font, size, maxWidth, paragraph)...)
+	}
+	return lines
+}
+
+func wrapParagraph(font Font, size, maxWidth float64, paragraph string) []string {
+	words := strings.Fields(paragraph)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	line := ""
+	for _, word := range words {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if font.Width(candidate, size) <= maxWidth {
+			line = candidate
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+			line = ""
+		}
+		// The word alone is too wide: break it between characters
+		for font.Width(word, size) > maxWidth {
+			head, tail := splitAtWidth(font, size, maxWidth, word)
+			lines = append(lines, head)
+			word = tail
+		}
+		line = word
+	}
+	if line == "" {
+		return lines
+	}
+	return append(lines, line)
+}
+
+// splitAtWidth returns the longest prefix of word that fits, and the rest.
+// At least one character cluster is always taken so that progress is made.
+func splitAtWidth(font Font, size, maxWidth float64, word string) (string, string) {
+	runes := []rune(word)
+	cut := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && unicode.Is(unicode.Mn, runes[i]) {
+			continue // keep marks with their base character
+		}
+		if font.Width(string(runes[:i]), size) > maxWidth && cut > 0 {
+			break
+		}
+		cut = i
+		if font.Width(string(runes[:i]), size) > maxWidth {
+			break
+		}
+	}
+	return string(runes[:cut]), string(runes[cut:])
+}
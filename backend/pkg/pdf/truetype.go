@@ -0,0 +1,385 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"unicode/utf16"
+)
+
+// ErrUnsupportedFont is returned for font files that cannot be embedded
+var ErrUnsupportedFont = errors.New("pdf: unsupported font file")
+
+// TrueTypeFont is a TrueType font embedded into the documents that use it.
+// Text is written as glyph IDs (Identity-H), so any script covered by the
+// font can be shown, including Thai.
+type TrueTypeFont struct {
+	name       string
+	data       []byte
+	unitsPerEm int
+	ascent     int
+	descent    int
+	bbox       [4]int
+	advances   []uint16
+	cmap       map[rune]uint16
+}
+
+// LoadTrueType reads and parses a TrueType font file
+func LoadTrueType(path string) (*TrueTypeFont, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTrueType(data)
+}
+
+// LoadFont loads the TrueType font at path for documents that must show text
+// Helvetica cannot, such as Thai. When path is empty or the font cannot be
+// loaded, nil is returned so that documents fall back to Helvetica.
+func LoadFont(path string) Font {
+	if path == "" {
+		return nil
+	}
+	font, err := LoadTrueType(path)
+	if err != nil {
+		slog.Warn("Failed to load PDF font, falling back to Helvetica", "path", path, "error", err)
+		return nil
+	}
+	return font
+}
+
+// ParseTrueType parses a TrueType (.ttf) font. OpenType fonts with CFF
+// outlines and font collections are not supported.
+func ParseTrueType(data []byte) (*TrueTypeFont, error) {
+	if len(data) < 12 {
+		return nil, ErrUnsupportedFont
+	}
+	if version := binary.BigEndian.Uint32(data); version != 0x00010000 && version != 0x74727565 {
+		return nil, fmt.Errorf("%w: not a TrueType font", ErrUnsupportedFont)
+	}
+
+	tables := make(map[string][]byte)
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		rec := 12 + i*16
+		if rec+16 > len(data) {
+			return nil, fmt.Errorf("%w: truncated table directory", ErrUnsupportedFont)
+		}
+		tag := string(data[rec : rec+4])
+		offset := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("%w: table %q out of range", ErrUnsupportedFont, tag)
+		}
+		tables[tag] = data[offset : offset+length]
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp", "cmap"} {
+		if _, ok := tables[tag]; !ok {
+			return nil, fmt.Errorf("%w: missing %s table", ErrUnsupportedFont, tag)
+		}
+	}
+
+	head, hhea, maxp := tables["head"], tables["hhea"], tables["maxp"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return nil, fmt.Errorf("%w: truncated header tables", ErrUnsupportedFont)
+	}
+
+	f := &TrueTypeFont{
+		name:       postScriptName(tables["name"]),
+		data:       data,
+		unitsPerEm: int(binary.BigEndian.Uint16(head[18:])),
+		ascent:     int(int16(binary.BigEndian.Uint16(hhea[4:]))),
+		descent:    int(int16(binary.BigEndian.Uint16(hhea[6:]))),
+	}
+	if f.unitsPerEm == 0 {
+		return nil, fmt.Errorf("%w: invalid unitsPerEm", ErrUnsupportedFont)
+	}
+	for i := range f.bbox {
+		f.bbox[i] = int(int16(binary.BigEndian.Uint16(head[36+2*i:])))
+	}
+
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	hmtx := tables["hmtx"]
+	if numHMetrics == 0 || numHMetrics > numGlyphs || len(hmtx) < numHMetrics*4 {
+		return nil, fmt.Errorf("%w: invalid hmtx table", ErrUnsupportedFont)
+	}
+	f.advances = make([]uint16, numGlyphs)
+	for i := 0; i < numGlyphs; i++ {
+		if i < numHMetrics {
+			f.advances[i] = binary.BigEndian.Uint16(hmtx[i*4:])
+		} else {
+			f.advances[i] = f.advances[numHMetrics-1]
+		}
+	}
+
+	cmap, err := parseCmap(tables["cmap"])
+	if err != nil {
+		return nil, err
+	}
+	for r, gid := range cmap {
+		if int(gid) >= numGlyphs {
+			delete(cmap, r)
+		}
+	}
+	f.cmap = cmap
+	return f, nil
+}
+
+// parseCmap reads the best Unicode subtable: format 12 (full Unicode) or format 4 (BMP)
+func parseCmap(table []byte) (map[rune]uint16, error) {
+	if len(table) < 4 {
+		return nil, fmt.Errorf("%w: truncated cmap", ErrUnsupportedFont)
+	}
+
+	var format4, format12 []byte
+	numTables := int(binary.BigEndian.Uint16(table[2:]))
+	for i := 0; i < numTables; i++ {
+		rec := 4 + i*8
+		if rec+8 > len(table) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(table[rec:])
+		encoding := binary.BigEndian.Uint16(table[rec+2:])
+		offset := int(binary.BigEndian.Uint32(table[rec+4:]))
+		if offset+2 > len(table) {
+			continue
+		}
+		if platform != 0 && !(platform == 3 && (encoding == 1 || encoding == 10)) {
+			continue
+		}
+		switch binary.BigEndian.Uint16(table[offset:]) {
+		case 4:
+			format4 = table[offset:]
+		case 12:
+			format12 = table[offset:]
+		}
+	}
+
+	switch {
+	case format12 != nil:
+		return parseCmap12(format12)
+	case format4 != nil:
+		return parseCmap4(format4)
+	}
+	return nil, fmt.Errorf("%w: no Unicode cmap", ErrUnsupportedFont)
+}
+
+func parseCmap4(sub []byte) (map[rune]uint16, error) {
+	if len(sub) < 14 {
+		return nil, fmt.Errorf("%w: truncated cmap format 4", ErrUnsupportedFont)
+	}
+	segCount := int(binary.BigEndian.Uint16(sub[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + segCount*2 + 2
+	deltas := startCodes + segCount*2
+	rangeOffsets := deltas + segCount*2
+	if rangeOffsets+segCount*2 > len(sub) {
+		return nil, fmt.Errorf("%w: truncated cmap format 4", ErrUnsupportedFont)
+	}
+
+	cmap := make(map[rune]uint16)
+	for seg := 0; seg < segCount; seg++ {
+		end := int(binary.BigEndian.Uint16(sub[endCodes+seg*2:]))
+		start := int(binary.BigEndian.Uint16(sub[startCodes+seg*2:]))
+		delta := binary.BigEndian.Uint16(sub[deltas+seg*2:])
+		rangeOffset := int(binary.BigEndian.Uint16(sub[rangeOffsets+seg*2:]))
+
+		for c := start; c <= end && c != 0xFFFF; c++ {
+			var gid uint16
+			if rangeOffset == 0 {
+				gid = uint16(c) + delta
+			} else {
+				pos := rangeOffsets + seg*2 + rangeOffset + (c-start)*2
+				if pos+2 > len(sub) {
+					continue
+				}
+				if gid = binary.BigEndian.Uint16(sub[pos:]); gid != 0 {
+					gid += delta
+				}
+			}
+			if gid != 0 {
+				cmap[rune(c)] = gid
+			}
+		}
+	}
+	return cmap, nil
+}
+
+func parseCmap12(sub []byte) (map[rune]uint16, error) {
+	if len(sub) < 16 {
+		return nil, fmt.Errorf("%w: truncated cmap format 12", ErrUnsupportedFont)
+	}
+	groups := int(binary.BigEndian.Uint32(sub[12:]))
+	if 16+groups*12 > len(sub) {
+		return nil, fmt.Errorf("%w: truncated cmap format 12", ErrUnsupportedFont)
+	}
+
+	cmap := make(map[rune]uint16)
+	for i := 0; i < groups; i++ {
+		g := sub[16+i*12:]
+		start := binary.BigEndian.Uint32(g)
+		end := binary.BigEndian.Uint32(g[4:])
+		gid := binary.BigEndian.Uint32(g[8:])
+		if end < start || end > 0x10FFFF {
+			continue
+		}
+		for c := start; c <= end; c++ {
+			cmap[rune(c)] = uint16(gid + c - start)
+		}
+	}
+	return cmap, nil
+}
+
+// postScriptName returns name ID 6 from the name table, restricted to characters valid in a PDF name
+func postScriptName(table []byte) string {
+	const fallback = "EmbeddedFont"
+	if len(table) < 6 {
+		return fallback
+	}
+	count := int(binary.BigEndian.Uint16(table[2:]))
+	storage := int(binary.BigEndian.Uint16(table[4:]))
+
+	for i := 0; i < count; i++ {
+		rec := 6 + i*12
+		if rec+12 > len(table) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(table[rec:])
+		nameID := binary.BigEndian.Uint16(table[rec+6:])
+		length := int(binary.BigEndian.Uint16(table[rec+8:]))
+		offset := storage + int(binary.BigEndian.Uint16(table[rec+10:]))
+		if nameID != 6 || offset+length > len(table) {
+			continue
+		}
+
+		raw := table[offset : offset+length]
+		var name string
+		if platform == 1 {
+			name = string(raw)
+		} else {
+			units := make([]uint16, len(raw)/2)
+			for j := range units {
+				units[j] = binary.BigEndian.Uint16(raw[j*2:])
+			}
+			name = string(utf16.Decode(units))
+		}
+
+		name = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' {
+				return r
+			}
+			return -1
+		}, name)
+		if name != "" {
+			return name
+		}
+	}
+	return fallback
+}
+
+// Name returns the PostScript name of the font
+func (f *TrueTypeFont) Name() string {
+	return f.name
+}
+
+// HasGlyph reports whether the font maps r to a glyph
+func (f *TrueTypeFont) HasGlyph(r rune) bool {
+	_, ok := f.cmap[r]
+	return ok
+}
+
+// Width returns the advance width of s in points at the given size
+func (f *TrueTypeFont) Width(s string, size float64) float64 {
+	total := 0
+	for _, r := range s {
+		total += int(f.advances[f.cmap[r]])
+	}
+	return float64(total) * size / float64(f.unitsPerEm)
+}
+
+func (f *TrueTypeFont) encode(s string) []byte {
+	out := make([]byte, 0, len(s)*2)
+	for _, r := range s {
+		gid := f.cmap[r]
+		out = append(out, byte(gid>>8), byte(gid))
+	}
+	return out
+}
+
+// scale converts font units to the 1/1000 em units used by PDF
+func (f *TrueTypeFont) scale(v int) int {
+	return v * 1000 / f.unitsPerEm
+}
+
+func (f *TrueTypeFont) writeObjects(pw *writer, ref int, runes []rune) {
+	cidRef := pw.alloc()
+	descriptorRef := pw.alloc()
+	fileRef := pw.alloc()
+	toUnicodeRef := pw.alloc()
+
+	pw.object(ref, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, cidRef, toUnicodeRef))
+
+	// Widths of the glyphs actually used, as "gid [width]" pairs
+	var widths bytes.Buffer
+	seen := make(map[uint16]bool)
+	for _, r := range runes {
+		gid, ok := f.cmap[r]
+		if !ok || seen[gid] {
+			continue
+		}
+		seen[gid] = true
+		fmt.Fprintf(&widths, "%d [%d] ", gid, f.scale(int(f.advances[gid])))
+	}
+
+	pw.object(cidRef, fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /DW %d /W [%s] /CIDToGIDMap /Identity >>",
+		f.name, descriptorRef, f.scale(int(f.advances[0])), bytes.TrimSpace(widths.Bytes())))
+
+	pw.object(descriptorRef, fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, f.scale(f.bbox[0]), f.scale(f.bbox[1]), f.scale(f.bbox[2]), f.scale(f.bbox[3]),
+		f.scale(f.ascent), f.scale(f.descent), f.scale(f.ascent), fileRef))
+
+	pw.stream(fileRef, fmt.Sprintf(" /Length1 %d", len(f.data)), f.data)
+	pw.stream(toUnicodeRef, "", f.toUnicode(runes))
+}
+
+// toUnicode builds a CMap so that text can be copied and searched in viewers
+func (f *TrueTypeFont) toUnicode(runes []rune) []byte {
+	type mapping struct {
+		gid uint16
+		r   rune
+	}
+	var mappings []mapping
+	seen := make(map[uint16]bool)
+	for _, r := range runes {
+		if gid, ok := f.cmap[r]; ok && !seen[gid] {
+			seen[gid] = true
+			mappings = append(mappings, mapping{gid, r})
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	b.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	b.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	b.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	// bfchar blocks are limited to 100 entries each
+	for start := 0; start < len(mappings); start += 100 {
+		end := min(start+100, len(mappings))
+		fmt.Fprintf(&b, "%d beginbfchar\n", end-start)
+		for _, m := range mappings[start:end] {
+			fmt.Fprintf(&b, "<%04X> <%s\n", m.gid, strings.TrimPrefix(textString(string(m.r)), "<FEFF"))
+		}
+		b.WriteString("endbfchar\n")
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.Bytes()
+}
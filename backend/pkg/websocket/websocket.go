@@ -0,0 +1,440 @@
+// Package websocket is a small RFC 6455 WebSocket implementation for
+// servers that exchange short text or binary messages with browsers. It
+// supports the opening handshake, fragmented messages, ping/pong and the
+// closing handshake, but no extensions or subprotocols.
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message types (frame opcodes)
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	closeMessage  = 8
+	pingMessage   = 9
+	pongMessage   = 10
+)
+
+// Close status codes
+const (
+	CloseNormal        = 1000
+	CloseGoingAway     = 1001
+	CloseProtocolError = 1002
+	CloseTooLarge      = 1009
+)
+
+// DefaultMaxMessageSize limits incoming messages unless Conn.MaxMessageSize is set
+const DefaultMaxMessageSize = 64 << 10
+
+// acceptGUID is appended to the client key to compute Sec-WebSocket-Accept
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var (
+	// ErrBadHandshake is returned by Upgrade for requests that are not a
+	// WebSocket handshake; a 400 response has been written
+	ErrBadHandshake = errors.New("websocket: bad handshake")
+	// ErrBadOrigin is returned by Upgrade for handshakes from an origin that
+	// is not allowed; a 403 response has been written
+	ErrBadOrigin = errors.New("websocket: origin not allowed")
+	// ErrMessageTooLarge is returned by ReadMessage for messages over the limit
+	ErrMessageTooLarge = errors.New("websocket: message too large")
+)
+
+// CloseError is returned by ReadMessage once the peer closed the connection
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("websocket: closed with code %d %s", e.Code, e.Reason)
+}
+
+// Conn is a WebSocket connection. One goroutine may read while others write;
+// writes are serialized.
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	client bool
+
+	// MaxMessageSize limits incoming messages; 0 means DefaultMaxMessageSize
+	MaxMessageSize int64
+	// PongHandler, if set, is called from ReadMessage for every pong
+	PongHandler func()
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+	closeSent bool
+}
+
+// Upgrader completes opening handshakes. Browsers do not apply CORS to
+// WebSockets, so handshakes whose Origin is neither the request's own host
+// nor listed in AllowedOrigins are refused. Requests without an Origin come
+// from other clients than browsers and are accepted.
+type Upgrader struct {
+	// AllowedOrigins lists other origins, such as "https://app.example.com",
+	// that may connect; "*" allows every origin
+	AllowedOrigins []string
+}
+
+// Upgrade completes the opening handshake of a same-origin WebSocket request
+// and takes over its connection. Deadlines set by the HTTP server are cleared.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	return Upgrader{}.Upgrade(w, r)
+}
+
+// Upgrade completes the opening handshake of a WebSocket request from an
+// allowed origin and takes over its connection. Deadlines set by the HTTP
+// server are cleared.
+func (u Upgrader) Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if r.Method != http.MethodGet || !headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		http.Error(w, "Expected a WebSocket handshake", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "Invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+	if !u.allowOrigin(r) {
+		http.Error(w, "Origin not allowed", http.StatusForbidden)
+		return nil, ErrBadOrigin
+	}
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		http.Error(w, "WebSocket not supported", http.StatusInternalServerError)
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Conn{conn: conn, reader: rw.Reader}, nil
+}
+
+func (u Upgrader) allowOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if parsed, err := url.Parse(origin); err == nil && strings.EqualFold(parsed.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range u.AllowedOrigins {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Dial opens a client connection to a ws:// URL, for tests and tools
+func Dial(url string, header http.Header) (*Conn, *http.Response, error) {
+	if !strings.HasPrefix(url, "ws://") {
+		return nil, nil, fmt.Errorf("websocket: unsupported URL %q", url)
+	}
+	req, err := http.NewRequest(http.MethodGet, "http://"+strings.TrimPrefix(url, "ws://"), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", key)
+
+	conn, err := net.Dial("tcp", req.URL.Host)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		conn.Close()
+		return nil, resp, ErrBadHandshake
+	}
+	return &Conn{conn: conn, reader: reader, client: true}, resp, nil
+}
+
+// ReadMessage returns the next text or binary message. Pings are answered
+// and a close from the peer is acknowledged and reported as a *CloseError.
+func (c *Conn) ReadMessage() (int, []byte, error) {
+	limit := c.MaxMessageSize
+	if limit <= 0 {
+		limit = DefaultMaxMessageSize
+	}
+
+	var messageType int
+	var message []byte
+	for {
+		fin, opcode, payload, err := c.readFrame(limit)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case pingMessage:
+			if err := c.writeFrame(pongMessage, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case pongMessage:
+			if c.PongHandler != nil {
+				c.PongHandler()
+			}
+			continue
+		case closeMessage:
+			closeErr := &CloseError{Code: CloseNormal}
+			if len(payload) >= 2 {
+				closeErr.Code = int(binary.BigEndian.Uint16(payload))
+				closeErr.Reason = string(payload[2:])
+			}
+			c.WriteClose(closeErr.Code, "")
+			return 0, nil, closeErr
+		case 0:
+			if messageType == 0 {
+				return 0, nil, c.fail(CloseProtocolError, "unexpected continuation frame")
+			}
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, c.fail(CloseProtocolError, "expected a continuation frame")
+			}
+			messageType = opcode
+		default:
+			return 0, nil, c.fail(CloseProtocolError, "unknown opcode")
+		}
+
+		if int64(len(message)+len(payload)) > limit {
+			return 0, nil, c.fail(CloseTooLarge, "message too large")
+		}
+		message = append(message, payload...)
+		if fin {
+			return messageType, message, nil
+		}
+	}
+}
+
+// ReadJSON reads the next message and decodes it into v
+func (c *Conn) ReadJSON(v interface{}) error {
+	_, message, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(message, v)
+}
+
+// WriteMessage sends a text or binary message in one frame
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("websocket: invalid message type %d", messageType)
+	}
+	return c.writeFrame(messageType, data)
+}
+
+// WriteJSON sends v encoded as JSON in a text message
+func (c *Conn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(TextMessage, data)
+}
+
+// WritePing sends a ping; the peer's pong is reported to PongHandler
+func (c *Conn) WritePing() error {
+	return c.writeFrame(pingMessage, nil)
+}
+
+// WriteClose starts or acknowledges the closing handshake. Only the first
+// call sends a frame.
+func (c *Conn) WriteClose(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+	return c.writeFrame(closeMessage, payload)
+}
+
+// SetReadDeadline sets the deadline for reading the next frames
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the deadline for writing the next frames
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// Close closes the underlying connection without a closing handshake
+func (c *Conn) Close() error {
+	var err error
+	c.closeOnce.Do(func() { err = c.conn.Close() })
+	return err
+}
+
+// fail sends a close frame with code and returns an error describing reason
+func (c *Conn) fail(code int, reason string) error {
+	c.WriteClose(code, reason)
+	if code == CloseTooLarge {
+		return ErrMessageTooLarge
+	}
+	return fmt.Errorf("websocket: protocol error: %s", reason)
+}
+
+func (c *Conn) readFrame(limit int64) (fin bool, opcode int, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin = header[0]&0x80 != 0
+	opcode = int(header[0] & 0x0f)
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, c.fail(CloseProtocolError, "reserved bits set")
+	}
+	masked := header[1]&0x80 != 0
+	if masked == c.client {
+		return false, 0, nil, c.fail(CloseProtocolError, "wrong frame masking")
+	}
+
+	length := int64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+	isControl := opcode >= closeMessage
+	if isControl && (length > 125 || !fin) {
+		return false, 0, nil, c.fail(CloseProtocolError, "invalid control frame")
+	}
+	if length < 0 || length > limit {
+		return false, 0, nil, c.fail(CloseTooLarge, "message too large")
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.reader, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, opcode, payload, nil
+}
+
+func (c *Conn) writeFrame(opcode int, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closeSent {
+		return net.ErrClosed
+	}
+	if opcode == closeMessage {
+		c.closeSent = true
+	}
+
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|byte(opcode))
+	maskBit := byte(0)
+	if c.client {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+
+	if !c.client {
+		frame = append(frame, payload...)
+	} else {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		for i, b := range payload {
+			frame = append(frame, b^mask[i%4])
+		}
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// acceptKey computes the Sec-WebSocket-Accept value for a client key
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// headerContains returns true if the comma-separated header contains token,
+// ignoring case
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
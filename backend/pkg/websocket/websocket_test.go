@@ -0,0 +1,198 @@
+package websocket
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newEchoServer echoes every message back until the client closes
+func newEchoServer(t *testing.T) (string, chan error) {
+	t.Helper()
+	done := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r)
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+		conn.MaxMessageSize = 1024
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				done <- err
+				return
+			}
+			if err := conn.WriteMessage(messageType, message); err != nil {
+				done <- err
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws://" + strings.TrimPrefix(srv.URL, "http://"), done
+}
+
+func TestConn_EchoAndClose(t *testing.T) {
+	url, done := newEchoServer(t)
+	conn, _, err := Dial(url, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer conn.Close()
+
+	long := strings.Repeat("x", 300) // needs the 16-bit length
+	for _, message := range []string{"hello", long} {
+		if err := conn.WriteMessage(TextMessage, []byte(message)); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		messageType, got, err := conn.ReadMessage()
+		if err != nil || messageType != TextMessage || string(got) != message {
+			t.Fatalf("expected %d bytes echoed, got %d bytes, %v", len(message), len(got), err)
+		}
+	}
+
+	var v struct{ N int }
+	if err := conn.WriteJSON(map[string]int{"n": 7}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := conn.ReadJSON(&v); err != nil || v.N != 7 {
+		t.Fatalf("expected JSON echoed, got %+v, %v", v, err)
+	}
+
+	pong := make(chan struct{}, 1)
+	conn.PongHandler = func() { pong <- struct{}{} }
+	if err := conn.WritePing(); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	conn.WriteMessage(TextMessage, []byte("after ping"))
+	if _, got, err := conn.ReadMessage(); err != nil || string(got) != "after ping" {
+		t.Fatalf("expected the message after the pong, got %q, %v", got, err)
+	}
+	select {
+	case <-pong:
+	default:
+		t.Error("expected a pong for the ping")
+	}
+
+	if err := conn.WriteClose(CloseNormal, "bye"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var closeErr *CloseError
+	if _, _, err := conn.ReadMessage(); !errors.As(err, &closeErr) || closeErr.Code != CloseNormal {
+		t.Errorf("expected the close to be acknowledged, got: %v", err)
+	}
+	select {
+	case err := <-done:
+		if !errors.As(err, &closeErr) || closeErr.Reason != "bye" {
+			t.Errorf("expected the server to see the close, got: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the server to stop reading")
+	}
+}
+
+func TestConn_FragmentedMessage(t *testing.T) {
+	url, _ := newEchoServer(t)
+	conn, _, err := Dial(url, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer conn.Close()
+
+	// Write "ab" + "cd" as a text frame without FIN and a final continuation
+	writeRaw := func(first byte, payload string) {
+		frame := []byte{first, 0x80 | byte(len(payload)), 1, 2, 3, 4}
+		for i := range payload {
+			frame = append(frame, payload[i]^byte(i%4+1))
+		}
+		if _, err := conn.conn.Write(frame); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	writeRaw(TextMessage, "ab")
+	writeRaw(0x80, "cd")
+
+	if _, got, err := conn.ReadMessage(); err != nil || string(got) != "abcd" {
+		t.Errorf("expected the fragments joined, got %q, %v", got, err)
+	}
+}
+
+func TestConn_RejectsLargeMessages(t *testing.T) {
+	url, done := newEchoServer(t)
+	conn, _, err := Dial(url, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer conn.Close()
+
+	conn.WriteMessage(BinaryMessage, make([]byte, 2048))
+	var closeErr *CloseError
+	if _, _, err := conn.ReadMessage(); !errors.As(err, &closeErr) || closeErr.Code != CloseTooLarge {
+		t.Errorf("expected a close for a large message, got: %v", err)
+	}
+	if err := <-done; !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("expected ErrMessageTooLarge on the server, got: %v", err)
+	}
+}
+
+func TestUpgrade_RejectsPlainRequests(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if _, err := Upgrade(rec, httptest.NewRequest(http.MethodGet, "/", nil)); !errors.Is(err, ErrBadHandshake) {
+		t.Errorf("expected ErrBadHandshake, got: %v", err)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestUpgrade_ChecksOrigin(t *testing.T) {
+	upgrader := Upgrader{AllowedOrigins: []string{"https://app.example.com"}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if conn, err := upgrader.Upgrade(w, r); err == nil {
+			conn.Close()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	url := "ws://" + strings.TrimPrefix(srv.URL, "http://")
+
+	cases := []struct {
+		origin string
+		want   error
+	}{
+		{"", nil},
+		{srv.URL, nil},
+		{"https://app.example.com", nil},
+		{"https://evil.example.com", ErrBadHandshake},
+	}
+	for _, tc := range cases {
+		t.Run(tc.origin, func(t *testing.T) {
+			header := http.Header{}
+			if tc.origin != "" {
+				header.Set("Origin", tc.origin)
+			}
+			conn, resp, err := Dial(url, header)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got: %v", tc.want, err)
+			}
+			if err != nil {
+				if resp.StatusCode != http.StatusForbidden {
+					t.Errorf("expected 403, got %d", resp.StatusCode)
+				}
+				return
+			}
+			conn.Close()
+		})
+	}
+}
+
+func TestAcceptKey(t *testing.T) {
+	// Example from RFC 6455 section 1.3
+	if got := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("unexpected accept key %q", got)
+	}
+}
@@ -1,5 +1,5 @@
 import axios from 'axios'
-import type { Quiz, CreateQuizRequest, ApiResponse } from '../types/quiz'
+import type { Quiz, SearchResult, TrashedQuiz, CreateQuizRequest, CreateQuizResponse, CloneQuizResponse, ItemStats, QuizChangeEvent, QuizChangeType, QuizTransition, WorkflowAction, QuizComment, CommentThreadList, ApiResponse } from '../types/quiz'
 
 const api = axios.create({
     baseURL: '/api/v1',
@@ -8,16 +8,94 @@ const api = axios.create({
     },
 })
 
+// getQuizzes lists quizzes in every status; GET /quizzes only returns published ones
 export async function getQuizzes(): Promise<Quiz[]> {
-    const { data } = await api.get<ApiResponse<Quiz[]>>('/quizzes')
+    const { data } = await api.get<ApiResponse<Quiz[]>>('/quizzes/manage')
     return data.data
 }
 
-export async function createQuiz(req: CreateQuizRequest): Promise<Quiz> {
-    const { data } = await api.post<ApiResponse<Quiz>>('/quizzes', req)
+export async function getQuizStats(id: string): Promise<ItemStats> {
+    const { data } = await api.get<ApiResponse<ItemStats>>(`/quizzes/${id}/stats`)
+    return data.data
+}
+
+export async function cloneQuiz(id: string): Promise<CloneQuizResponse> {
+    const { data } = await api.post<ApiResponse<CloneQuizResponse>>(`/quizzes/${id}/clone`)
+    return data.data
+}
+
+export async function transitionQuiz(id: string, action: WorkflowAction, comment?: string): Promise<Quiz> {
+    const { data } = await api.post<ApiResponse<Quiz>>(`/quizzes/${id}/${action}`, { comment })
+    return data.data
+}
+
+export async function scheduleQuiz(id: string, publishAt?: string, unpublishAt?: string): Promise<Quiz> {
+    const { data } = await api.put<ApiResponse<Quiz>>(`/quizzes/${id}/schedule`, { publish_at: publishAt, unpublish_at: unpublishAt })
+    return data.data
+}
+
+export async function getComments(quizId: string, resolved?: boolean): Promise<CommentThreadList> {
+    const { data } = await api.get<ApiResponse<CommentThreadList>>(`/quizzes/${quizId}/comments`, { params: { resolved } })
+    return data.data
+}
+
+export async function addComment(quizId: string, body: string, options: { choice?: number; parentId?: string } = {}): Promise<QuizComment> {
+    const { data } = await api.post<ApiResponse<QuizComment>>(`/quizzes/${quizId}/comments`, {
+        body,
+        choice: options.choice,
+        parent_id: options.parentId,
+    })
+    return data.data
+}
+
+export async function setCommentResolved(id: string, resolved: boolean): Promise<QuizComment> {
+    const { data } = await api.post<ApiResponse<QuizComment>>(`/comments/${id}/${resolved ? 'resolve' : 'reopen'}`)
+    return data.data
+}
+
+export async function getTransitions(id: string): Promise<QuizTransition[]> {
+    const { data } = await api.get<ApiResponse<QuizTransition[]>>(`/quizzes/${id}/transitions`)
+    return data.data
+}
+
+export async function searchQuizzes(q: string, limit?: number): Promise<SearchResult[]> {
+    const { data } = await api.get<ApiResponse<SearchResult[]>>('/quizzes/search', { params: { q, limit } })
+    return data.data
+}
+
+export async function createQuiz(req: CreateQuizRequest): Promise<CreateQuizResponse> {
+    const { data } = await api.post<ApiResponse<CreateQuizResponse>>('/quizzes', req)
     return data.data
 }
 
 export async function deleteQuiz(id: string): Promise<void> {
     await api.delete(`/quizzes/${id}`)
 }
+
+export async function getTrash(): Promise<TrashedQuiz[]> {
+    const { data } = await api.get<ApiResponse<TrashedQuiz[]>>('/quizzes/trash')
+    return data.data
+}
+
+export async function restoreQuiz(id: string, position: 'original' | 'end' = 'original'): Promise<Quiz> {
+    const { data } = await api.post<ApiResponse<Quiz>>(`/quizzes/trash/${id}/restore`, { position })
+    return data.data
+}
+
+export async function purgeQuiz(id: string): Promise<void> {
+    await api.delete(`/quizzes/trash/${id}`)
+}
+
+const quizChangeTypes: QuizChangeType[] = ['created', 'updated', 'deleted', 'reordered', 'reset']
+
+// subscribeQuizEvents calls onChange for every change to the quiz list until
+// the returned function is called. The browser reconnects on its own and
+// resumes after the last event it received.
+export function subscribeQuizEvents(onChange: (event: QuizChangeEvent) => void): () => void {
+    if (typeof EventSource === 'undefined') return () => {}
+    const source = new EventSource('/api/v1/quizzes/events')
+    for (const type of quizChangeTypes) {
+        source.addEventListener(type, (e) => onChange(JSON.parse((e as MessageEvent).data)))
+    }
+    return () => source.close()
+}
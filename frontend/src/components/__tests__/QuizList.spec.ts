@@ -18,7 +18,8 @@ const mockDeleteQuiz = vi.fn()
 
 vi.mock('../../api/quiz', () => ({
     getQuizzes: () => mockGetQuizzes(),
-    deleteQuiz: () => mockDeleteQuiz()
+    deleteQuiz: () => mockDeleteQuiz(),
+    subscribeQuizEvents: () => () => {}
 }))
 
 describe('QuizList', () => {
@@ -5,7 +5,45 @@ export interface Quiz {
     choice2: string
     choice3: string
     choice4: string
+    answer?: number
     display_order: number
+    revision_id?: string
+    status?: QuizStatus
+    created_by?: string
+    updated_by?: string
+    publish_at?: string
+    unpublish_at?: string
+    unresolved_comments?: number
+    p_value?: number
+    locale?: string
+    explanation?: string
+    translation_outdated?: boolean
+}
+
+export type QuizStatus = 'draft' | 'in_review' | 'published' | 'archived'
+
+export type WorkflowAction = 'submit' | 'approve' | 'reject' | 'archive' | 'reopen'
+
+export interface QuizTransition {
+    id: string
+    // 'revise' is recorded when editing a published quiz sends it back to review
+    action: WorkflowAction | 'revise'
+    from_status: QuizStatus
+    to_status: QuizStatus
+    comment?: string
+    actor_id?: string
+    created_at: string
+}
+
+export interface SearchResult extends Quiz {
+    rank: number
+    // snippet is HTML-escaped, with matches wrapped in <mark>
+    highlights: { field: string; snippet: string }[]
+}
+
+export interface TrashedQuiz extends Quiz {
+    deleted_at: string
+    purge_at?: string
 }
 
 export interface CreateQuizRequest {
@@ -14,6 +52,24 @@ export interface CreateQuizRequest {
     choice2: string
     choice3: string
     choice4: string
+    answer?: number
+    on_duplicate?: 'warn' | 'block'
+}
+
+export interface DuplicateMatch {
+    id: string
+    question: string
+    similarity: number
+    exact: boolean
+}
+
+export interface CreateQuizResponse extends Quiz {
+    duplicates?: DuplicateMatch[]
+}
+
+export interface CloneQuizResponse {
+    quiz: Quiz
+    id_map: Record<string, string>
 }
 
 export interface ApiResponse<T> {
@@ -24,3 +80,309 @@ export interface ApiResponse<T> {
         message: string
     }
 }
+
+export interface QuizComment {
+    id: string
+    quiz_id: string
+    parent_id?: string
+    choice?: number
+    body: string
+    author_id?: string
+    mentions: string[]
+    resolved_at?: string
+    resolved_by?: string
+    created_at: string
+    updated_at: string
+}
+
+export interface CommentThread extends QuizComment {
+    replies: QuizComment[]
+}
+
+export interface CommentThreadList {
+    threads: CommentThread[]
+    unresolved: number
+}
+
+export type AttemptMode = 'fixed' | 'adaptive'
+
+export interface StartAttemptRequest {
+    quiz_set_id?: string
+    assignment_id?: string
+    mode?: AttemptMode
+    model?: '1pl' | '2pl'
+    max_items?: number
+    target_se?: number
+}
+
+export interface QuizHint {
+    position: number
+    body: string
+    penalty: number
+}
+
+export interface RevealedHint {
+    hint: number
+    body: string
+    penalty: number
+}
+
+export interface AttemptQuestion {
+    position: number
+    quiz_id: string
+    question: string
+    choice1: string
+    choice2: string
+    choice3: string
+    choice4: string
+    hints: RevealedHint[]
+    hints_left: number
+}
+
+export interface HintReveal {
+    quiz_id: string
+    hint: RevealedHint
+    hints_left: number
+    total_penalty: number
+}
+
+export interface AttemptAnswer {
+    position: number
+    quiz_id: string
+    revision_id: string
+    choice?: number
+    correct: boolean
+    answer: number
+    points: number
+    hints_used: number
+    hint_penalty: number
+    time_spent_ms: number
+}
+
+export interface Attempt {
+    id: string
+    quiz_set_id?: string
+    user_id?: string
+    assignment_id?: string
+    late?: boolean
+    mode: AttemptMode
+    model?: '1pl' | '2pl'
+    max_items: number
+    target_se?: number
+    status: 'in_progress' | 'submitted'
+    answered: number
+    score: number
+    max_score: number
+    hints_used: number
+    hint_penalty: number
+    ability?: { estimate: number, standard_error: number, lower: number, upper: number }
+    question?: AttemptQuestion
+    answers?: AttemptAnswer[]
+    started_at: string
+    submitted_at?: string
+}
+
+export type LatePolicy = 'reject' | 'accept' | 'penalty'
+
+export interface Assignment {
+    id: string
+    quiz_set_id: string
+    title: string
+    opens_at?: string
+    due_at: string
+    late_policy: LatePolicy
+    late_penalty?: number
+    max_attempts?: number
+    user_ids: string[]
+    group_ids: string[]
+    created_by?: string
+    created_at: string
+    updated_at: string
+}
+
+export type AssignmentStatus = 'upcoming' | 'open' | 'in_progress' | 'submitted' | 'overdue' | 'missed'
+
+export interface MyAssignment {
+    id: string
+    quiz_set_id: string
+    title: string
+    opens_at?: string
+    due_at: string
+    late_policy: LatePolicy
+    late_penalty?: number
+    max_attempts?: number
+    status: AssignmentStatus
+    attempts_used: number
+    attempts_left?: number
+    can_start: boolean
+    best_attempt_id?: string
+    best_score?: number
+    max_score?: number
+    late: boolean
+}
+
+export interface UserGroup {
+    id: string
+    name: string
+    user_ids: string[]
+    created_by?: string
+    created_at: string
+    updated_at: string
+}
+
+export interface ChoiceStats {
+    choice: number
+    count: number
+    rate: number
+    // correct and answer are only sent to admins and the quiz's author
+    correct?: boolean
+}
+
+export interface ItemStats {
+    quiz_id: string
+    answer?: number
+    responses: number
+    omitted: number
+    p_value?: number
+    point_biserial?: number
+    average_time_ms?: number
+    choices: ChoiceStats[]
+}
+
+export type LeaderboardWindow = 'all_time' | 'weekly'
+
+export interface LeaderboardEntry {
+    rank: number
+    user_id?: string
+    hidden?: boolean
+    score: number
+    max_score: number
+    duration_ms: number
+    submitted_at: string
+}
+
+export interface Leaderboard {
+    items: LeaderboardEntry[]
+    page: number
+    page_size: number
+    total: number
+    total_pages: number
+    window: LeaderboardWindow
+    period: string
+    me: LeaderboardEntry | null
+}
+
+export type QuizChangeType = 'created' | 'updated' | 'deleted' | 'reordered' | 'reset'
+
+export interface QuizChangeEvent {
+    id: string
+    type: QuizChangeType
+    quiz_id?: string
+    user_id?: string
+    at: string
+}
+
+export interface LiveSession {
+    code: string
+    host_token: string
+    questions: number
+    time_limit_seconds: number
+}
+
+export interface LiveStanding {
+    rank: number
+    name: string
+    score: number
+    points: number
+    correct: boolean
+}
+
+export type LiveClientMessage =
+    | { type: 'next' }
+    | { type: 'end' }
+    | { type: 'answer'; choice: number }
+
+export type LiveServerMessage =
+    | { type: 'joined'; role: 'host' | 'player'; name?: string; code: string; questions: number; time_limit_ms: number }
+    | { type: 'lobby'; players: string[] }
+    | {
+          type: 'question'
+          index: number
+          total: number
+          quiz_id: string
+          question: string
+          choices: string[]
+          time_limit_ms: number
+          deadline: string
+      }
+    | { type: 'answer_accepted'; index: number }
+    | { type: 'progress'; index: number; answered: number; players: number }
+    | { type: 'scoreboard'; index: number; total: number; answer: number; last: boolean; standings: LiveStanding[] }
+    | { type: 'finished'; standings: LiveStanding[] }
+    | { type: 'ended'; reason: 'host_ended' | 'shutdown' | 'idle' }
+    | { type: 'error'; code: string; message: string }
+
+export type CertificateStatus = 'valid' | 'revoked'
+
+export interface Certificate {
+    id: string
+    code: string
+    status: CertificateStatus
+    attempt_id?: string
+    quiz_set_id?: string
+    quiz_set_title: string
+    user_id: string
+    score: number
+    max_score: number
+    percent: number
+    pass_mark: number
+    issued_at: string
+    revoked_at?: string
+    revoked_by?: string
+    revoke_reason?: string
+}
+
+export interface CertificateVerification {
+    code: string
+    status: CertificateStatus
+    valid: boolean
+    quiz_set_title: string
+    issued_at: string
+    revoked_at?: string
+    holder_matches?: boolean
+}
+
+export interface PracticeCard {
+    ease: number
+    interval_days: number
+    repetitions: number
+    lapses: number
+    reviews: number
+    due_at: string
+    last_reviewed_at?: string
+}
+
+export interface PracticeItem {
+    quiz_id: string
+    question: string
+    choice1: string
+    choice2: string
+    choice3: string
+    choice4: string
+    new: boolean
+    card?: PracticeCard
+}
+
+export interface PracticeNext {
+    items: PracticeItem[]
+    due: number
+    next_due_at?: string
+}
+
+export interface PracticeAnswerResult {
+    quiz_id: string
+    correct: boolean
+    answer: number
+    quality: number
+    card: PracticeCard
+}